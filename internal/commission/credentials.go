@@ -0,0 +1,249 @@
+package commission
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+)
+
+// ErrNoCredentials is returned when a fabric has no operational credentials
+// to reach or commission nodes with.
+var ErrNoCredentials = errors.New("fabric has no operational credentials")
+
+// AdminVendorID is the vendor ID of the fabrics this controller creates: the
+// test vendor, as the controller has no vendor ID of its own.
+const AdminVendorID uint16 = 0xFFF1
+
+// generatedSource marks bundles created by EnsureCredentials.
+const generatedSource = "generated"
+
+// Credentials decodes the operational credentials of the bundle. The root
+// key is optional: without it the controller can reach the nodes of the
+// fabric but not commission new ones.
+func (b *Bundle) Credentials() (*credentials.Fabric, error) {
+	if b.RootCert == "" || b.OperationalCert == "" || b.OperationalKey == "" || b.IPK == "" {
+		return nil, fmt.Errorf("%w: the bundle needs the root and operational certificates, the operational key and the IPK", ErrNoCredentials)
+	}
+	var err error
+	fabric := &credentials.Fabric{VendorID: AdminVendorID}
+	if fabric.RCAC, err = parseCertificate(b.RootCert); err != nil {
+		return nil, fmt.Errorf("root certificate: %w", err)
+	}
+	if b.IntermediateCert != "" {
+		if fabric.ICAC, err = parseCertificate(b.IntermediateCert); err != nil {
+			return nil, fmt.Errorf("intermediate certificate: %w", err)
+		}
+	}
+	if fabric.NOC, err = parseCertificate(b.OperationalCert); err != nil {
+		return nil, fmt.Errorf("operational certificate: %w", err)
+	}
+	if fabric.Key, err = parsePrivateKey(b.OperationalKey); err != nil {
+		return nil, fmt.Errorf("operational key: %w", err)
+	}
+	if b.RootKey != "" {
+		if fabric.RootKey, err = parsePrivateKey(b.RootKey); err != nil {
+			return nil, fmt.Errorf("root key: %w", err)
+		}
+	}
+	if fabric.EpochKey, err = parseIPK(b.IPK); err != nil {
+		return nil, err
+	}
+	var icac []byte
+	if fabric.ICAC != nil {
+		if icac, err = fabric.ICAC.Encode(); err != nil {
+			return nil, err
+		}
+	}
+	noc, err := fabric.NOC.Encode()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := credentials.VerifyNOC(fabric.RCAC, noc, icac, time.Time{}); err != nil {
+		return nil, err
+	}
+	fabric.NodeID, _ = fabric.NOC.Subject.Value(credentials.DNNodeID)
+	fabric.FabricID, _ = fabric.NOC.Subject.Value(credentials.DNFabricID)
+	if b.FabricID != 0 && b.FabricID != fabric.FabricID {
+		return nil, fmt.Errorf("bundle fabric ID %016X differs from the operational certificate", b.FabricID)
+	}
+	if pub, err := fabric.NOC.ECDSAPublicKey(); err != nil || !pub.Equal(&fabric.Key.PublicKey) {
+		return nil, errors.New("operational key does not match the operational certificate")
+	}
+	return fabric, nil
+}
+
+// newBundle encodes fabric credentials as a bundle, with the certificates
+// and keys in PEM.
+func newBundle(fabric *credentials.Fabric) (*Bundle, error) {
+	rootCert, err := certificatePEM(fabric.RCAC)
+	if err != nil {
+		return nil, err
+	}
+	operationalCert, err := certificatePEM(fabric.NOC)
+	if err != nil {
+		return nil, err
+	}
+	operationalKey, err := privateKeyPEM(fabric.Key)
+	if err != nil {
+		return nil, err
+	}
+	rootKey, err := privateKeyPEM(fabric.RootKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Bundle{
+		NodeID:          fabric.NodeID,
+		FabricID:        fabric.FabricID,
+		RootCert:        rootCert,
+		RootKey:         rootKey,
+		OperationalCert: operationalCert,
+		OperationalKey:  operationalKey,
+		IPK:             base64.StdEncoding.EncodeToString(fabric.EpochKey),
+		Source:          generatedSource,
+		ImportedAt:      time.Now(),
+	}, nil
+}
+
+// LoadCredentials returns the operational credentials of the fabric with
+// fabricIndex, or ErrNoCredentials when it has no bundle.
+func LoadCredentials(ctx context.Context, s store.Store, fabricIndex uint8) (*credentials.Fabric, error) {
+	state, err := LoadState(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	f := state.Fabric(fabricIndex)
+	if f == nil || f.Bundle == nil {
+		return nil, fmt.Errorf("%w: fabric %d", ErrNoCredentials, fabricIndex)
+	}
+	return f.Bundle.Credentials()
+}
+
+// EnsureCredentials returns the operational credentials of the fabric with
+// fabricIndex, creating the fabric with a new root CA, controller NOC and
+// IPK when it has no bundle yet.
+func EnsureCredentials(ctx context.Context, s store.Store, fabricIndex uint8) (*credentials.Fabric, error) {
+	var fabric *credentials.Fabric
+	_, err := update(ctx, s, func(state *State) error {
+		f := state.fabric(fabricIndex)
+		var err error
+		if f.Bundle != nil {
+			fabric, err = f.Bundle.Credentials()
+			return err
+		}
+		fabricID := f.FabricID
+		if fabricID == 0 {
+			if fabricID, err = randomID(); err != nil {
+				return err
+			}
+		}
+		nodeID, err := randomID()
+		if err != nil {
+			return err
+		}
+		// 2.5.5.1. Operational Node ID: the top of the range is reserved.
+		nodeID = nodeID%0xFFFFFFEFFFFFFFFF + 1
+		if fabric, err = credentials.NewFabric(rand.Reader, fabricID, nodeID, AdminVendorID); err != nil {
+			return err
+		}
+		if f.Bundle, err = newBundle(fabric); err != nil {
+			return err
+		}
+		f.FabricID = fabricID
+		return f.updateCompressedFabricID()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fabric, nil
+}
+
+// parseCertificate decodes a certificate given as X.509 PEM, or as base64
+// encoded X.509 DER or Matter TLV.
+func parseCertificate(text string) (*credentials.Certificate, error) {
+	text = strings.TrimSpace(text)
+	if block, _ := pem.Decode([]byte(text)); block != nil {
+		return credentials.ParseX509(block.Bytes)
+	}
+	b, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate encoding: %w", err)
+	}
+	if cert, err := credentials.DecodeCertificate(b); err == nil {
+		return cert, nil
+	}
+	return credentials.ParseX509(b)
+}
+
+// parsePrivateKey decodes a P-256 key in SEC 1 or PKCS #8 PEM.
+func parsePrivateKey(text string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(text)))
+	if block == nil {
+		return nil, errors.New("key is not PEM encoded")
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("key is not ECDSA")
+	}
+	return ecKey, nil
+}
+
+// parseIPK decodes the IPK epoch key given in hex or base64.
+func parseIPK(text string) ([]byte, error) {
+	text = strings.TrimSpace(text)
+	key, err := hex.DecodeString(text)
+	if err != nil {
+		key, err = base64.StdEncoding.DecodeString(text)
+	}
+	if err != nil || len(key) != credentials.EpochKeySize {
+		return nil, fmt.Errorf("IPK must be a %d-byte key in hex or base64", credentials.EpochKeySize)
+	}
+	return key, nil
+}
+
+func certificatePEM(cert *credentials.Certificate) (string, error) {
+	der, err := cert.X509()
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
+
+func privateKeyPEM(key *ecdsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), nil
+}
+
+// randomID returns a random non-zero 64-bit identifier.
+func randomID() (uint64, error) {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, err
+		}
+		if id := binary.LittleEndian.Uint64(b[:]); id != 0 {
+			return id, nil
+		}
+	}
+}
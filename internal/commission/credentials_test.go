@@ -0,0 +1,63 @@
+package commission
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+)
+
+func TestEnsureCredentials(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewJSONFileStore(filepath.Join(t.TempDir(), "state.json"))
+	if _, err := LoadCredentials(ctx, s, DefaultFabricIndex); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("LoadCredentials() before creation = %v, want %v", err, ErrNoCredentials)
+	}
+	created, err := EnsureCredentials(ctx, s, DefaultFabricIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.RootKey == nil || created.FabricID == 0 || created.NodeID == 0 {
+		t.Fatalf("created fabric %+v", created)
+	}
+	again, err := EnsureCredentials(ctx, s, DefaultFabricIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadCredentials(ctx, s, DefaultFabricIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*credentials.Fabric{again, loaded} {
+		if f.FabricID != created.FabricID || f.NodeID != created.NodeID ||
+			f.RootKey == nil || !f.RootKey.Equal(created.RootKey) || !bytes.Equal(f.EpochKey, created.EpochKey) {
+			t.Errorf("stored fabric %016X node %016X differs from the created one", f.FabricID, f.NodeID)
+		}
+	}
+	state, err := LoadState(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := state.Fabric(DefaultFabricIndex)
+	if want, _ := created.CompressedFabricID(); f.FabricID != created.FabricID || f.CompressedFabricID != want {
+		t.Errorf("fabric %016X/%016X, want %016X/%016X", f.FabricID, f.CompressedFabricID, created.FabricID, want)
+	}
+
+	// A bundle whose operational key is not the key of its NOC is rejected.
+	other, err := EnsureCredentials(ctx, s, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := *f.Bundle
+	bundle.OperationalKey, err = privateKeyPEM(other.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bundle.Credentials(); err == nil {
+		t.Error("Credentials() accepted a mismatched operational key")
+	}
+}
@@ -0,0 +1,209 @@
+package commission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"go.yaml.in/yaml/v3"
+)
+
+// maxOperationalNodeID is the upper bound of the operational node ID range.
+const maxOperationalNodeID uint64 = 0xFFFFFFEFFFFFFFFF
+
+// Manifest lists the devices of a batch commissioning run.
+type Manifest struct {
+	// Fabric is the local fabric index the devices are recorded on.
+	Fabric uint8 `yaml:"fabric,omitempty"`
+	// Network is the default network provisioned on every device.
+	Network *ManifestNetwork `yaml:"network,omitempty"`
+	Devices []ManifestDevice `yaml:"devices"`
+}
+
+// ManifestNetwork holds the operational network credentials of a device.
+// At most one of WiFi and ThreadDataset is set.
+type ManifestNetwork struct {
+	WiFi *ManifestWiFi `yaml:"wifi,omitempty"`
+	// ThreadDataset is the hex encoded Thread operational dataset.
+	ThreadDataset string `yaml:"thread-dataset,omitempty"`
+}
+
+// ManifestWiFi holds Wi-Fi credentials.
+type ManifestWiFi struct {
+	SSID       string `yaml:"ssid"`
+	Passphrase string `yaml:"passphrase,omitempty"`
+}
+
+// ManifestDevice is a device to commission. Exactly one of QR and Code is set.
+type ManifestDevice struct {
+	Label string `yaml:"label,omitempty"`
+	QR    string `yaml:"qr,omitempty"`
+	Code  string `yaml:"code,omitempty"`
+	// NodeID is the operational node ID assigned to the device. It is
+	// required and unique within the manifest, since the commissioning state
+	// keeps one record per node ID.
+	NodeID uint64 `yaml:"node-id"`
+	// Address is the on-network address (ip or ip:port); empty discovers
+	// the device.
+	Address string `yaml:"address,omitempty"`
+	// Network overrides Manifest.Network.
+	Network *ManifestNetwork `yaml:"network,omitempty"`
+}
+
+// Payload returns the onboarding payload of the device.
+func (d ManifestDevice) Payload() string {
+	if d.QR != "" {
+		return strings.TrimSpace(d.QR)
+	}
+	return strings.TrimSpace(d.Code)
+}
+
+// Key identifies the device in a manifest report: its label, or its payload
+// when it has none.
+func (d ManifestDevice) Key() string {
+	if d.Label != "" {
+		return d.Label
+	}
+	return d.Payload()
+}
+
+// LoadManifest reads and validates a YAML manifest.
+func LoadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, err
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("manifest %s: %w", path, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return Manifest{}, fmt.Errorf("manifest %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// Validate checks that every device has one valid onboarding payload and a
+// unique key and node ID.
+func (m Manifest) Validate() error {
+	if len(m.Devices) == 0 {
+		return errors.New("no devices")
+	}
+	if err := m.Network.validate(); err != nil {
+		return err
+	}
+	keys := map[string]int{}
+	nodeIDs := map[uint64]int{}
+	for i, d := range m.Devices {
+		n := i + 1
+		if (d.QR == "") == (d.Code == "") {
+			return fmt.Errorf("device %d: specify exactly one of qr or code", n)
+		}
+		if _, _, err := ParseOnboardingPayload(d.Payload()); err != nil {
+			return fmt.Errorf("device %d: %w", n, err)
+		}
+		if d.NodeID == 0 {
+			return fmt.Errorf("device %d: node-id is required", n)
+		}
+		if d.NodeID > maxOperationalNodeID {
+			return fmt.Errorf("device %d: node-id 0x%016X is not an operational node ID", n, d.NodeID)
+		}
+		if j, ok := keys[d.Key()]; ok {
+			return fmt.Errorf("device %d: duplicates device %d", n, j)
+		}
+		keys[d.Key()] = n
+		if j, ok := nodeIDs[d.NodeID]; ok {
+			return fmt.Errorf("device %d: node-id %d is also used by device %d", n, d.NodeID, j)
+		}
+		nodeIDs[d.NodeID] = n
+		if err := d.Network.validate(); err != nil {
+			return fmt.Errorf("device %d: %w", n, err)
+		}
+	}
+	return nil
+}
+
+// NetworkFor returns the network credentials of d.
+func (m Manifest) NetworkFor(d ManifestDevice) *ManifestNetwork {
+	if d.Network != nil {
+		return d.Network
+	}
+	return m.Network
+}
+
+func (n *ManifestNetwork) validate() error {
+	switch {
+	case n == nil:
+		return nil
+	case n.WiFi != nil && n.ThreadDataset != "":
+		return errors.New("network: specify only one of wifi or thread-dataset")
+	case n.WiFi != nil && n.WiFi.SSID == "":
+		return errors.New("network: wifi ssid is required")
+	}
+	return nil
+}
+
+// Manifest report statuses.
+const (
+	ManifestStatusCommissioned = "commissioned"
+	ManifestStatusFailed       = "failed"
+)
+
+// ManifestReport records the outcome of batch commissioning runs of a
+// manifest, so that a later run retries only the devices that failed.
+type ManifestReport struct {
+	Manifest  string                  `json:"manifest"`
+	UpdatedAt time.Time               `json:"updated_at"`
+	Devices   []ManifestDeviceOutcome `json:"devices"`
+}
+
+// ManifestDeviceOutcome is the latest outcome for a manifest device.
+type ManifestDeviceOutcome struct {
+	Key       string    `json:"key"`
+	NodeID    uint64    `json:"node_id,omitempty"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Device    string    `json:"device,omitempty"`
+	Attempts  int       `json:"attempts"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Outcome returns the outcome recorded for key, or nil if there is none.
+func (r *ManifestReport) Outcome(key string) *ManifestDeviceOutcome {
+	for i := range r.Devices {
+		if r.Devices[i].Key == key {
+			return &r.Devices[i]
+		}
+	}
+	return nil
+}
+
+// Record stores outcome, replacing an earlier outcome for the same key and
+// counting the attempts.
+func (r *ManifestReport) Record(outcome ManifestDeviceOutcome) {
+	if prev := r.Outcome(outcome.Key); prev != nil {
+		outcome.Attempts = prev.Attempts + 1
+		*prev = outcome
+		return
+	}
+	outcome.Attempts = 1
+	r.Devices = append(r.Devices, outcome)
+}
+
+// LoadManifestReport loads a manifest report from the store.
+func LoadManifestReport(ctx context.Context, s store.Store) (ManifestReport, error) {
+	var report ManifestReport
+	if err := s.Load(ctx, &report); err != nil {
+		return ManifestReport{}, err
+	}
+	return report, nil
+}
+
+// SaveManifestReport persists a manifest report to the store.
+func SaveManifestReport(ctx context.Context, s store.Store, report ManifestReport) error {
+	return s.Save(ctx, &report)
+}
@@ -0,0 +1,161 @@
+package commission
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+)
+
+const (
+	testQR    = "MT:Y.ET0EDB00SWDX0IA00"
+	testCode1 = "30357507966"
+	testCode2 = "35729935174"
+)
+
+func writeManifest(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "devices.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadManifest(t *testing.T) {
+	path := writeManifest(t, `
+fabric: 2
+network:
+  wifi:
+    ssid: home
+    passphrase: secret
+devices:
+  - label: kitchen
+    qr: "`+testQR+`"
+    node-id: 10
+  - code: "`+testCode1+`"
+    node-id: 11
+    address: 192.0.2.1:5540
+    network:
+      thread-dataset: "0e080000000000010000"
+`)
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if m.Fabric != 2 || len(m.Devices) != 2 {
+		t.Fatalf("manifest = %+v", m)
+	}
+	kitchen, second := m.Devices[0], m.Devices[1]
+	if kitchen.Key() != "kitchen" || kitchen.Payload() != testQR || kitchen.NodeID != 10 {
+		t.Errorf("device 1 = %+v", kitchen)
+	}
+	if second.Key() != testCode1 || second.Address != "192.0.2.1:5540" {
+		t.Errorf("device 2 = %+v", second)
+	}
+	if n := m.NetworkFor(kitchen); n == nil || n.WiFi == nil || n.WiFi.SSID != "home" || n.WiFi.Passphrase != "secret" {
+		t.Errorf("network of device 1 = %+v", n)
+	}
+	if n := m.NetworkFor(second); n == nil || n.WiFi != nil || n.ThreadDataset == "" {
+		t.Errorf("network of device 2 = %+v", n)
+	}
+}
+
+func TestLoadManifestErrors(t *testing.T) {
+	if _, err := LoadManifest(writeManifest(t, "devices: [")); err == nil {
+		t.Errorf("malformed YAML accepted")
+	}
+	if _, err := LoadManifest(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Errorf("missing file accepted")
+	}
+}
+
+func TestManifestValidate(t *testing.T) {
+	device := func(code string, nodeID uint64) ManifestDevice {
+		return ManifestDevice{Code: code, NodeID: nodeID}
+	}
+	tests := []struct {
+		name    string
+		m       Manifest
+		wantErr string
+	}{
+		{name: "valid", m: Manifest{Devices: []ManifestDevice{device(testCode1, 1), device(testCode2, 2)}}},
+		{name: "no devices", m: Manifest{}, wantErr: "no devices"},
+		{name: "missing node-id", m: Manifest{Devices: []ManifestDevice{device(testCode1, 1), device(testCode2, 0)}}, wantErr: "device 2: node-id is required"},
+		{name: "duplicate node-id", m: Manifest{Devices: []ManifestDevice{device(testCode1, 7), device(testCode2, 7)}}, wantErr: "node-id 7 is also used by device 1"},
+		{name: "non-operational node-id", m: Manifest{Devices: []ManifestDevice{device(testCode1, 0xFFFFFFFD00000001)}}, wantErr: "not an operational node ID"},
+		{name: "duplicate key", m: Manifest{Devices: []ManifestDevice{device(testCode1, 1), device(testCode1, 2)}}, wantErr: "device 2: duplicates device 1"},
+		{name: "qr and code", m: Manifest{Devices: []ManifestDevice{{QR: testQR, Code: testCode1, NodeID: 1}}}, wantErr: "exactly one of qr or code"},
+		{name: "no payload", m: Manifest{Devices: []ManifestDevice{{NodeID: 1}}}, wantErr: "exactly one of qr or code"},
+		{name: "invalid payload", m: Manifest{Devices: []ManifestDevice{device("1234", 1)}}, wantErr: "device 1"},
+		{
+			name:    "wifi and thread",
+			m:       Manifest{Network: &ManifestNetwork{WiFi: &ManifestWiFi{SSID: "home"}, ThreadDataset: "00"}, Devices: []ManifestDevice{device(testCode1, 1)}},
+			wantErr: "only one of wifi or thread-dataset",
+		},
+		{
+			name:    "wifi without ssid",
+			m:       Manifest{Devices: []ManifestDevice{{Code: testCode1, NodeID: 1, Network: &ManifestNetwork{WiFi: &ManifestWiFi{}}}}},
+			wantErr: "device 1: network: wifi ssid is required",
+		},
+	}
+	for _, tt := range tests {
+		err := tt.m.Validate()
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: Validate = %v", tt.name, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: Validate = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestManifestReportRecord(t *testing.T) {
+	var r ManifestReport
+	if r.Outcome("kitchen") != nil {
+		t.Fatalf("outcome of an empty report")
+	}
+	r.Record(ManifestDeviceOutcome{Key: "kitchen", NodeID: 10, Status: ManifestStatusFailed, Error: "timeout"})
+	r.Record(ManifestDeviceOutcome{Key: "hall", NodeID: 11, Status: ManifestStatusCommissioned})
+	r.Record(ManifestDeviceOutcome{Key: "kitchen", NodeID: 10, Status: ManifestStatusCommissioned})
+
+	if len(r.Devices) != 2 {
+		t.Fatalf("devices = %+v", r.Devices)
+	}
+	kitchen := r.Outcome("kitchen")
+	if kitchen == nil || kitchen.Status != ManifestStatusCommissioned || kitchen.Error != "" || kitchen.Attempts != 2 {
+		t.Errorf("kitchen = %+v", kitchen)
+	}
+	if hall := r.Outcome("hall"); hall == nil || hall.Attempts != 1 {
+		t.Errorf("hall = %+v", hall)
+	}
+}
+
+func TestManifestReportStore(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewJSONFileStore(filepath.Join(t.TempDir(), "report.json"))
+	empty, err := LoadManifestReport(ctx, s)
+	if err != nil || len(empty.Devices) != 0 {
+		t.Fatalf("LoadManifestReport of a missing file = %+v, %v", empty, err)
+	}
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	report := ManifestReport{Manifest: "devices.yaml", UpdatedAt: now}
+	report.Record(ManifestDeviceOutcome{Key: "kitchen", NodeID: 10, Status: ManifestStatusFailed, Error: "timeout", UpdatedAt: now})
+	if err := SaveManifestReport(ctx, s, report); err != nil {
+		t.Fatalf("SaveManifestReport: %v", err)
+	}
+	loaded, err := LoadManifestReport(ctx, s)
+	if err != nil {
+		t.Fatalf("LoadManifestReport: %v", err)
+	}
+	got := loaded.Outcome("kitchen")
+	if loaded.Manifest != "devices.yaml" || !loaded.UpdatedAt.Equal(now) || got == nil ||
+		got.NodeID != 10 || got.Error != "timeout" || got.Attempts != 1 {
+		t.Errorf("loaded = %+v", loaded)
+	}
+}
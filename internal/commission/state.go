@@ -1,13 +1,24 @@
 package commission
 
 import (
+	"cmp"
 	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
 	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/types"
 )
 
 // PayloadRecord stores onboarding payload details for commissioning.
@@ -26,15 +37,59 @@ type PayloadRecord struct {
 
 // Bundle stores imported operational credentials for later reuse.
 type Bundle struct {
-	NodeID           uint64    `json:"node_id"`
-	FabricID         uint64    `json:"fabric_id,omitempty"`
-	RootCert         string    `json:"root_cert,omitempty"`
-	IntermediateCert string    `json:"intermediate_cert,omitempty"`
-	OperationalCert  string    `json:"operational_cert,omitempty"`
-	OperationalKey   string    `json:"operational_key,omitempty"`
-	IPK              string    `json:"ipk,omitempty"`
-	Source           string    `json:"source,omitempty"`
-	ImportedAt       time.Time `json:"imported_at"`
+	NodeID   uint64 `json:"node_id"`
+	FabricID uint64 `json:"fabric_id,omitempty"`
+	// RootCert is the root certificate as X.509 PEM, or as base64 encoded
+	// X.509 DER or Matter TLV.
+	RootCert         string `json:"root_cert,omitempty"`
+	IntermediateCert string `json:"intermediate_cert,omitempty"`
+	// RootKey is the PEM key of the root CA, present when this controller
+	// administers the fabric and can commission nodes onto it.
+	RootKey         string `json:"root_key,omitempty"`
+	OperationalCert string `json:"operational_cert,omitempty"`
+	OperationalKey  string `json:"operational_key,omitempty"`
+	// IPK is the epoch key of the identity protection key, in hex or base64.
+	IPK        string    `json:"ipk,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// RootPublicKey returns the uncompressed public key of the root certificate.
+func (b *Bundle) RootPublicKey() ([]byte, error) {
+	text := strings.TrimSpace(b.RootCert)
+	if text == "" {
+		return nil, fmt.Errorf("bundle has no root certificate")
+	}
+	var der []byte
+	if block, _ := pem.Decode([]byte(text)); block != nil {
+		der = block.Bytes
+	} else if v, err := base64.StdEncoding.DecodeString(text); err == nil {
+		der = v
+	} else {
+		return nil, fmt.Errorf("invalid root certificate encoding: %w", err)
+	}
+	if cert, err := x509.ParseCertificate(der); err == nil {
+		pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("root certificate key is not ECDSA")
+		}
+		key, err := pub.ECDH()
+		if err != nil {
+			return nil, err
+		}
+		return key.Bytes(), nil
+	}
+	// 6.5.2. Matter certificate: ec-pub-key is context tag 9.
+	var cert struct {
+		ECPublicKey []byte `tlv:"9"`
+	}
+	if err := tlv.Unmarshal(der, &cert); err != nil {
+		return nil, fmt.Errorf("invalid root certificate: %w", err)
+	}
+	if len(cert.ECPublicKey) == 0 {
+		return nil, fmt.Errorf("root certificate has no public key")
+	}
+	return cert.ECPublicKey, nil
 }
 
 // ResultRecord stores the last successful commissioning result.
@@ -45,24 +100,224 @@ type ResultRecord struct {
 	Device             string    `json:"device,omitempty"`
 	CommissionedAt     time.Time `json:"commissioned_at"`
 	PayloadFingerprint string    `json:"payload_fingerprint,omitempty"`
+	// Addresses are the operational addresses the node answered CASE at
+	// when it was commissioned.
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// DefaultFabricIndex is the local fabric used when none is selected.
+const DefaultFabricIndex uint8 = 1
+
+// NodeRecord stores the onboarding payload and commissioning result of a
+// node, and the checkpoint of an interrupted commissioning attempt.
+type NodeRecord struct {
+	NodeID     uint64                    `json:"node_id"`
+	Payload    *PayloadRecord            `json:"payload,omitempty"`
+	Result     *ResultRecord             `json:"result,omitempty"`
+	Checkpoint *commissioning.Checkpoint `json:"checkpoint,omitempty"`
+}
+
+// Fabric keeps the credentials and commissioned nodes of one fabric.
+type Fabric struct {
+	Index              uint8        `json:"index"`
+	FabricID           uint64       `json:"fabric_id,omitempty"`
+	CompressedFabricID uint64       `json:"compressed_fabric_id,omitempty"`
+	Bundle             *Bundle      `json:"bundle,omitempty"`
+	Nodes              []NodeRecord `json:"nodes,omitempty"`
+	CreatedAt          time.Time    `json:"created_at"`
 }
 
-// State keeps commissioning-related data for a single hub.
+// updateCompressedFabricID derives CompressedFabricID from the root public
+// key of the bundle and FabricID. It does nothing until both are known.
+func (f *Fabric) updateCompressedFabricID() error {
+	f.CompressedFabricID = 0
+	if f.Bundle == nil || f.Bundle.RootCert == "" || f.FabricID == 0 {
+		return nil
+	}
+	key, err := f.Bundle.RootPublicKey()
+	if err != nil {
+		return err
+	}
+	cfid, err := types.NewCompressedFabricID(key, f.FabricID)
+	if err != nil {
+		return err
+	}
+	f.CompressedFabricID = uint64(cfid)
+	return nil
+}
+
+// Node returns the record of nodeID, or nil if the fabric has none.
+func (f *Fabric) Node(nodeID uint64) *NodeRecord {
+	for i := range f.Nodes {
+		if f.Nodes[i].NodeID == nodeID {
+			return &f.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// LastCommissioned returns the most recently commissioned node, or nil if no
+// node on the fabric has been commissioned.
+func (f *Fabric) LastCommissioned() *NodeRecord {
+	var last *NodeRecord
+	for i := range f.Nodes {
+		node := &f.Nodes[i]
+		if node.Result == nil {
+			continue
+		}
+		if last == nil || node.Result.CommissionedAt.After(last.Result.CommissionedAt) {
+			last = node
+		}
+	}
+	return last
+}
+
+func (f *Fabric) node(nodeID uint64) *NodeRecord {
+	if node := f.Node(nodeID); node != nil {
+		return node
+	}
+	f.Nodes = append(f.Nodes, NodeRecord{NodeID: nodeID})
+	return &f.Nodes[len(f.Nodes)-1]
+}
+
+// State keeps commissioning-related data for the fabrics of a host, ordered
+// by fabric index.
 type State struct {
+	Fabrics []Fabric `json:"fabrics,omitempty"`
+}
+
+// Fabric returns the fabric with index, or nil if there is none. Index zero
+// selects DefaultFabricIndex.
+func (s *State) Fabric(index uint8) *Fabric {
+	if index == 0 {
+		index = DefaultFabricIndex
+	}
+	for i := range s.Fabrics {
+		if s.Fabrics[i].Index == index {
+			return &s.Fabrics[i]
+		}
+	}
+	return nil
+}
+
+// RemoveFabric removes the fabric with index and reports whether it existed.
+func (s *State) RemoveFabric(index uint8) bool {
+	for i := range s.Fabrics {
+		if s.Fabrics[i].Index == index {
+			s.Fabrics = slices.Delete(s.Fabrics, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveNode removes nodeID from the fabric with fabricIndex, zero selecting
+// DefaultFabricIndex, and reports whether it was recorded there. The same
+// node ID on other fabrics is a different node and is kept.
+func (s *State) RemoveNode(fabricIndex uint8, nodeID uint64) bool {
+	f := s.Fabric(fabricIndex)
+	if f == nil {
+		return false
+	}
+	n := len(f.Nodes)
+	f.Nodes = slices.DeleteFunc(f.Nodes, func(node NodeRecord) bool {
+		return node.NodeID == nodeID
+	})
+	return len(f.Nodes) != n
+}
+
+// AssignNodeID moves the records kept under the provisional node ID from,
+// such as the payload of a device commissioned without a node ID, to the
+// node ID to assigned by the commissioner, replacing a stale record of to.
+// It reports whether the fabric with fabricIndex had a record of from.
+func (s *State) AssignNodeID(fabricIndex uint8, from, to uint64) bool {
+	f := s.Fabric(fabricIndex)
+	if f == nil || from == to {
+		return f != nil && f.Node(from) != nil
+	}
+	node := f.Node(from)
+	if node == nil {
+		return false
+	}
+	moved := *node
+	moved.NodeID = to
+	f.Nodes = slices.DeleteFunc(f.Nodes, func(node NodeRecord) bool {
+		return node.NodeID == from || node.NodeID == to
+	})
+	f.Nodes = append(f.Nodes, moved)
+	return true
+}
+
+func (s *State) fabric(index uint8) *Fabric {
+	if index == 0 {
+		index = DefaultFabricIndex
+	}
+	if f := s.Fabric(index); f != nil {
+		return f
+	}
+	s.Fabrics = append(s.Fabrics, Fabric{Index: index, CreatedAt: time.Now()})
+	slices.SortFunc(s.Fabrics, func(a, b Fabric) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+	return s.Fabric(index)
+}
+
+// stateFile is the stored form of State. The single Payload, Bundle and
+// Result of older state files are migrated into DefaultFabricIndex.
+type stateFile struct {
+	State
 	Payload *PayloadRecord `json:"payload,omitempty"`
 	Bundle  *Bundle        `json:"bundle,omitempty"`
 	Result  *ResultRecord  `json:"result,omitempty"`
 }
 
-// LoadState loads commissioning state from the store.
+// LoadState loads commissioning state from the store. Like ImportBundle, it
+// rejects a bundle whose root certificate cannot be parsed, and it derives
+// the compressed fabric identifiers again from the stored bundles.
 func LoadState(ctx context.Context, s store.Store) (State, error) {
-	var state State
-	if err := s.Load(ctx, &state); err != nil {
+	var file stateFile
+	if err := s.Load(ctx, &file); err != nil {
 		return State{}, err
 	}
+	state := file.State
+	if file.Payload != nil || file.Bundle != nil || file.Result != nil {
+		migrateLegacyState(&state, file)
+	}
+	for i := range state.Fabrics {
+		f := &state.Fabrics[i]
+		if err := f.updateCompressedFabricID(); err != nil {
+			return State{}, fmt.Errorf("fabric %d: %w", f.Index, err)
+		}
+	}
 	return state, nil
 }
 
+// migrateLegacyState moves the single Payload, Bundle and Result of an older
+// state file into DefaultFabricIndex.
+func migrateLegacyState(state *State, file stateFile) {
+	f := state.fabric(DefaultFabricIndex)
+	if file.Bundle != nil && f.Bundle == nil {
+		f.Bundle = file.Bundle
+		f.FabricID = file.Bundle.FabricID
+		f.CreatedAt = file.Bundle.ImportedAt
+	}
+	if file.Bundle == nil && file.Payload != nil {
+		f.CreatedAt = file.Payload.ImportedAt
+	}
+	if file.Payload != nil {
+		node := f.node(file.Payload.NodeID)
+		if node.Payload == nil {
+			node.Payload = file.Payload
+		}
+	}
+	if file.Result != nil {
+		node := f.node(file.Result.NodeID)
+		if node.Result == nil {
+			node.Result = file.Result
+		}
+	}
+}
+
 // SaveState persists commissioning state to the store.
 func SaveState(ctx context.Context, s store.Store, state State) error {
 	return s.Save(ctx, &state)
@@ -88,8 +343,10 @@ func ParseOnboardingPayload(payload string) (encoding.OnboardingPayload, bool, e
 	return manualPayload, false, nil
 }
 
-// ImportPayload records onboarding payload details (QR or manual pairing code).
-func ImportPayload(ctx context.Context, s store.Store, nodeID uint64, payload string) (State, error) {
+// ImportPayload records onboarding payload details (QR or manual pairing code)
+// for nodeID on the fabric with fabricIndex, creating the fabric if needed.
+// It discards the checkpoint of an earlier attempt.
+func ImportPayload(ctx context.Context, s store.Store, fabricIndex uint8, nodeID uint64, payload string) (State, error) {
 	parsed, isQR, err := ParseOnboardingPayload(payload)
 	if err != nil {
 		return State{}, err
@@ -109,43 +366,102 @@ func ImportPayload(ctx context.Context, s store.Store, nodeID uint64, payload st
 
 	record.PayloadFingerprint = fingerprintPayload(record)
 
-	state, err := LoadState(ctx, s)
-	if err != nil {
-		return State{}, err
-	}
-	state.Payload = &record
-	if err := SaveState(ctx, s, state); err != nil {
-		return State{}, err
-	}
-	return state, nil
+	return update(ctx, s, func(state *State) error {
+		node := state.fabric(fabricIndex).node(nodeID)
+		node.Payload = &record
+		node.Checkpoint = nil
+		return nil
+	})
 }
 
-// ImportBundle saves a commissioning bundle for later operational reuse.
-func ImportBundle(ctx context.Context, s store.Store, bundle Bundle) (State, error) {
+// ImportBundle saves a commissioning bundle for later operational reuse as
+// the credentials of the fabric with fabricIndex, and derives the compressed
+// fabric identifier from its root certificate.
+func ImportBundle(ctx context.Context, s store.Store, fabricIndex uint8, bundle Bundle) (State, error) {
 	if bundle.ImportedAt.IsZero() {
 		bundle.ImportedAt = time.Now()
 	}
-	state, err := LoadState(ctx, s)
-	if err != nil {
-		return State{}, err
-	}
-	state.Bundle = &bundle
-	if err := SaveState(ctx, s, state); err != nil {
-		return State{}, err
-	}
-	return state, nil
+	return update(ctx, s, func(state *State) error {
+		f := state.fabric(fabricIndex)
+		f.Bundle = &bundle
+		if bundle.FabricID != 0 {
+			f.FabricID = bundle.FabricID
+		}
+		return f.updateCompressedFabricID()
+	})
 }
 
-// UpdateResult records the result of a successful commissioning.
-func UpdateResult(ctx context.Context, s store.Store, result ResultRecord) (State, error) {
+// UpdateResult records the result of a successful commissioning on the
+// fabric with fabricIndex.
+func UpdateResult(ctx context.Context, s store.Store, fabricIndex uint8, result ResultRecord) (State, error) {
 	if result.CommissionedAt.IsZero() {
 		result.CommissionedAt = time.Now()
 	}
+	return update(ctx, s, func(state *State) error {
+		node := state.fabric(fabricIndex).node(result.NodeID)
+		node.Result = &result
+		node.Checkpoint = nil
+		return nil
+	})
+}
+
+// AssignNodeID moves the records of the provisional node ID from on the
+// fabric with fabricIndex to the node ID to assigned by the commissioner.
+func AssignNodeID(ctx context.Context, s store.Store, fabricIndex uint8, from, to uint64) (State, error) {
+	if to == 0 {
+		return State{}, errors.New("assigned node ID is zero")
+	}
+	return update(ctx, s, func(state *State) error {
+		state.AssignNodeID(fabricIndex, from, to)
+		return nil
+	})
+}
+
+// SaveCheckpoint records the checkpoint of an interrupted commissioning
+// attempt for nodeID on the fabric with fabricIndex.
+func SaveCheckpoint(ctx context.Context, s store.Store, fabricIndex uint8, nodeID uint64, checkpoint commissioning.Checkpoint) (State, error) {
+	return update(ctx, s, func(state *State) error {
+		state.fabric(fabricIndex).node(nodeID).Checkpoint = &checkpoint
+		return nil
+	})
+}
+
+// RemoveFabric deletes the fabric with index and everything recorded for it.
+func RemoveFabric(ctx context.Context, s store.Store, index uint8) (State, error) {
+	return update(ctx, s, func(state *State) error {
+		if !state.RemoveFabric(index) {
+			return fmt.Errorf("fabric %d not found", index)
+		}
+		return nil
+	})
+}
+
+// RemoveNode deletes the records of nodeID on the fabric with fabricIndex
+// and reports whether it was recorded there.
+func RemoveNode(ctx context.Context, s store.Store, fabricIndex uint8, nodeID uint64) (bool, error) {
+	var removed bool
+	_, err := update(ctx, s, func(state *State) error {
+		removed = state.RemoveNode(fabricIndex, nodeID)
+		return nil
+	})
+	return removed, err
+}
+
+// stateMu serializes the read-modify-write updates of the state, which
+// concurrent commissioning attempts perform.
+var stateMu sync.Mutex
+
+// update applies fn to the stored state and saves it.
+func update(ctx context.Context, s store.Store, fn func(*State) error) (State, error) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
 	state, err := LoadState(ctx, s)
 	if err != nil {
 		return State{}, err
 	}
-	state.Result = &result
+	if err := fn(&state); err != nil {
+		return State{}, err
+	}
 	if err := SaveState(ctx, s, state); err != nil {
 		return State{}, err
 	}
@@ -0,0 +1,280 @@
+package commission
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// The root public key and fabric ID of the 4.3.2.2. Compressed Fabric
+// Identifier example, and the compressed fabric ID derived from them.
+const (
+	specRootPublicKey = "044a9f42b1ca4840d37292bbc7f6a7e11e22200c976fc900dbc98a7a383a641c" +
+		"b8254a2e56d4e295a847943b4e3897c4a773e930277b4d9fbede8a052686bfacfa"
+	specFabricID           = 0x2906C908D115D362
+	specCompressedFabricID = 0x87E1B004E235A130
+)
+
+// specRootCert returns a Matter TLV certificate holding only the example
+// root public key, base64 encoded.
+func specRootCert(t *testing.T) string {
+	t.Helper()
+	key, _ := hex.DecodeString(specRootPublicKey)
+	cert := struct {
+		ECPublicKey []byte `tlv:"9"`
+	}{key}
+	b, err := tlv.Marshal(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// x509RootCert returns a self-signed DER certificate of pub signed by priv.
+func x509RootCert(t *testing.T, pub, priv any) []byte {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "root"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestBundleRootPublicKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPub, err := ecKey.PublicKey.ECDH()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER := x509RootCert(t, &ecKey.PublicKey, ecKey)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specKey, _ := hex.DecodeString(specRootPublicKey)
+
+	tests := []struct {
+		name     string
+		rootCert string
+		want     []byte
+		wantErr  string
+	}{
+		{name: "x509 pem", rootCert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ecDER})), want: ecPub.Bytes()},
+		{name: "x509 base64", rootCert: base64.StdEncoding.EncodeToString(ecDER), want: ecPub.Bytes()},
+		{name: "matter tlv base64", rootCert: " " + specRootCert(t) + "\n", want: specKey},
+		{name: "empty", rootCert: "", wantErr: "no root certificate"},
+		// Hex digits are base64 too; decoded as base64 they are no certificate.
+		{name: "hex", rootCert: hex.EncodeToString(ecDER), wantErr: "invalid root certificate"},
+		{name: "not a certificate", rootCert: base64.StdEncoding.EncodeToString([]byte("root")), wantErr: "invalid root certificate"},
+		{name: "tlv without key", rootCert: "FRg=", wantErr: "no public key"},
+		{name: "ed25519", rootCert: base64.StdEncoding.EncodeToString(x509RootCert(t, edPub, edKey)), wantErr: "not ECDSA"},
+	}
+	for _, tt := range tests {
+		b := Bundle{RootCert: tt.rootCert}
+		got, err := b.RootPublicKey()
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: RootPublicKey = %X, %v, want %q", tt.name, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || !bytes.Equal(got, tt.want) {
+			t.Errorf("%s: RootPublicKey = %X, %v, want %X", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestLoadStateLegacy(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.json")
+	legacy := `{
+  "payload": {"node_id": 5, "vendor_id": 65521, "product_id": 32768, "discriminator": 3840, "imported_at": "2025-01-01T00:00:00Z", "payload_fingerprint": "fp"},
+  "bundle": {"node_id": 5, "fabric_id": 2956271245120099170, "root_cert": "` + specRootCert(t) + `", "imported_at": "2025-01-02T00:00:00Z"},
+  "result": {"node_id": 5, "vendor_id": 65521, "product_id": 32768, "commissioned_at": "2025-01-03T00:00:00Z"}
+}`
+	if err := os.WriteFile(path, []byte(legacy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s := store.NewJSONFileStore(path)
+	state, err := LoadState(ctx, s)
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if len(state.Fabrics) != 1 {
+		t.Fatalf("fabrics = %+v", state.Fabrics)
+	}
+	f := state.Fabric(DefaultFabricIndex)
+	if f == nil || f.FabricID != specFabricID || f.CompressedFabricID != specCompressedFabricID || f.Bundle == nil ||
+		!f.CreatedAt.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("fabric = %+v", f)
+	}
+	node := f.Node(5)
+	if node == nil || node.Payload == nil || node.Payload.PayloadFingerprint != "fp" || node.Result == nil || node.Result.VendorID != 65521 {
+		t.Fatalf("node = %+v", node)
+	}
+
+	// Saving writes the migrated form, which loads the same.
+	if err := SaveState(ctx, s, state); err != nil {
+		t.Fatal(err)
+	}
+	var saved map[string]json.RawMessage
+	if err := s.Load(ctx, &saved); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"payload", "bundle", "result"} {
+		if _, ok := saved[key]; ok {
+			t.Errorf("saved state keeps the legacy %s", key)
+		}
+	}
+	reloaded, err := LoadState(ctx, s)
+	if err != nil || len(reloaded.Fabrics) != 1 || reloaded.Fabric(DefaultFabricIndex).Node(5) == nil {
+		t.Errorf("reloaded state = %+v, %v", reloaded, err)
+	}
+}
+
+func TestInvalidRootCert(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	bundle := Bundle{FabricID: specFabricID, RootCert: "not base64"}
+
+	s := store.NewJSONFileStore(filepath.Join(dir, "import.json"))
+	if _, err := ImportBundle(ctx, s, 1, bundle); err == nil {
+		t.Errorf("ImportBundle accepted an invalid root certificate")
+	}
+
+	s = store.NewJSONFileStore(filepath.Join(dir, "state.json"))
+	state := State{Fabrics: []Fabric{{Index: 2, FabricID: specFabricID, Bundle: &bundle}}}
+	if err := SaveState(ctx, s, state); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadState(ctx, s); err == nil || !strings.Contains(err.Error(), "fabric 2:") {
+		t.Errorf("LoadState = %v, want an error for fabric 2", err)
+	}
+
+	s = store.NewJSONFileStore(filepath.Join(dir, "legacy.json"))
+	if err := s.Save(ctx, stateFile{Bundle: &bundle}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadState(ctx, s); err == nil {
+		t.Errorf("LoadState accepted a legacy bundle with an invalid root certificate")
+	}
+}
+
+func TestRemoveNode(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewJSONFileStore(filepath.Join(t.TempDir(), "state.json"))
+	for _, fabric := range []uint8{1, 2} {
+		for _, nodeID := range []uint64{10, 11} {
+			if _, err := UpdateResult(ctx, s, fabric, ResultRecord{NodeID: nodeID}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name        string
+		fabric      uint8
+		nodeID      uint64
+		wantRemoved bool
+	}{
+		{name: "fabric 2", fabric: 2, nodeID: 10, wantRemoved: true},
+		{name: "again", fabric: 2, nodeID: 10, wantRemoved: false},
+		{name: "unknown node", fabric: 1, nodeID: 12, wantRemoved: false},
+		{name: "unknown fabric", fabric: 3, nodeID: 11, wantRemoved: false},
+		{name: "default fabric", fabric: 0, nodeID: 11, wantRemoved: true},
+	}
+	for _, tt := range tests {
+		removed, err := RemoveNode(ctx, s, tt.fabric, tt.nodeID)
+		if err != nil || removed != tt.wantRemoved {
+			t.Errorf("%s: RemoveNode = %v, %v, want %v", tt.name, removed, err, tt.wantRemoved)
+		}
+	}
+
+	state, err := LoadState(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[uint8][]uint64{1: {10}, 2: {11}}
+	for fabric, nodeIDs := range want {
+		f := state.Fabric(fabric)
+		if f == nil || len(f.Nodes) != len(nodeIDs) {
+			t.Errorf("fabric %d = %+v, want nodes %v", fabric, f, nodeIDs)
+			continue
+		}
+		for _, nodeID := range nodeIDs {
+			if f.Node(nodeID) == nil {
+				t.Errorf("fabric %d: node %d was removed", fabric, nodeID)
+			}
+		}
+	}
+	if state.Fabric(3) != nil {
+		t.Errorf("RemoveNode created fabric 3")
+	}
+}
+
+func TestAssignNodeID(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodes     []NodeRecord
+		from, to  uint64
+		wantFound bool
+		want      []uint64
+	}{
+		{name: "provisional", nodes: []NodeRecord{{NodeID: 0, Payload: &PayloadRecord{}}, {NodeID: 5}}, from: 0, to: 7, wantFound: true, want: []uint64{5, 7}},
+		{name: "stale record", nodes: []NodeRecord{{NodeID: 0, Payload: &PayloadRecord{}}, {NodeID: 7}}, from: 0, to: 7, wantFound: true, want: []uint64{7}},
+		{name: "same", nodes: []NodeRecord{{NodeID: 7, Payload: &PayloadRecord{}}}, from: 7, to: 7, wantFound: true, want: []uint64{7}},
+		{name: "missing", nodes: []NodeRecord{{NodeID: 5}}, from: 0, to: 7, wantFound: false, want: []uint64{5}},
+	}
+	for _, tt := range tests {
+		state := State{Fabrics: []Fabric{{Index: DefaultFabricIndex, Nodes: tt.nodes}}}
+		if found := state.AssignNodeID(0, tt.from, tt.to); found != tt.wantFound {
+			t.Errorf("%s: AssignNodeID = %v, want %v", tt.name, found, tt.wantFound)
+		}
+		f := state.Fabric(DefaultFabricIndex)
+		if len(f.Nodes) != len(tt.want) {
+			t.Errorf("%s: nodes = %+v, want %v", tt.name, f.Nodes, tt.want)
+			continue
+		}
+		for _, nodeID := range tt.want {
+			if f.Node(nodeID) == nil {
+				t.Errorf("%s: node %d is missing", tt.name, nodeID)
+			}
+		}
+		if tt.wantFound && f.Node(tt.to).Payload == nil {
+			t.Errorf("%s: payload was not moved to node %d", tt.name, tt.to)
+		}
+	}
+
+	s := store.NewJSONFileStore(filepath.Join(t.TempDir(), "state.json"))
+	if _, err := AssignNodeID(context.Background(), s, 0, 0, 0); err == nil {
+		t.Errorf("AssignNodeID to node 0 succeeded")
+	}
+}
@@ -1,16 +1,51 @@
 package matterctrl
 
-import "context"
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
 
 // Controller abstracts Matter operational connections.
 type Controller interface {
 	// Ping checks connectivity to an operational node.
 	Ping(ctx context.Context, nodeID uint64) error
 
-	// ReadAttribute reads an attribute value by numeric identifiers.
-	ReadAttribute(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32) (any, error)
+	// ReadAttribute reads an attribute value by numeric identifiers. A path the
+	// node rejects is reported as an *im.StatusError.
+	ReadAttribute(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32) (im.Value, error)
 	// WriteAttribute writes an attribute value by numeric identifiers.
 	WriteAttribute(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32, value any) error
 	// InvokeCommand invokes a command by numeric identifiers.
 	InvokeCommand(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, cmdID uint32, payload any) (any, error)
+	// TimedInvokeCommand invokes a command that requires a timed
+	// interaction, such as OpenCommissioningWindow; the node accepts the
+	// command within timeout.
+	TimedInvokeCommand(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, cmdID uint32, payload any, timeout time.Duration) (any, error)
+
+	// ReadEvents reads events matching paths whose event number is at least eventMin.
+	ReadEvents(ctx context.Context, nodeID uint64, paths []im.EventPath, eventMin uint64) ([]im.EventData, error)
+	// SubscribeEvents subscribes to events matching paths whose event number is at
+	// least eventMin. The returned channel is closed when ctx is done or the
+	// subscription is lost.
+	SubscribeEvents(ctx context.Context, nodeID uint64, paths []im.EventPath, eventMin uint64, minInterval, maxInterval time.Duration) (<-chan im.EventData, error)
+}
+
+// SessionCache is implemented by controllers that cache operational sessions
+// or CASE session resumption records.
+type SessionCache interface {
+	// ForgetNode drops the cached sessions and resumption records of nodeID.
+	ForgetNode(nodeID uint64) error
+}
+
+// NodeResolver is implemented by controllers that locate operational nodes
+// through DNS-SD, so a node is found again after its address changes.
+type NodeResolver interface {
+	// ResolveNode returns the current operational address of nodeID.
+	ResolveNode(ctx context.Context, nodeID uint64) (*net.UDPAddr, error)
+	// ForgetNodeAddress drops the cached DNS-SD answer of nodeID so that
+	// the next ResolveNode queries the network again.
+	ForgetNodeAddress(nodeID uint64)
 }
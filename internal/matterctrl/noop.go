@@ -3,6 +3,10 @@ package matterctrl
 import (
 	"context"
 	"errors"
+	"net"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
 )
 
 // ErrControllerUnavailable is returned when no operational controller is configured.
@@ -11,7 +15,11 @@ var ErrControllerUnavailable = errors.New("operational controller is unavailable
 // NoopController returns ErrControllerUnavailable for all operations.
 type NoopController struct{}
 
-var _ Controller = (*NoopController)(nil)
+var (
+	_ Controller   = (*NoopController)(nil)
+	_ SessionCache = (*NoopController)(nil)
+	_ NodeResolver = (*NoopController)(nil)
+)
 
 // NewNoopController returns a controller that always fails with ErrControllerUnavailable.
 func NewNoopController() *NoopController {
@@ -24,8 +32,8 @@ func (c *NoopController) Ping(_ context.Context, _ uint64) error {
 }
 
 // ReadAttribute reads an attribute value by numeric identifiers.
-func (c *NoopController) ReadAttribute(_ context.Context, _ uint64, _ uint16, _ uint32, _ uint32) (any, error) {
-	return nil, ErrControllerUnavailable
+func (c *NoopController) ReadAttribute(_ context.Context, _ uint64, _ uint16, _ uint32, _ uint32) (im.Value, error) {
+	return im.Value{}, ErrControllerUnavailable
 }
 
 // WriteAttribute writes an attribute value by numeric identifiers.
@@ -37,3 +45,31 @@ func (c *NoopController) WriteAttribute(_ context.Context, _ uint64, _ uint16, _
 func (c *NoopController) InvokeCommand(_ context.Context, _ uint64, _ uint16, _ uint32, _ uint32, _ any) (any, error) {
 	return nil, ErrControllerUnavailable
 }
+
+// TimedInvokeCommand returns ErrControllerUnavailable.
+func (c *NoopController) TimedInvokeCommand(_ context.Context, _ uint64, _ uint16, _ uint32, _ uint32, _ any, _ time.Duration) (any, error) {
+	return nil, ErrControllerUnavailable
+}
+
+// ReadEvents reads events matching paths whose event number is at least eventMin.
+func (c *NoopController) ReadEvents(_ context.Context, _ uint64, _ []im.EventPath, _ uint64) ([]im.EventData, error) {
+	return nil, ErrControllerUnavailable
+}
+
+// SubscribeEvents subscribes to events matching paths.
+func (c *NoopController) SubscribeEvents(_ context.Context, _ uint64, _ []im.EventPath, _ uint64, _, _ time.Duration) (<-chan im.EventData, error) {
+	return nil, ErrControllerUnavailable
+}
+
+// ForgetNode does nothing as no sessions are cached.
+func (c *NoopController) ForgetNode(_ uint64) error {
+	return nil
+}
+
+// ForgetNodeAddress does nothing as no addresses are cached.
+func (c *NoopController) ForgetNodeAddress(_ uint64) {}
+
+// ResolveNode returns ErrControllerUnavailable.
+func (c *NoopController) ResolveNode(_ context.Context, _ uint64) (*net.UDPAddr, error) {
+	return nil, ErrControllerUnavailable
+}
@@ -0,0 +1,308 @@
+package matterctrl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+// Establisher opens CASE sessions to operational nodes, such as
+// matter.CASEEstablisher.
+type Establisher interface {
+	Establish(ctx context.Context, peer commissioning.OperationalPeer, addrs []*net.UDPAddr) (*transport.Session, error)
+}
+
+// OperationalController is a Controller that reaches the nodes of one
+// fabric over CASE sessions. It keeps one session per node, runs the
+// operations on a node one at a time, and drops the session of a node
+// that stops answering so the next operation resolves and reaches it again.
+// Subscriptions get sessions of their own.
+type OperationalController struct {
+	establisher Establisher
+	resolver    NodeResolver
+	fabricID    uint64
+
+	mu    sync.Mutex
+	nodes map[uint64]*operationalNode
+}
+
+// operationalNode serializes the operations on a node and holds its session.
+type operationalNode struct {
+	mu      sync.Mutex
+	session *transport.Session
+}
+
+var (
+	_ Controller   = (*OperationalController)(nil)
+	_ SessionCache = (*OperationalController)(nil)
+)
+
+// NewOperationalController returns a controller reaching the nodes of the
+// fabric with fabricID, located through resolver.
+func NewOperationalController(establisher Establisher, resolver NodeResolver, fabricID uint64) *OperationalController {
+	return &OperationalController{
+		establisher: establisher,
+		resolver:    resolver,
+		fabricID:    fabricID,
+		nodes:       map[uint64]*operationalNode{},
+	}
+}
+
+// Ping checks connectivity to an operational node by reading its data model
+// revision.
+func (c *OperationalController) Ping(ctx context.Context, nodeID uint64) error {
+	_, err := c.ReadAttribute(ctx, nodeID, 0, clusters.BasicInformationClusterID, clusters.BasicInformationAttrDataModelRevision)
+	return err
+}
+
+// ReadAttribute reads an attribute value by numeric identifiers.
+func (c *OperationalController) ReadAttribute(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32) (im.Value, error) {
+	path := im.NewAttributePath(endpoint, clusterID, attrID)
+	var report *im.ReportData
+	err := c.do(ctx, nodeID, func(s *transport.Session) error {
+		var err error
+		report, err = s.Read(ctx, &im.ReadRequest{AttributeRequests: []im.AttributePath{path}, FabricFiltered: true})
+		return err
+	})
+	if err != nil {
+		return im.Value{}, err
+	}
+	if len(report.AttributeStatuses) != 0 {
+		return im.Value{}, report.AttributeStatuses[0]
+	}
+	var values [][]byte
+	for _, data := range report.Attributes {
+		if data.Path.String() == path.String() {
+			values = append(values, data.Data)
+		}
+	}
+	switch len(values) {
+	case 0:
+		return im.Value{}, fmt.Errorf("node %016X reported no value for %s", nodeID, path)
+	case 1:
+		return im.NewValue(values[0])
+	}
+	return appendListItems(values[0], values[1:])
+}
+
+// appendListItems returns the list attribute a chunked report carries as
+// the list followed by the items appended to it.
+func appendListItems(list []byte, items [][]byte) (im.Value, error) {
+	enc := tlv.NewEncoder()
+	enc.StartArray(tlv.AnonymousTag())
+	r := tlv.NewReader(list)
+	if !r.Next() || r.EnterContainer() != nil {
+		return im.Value{}, fmt.Errorf("%w: chunked attribute is not a list", im.ErrMalformed)
+	}
+	for r.Next() {
+		if err := r.Copy(enc, tlv.AnonymousTag()); err != nil {
+			return im.Value{}, err
+		}
+	}
+	if err := r.ExitContainer(); err != nil {
+		return im.Value{}, err
+	}
+	for _, item := range items {
+		r := tlv.NewReader(item)
+		if !r.Next() {
+			return im.Value{}, fmt.Errorf("%w: empty list item", im.ErrMalformed)
+		}
+		if err := r.Copy(enc, tlv.AnonymousTag()); err != nil {
+			return im.Value{}, err
+		}
+	}
+	if err := enc.EndContainer(); err != nil {
+		return im.Value{}, err
+	}
+	return im.NewValue(enc.Bytes())
+}
+
+// WriteAttribute writes an attribute value by numeric identifiers. The
+// value is encoded with tlv.Marshal unless it is an im.Value.
+func (c *OperationalController) WriteAttribute(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32, value any) error {
+	if value == nil {
+		return errors.New("write attribute: value is nil")
+	}
+	data, err := encodeFields(value)
+	if err != nil {
+		return err
+	}
+	req := &im.WriteRequest{Attributes: []im.AttributeData{{Path: im.NewAttributePath(endpoint, clusterID, attrID), Data: data}}}
+	return c.do(ctx, nodeID, func(s *transport.Session) error {
+		return s.Write(ctx, req, 0)
+	})
+}
+
+// InvokeCommand invokes a command by numeric identifiers. The payload is
+// encoded with tlv.Marshal unless it is an im.Value, and nil sends an
+// empty structure. The response command fields are returned as an im.Value, the zero
+// Value for commands answered with a success status.
+func (c *OperationalController) InvokeCommand(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, cmdID uint32, payload any) (any, error) {
+	return c.TimedInvokeCommand(ctx, nodeID, endpoint, clusterID, cmdID, payload, 0)
+}
+
+// TimedInvokeCommand invokes a command as InvokeCommand does, preceded by a
+// Timed Request with timeout unless timeout is zero.
+func (c *OperationalController) TimedInvokeCommand(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, cmdID uint32, payload any, timeout time.Duration) (any, error) {
+	fields, err := encodeFields(payload)
+	if err != nil {
+		return nil, err
+	}
+	req := &im.InvokeRequest{Commands: []im.CommandData{{
+		Path:   im.CommandPath{Endpoint: endpoint, Cluster: clusterID, Command: cmdID},
+		Fields: fields,
+	}}}
+	var resp im.Value
+	err = c.do(ctx, nodeID, func(s *transport.Session) error {
+		resp, err = s.InvokeRequest(ctx, req, timeout)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReadEvents reads events matching paths whose event number is at least eventMin.
+func (c *OperationalController) ReadEvents(ctx context.Context, nodeID uint64, paths []im.EventPath, eventMin uint64) ([]im.EventData, error) {
+	req := &im.ReadRequest{EventRequests: paths, EventFilters: []im.EventFilter{{EventMin: eventMin}}, FabricFiltered: true}
+	var report *im.ReportData
+	err := c.do(ctx, nodeID, func(s *transport.Session) error {
+		var err error
+		report, err = s.Read(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(report.Events) == 0 && len(report.EventStatuses) != 0 {
+		return nil, report.EventStatuses[0]
+	}
+	return report.Events, nil
+}
+
+// SubscribeEvents subscribes to events matching paths whose event number is
+// at least eventMin, on a session of its own. The events of the priming
+// report are delivered first. The returned channel is closed when ctx is
+// done or the subscription is lost.
+func (c *OperationalController) SubscribeEvents(ctx context.Context, nodeID uint64, paths []im.EventPath, eventMin uint64, minInterval, maxInterval time.Duration) (<-chan im.EventData, error) {
+	session, err := c.establish(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	sub, priming, err := session.Subscribe(ctx, &im.SubscribeRequest{
+		MinIntervalFloor: minInterval,
+		MaxIntervalCeil:  maxInterval,
+		EventRequests:    paths,
+		EventFilters:     []im.EventFilter{{EventMin: eventMin}},
+		FabricFiltered:   true,
+	})
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	events := make(chan im.EventData)
+	go func() {
+		defer close(events)
+		defer sub.Close()
+		report := priming
+		for {
+			for _, ev := range report.Events {
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+			next, err := sub.Next(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					c.resolver.ForgetNodeAddress(nodeID)
+				}
+				return
+			}
+			report = next
+		}
+	}()
+	return events, nil
+}
+
+// ForgetNode closes the session of nodeID.
+func (c *OperationalController) ForgetNode(nodeID uint64) error {
+	c.mu.Lock()
+	node, ok := c.nodes[nodeID]
+	delete(c.nodes, nodeID)
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	if node.session != nil {
+		node.session.Close()
+		node.session = nil
+	}
+	return nil
+}
+
+// do runs op on the session of nodeID, establishing it if needed. A failure
+// other than a status reported by the node closes the session and drops
+// the address of the node.
+func (c *OperationalController) do(ctx context.Context, nodeID uint64, op func(*transport.Session) error) error {
+	c.mu.Lock()
+	node, ok := c.nodes[nodeID]
+	if !ok {
+		node = &operationalNode{}
+		c.nodes[nodeID] = node
+	}
+	c.mu.Unlock()
+
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	if node.session == nil {
+		session, err := c.establish(ctx, nodeID)
+		if err != nil {
+			return err
+		}
+		node.session = session
+	}
+	err := op(node.session)
+	var status *im.StatusError
+	if err != nil && !errors.As(err, &status) {
+		node.session.Close()
+		node.session = nil
+		c.resolver.ForgetNodeAddress(nodeID)
+	}
+	return err
+}
+
+// establish resolves nodeID and opens a CASE session to it.
+func (c *OperationalController) establish(ctx context.Context, nodeID uint64) (*transport.Session, error) {
+	addr, err := c.resolver.ResolveNode(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve node %016X: %w", nodeID, err)
+	}
+	peer := commissioning.OperationalPeer{FabricID: c.fabricID, NodeID: nodeID}
+	return c.establisher.Establish(ctx, peer, []*net.UDPAddr{addr})
+}
+
+// encodeFields returns the TLV encoding of v, an empty structure for a nil
+// v as for commands without fields.
+func encodeFields(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return tlv.Marshal(struct{}{})
+	case im.Value:
+		return v.Bytes(), nil
+	}
+	return tlv.Marshal(v)
+}
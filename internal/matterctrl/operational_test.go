@@ -0,0 +1,400 @@
+package matterctrl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+const testFabricID uint64 = 0xFAB000000000001D
+
+type readRequestView struct {
+	Attributes []struct {
+		Endpoint  uint16 `tlv:"2"`
+		Cluster   uint32 `tlv:"3"`
+		Attribute uint32 `tlv:"4"`
+	} `tlv:"0"`
+	Events []struct {
+		Cluster uint32 `tlv:"2"`
+	} `tlv:"1"`
+}
+
+// fakeNode answers the interactions of the controller on the peer ends of
+// the sessions it establishes.
+type fakeNode struct {
+	t          *testing.T
+	ctx        context.Context
+	attributes []im.AttributeData
+	events     []im.EventData
+	status     im.Status
+
+	mu           sync.Mutex
+	establishes  int
+	invoked      []im.CommandData
+	written      []im.AttributeData
+	unresponsive bool
+}
+
+// Establish opens an unsecured loopback session served by the node. Only
+// nodes of the test fabric are reached.
+func (n *fakeNode) Establish(_ context.Context, peer commissioning.OperationalPeer, addrs []*net.UDPAddr) (*transport.Session, error) {
+	if peer.FabricID != testFabricID || len(addrs) != 1 {
+		return nil, errors.New("unexpected peer")
+	}
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	conn, err := transport.Dial(pc.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		pc.Close()
+		return nil, err
+	}
+	n.mu.Lock()
+	n.establishes++
+	n.mu.Unlock()
+	go n.serve(transport.NewUnsecuredSession(transport.NewConn(pc, nil), 0))
+	return transport.NewUnsecuredSession(conn, 1, transport.WithRetransmitInterval(10*time.Millisecond)), nil
+}
+
+func (n *fakeNode) serve(s *transport.Session) {
+	defer s.Close()
+	for {
+		ex, msg, err := s.Accept(n.ctx)
+		if err != nil {
+			return
+		}
+		n.mu.Lock()
+		unresponsive := n.unresponsive
+		n.mu.Unlock()
+		if unresponsive {
+			return
+		}
+		if err := n.handle(s, ex, msg); err != nil {
+			n.t.Error(err)
+		}
+		ex.Close()
+	}
+}
+
+func (n *fakeNode) handle(s *transport.Session, ex *transport.Exchange, msg *transport.Message) error {
+	switch {
+	case msg.Is(protocol.InteractionModelProtocol, protocol.ReadRequestMessage):
+		var req readRequestView
+		if err := tlv.Unmarshal(msg.Payload, &req); err != nil {
+			return err
+		}
+		report := &im.ReportData{SuppressResponse: true}
+		for _, path := range req.Attributes {
+			want := im.NewAttributePath(path.Endpoint, path.Cluster, path.Attribute).String()
+			for _, data := range n.attributes {
+				if data.Path.String() == want {
+					report.Attributes = append(report.Attributes, data)
+				}
+			}
+		}
+		if len(req.Events) != 0 {
+			report.Events = n.events
+		}
+		return n.send(ex, protocol.ReportDataMessage, report.Encode)
+	case msg.Is(protocol.InteractionModelProtocol, protocol.InvokeRequestMessage):
+		req, err := im.DecodeInvokeRequest(msg.Payload)
+		if err != nil {
+			return err
+		}
+		n.mu.Lock()
+		n.invoked = append(n.invoked, req.Commands...)
+		status := n.status
+		n.mu.Unlock()
+		return n.send(ex, protocol.InvokeResponseMessage, im.NewInvokeStatusResponse(req.Commands[0].Path, status).Encode)
+	case msg.Is(protocol.InteractionModelProtocol, protocol.WriteRequestMessage):
+		var req struct {
+			Writes []struct {
+				Data uint8 `tlv:"2"`
+			} `tlv:"2"`
+		}
+		if err := tlv.Unmarshal(msg.Payload, &req); err != nil {
+			return err
+		}
+		n.mu.Lock()
+		for _, w := range req.Writes {
+			data, _ := tlv.Marshal(w.Data)
+			n.written = append(n.written, im.AttributeData{Data: data})
+		}
+		n.mu.Unlock()
+		return n.send(ex, protocol.WriteResponseMessage, func() ([]byte, error) {
+			return tlv.Marshal(struct {
+				Responses []struct{} `tlv:"0"`
+			}{})
+		})
+	case msg.Is(protocol.InteractionModelProtocol, protocol.SubscribeRequestMessage):
+		return n.subscribe(s, ex)
+	}
+	return errors.New("unexpected message")
+}
+
+// subscribe primes a subscription with the first event of the node, then
+// reports the others one at a time.
+func (n *fakeNode) subscribe(s *transport.Session, ex *transport.Exchange) error {
+	id := uint32(1)
+	priming := &im.ReportData{SubscriptionID: &id, Events: n.events[:1]}
+	payload, err := priming.Encode()
+	if err != nil {
+		return err
+	}
+	if _, err := ex.Request(n.ctx, protocol.InteractionModelProtocol, protocol.ReportDataMessage, payload); err != nil {
+		return err
+	}
+	resp := &im.SubscribeResponse{SubscriptionID: id, MaxInterval: time.Minute}
+	if err := n.send(ex, protocol.SubscribeResponseMessage, resp.Encode); err != nil {
+		return err
+	}
+	for _, ev := range n.events[1:] {
+		report := &im.ReportData{SubscriptionID: &id, Events: []im.EventData{ev}}
+		payload, err := report.Encode()
+		if err != nil {
+			return err
+		}
+		ex := s.NewExchange()
+		_, err = ex.Request(n.ctx, protocol.InteractionModelProtocol, protocol.ReportDataMessage, payload)
+		ex.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// set updates the behavior of the node.
+func (n *fakeNode) set(status im.Status, unresponsive bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status, n.unresponsive = status, unresponsive
+}
+
+// recorded returns the number of sessions established and the commands
+// invoked and values written so far.
+func (n *fakeNode) recorded() (int, []im.CommandData, []im.AttributeData) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.establishes, n.invoked, n.written
+}
+
+func (n *fakeNode) send(ex *transport.Exchange, opcode protocol.Opcode, encode func() ([]byte, error)) error {
+	payload, err := encode()
+	if err != nil {
+		return err
+	}
+	return ex.Send(n.ctx, protocol.InteractionModelProtocol, opcode, payload)
+}
+
+// forgettingResolver resolves every node to one address and records the
+// nodes forgotten.
+type forgettingResolver struct {
+	mu        sync.Mutex
+	forgotten []uint64
+}
+
+func (r *forgettingResolver) ResolveNode(_ context.Context, _ uint64) (*net.UDPAddr, error) {
+	return &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 5540}, nil
+}
+
+func (r *forgettingResolver) ForgetNodeAddress(nodeID uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.forgotten = append(r.forgotten, nodeID)
+}
+
+func newTestController(t *testing.T, node *fakeNode) (*OperationalController, *forgettingResolver) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	node.t, node.ctx = t, ctx
+	resolver := &forgettingResolver{}
+	return NewOperationalController(node, resolver, testFabricID), resolver
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := tlv.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestOperationalControllerReadAttribute(t *testing.T) {
+	battery := im.NewAttributePath(1, clusters.PowerSourceClusterID, clusters.PowerSourceAttrBatPercentRemaining)
+	parts := im.NewAttributePath(0, clusters.DescriptorClusterID, clusters.DescriptorAttrPartsList)
+	node := &fakeNode{attributes: []im.AttributeData{
+		{Path: battery, Data: mustMarshal(t, uint8(200))},
+		// A chunked list: the list, then an appended item.
+		{Path: parts, Data: mustMarshal(t, []uint16{1, 2})},
+		{Path: parts, Data: mustMarshal(t, uint16(3))},
+	}}
+	ctrl, _ := newTestController(t, node)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name    string
+		path    im.AttributePath
+		list    bool
+		want    []uint64
+		wantErr bool
+	}{
+		{"scalar", battery, false, []uint64{200}, false},
+		{"chunked list", parts, true, []uint64{1, 2, 3}, false},
+		{"not reported", im.NewAttributePath(2, clusters.PowerSourceClusterID, clusters.PowerSourceAttrBatPercentRemaining), false, nil, true},
+	}
+	for _, tt := range tests {
+		value, err := ctrl.ReadAttribute(ctx, 1, *tt.path.Endpoint, *tt.path.Cluster, *tt.path.Attribute)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: ReadAttribute() = %v", tt.name, value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ReadAttribute() = %v", tt.name, err)
+			continue
+		}
+		items := []im.Value{value}
+		if tt.list {
+			if items, err = value.AsList(); err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+				continue
+			}
+		}
+		var got []uint64
+		for _, item := range items {
+			v, _ := item.AsUint()
+			got = append(got, v)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: value %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if establishes, _, _ := node.recorded(); establishes != 1 {
+		t.Errorf("established %d sessions, want 1", establishes)
+	}
+}
+
+func TestOperationalControllerInvoke(t *testing.T) {
+	node := &fakeNode{status: im.StatusUnsupportedCluster}
+	ctrl, resolver := newTestController(t, node)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A failure status reported by the node keeps the session.
+	req := clusters.OperationalCredentialsRemoveFabricRequest{FabricIndex: 3}
+	var status *im.StatusError
+	if _, err := ctrl.InvokeCommand(ctx, 1, 0, clusters.OperationalCredentialsClusterID, clusters.OperationalCredentialsCmdRemoveFabric, req); !errors.As(err, &status) {
+		t.Fatalf("InvokeCommand() = %v, want a status error", err)
+	}
+	node.set(im.StatusSuccess, false)
+	resp, err := ctrl.InvokeCommand(ctx, 1, 1, clusters.OnOffClusterID, clusters.OnOffCmdToggle, nil)
+	if err != nil {
+		t.Fatalf("InvokeCommand() = %v", err)
+	}
+	if v, ok := resp.(im.Value); !ok || !v.IsNull() {
+		t.Errorf("response %v, want the zero Value", resp)
+	}
+	if err := ctrl.WriteAttribute(ctx, 1, 1, clusters.OnOffClusterID, clusters.OnOffAttrStartUpOnOff, uint8(2)); err != nil {
+		t.Errorf("WriteAttribute() = %v", err)
+	}
+	establishes, invoked, written := node.recorded()
+	if establishes != 1 {
+		t.Errorf("established %d sessions, want 1", establishes)
+	}
+	if len(invoked) != 2 || !bytes.Equal(invoked[1].Fields, []byte{0x15, 0x18}) {
+		t.Fatalf("invoked %+v", invoked)
+	}
+	var fields struct {
+		FabricIndex uint8 `tlv:"0"`
+	}
+	if err := tlv.Unmarshal(invoked[0].Fields, &fields); err != nil || fields.FabricIndex != 3 {
+		t.Errorf("RemoveFabric fields %+v, %v", fields, err)
+	}
+	if len(written) != 1 || written[0].Data[1] != 2 {
+		t.Errorf("written %+v", written)
+	}
+
+	// A node that stops answering loses its session and address; the next
+	// operation establishes a new session.
+	node.set(im.StatusSuccess, true)
+	if err := ctrl.Ping(ctx, 1); err == nil || errors.As(err, &status) {
+		t.Fatalf("Ping() of an unresponsive node = %v", err)
+	}
+	resolver.mu.Lock()
+	forgotten := slices.Clone(resolver.forgotten)
+	resolver.mu.Unlock()
+	if !slices.Equal(forgotten, []uint64{1}) {
+		t.Errorf("forgotten addresses %v, want node 1", forgotten)
+	}
+	node.set(im.StatusSuccess, false)
+	if _, err := ctrl.InvokeCommand(ctx, 1, 1, clusters.OnOffClusterID, clusters.OnOffCmdToggle, nil); err != nil {
+		t.Errorf("InvokeCommand() after reconnecting = %v", err)
+	}
+	if establishes, _, _ := node.recorded(); establishes != 2 {
+		t.Errorf("established %d sessions, want 2", establishes)
+	}
+	if err := ctrl.ForgetNode(1); err != nil {
+		t.Errorf("ForgetNode() = %v", err)
+	}
+}
+
+func TestOperationalControllerEvents(t *testing.T) {
+	path := im.NewClusterEventPath(1, clusters.DoorLockClusterID)
+	var events []im.EventData
+	for i := range 3 {
+		events = append(events, im.EventData{
+			Path:           im.NewEventPath(1, clusters.DoorLockClusterID, 0x02),
+			EventNumber:    uint64(10 + i),
+			Priority:       im.EventPriorityCritical,
+			EpochTimestamp: 1700000000000 + uint64(i),
+		})
+	}
+	node := &fakeNode{events: events}
+	ctrl, _ := newTestController(t, node)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	read, err := ctrl.ReadEvents(ctx, 1, []im.EventPath{path}, 10)
+	if err != nil || len(read) != len(events) {
+		t.Fatalf("ReadEvents() = %v, %v", read, err)
+	}
+
+	ch, err := ctrl.SubscribeEvents(ctx, 1, []im.EventPath{path}, 10, 0, time.Minute)
+	if err != nil {
+		t.Fatalf("SubscribeEvents() = %v", err)
+	}
+	for i, want := range events {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				t.Fatalf("subscription closed after %d events", i)
+			}
+			if ev.EventNumber != want.EventNumber {
+				t.Errorf("event %d: number %d, want %d", i, ev.EventNumber, want.EventNumber)
+			}
+		case <-ctx.Done():
+			t.Fatalf("event %d not reported", i)
+		}
+	}
+	if establishes, _, _ := node.recorded(); establishes != 2 {
+		t.Errorf("established %d sessions, want one for reads and one for the subscription", establishes)
+	}
+}
@@ -0,0 +1,97 @@
+package matterctrl
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
+)
+
+// ErrNodeAddressUnavailable is returned when an operational node advertises no usable address.
+var ErrNodeAddressUnavailable = errors.New("operational node has no address")
+
+// OperationalResolver resolves nodes of one fabric through _matter._tcp
+// operational discovery. Answers are cached by the discoverer until their TTLs
+// expire; ForgetNodeAddress drops a stale answer so the next lookup queries
+// again.
+type OperationalResolver struct {
+	discoverer         mdns.Discoverer
+	compressedFabricID uint64
+}
+
+var _ NodeResolver = (*OperationalResolver)(nil)
+
+// NewOperationalResolver returns a resolver for the fabric identified by compressedFabricID.
+func NewOperationalResolver(discoverer mdns.Discoverer, compressedFabricID uint64) *OperationalResolver {
+	return &OperationalResolver{
+		discoverer:         discoverer,
+		compressedFabricID: compressedFabricID,
+	}
+}
+
+// ResolveNode returns the current operational address of nodeID, preferring
+// routable IPv6 addresses over IPv4 and link-local ones.
+func (r *OperationalResolver) ResolveNode(ctx context.Context, nodeID uint64) (*net.UDPAddr, error) {
+	node, err := r.discoverer.ResolveOperationalNode(ctx, r.compressedFabricID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	addrs := mdns.OperationalAddresses(node)
+	if len(addrs) == 0 {
+		return nil, ErrNodeAddressUnavailable
+	}
+	return addrs[0], nil
+}
+
+// ForgetNodeAddress drops the cached operational address of nodeID.
+func (r *OperationalResolver) ForgetNodeAddress(nodeID uint64) {
+	r.discoverer.ForgetOperationalNode(r.compressedFabricID, nodeID)
+}
+
+// ResolvingController is a Controller that locates nodes with an
+// OperationalResolver.
+type ResolvingController struct {
+	Controller
+	*OperationalResolver
+}
+
+var (
+	_ NodeResolver = (*ResolvingController)(nil)
+	_ SessionCache = (*ResolvingController)(nil)
+)
+
+// NewResolvingController returns ctrl resolving nodes through resolver.
+func NewResolvingController(ctrl Controller, resolver *OperationalResolver) *ResolvingController {
+	return &ResolvingController{
+		Controller:          ctrl,
+		OperationalResolver: resolver,
+	}
+}
+
+// ForgetNode drops the cached sessions of nodeID if the controller caches
+// any.
+func (c *ResolvingController) ForgetNode(nodeID uint64) error {
+	if cache, ok := c.Controller.(SessionCache); ok {
+		return cache.ForgetNode(nodeID)
+	}
+	return nil
+}
+
+// AddressBook resolves nodes to fixed operational addresses, such as those
+// they were commissioned at, when operational discovery is disabled.
+type AddressBook map[uint64]*net.UDPAddr
+
+var _ NodeResolver = AddressBook(nil)
+
+// ResolveNode returns the address of nodeID.
+func (b AddressBook) ResolveNode(_ context.Context, nodeID uint64) (*net.UDPAddr, error) {
+	addr, ok := b[nodeID]
+	if !ok {
+		return nil, ErrNodeAddressUnavailable
+	}
+	return addr, nil
+}
+
+// ForgetNodeAddress does nothing as the addresses are fixed.
+func (b AddressBook) ForgetNodeAddress(_ uint64) {}
@@ -0,0 +1,87 @@
+package matterctrl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
+)
+
+type fakeOperationalNode struct {
+	mdns.OperationalNode
+	addrs []net.IP
+}
+
+func (n *fakeOperationalNode) Addresses() ([]net.IP, bool) { return n.addrs, len(n.addrs) != 0 }
+func (n *fakeOperationalNode) Port() (int, bool)           { return 5541, true }
+
+// fakeDiscoverer answers for the nodes of one fabric and records the nodes
+// forgotten.
+type fakeDiscoverer struct {
+	mdns.Discoverer
+	cfid      uint64
+	nodes     map[uint64]*fakeOperationalNode
+	forgotten []uint64
+}
+
+func (d *fakeDiscoverer) ResolveOperationalNode(_ context.Context, cfid uint64, nodeID uint64) (mdns.OperationalNode, error) {
+	node, ok := d.nodes[nodeID]
+	if cfid != d.cfid || !ok {
+		return nil, mdns.ErrOperationalNodeNotFound
+	}
+	return node, nil
+}
+
+func (d *fakeDiscoverer) ForgetOperationalNode(cfid uint64, nodeID uint64) {
+	if cfid == d.cfid {
+		d.forgotten = append(d.forgotten, nodeID)
+	}
+}
+
+func TestResolvingController(t *testing.T) {
+	disc := &fakeDiscoverer{
+		cfid: 0x87E1B004E235A130,
+		nodes: map[uint64]*fakeOperationalNode{
+			1: {addrs: []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1")}},
+			2: {},
+		},
+	}
+	var ctrl Controller = NewResolvingController(NewNoopController(), NewOperationalResolver(disc, disc.cfid))
+	resolver, ok := ctrl.(NodeResolver)
+	if !ok {
+		t.Fatal("ResolvingController is not a NodeResolver")
+	}
+
+	tests := []struct {
+		name    string
+		nodeID  uint64
+		want    string
+		wantErr error
+	}{
+		{name: "routable ipv6", nodeID: 1, want: "[2001:db8::1]:5541"},
+		{name: "no address", nodeID: 2, wantErr: ErrNodeAddressUnavailable},
+		{name: "not found", nodeID: 3, wantErr: mdns.ErrOperationalNodeNotFound},
+	}
+	for _, tt := range tests {
+		addr, err := resolver.ResolveNode(context.Background(), tt.nodeID)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: ResolveNode = %v, %v, want %v", tt.name, addr, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || addr.String() != tt.want {
+			t.Errorf("%s: ResolveNode = %v, %v, want %s", tt.name, addr, err, tt.want)
+		}
+	}
+
+	resolver.ForgetNodeAddress(1)
+	if len(disc.forgotten) != 1 || disc.forgotten[0] != 1 {
+		t.Errorf("forgotten = %v", disc.forgotten)
+	}
+	if err := ctrl.Ping(context.Background(), 1); !errors.Is(err, ErrControllerUnavailable) {
+		t.Errorf("Ping = %v", err)
+	}
+}
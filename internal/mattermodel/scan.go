@@ -4,21 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-)
-
-const (
-	descriptorClusterID       uint32 = 0x001D
-	descriptorPartsListAttrID uint32 = 0x0003
 
-	bridgedDeviceBasicInfoClusterID uint32 = 0x0039
-	bridgedNodeLabelAttrID          uint32 = 0x0005
-	bridgedReachableAttrID          uint32 = 0x0011
-	bridgedUniqueIDAttrID           uint32 = 0x0012
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
 )
 
 // Controller defines the minimal interface required to scan bridged devices.
 type Controller interface {
-	ReadAttribute(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32) (any, error)
+	ReadAttribute(ctx context.Context, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32) (im.Value, error)
 }
 
 // ScanBridgedDevices enumerates endpoints from PartsList and reads bridged device attributes.
@@ -42,70 +35,38 @@ func ScanBridgedDevices(ctx context.Context, ctrl Controller, nodeID uint64) ([]
 }
 
 func readPartsList(ctx context.Context, ctrl Controller, nodeID uint64) ([]uint16, error) {
-	raw, err := ctrl.ReadAttribute(ctx, nodeID, 0, descriptorClusterID, descriptorPartsListAttrID)
+	value, err := ctrl.ReadAttribute(ctx, nodeID, 0, clusters.DescriptorClusterID, clusters.DescriptorAttrPartsList)
 	if err != nil {
 		return nil, err
 	}
-	switch value := raw.(type) {
-	case []uint16:
-		return value, nil
-	case []uint32:
-		parts := make([]uint16, len(value))
-		for i, v := range value {
-			if v > 0xffff {
-				return nil, fmt.Errorf("parts list entry out of range: %d", v)
-			}
-			parts[i] = uint16(v)
-		}
-		return parts, nil
-	case []int:
-		parts := make([]uint16, len(value))
-		for i, v := range value {
-			if v < 0 || v > 0xffff {
-				return nil, fmt.Errorf("parts list entry out of range: %d", v)
-			}
-			parts[i] = uint16(v)
-		}
-		return parts, nil
-	case []any:
-		parts := make([]uint16, 0, len(value))
-		for _, entry := range value {
-			switch v := entry.(type) {
-			case uint16:
-				parts = append(parts, v)
-			case uint32:
-				if v > 0xffff {
-					return nil, fmt.Errorf("parts list entry out of range: %d", v)
-				}
-				parts = append(parts, uint16(v))
-			case int:
-				if v < 0 || v > 0xffff {
-					return nil, fmt.Errorf("parts list entry out of range: %d", v)
-				}
-				parts = append(parts, uint16(v))
-			default:
-				return nil, fmt.Errorf("unsupported parts list entry type %T", entry)
-			}
+	entries, err := value.AsList()
+	if err != nil {
+		return nil, fmt.Errorf("parts list: %w", err)
+	}
+	parts := make([]uint16, 0, len(entries))
+	for _, entry := range entries {
+		endpoint, err := im.UintAs[uint16](entry)
+		if err != nil {
+			return nil, fmt.Errorf("parts list entry: %w", err)
 		}
-		return parts, nil
-	default:
-		return nil, fmt.Errorf("unsupported parts list type %T", raw)
+		parts = append(parts, endpoint)
 	}
+	return parts, nil
 }
 
 func readBridgedDevice(ctx context.Context, ctrl Controller, nodeID uint64, endpoint uint16) (BridgedDevice, bool, error) {
-	uniqueID, err := readStringAttribute(ctx, ctrl, nodeID, endpoint, bridgedDeviceBasicInfoClusterID, bridgedUniqueIDAttrID)
+	uniqueID, err := readStringAttribute(ctx, ctrl, nodeID, endpoint, clusters.BridgedDeviceBasicInformationClusterID, clusters.BridgedDeviceBasicInformationAttrUniqueID)
 	if err != nil {
 		if errors.Is(err, errAttributeUnavailable) {
 			return BridgedDevice{}, false, nil
 		}
 		return BridgedDevice{}, false, err
 	}
-	nodeLabel, err := readStringAttribute(ctx, ctrl, nodeID, endpoint, bridgedDeviceBasicInfoClusterID, bridgedNodeLabelAttrID)
+	nodeLabel, err := readStringAttribute(ctx, ctrl, nodeID, endpoint, clusters.BridgedDeviceBasicInformationClusterID, clusters.BridgedDeviceBasicInformationAttrNodeLabel)
 	if err != nil && !errors.Is(err, errAttributeUnavailable) {
 		return BridgedDevice{}, false, err
 	}
-	reachable, err := readBoolAttribute(ctx, ctrl, nodeID, endpoint, bridgedDeviceBasicInfoClusterID, bridgedReachableAttrID)
+	reachable, err := readBoolAttribute(ctx, ctrl, nodeID, endpoint, clusters.BridgedDeviceBasicInformationClusterID, clusters.BridgedDeviceBasicInformationAttrReachable)
 	if err != nil && !errors.Is(err, errAttributeUnavailable) {
 		return BridgedDevice{}, false, err
 	}
@@ -122,38 +83,42 @@ func readBridgedDevice(ctx context.Context, ctrl Controller, nodeID uint64, endp
 
 var errAttributeUnavailable = errors.New("attribute unavailable")
 
-func readStringAttribute(ctx context.Context, ctrl Controller, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32) (string, error) {
-	raw, err := ctrl.ReadAttribute(ctx, nodeID, endpoint, clusterID, attrID)
+// readAttribute reads an attribute, reporting null values and unsupported
+// paths as errAttributeUnavailable.
+func readAttribute(ctx context.Context, ctrl Controller, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32) (im.Value, error) {
+	value, err := ctrl.ReadAttribute(ctx, nodeID, endpoint, clusterID, attrID)
 	if err != nil {
-		return "", err
+		var statusErr *im.StatusError
+		if errors.As(err, &statusErr) {
+			switch statusErr.Status {
+			case im.StatusUnsupportedEndpoint, im.StatusUnsupportedCluster, im.StatusUnsupportedAttribute:
+				return im.Value{}, errAttributeUnavailable
+			}
+		}
+		return im.Value{}, err
 	}
-	if raw == nil {
-		return "", errAttributeUnavailable
+	if value.IsNull() {
+		return im.Value{}, errAttributeUnavailable
 	}
-	switch value := raw.(type) {
-	case string:
-		return value, nil
-	case []byte:
-		return string(value), nil
-	default:
-		return "", fmt.Errorf("unsupported string attribute type %T", raw)
+	return value, nil
+}
+
+func readStringAttribute(ctx context.Context, ctrl Controller, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32) (string, error) {
+	value, err := readAttribute(ctx, ctrl, nodeID, endpoint, clusterID, attrID)
+	if err != nil {
+		return "", err
 	}
+	return value.AsString()
 }
 
 func readBoolAttribute(ctx context.Context, ctrl Controller, nodeID uint64, endpoint uint16, clusterID uint32, attrID uint32) (*bool, error) {
-	raw, err := ctrl.ReadAttribute(ctx, nodeID, endpoint, clusterID, attrID)
+	value, err := readAttribute(ctx, ctrl, nodeID, endpoint, clusterID, attrID)
 	if err != nil {
 		return nil, err
 	}
-	if raw == nil {
-		return nil, errAttributeUnavailable
-	}
-	switch value := raw.(type) {
-	case bool:
-		return &value, nil
-	case *bool:
-		return value, nil
-	default:
-		return nil, fmt.Errorf("unsupported bool attribute type %T", raw)
+	b, err := value.AsBool()
+	if err != nil {
+		return nil, err
 	}
+	return &b, nil
 }
@@ -8,13 +8,13 @@ import (
 	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
 	"github.com/YashubuStudio/go-matter-pack/internal/mattermodel"
 	"github.com/YashubuStudio/go-matter-pack/internal/metrics"
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
 )
 
 const (
-	powerSourceClusterID      uint32 = 0x002F
-	batPercentRemainingAttrID uint32 = 0x000C
-	powerSourceReaderName            = "power_source"
-	metricBatteryPercentKey          = "battery_percent"
+	powerSourceReaderName   = "power_source"
+	metricBatteryPercentKey = "battery_percent"
 )
 
 // PowerSourceReader reads battery information from the Power Source cluster.
@@ -29,52 +29,29 @@ func (r *PowerSourceReader) Name() string {
 
 // Read reads the battery percentage remaining.
 func (r *PowerSourceReader) Read(ctx context.Context, ctrl matterctrl.Controller, nodeID uint64, dev mattermodel.BridgedDevice) (map[string]any, error) {
-	raw, err := ctrl.ReadAttribute(ctx, nodeID, dev.Endpoint, powerSourceClusterID, batPercentRemainingAttrID)
+	value, err := ctrl.ReadAttribute(ctx, nodeID, dev.Endpoint, clusters.PowerSourceClusterID, clusters.PowerSourceAttrBatPercentRemaining)
 	if err != nil {
 		return nil, err
 	}
-	if raw == nil {
+	if value.IsNull() {
 		return nil, errors.New("battery percent remaining is unavailable")
 	}
-	percent, err := parsePercentRemaining(raw)
+	percent, err := parsePercentRemaining(value)
 	if err != nil {
 		return nil, err
 	}
 	return map[string]any{metricBatteryPercentKey: percent}, nil
 }
 
-func parsePercentRemaining(raw any) (float64, error) {
-	switch value := raw.(type) {
-	case uint8:
-		return float64(value) / 2.0, nil
-	case uint16:
-		return float64(value) / 2.0, nil
-	case int:
-		if value < 0 {
-			return 0, fmt.Errorf("battery percent remaining out of range: %d", value)
-		}
-		return float64(value) / 2.0, nil
-	case int32:
-		if value < 0 {
-			return 0, fmt.Errorf("battery percent remaining out of range: %d", value)
-		}
-		return float64(value) / 2.0, nil
-	case int64:
-		if value < 0 {
-			return 0, fmt.Errorf("battery percent remaining out of range: %d", value)
-		}
-		return float64(value) / 2.0, nil
-	case float32:
-		if value < 0 {
-			return 0, fmt.Errorf("battery percent remaining out of range: %f", value)
-		}
-		return float64(value), nil
-	case float64:
-		if value < 0 {
-			return 0, fmt.Errorf("battery percent remaining out of range: %f", value)
-		}
-		return value, nil
-	default:
-		return 0, fmt.Errorf("unsupported battery percent remaining type %T", raw)
+// parsePercentRemaining converts BatPercentRemaining, expressed in half
+// percent steps (0-200), into a percentage.
+func parsePercentRemaining(value im.Value) (float64, error) {
+	half, err := im.UintAs[uint8](value)
+	if err != nil {
+		return 0, fmt.Errorf("battery percent remaining: %w", err)
+	}
+	if half > 200 {
+		return 0, fmt.Errorf("battery percent remaining out of range: %d", half)
 	}
+	return float64(half) / 2.0, nil
 }
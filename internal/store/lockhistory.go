@@ -0,0 +1,108 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultLockHistoryLimit caps the number of events kept per device.
+const DefaultLockHistoryLimit = 1000
+
+// LockHistory holds Door Lock events keyed by device UniqueID.
+type LockHistory struct {
+	UpdatedAt time.Time                 `json:"updated_at"`
+	Devices   map[string]LockHistoryLog `json:"devices"`
+}
+
+// LockHistoryLog stores the events collected for a single lock.
+type LockHistoryLog struct {
+	// LastEventNumber is the highest event number seen; the next sync reads
+	// events after it.
+	LastEventNumber uint64      `json:"last_event_number"`
+	Events          []LockEvent `json:"events"`
+}
+
+// LockEvent is a decoded Door Lock event.
+type LockEvent struct {
+	EventNumber     uint64     `json:"event_number"`
+	Kind            string     `json:"kind"`
+	Priority        string     `json:"priority"`
+	Timestamp       *time.Time `json:"timestamp,omitempty"`
+	SystemTimeMs    uint64     `json:"system_time_ms,omitempty"`
+	OperationType   string     `json:"operation_type,omitempty"`
+	OperationSource string     `json:"operation_source,omitempty"`
+	OperationError  string     `json:"operation_error,omitempty"`
+	AlarmCode       string     `json:"alarm_code,omitempty"`
+	UserIndex       *uint16    `json:"user_index,omitempty"`
+	FabricIndex     *uint8     `json:"fabric_index,omitempty"`
+	SourceNode      *uint64    `json:"source_node,omitempty"`
+}
+
+// NewLockHistory initializes an empty lock history.
+func NewLockHistory() *LockHistory {
+	return &LockHistory{Devices: make(map[string]LockHistoryLog)}
+}
+
+// LastEventNumber returns the highest event number recorded for a device.
+func (h *LockHistory) LastEventNumber(uniqueID string) (uint64, bool) {
+	if h == nil || h.Devices == nil {
+		return 0, false
+	}
+	log, ok := h.Devices[uniqueID]
+	if !ok || len(log.Events) == 0 {
+		return 0, false
+	}
+	return log.LastEventNumber, true
+}
+
+// Append merges events into a device log, skipping event numbers already
+// recorded, and keeps at most limit events (the newest are kept). A limit of
+// zero or less means DefaultLockHistoryLimit. It returns the events that were
+// newly added.
+func (h *LockHistory) Append(now time.Time, uniqueID string, events []LockEvent, limit int) []LockEvent {
+	if h.Devices == nil {
+		h.Devices = make(map[string]LockHistoryLog)
+	}
+	if limit <= 0 {
+		limit = DefaultLockHistoryLimit
+	}
+	log := h.Devices[uniqueID]
+	seen := make(map[uint64]struct{}, len(log.Events))
+	for _, ev := range log.Events {
+		seen[ev.EventNumber] = struct{}{}
+	}
+	added := []LockEvent{}
+	for _, ev := range events {
+		if _, ok := seen[ev.EventNumber]; ok {
+			continue
+		}
+		seen[ev.EventNumber] = struct{}{}
+		added = append(added, ev)
+		log.Events = append(log.Events, ev)
+		if ev.EventNumber > log.LastEventNumber {
+			log.LastEventNumber = ev.EventNumber
+		}
+	}
+	sort.Slice(log.Events, func(i, j int) bool {
+		return log.Events[i].EventNumber < log.Events[j].EventNumber
+	})
+	if len(log.Events) > limit {
+		log.Events = append([]LockEvent(nil), log.Events[len(log.Events)-limit:]...)
+	}
+	h.Devices[uniqueID] = log
+	h.UpdatedAt = now
+	return added
+}
+
+// Events returns the recorded events for a device in event number order.
+func (h *LockHistory) Events(uniqueID string) []LockEvent {
+	if h == nil || h.Devices == nil {
+		return nil
+	}
+	return h.Devices[uniqueID].Events
+}
+
+// Remove deletes the history of a device.
+func (h *LockHistory) Remove(uniqueID string) {
+	delete(h.Devices, uniqueID)
+}
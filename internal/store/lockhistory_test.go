@@ -0,0 +1,102 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func lockEvents(numbers ...uint64) []LockEvent {
+	events := make([]LockEvent, 0, len(numbers))
+	for _, n := range numbers {
+		events = append(events, LockEvent{EventNumber: n, Kind: "lock_operation"})
+	}
+	return events
+}
+
+func eventNumbers(events []LockEvent) []uint64 {
+	out := make([]uint64, 0, len(events))
+	for _, ev := range events {
+		out = append(out, ev.EventNumber)
+	}
+	return out
+}
+
+func equalNumbers(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLockHistoryAppend(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []uint64
+		append   []uint64
+		limit    int
+		added    []uint64
+		kept     []uint64
+		last     uint64
+	}{
+		{name: "empty", append: []uint64{3, 1, 2}, added: []uint64{3, 1, 2}, kept: []uint64{1, 2, 3}, last: 3},
+		{name: "dedupe recorded", existing: []uint64{1, 2}, append: []uint64{2, 3}, added: []uint64{3}, kept: []uint64{1, 2, 3}, last: 3},
+		{name: "dedupe within batch", append: []uint64{5, 5, 6}, added: []uint64{5, 6}, kept: []uint64{5, 6}, last: 6},
+		{name: "nothing new", existing: []uint64{1, 2}, append: []uint64{1}, added: []uint64{}, kept: []uint64{1, 2}, last: 2},
+		{name: "trim keeps newest", existing: []uint64{1, 2, 3}, append: []uint64{4, 5}, limit: 3, added: []uint64{4, 5}, kept: []uint64{3, 4, 5}, last: 5},
+		{name: "trim batch", append: []uint64{9, 8, 7, 6}, limit: 2, added: []uint64{9, 8, 7, 6}, kept: []uint64{8, 9}, last: 9},
+		{name: "older event below limit", existing: []uint64{10, 11}, append: []uint64{4}, limit: 2, added: []uint64{4}, kept: []uint64{10, 11}, last: 11},
+	}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tt := range tests {
+		h := NewLockHistory()
+		if tt.existing != nil {
+			h.Append(now.Add(-time.Hour), "lock-1", lockEvents(tt.existing...), tt.limit)
+		}
+		added := h.Append(now, "lock-1", lockEvents(tt.append...), tt.limit)
+		if got := eventNumbers(added); !equalNumbers(got, tt.added) {
+			t.Errorf("%s: added = %v, want %v", tt.name, got, tt.added)
+		}
+		if got := eventNumbers(h.Events("lock-1")); !equalNumbers(got, tt.kept) {
+			t.Errorf("%s: kept = %v, want %v", tt.name, got, tt.kept)
+		}
+		if last, ok := h.LastEventNumber("lock-1"); !ok || last != tt.last {
+			t.Errorf("%s: LastEventNumber = %d, %v, want %d", tt.name, last, ok, tt.last)
+		}
+		if !h.UpdatedAt.Equal(now) {
+			t.Errorf("%s: UpdatedAt = %v", tt.name, h.UpdatedAt)
+		}
+	}
+}
+
+func TestLockHistoryDefaultLimit(t *testing.T) {
+	numbers := make([]uint64, DefaultLockHistoryLimit+5)
+	for i := range numbers {
+		numbers[i] = uint64(i + 1)
+	}
+	h := NewLockHistory()
+	h.Append(time.Now(), "lock-1", lockEvents(numbers...), 0)
+	events := h.Events("lock-1")
+	if len(events) != DefaultLockHistoryLimit || events[0].EventNumber != 6 {
+		t.Fatalf("kept %d events starting at %d", len(events), events[0].EventNumber)
+	}
+}
+
+func TestLockHistoryDevicesAreSeparate(t *testing.T) {
+	h := NewLockHistory()
+	h.Append(time.Now(), "lock-1", lockEvents(1, 2), 0)
+	if added := h.Append(time.Now(), "lock-2", lockEvents(1), 0); len(added) != 1 {
+		t.Fatalf("event 1 on another device was treated as a duplicate")
+	}
+	h.Remove("lock-1")
+	if _, ok := h.LastEventNumber("lock-1"); ok {
+		t.Errorf("history of removed device is still present")
+	}
+	if got := eventNumbers(h.Events("lock-2")); !equalNumbers(got, []uint64{1}) {
+		t.Errorf("lock-2 events = %v", got)
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter"
+)
+
+// DefaultBatchConcurrency is the default number of devices commissioned at once.
+const DefaultBatchConcurrency = 4
+
+// BatchDevice is a device of a batch commissioning run.
+type BatchDevice struct {
+	// Key identifies the device in the manifest report.
+	Key string
+	// NodeID is the operational node ID to assign. It must be non-zero and
+	// unique within the batch, since the commissioning state keeps one
+	// record per node ID.
+	NodeID  uint64
+	Payload string
+	// Address is the on-network address; nil discovers the device.
+	Address net.IP
+	Port    int
+	Options []matter.CommissionOption
+}
+
+// BatchOptions configures a batch commissioning run.
+type BatchOptions struct {
+	// Concurrency bounds the devices commissioned at once; zero uses
+	// DefaultBatchConcurrency.
+	Concurrency int
+	// Timeout bounds the commissioning of each device; zero means no limit.
+	Timeout time.Duration
+	// RetryAll commissions every device, including those the report already
+	// records as commissioned.
+	RetryAll bool
+}
+
+// CommissionBatch commissions devices, at most opts.Concurrency at a time,
+// and records the outcome of each device in the manifest report stored in
+// reportStore as soon as it is known. Devices the report already records as
+// commissioned are skipped unless opts.RetryAll is set, so re-running a
+// manifest retries only the failures. Per-device failures are recorded in
+// the report rather than returned.
+func (s *CommissionService) CommissionBatch(ctx context.Context, manifest string, devices []BatchDevice, reportStore store.Store, opts BatchOptions) (commission.ManifestReport, error) {
+	if err := s.validate(); err != nil {
+		return commission.ManifestReport{}, err
+	}
+	if reportStore == nil {
+		return commission.ManifestReport{}, errors.New("report store is nil")
+	}
+	nodeIDs := make(map[uint64]string, len(devices))
+	for _, device := range devices {
+		if device.NodeID == 0 {
+			return commission.ManifestReport{}, fmt.Errorf("device %s: node ID is required", device.Key)
+		}
+		if other, ok := nodeIDs[device.NodeID]; ok {
+			return commission.ManifestReport{}, fmt.Errorf("device %s: node ID %d is also used by device %s", device.Key, device.NodeID, other)
+		}
+		nodeIDs[device.NodeID] = device.Key
+	}
+	report, err := commission.LoadManifestReport(ctx, reportStore)
+	if err != nil {
+		return commission.ManifestReport{}, err
+	}
+	report.Manifest = manifest
+
+	// Decide which devices to commission before any worker starts; the
+	// workers update the report concurrently.
+	pending := make([]BatchDevice, 0, len(devices))
+	for _, device := range devices {
+		if !opts.RetryAll {
+			if prev := report.Outcome(device.Key); prev != nil && prev.Status == commission.ManifestStatusCommissioned {
+				continue
+			}
+		}
+		pending = append(pending, device)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	var (
+		mu      sync.Mutex
+		saveErr error
+		wg      sync.WaitGroup
+	)
+	record := func(outcome commission.ManifestDeviceOutcome) {
+		mu.Lock()
+		defer mu.Unlock()
+		report.Record(outcome)
+		report.UpdatedAt = outcome.UpdatedAt
+		if err := commission.SaveManifestReport(ctx, reportStore, report); err != nil && saveErr == nil {
+			saveErr = err
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	for _, device := range pending {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return report, ctx.Err()
+		}
+		wg.Add(1)
+		go func(device BatchDevice) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			record(s.commissionBatchDevice(ctx, device, opts.Timeout))
+		}(device)
+	}
+	wg.Wait()
+	return report, saveErr
+}
+
+func (s *CommissionService) commissionBatchDevice(ctx context.Context, device BatchDevice, timeout time.Duration) commission.ManifestDeviceOutcome {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var commissionee matter.Commissionee
+	var err error
+	if device.Address != nil {
+		_, commissionee, err = s.CommissionOnNetwork(ctx, device.NodeID, device.Payload, device.Address, device.Port, device.Options...)
+	} else {
+		_, commissionee, err = s.Commission(ctx, device.NodeID, device.Payload, device.Options...)
+	}
+	outcome := commission.ManifestDeviceOutcome{
+		Key:       device.Key,
+		NodeID:    device.NodeID,
+		Status:    commission.ManifestStatusCommissioned,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err != nil {
+		outcome.Status = commission.ManifestStatusFailed
+		outcome.Error = err.Error()
+		return outcome
+	}
+	outcome.Device = commissionee.String()
+	return outcome
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter"
+	"github.com/YashubuStudio/go-matter-pack/matter/ble"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
+	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
+)
+
+// Pairing codes and their passcodes.
+const (
+	batchCode1 = "30357507966" // passcode 13045239
+	batchCode2 = "35729935174" // passcode 57630675
+	batchCode3 = "21676928175" // passcode 46154113
+)
+
+type fakeCommissionee struct {
+	passcode encoding.Passcode
+	result   *commissioning.Result
+}
+
+func (c fakeCommissionee) VendorID() matter.VendorID   { return 0xFFF1 }
+func (c fakeCommissionee) ProductID() matter.ProductID { return 0x8000 }
+func (c fakeCommissionee) String() string              { return fmt.Sprintf("device-%d", c.passcode) }
+
+func (c fakeCommissionee) Result() *commissioning.Result { return c.result }
+
+// fakeCommissioner commissions every payload whose passcode is not in fail,
+// recording the passcodes it was asked for. A non-zero assign is reported as
+// the node ID of every commissioned device.
+type fakeCommissioner struct {
+	mu     sync.Mutex
+	fail   map[encoding.Passcode]bool
+	assign uint64
+	calls  []encoding.Passcode
+}
+
+func (c *fakeCommissioner) Scannar() ble.Scanner        { return nil }
+func (c *fakeCommissioner) Discoverer() mdns.Discoverer { return nil }
+func (c *fakeCommissioner) Start() error                { return nil }
+func (c *fakeCommissioner) Stop() error                 { return nil }
+func (c *fakeCommissioner) Discover(context.Context, matter.Query) ([]matter.CommissionableDevice, error) {
+	return nil, nil
+}
+
+func (c *fakeCommissioner) Commission(ctx context.Context, payload matter.OnboardingPayload, opts ...matter.CommissionOption) (matter.Commissionee, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, payload.Passcode())
+	if c.fail[payload.Passcode()] {
+		return nil, errors.New("device did not respond")
+	}
+	commissionee := fakeCommissionee{passcode: payload.Passcode()}
+	if c.assign != 0 {
+		commissionee.result = &commissioning.Result{
+			NodeID:    c.assign,
+			Addresses: []*net.UDPAddr{{IP: net.IPv4(192, 0, 2, 10), Port: 5540}},
+		}
+	}
+	return commissionee, nil
+}
+
+func (c *fakeCommissioner) called() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.calls)
+}
+
+func newBatchService(t *testing.T, commissioner matter.Commissioner) (*CommissionService, store.Store) {
+	t.Helper()
+	dir := t.TempDir()
+	service := NewCommissionService(commissioner, store.NewJSONFileStore(filepath.Join(dir, "state.json")))
+	return service, store.NewJSONFileStore(filepath.Join(dir, "report.json"))
+}
+
+func batchDevices() []BatchDevice {
+	return []BatchDevice{
+		{Key: "kitchen", NodeID: 1, Payload: batchCode1},
+		{Key: "hall", NodeID: 2, Payload: batchCode2},
+		{Key: "garage", NodeID: 3, Payload: batchCode3},
+	}
+}
+
+func TestCommissionBatch(t *testing.T) {
+	ctx := context.Background()
+	commissioner := &fakeCommissioner{fail: map[encoding.Passcode]bool{57630675: true}}
+	service, reportStore := newBatchService(t, commissioner)
+
+	report, err := service.CommissionBatch(ctx, "devices.yaml", batchDevices(), reportStore, BatchOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("CommissionBatch: %v", err)
+	}
+	if report.Manifest != "devices.yaml" || len(report.Devices) != 3 {
+		t.Fatalf("report = %+v", report)
+	}
+	tests := []struct {
+		key    string
+		nodeID uint64
+		status string
+		device string
+	}{
+		{"kitchen", 1, commission.ManifestStatusCommissioned, "device-13045239"},
+		{"hall", 2, commission.ManifestStatusFailed, ""},
+		{"garage", 3, commission.ManifestStatusCommissioned, "device-46154113"},
+	}
+	for _, tt := range tests {
+		got := report.Outcome(tt.key)
+		if got == nil {
+			t.Errorf("%s: no outcome", tt.key)
+			continue
+		}
+		if got.NodeID != tt.nodeID || got.Status != tt.status || got.Device != tt.device || got.Attempts != 1 {
+			t.Errorf("%s: outcome = %+v", tt.key, got)
+		}
+		if (tt.status == commission.ManifestStatusFailed) != (got.Error != "") {
+			t.Errorf("%s: error = %q", tt.key, got.Error)
+		}
+	}
+
+	saved, err := commission.LoadManifestReport(ctx, reportStore)
+	if err != nil {
+		t.Fatalf("LoadManifestReport: %v", err)
+	}
+	if len(saved.Devices) != 3 {
+		t.Errorf("saved report = %+v", saved)
+	}
+}
+
+func TestCommissionBatchRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		retryAll  bool
+		wantCalls int
+		// wantAttempts are the attempts of kitchen, hall and garage.
+		wantAttempts []int
+	}{
+		{name: "failures only", retryAll: false, wantCalls: 1, wantAttempts: []int{1, 2, 1}},
+		{name: "retry all", retryAll: true, wantCalls: 3, wantAttempts: []int{2, 2, 2}},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		commissioner := &fakeCommissioner{fail: map[encoding.Passcode]bool{57630675: true}}
+		service, reportStore := newBatchService(t, commissioner)
+		if _, err := service.CommissionBatch(ctx, "devices.yaml", batchDevices(), reportStore, BatchOptions{}); err != nil {
+			t.Fatalf("%s: first run: %v", tt.name, err)
+		}
+
+		second := &fakeCommissioner{}
+		service.commissioner = second
+		report, err := service.CommissionBatch(ctx, "devices.yaml", batchDevices(), reportStore, BatchOptions{RetryAll: tt.retryAll})
+		if err != nil {
+			t.Fatalf("%s: second run: %v", tt.name, err)
+		}
+		if n := second.called(); n != tt.wantCalls {
+			t.Errorf("%s: commissioned %d devices, want %d", tt.name, n, tt.wantCalls)
+		}
+		for i, key := range []string{"kitchen", "hall", "garage"} {
+			got := report.Outcome(key)
+			if got == nil || got.Status != commission.ManifestStatusCommissioned || got.Attempts != tt.wantAttempts[i] {
+				t.Errorf("%s: %s outcome = %+v", tt.name, key, got)
+			}
+		}
+	}
+}
+
+func TestCommissionBatchNodeIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		devices []BatchDevice
+		wantErr string
+	}{
+		{
+			name:    "missing node ID",
+			devices: []BatchDevice{{Key: "kitchen", NodeID: 1, Payload: batchCode1}, {Key: "hall", Payload: batchCode2}},
+			wantErr: "device hall: node ID is required",
+		},
+		{
+			name:    "duplicate node ID",
+			devices: []BatchDevice{{Key: "kitchen", NodeID: 5, Payload: batchCode1}, {Key: "hall", NodeID: 5, Payload: batchCode2}},
+			wantErr: "device hall: node ID 5 is also used by device kitchen",
+		},
+	}
+	for _, tt := range tests {
+		commissioner := &fakeCommissioner{}
+		service, reportStore := newBatchService(t, commissioner)
+		_, err := service.CommissionBatch(context.Background(), "devices.yaml", tt.devices, reportStore, BatchOptions{})
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: CommissionBatch = %v, want %q", tt.name, err, tt.wantErr)
+		}
+		if n := commissioner.called(); n != 0 {
+			t.Errorf("%s: commissioned %d devices before validating", tt.name, n)
+		}
+	}
+}
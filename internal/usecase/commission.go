@@ -10,12 +10,15 @@ import (
 	"github.com/YashubuStudio/go-matter-pack/internal/commission"
 	"github.com/YashubuStudio/go-matter-pack/internal/store"
 	"github.com/YashubuStudio/go-matter-pack/matter"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
 )
 
 // CommissionService handles onboarding payload import and commissioning.
 type CommissionService struct {
 	commissioner matter.Commissioner
 	store        store.Store
+	fabricIndex  uint8
 }
 
 // NewCommissionService returns a new CommissionService.
@@ -23,6 +26,12 @@ func NewCommissionService(commissioner matter.Commissioner, store store.Store) *
 	return &CommissionService{commissioner: commissioner, store: store}
 }
 
+// SetFabric selects the local fabric that payloads and results are recorded
+// on; zero selects commission.DefaultFabricIndex.
+func (s *CommissionService) SetFabric(index uint8) {
+	s.fabricIndex = index
+}
+
 // ImportPayload parses and saves an onboarding payload.
 func (s *CommissionService) ImportPayload(ctx context.Context, nodeID uint64, payload string) (commission.State, error) {
 	if s == nil {
@@ -31,103 +40,167 @@ func (s *CommissionService) ImportPayload(ctx context.Context, nodeID uint64, pa
 	if s.store == nil {
 		return commission.State{}, errors.New("store is nil")
 	}
-	return commission.ImportPayload(ctx, s.store, nodeID, payload)
+	return commission.ImportPayload(ctx, s.store, s.fabricIndex, nodeID, payload)
 }
 
 // Commission commissions a device and updates the commissioning result.
-func (s *CommissionService) Commission(ctx context.Context, nodeID uint64, payload string) (commission.State, matter.Commissionee, error) {
-	if s == nil {
-		return commission.State{}, nil, errors.New("commission service is nil")
-	}
-	if s.store == nil {
-		return commission.State{}, nil, errors.New("store is nil")
-	}
-	if s.commissioner == nil {
-		return commission.State{}, nil, errors.New("commissioner is nil")
+// Progress is checkpointed in the state so that a failed attempt can be
+// continued with Resume.
+func (s *CommissionService) Commission(ctx context.Context, nodeID uint64, payload string, opts ...matter.CommissionOption) (commission.State, matter.Commissionee, error) {
+	if err := s.validate(); err != nil {
+		return commission.State{}, nil, err
 	}
-
 	onboarding, _, err := commission.ParseOnboardingPayload(payload)
 	if err != nil {
 		return commission.State{}, nil, err
 	}
-
-	state, err := commission.ImportPayload(ctx, s.store, nodeID, payload)
+	state, err := commission.ImportPayload(ctx, s.store, s.fabricIndex, nodeID, payload)
 	if err != nil {
 		return commission.State{}, nil, err
 	}
-
-	commissionee, err := s.commissioner.Commission(ctx, onboarding)
-	if err != nil {
-		return state, nil, err
-	}
-
-	result := commission.ResultRecord{
-		NodeID:             nodeID,
-		VendorID:           uint16(commissionee.VendorID()),
-		ProductID:          uint16(commissionee.ProductID()),
-		Device:             commissionee.String(),
-		CommissionedAt:     time.Now().UTC(),
-		PayloadFingerprint: payloadFingerprint(state),
-	}
-	updated, err := commission.UpdateResult(ctx, s.store, result)
-	if err != nil {
-		return state, commissionee, fmt.Errorf("commissioned but failed to update state: %w", err)
-	}
-	return updated, commissionee, nil
+	return s.commission(ctx, state, nodeID, onboarding, nil, 0, opts)
 }
 
 // CommissionOnNetwork commissions a device by direct on-network address and updates the commissioning result.
-func (s *CommissionService) CommissionOnNetwork(ctx context.Context, nodeID uint64, payload string, address net.IP, port int) (commission.State, matter.Commissionee, error) {
-	if s == nil {
-		return commission.State{}, nil, errors.New("commission service is nil")
-	}
-	if s.store == nil {
-		return commission.State{}, nil, errors.New("store is nil")
-	}
-	if s.commissioner == nil {
-		return commission.State{}, nil, errors.New("commissioner is nil")
+func (s *CommissionService) CommissionOnNetwork(ctx context.Context, nodeID uint64, payload string, address net.IP, port int, opts ...matter.CommissionOption) (commission.State, matter.Commissionee, error) {
+	if err := s.validate(); err != nil {
+		return commission.State{}, nil, err
 	}
 	if address == nil {
 		return commission.State{}, nil, errors.New("on-network address is required")
 	}
-
 	onboarding, _, err := commission.ParseOnboardingPayload(payload)
 	if err != nil {
 		return commission.State{}, nil, err
 	}
+	state, err := commission.ImportPayload(ctx, s.store, s.fabricIndex, nodeID, payload)
+	if err != nil {
+		return commission.State{}, nil, err
+	}
+	return s.commission(ctx, state, nodeID, onboarding, address, port, opts)
+}
 
-	state, err := commission.ImportPayload(ctx, s.store, nodeID, payload)
+// Resume continues the interrupted commissioning of nodeID from its last
+// checkpoint with the stored onboarding payload. It fails if the fail-safe
+// timer of the attempt has expired. A nil address discovers the device as
+// Commission does.
+func (s *CommissionService) Resume(ctx context.Context, nodeID uint64, address net.IP, port int, opts ...matter.CommissionOption) (commission.State, matter.Commissionee, error) {
+	if err := s.validate(); err != nil {
+		return commission.State{}, nil, err
+	}
+	state, err := commission.LoadState(ctx, s.store)
 	if err != nil {
 		return commission.State{}, nil, err
 	}
+	var node *commission.NodeRecord
+	if fabric := state.Fabric(s.fabricIndex); fabric != nil {
+		node = fabric.Node(nodeID)
+	}
+	if node == nil || node.Checkpoint == nil || node.Payload == nil {
+		return state, nil, fmt.Errorf("no interrupted commissioning of node %d to resume", nodeID)
+	}
+	if node.Checkpoint.Expired(time.Now()) {
+		return state, nil, fmt.Errorf("%w; commission the device again", commissioning.ErrCheckpointExpired)
+	}
+	payload := node.Payload.QRCode
+	if payload == "" {
+		payload = node.Payload.PairingCode
+	}
+	onboarding, _, err := commission.ParseOnboardingPayload(payload)
+	if err != nil {
+		return state, nil, err
+	}
+	opts = append(opts, matter.WithResume(*node.Checkpoint))
+	return s.commission(ctx, state, nodeID, onboarding, address, port, opts)
+}
 
-	onNetworkCommissioner, ok := s.commissioner.(matter.OnNetworkCommissioner)
-	if !ok {
-		return state, nil, errors.New("commissioner does not support on-network commissioning")
+// commission runs the commissioner, checkpointing its progress, and records
+// the result.
+func (s *CommissionService) commission(ctx context.Context, state commission.State, nodeID uint64, onboarding encoding.OnboardingPayload, address net.IP, port int, opts []matter.CommissionOption) (commission.State, matter.Commissionee, error) {
+	if nodeID != 0 {
+		opts = append([]matter.CommissionOption{matter.WithNodeID(nodeID)}, opts...)
+	}
+	var saveErr error
+	opts = append(opts, matter.WithCheckpoint(func(cp commissioning.Checkpoint) {
+		if _, err := commission.SaveCheckpoint(ctx, s.store, s.fabricIndex, nodeID, cp); err != nil && saveErr == nil {
+			saveErr = err
+		}
+	}))
+
+	var commissionee matter.Commissionee
+	var err error
+	if address != nil {
+		onNetworkCommissioner, ok := s.commissioner.(matter.OnNetworkCommissioner)
+		if !ok {
+			return state, nil, errors.New("commissioner does not support on-network commissioning")
+		}
+		commissionee, err = onNetworkCommissioner.CommissionOnNetwork(ctx, onboarding, address, port, opts...)
+	} else {
+		commissionee, err = s.commissioner.Commission(ctx, onboarding, opts...)
 	}
-	commissionee, err := onNetworkCommissioner.CommissionOnNetwork(ctx, onboarding, address, port)
 	if err != nil {
+		if saveErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to save checkpoint: %w", saveErr))
+		}
 		return state, nil, err
 	}
 
+	// The commissioner assigns a node ID when none was requested; record the
+	// result under it rather than the provisional ID zero.
+	assigned := nodeID
+	var addresses []string
+	if res := commissionee.Result(); res != nil {
+		if res.NodeID != 0 {
+			assigned = res.NodeID
+		}
+		for _, addr := range res.Addresses {
+			addresses = append(addresses, addr.String())
+		}
+	}
+	fingerprint := s.payloadFingerprint(state, nodeID)
+	if assigned != nodeID {
+		if _, err := commission.AssignNodeID(ctx, s.store, s.fabricIndex, nodeID, assigned); err != nil {
+			return state, commissionee, fmt.Errorf("commissioned node %d but failed to update state: %w", assigned, err)
+		}
+	}
+
 	result := commission.ResultRecord{
-		NodeID:             nodeID,
+		NodeID:             assigned,
 		VendorID:           uint16(commissionee.VendorID()),
 		ProductID:          uint16(commissionee.ProductID()),
 		Device:             commissionee.String(),
 		CommissionedAt:     time.Now().UTC(),
-		PayloadFingerprint: payloadFingerprint(state),
+		PayloadFingerprint: fingerprint,
+		Addresses:          addresses,
 	}
-	updated, err := commission.UpdateResult(ctx, s.store, result)
+	updated, err := commission.UpdateResult(ctx, s.store, s.fabricIndex, result)
 	if err != nil {
-		return state, commissionee, fmt.Errorf("commissioned but failed to update state: %w", err)
+		return state, commissionee, fmt.Errorf("commissioned node %d but failed to update state: %w", assigned, err)
 	}
 	return updated, commissionee, nil
 }
 
-func payloadFingerprint(state commission.State) string {
-	if state.Payload == nil {
+func (s *CommissionService) validate() error {
+	if s == nil {
+		return errors.New("commission service is nil")
+	}
+	if s.store == nil {
+		return errors.New("store is nil")
+	}
+	if s.commissioner == nil {
+		return errors.New("commissioner is nil")
+	}
+	return nil
+}
+
+func (s *CommissionService) payloadFingerprint(state commission.State, nodeID uint64) string {
+	fabric := state.Fabric(s.fabricIndex)
+	if fabric == nil {
+		return ""
+	}
+	node := fabric.Node(nodeID)
+	if node == nil || node.Payload == nil {
 		return ""
 	}
-	return state.Payload.PayloadFingerprint
+	return node.Payload.PayloadFingerprint
 }
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+)
+
+func TestCommissionAssignedNodeID(t *testing.T) {
+	tests := []struct {
+		name   string
+		nodeID uint64
+		assign uint64
+		want   uint64
+	}{
+		{"requested", 0x10, 0x10, 0x10},
+		{"assigned", 0, 0x42, 0x42},
+		{"no result", 0x11, 0, 0x11},
+	}
+	for _, tt := range tests {
+		service, _ := newBatchService(t, &fakeCommissioner{assign: tt.assign})
+		state, commissionee, err := service.Commission(context.Background(), tt.nodeID, batchCode1)
+		if err != nil {
+			t.Fatalf("%s: Commission: %v", tt.name, err)
+		}
+		if commissionee == nil {
+			t.Fatalf("%s: no commissionee", tt.name)
+		}
+		f := state.Fabric(commission.DefaultFabricIndex)
+		if f == nil {
+			t.Fatalf("%s: no fabric", tt.name)
+		}
+		node := f.Node(tt.want)
+		if node == nil || node.Result == nil {
+			t.Fatalf("%s: node %d has no result: %+v", tt.name, tt.want, f.Nodes)
+		}
+		if node.Result.NodeID != tt.want {
+			t.Errorf("%s: result node ID = %d, want %d", tt.name, node.Result.NodeID, tt.want)
+		}
+		if wantAddr := tt.assign != 0; wantAddr != slices.Equal(node.Result.Addresses, []string{"192.0.2.10:5540"}) {
+			t.Errorf("%s: result addresses = %v", tt.name, node.Result.Addresses)
+		}
+		if node.Payload == nil {
+			t.Errorf("%s: payload was not kept with node %d", tt.name, tt.want)
+		}
+		if len(f.Nodes) != 1 {
+			t.Errorf("%s: nodes = %+v, want only node %d", tt.name, f.Nodes, tt.want)
+		}
+	}
+}
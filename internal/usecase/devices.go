@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+const operationalCredentialsEndpoint uint16 = 0
+
+// RemoveOptions configures device removal.
+type RemoveOptions struct {
+	// Force updates the local state even when the node cannot be reached or
+	// refuses to remove the fabric.
+	Force bool
+	// FabricIndex is the local fabric the node is recorded on; zero selects
+	// commission.DefaultFabricIndex.
+	FabricIndex uint8
+	// AllBridged allows a registry unique ID as the target. A bridged device
+	// is removed through its hub, which removes every device behind it.
+	AllBridged bool
+}
+
+// RemoveResult describes a removed node.
+type RemoveResult struct {
+	NodeID uint64
+	// FabricIndex is the index of the fabric removed on the node, or zero if
+	// it could not be read.
+	FabricIndex uint8
+	// RemoteErr is the RemoveFabric failure ignored by RemoveOptions.Force.
+	RemoteErr error
+	// Devices are the registry records removed along with the node.
+	Devices []string
+	// Recorded reports whether the node was recorded on the local fabric.
+	Recorded bool
+}
+
+// DeviceService decommissions devices.
+type DeviceService struct {
+	ctrl     matterctrl.Controller
+	registry store.Store
+	state    store.Store
+}
+
+// NewDeviceService returns a new DeviceService. registry holds the bridged
+// device registry and state the commissioning state.
+func NewDeviceService(ctrl matterctrl.Controller, registry store.Store, state store.Store) *DeviceService {
+	return &DeviceService{ctrl: ctrl, registry: registry, state: state}
+}
+
+// Remove decommissions the node identified by target, a registry unique ID
+// or a node ID. A bridged device resolves to its hub, so removing it removes
+// the hub and every device behind it; this requires opts.AllBridged. The
+// node is asked to remove our fabric with Operational Credentials
+// RemoveFabric; then its cached sessions and address are dropped and it is
+// deleted from the registry and from opts.FabricIndex in the commissioning
+// state.
+func (s *DeviceService) Remove(ctx context.Context, target string, opts RemoveOptions) (RemoveResult, error) {
+	if s == nil {
+		return RemoveResult{}, errors.New("device service is nil")
+	}
+	if s.ctrl == nil {
+		return RemoveResult{}, errors.New("controller is nil")
+	}
+	if s.registry == nil || s.state == nil {
+		return RemoveResult{}, errors.New("store is nil")
+	}
+	if target == "" {
+		return RemoveResult{}, errors.New("unique id or node id is required")
+	}
+
+	var registry store.Registry
+	if err := s.registry.Load(ctx, &registry); err != nil {
+		return RemoveResult{}, err
+	}
+	nodeID, bridged, err := resolveRemoveTarget(registry, target)
+	if err != nil {
+		return RemoveResult{}, err
+	}
+	if bridged && !opts.AllBridged {
+		return RemoveResult{}, fmt.Errorf("%s is a bridged device of hub node %d; removing it removes the hub and %d devices behind it",
+			target, nodeID, len(hubDevices(registry, nodeID)))
+	}
+
+	result := RemoveResult{NodeID: nodeID}
+	result.FabricIndex, err = s.removeFabric(ctx, nodeID)
+	if err != nil {
+		if !opts.Force {
+			return result, err
+		}
+		result.RemoteErr = err
+	}
+
+	if cache, ok := s.ctrl.(matterctrl.SessionCache); ok {
+		if err := cache.ForgetNode(nodeID); err != nil {
+			return result, fmt.Errorf("forget sessions of node %d: %w", nodeID, err)
+		}
+	}
+	if resolver, ok := s.ctrl.(matterctrl.NodeResolver); ok {
+		resolver.ForgetNodeAddress(nodeID)
+	}
+
+	result.Devices = hubDevices(registry, nodeID)
+	for _, uniqueID := range result.Devices {
+		registry.Remove(uniqueID)
+	}
+	if registry.HubNodeID == nodeID {
+		registry.HubNodeID = 0
+	}
+	if err := s.registry.Save(ctx, &registry); err != nil {
+		return result, err
+	}
+
+	result.Recorded, err = commission.RemoveNode(ctx, s.state, opts.FabricIndex, nodeID)
+	return result, err
+}
+
+// hubDevices returns the sorted unique IDs of the registry devices behind
+// nodeID.
+func hubDevices(registry store.Registry, nodeID uint64) []string {
+	var devices []string
+	for uniqueID, record := range registry.Devices {
+		if record.NodeID == nodeID || (record.NodeID == 0 && registry.HubNodeID == nodeID) {
+			devices = append(devices, uniqueID)
+		}
+	}
+	sort.Strings(devices)
+	return devices
+}
+
+// removeFabric removes the fabric we access nodeID on from the node and
+// returns its index.
+func (s *DeviceService) removeFabric(ctx context.Context, nodeID uint64) (uint8, error) {
+	value, err := s.ctrl.ReadAttribute(ctx, nodeID, operationalCredentialsEndpoint, clusters.OperationalCredentialsClusterID, clusters.OperationalCredentialsAttrCurrentFabricIndex)
+	if err != nil {
+		return 0, err
+	}
+	index, err := value.AsUint()
+	if err != nil || index == 0 || index > 0xFE {
+		return 0, fmt.Errorf("invalid current fabric index of node %d", nodeID)
+	}
+	fabricIndex := uint8(index)
+	resp, err := s.ctrl.InvokeCommand(ctx, nodeID, operationalCredentialsEndpoint, clusters.OperationalCredentialsClusterID, clusters.OperationalCredentialsCmdRemoveFabric, clusters.OperationalCredentialsRemoveFabricRequest{
+		FabricIndex: fabricIndex,
+	})
+	if err != nil {
+		return fabricIndex, err
+	}
+	if v, ok := resp.(im.Value); ok && v.Bytes() != nil {
+		var noc clusters.OperationalCredentialsNOCResponse
+		if err := v.Unmarshal(&noc); err != nil {
+			return fabricIndex, fmt.Errorf("remove fabric: %w", err)
+		}
+		if noc.StatusCode != clusters.OperationalCredentialsNodeOperationalCertStatusEnumOK {
+			return fabricIndex, fmt.Errorf("remove fabric: device returned %s", noc.StatusCode)
+		}
+	}
+	return fabricIndex, nil
+}
+
+// resolveRemoveTarget returns the hub node of the registry device target,
+// reporting that target is bridged, or target parsed as a node ID.
+func resolveRemoveTarget(registry store.Registry, target string) (uint64, bool, error) {
+	if record, ok := registry.Find(target); ok {
+		nodeID := record.NodeID
+		if nodeID == 0 {
+			nodeID = registry.HubNodeID
+		}
+		if nodeID == 0 {
+			return 0, true, errors.New("hub node id is not set")
+		}
+		return nodeID, true, nil
+	}
+	nodeID, err := strconv.ParseUint(target, 0, 64)
+	if err != nil || nodeID == 0 {
+		return 0, false, fmt.Errorf("device not found for unique_id %s", target)
+	}
+	return nodeID, false, nil
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
+	"github.com/YashubuStudio/go-matter-pack/internal/mattermodel"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+const removeHubNodeID = 0x10
+
+// removeController answers RemoveFabric on fabric index 3 of a node with
+// status, or fails every operation with err.
+type removeController struct {
+	matterctrl.Controller
+	status clusters.OperationalCredentialsNodeOperationalCertStatusEnum
+	err    error
+
+	removed   []uint8
+	forgotten []uint64
+}
+
+func (c *removeController) ReadAttribute(_ context.Context, _ uint64, _ uint16, clusterID uint32, attrID uint32) (im.Value, error) {
+	if c.err != nil {
+		return im.Value{}, c.err
+	}
+	if clusterID != clusters.OperationalCredentialsClusterID || attrID != clusters.OperationalCredentialsAttrCurrentFabricIndex {
+		return im.Value{}, errors.New("unexpected attribute")
+	}
+	b, err := tlv.Marshal(uint8(3))
+	if err != nil {
+		return im.Value{}, err
+	}
+	return im.NewValue(b)
+}
+
+func (c *removeController) InvokeCommand(_ context.Context, _ uint64, _ uint16, _ uint32, _ uint32, payload any) (any, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	req, ok := payload.(clusters.OperationalCredentialsRemoveFabricRequest)
+	if !ok {
+		return nil, errors.New("unexpected command")
+	}
+	c.removed = append(c.removed, req.FabricIndex)
+	b, err := tlv.Marshal(clusters.OperationalCredentialsNOCResponse{StatusCode: c.status, FabricIndex: &req.FabricIndex})
+	if err != nil {
+		return nil, err
+	}
+	return im.NewValue(b)
+}
+
+func (c *removeController) ForgetNode(nodeID uint64) error {
+	c.forgotten = append(c.forgotten, nodeID)
+	return nil
+}
+
+// newRemoveService returns a DeviceService whose registry holds two bridged
+// devices behind removeHubNodeID, which is recorded on local fabrics 1 and 2.
+func newRemoveService(t *testing.T, ctrl matterctrl.Controller) (*DeviceService, store.Store, store.Store) {
+	t.Helper()
+	ctx := context.Background()
+	dir := t.TempDir()
+	registryStore := store.NewJSONFileStore(filepath.Join(dir, "registry.json"))
+	stateStore := store.NewJSONFileStore(filepath.Join(dir, "state.json"))
+
+	registry := store.NewRegistry()
+	registry.ApplyScan(time.Now(), removeHubNodeID, []mattermodel.BridgedDevice{
+		{UniqueID: "lamp", Endpoint: 2},
+		{UniqueID: "plug", Endpoint: 3},
+	})
+	if err := registryStore.Save(ctx, registry); err != nil {
+		t.Fatal(err)
+	}
+	for _, fabric := range []uint8{1, 2} {
+		if _, err := commission.UpdateResult(ctx, stateStore, fabric, commission.ResultRecord{NodeID: removeHubNodeID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewDeviceService(ctrl, registryStore, stateStore), registryStore, stateStore
+}
+
+func TestDeviceServiceRemove(t *testing.T) {
+	errUnreachable := errors.New("node is unreachable")
+	tests := []struct {
+		name   string
+		target string
+		opts   RemoveOptions
+		// status and err configure the removeController.
+		status        clusters.OperationalCredentialsNodeOperationalCertStatusEnum
+		err           error
+		wantErr       string
+		wantRemoteErr bool
+		wantDevices   []string
+		wantRecorded  bool
+		// wantFabrics are the local fabrics still recording the hub.
+		wantFabrics []uint8
+	}{
+		{
+			name:        "bridged device without all-bridged",
+			target:      "lamp",
+			wantErr:     "lamp is a bridged device of hub node 16; removing it removes the hub and 2 devices behind it",
+			wantFabrics: []uint8{1, 2},
+		},
+		{
+			name:         "bridged device with all-bridged",
+			target:       "lamp",
+			opts:         RemoveOptions{AllBridged: true},
+			wantDevices:  []string{"lamp", "plug"},
+			wantRecorded: true,
+			wantFabrics:  []uint8{2},
+		},
+		{
+			name:         "node on fabric 2",
+			target:       "0x10",
+			opts:         RemoveOptions{FabricIndex: 2},
+			wantDevices:  []string{"lamp", "plug"},
+			wantRecorded: true,
+			wantFabrics:  []uint8{1},
+		},
+		{
+			name:        "node not on fabric 3",
+			target:      "16",
+			opts:        RemoveOptions{FabricIndex: 3},
+			wantDevices: []string{"lamp", "plug"},
+			wantFabrics: []uint8{1, 2},
+		},
+		{
+			name:        "unreachable without force",
+			target:      "16",
+			err:         errUnreachable,
+			wantErr:     errUnreachable.Error(),
+			wantFabrics: []uint8{1, 2},
+		},
+		{
+			name:        "refused without force",
+			target:      "16",
+			status:      clusters.OperationalCredentialsNodeOperationalCertStatusEnumInvalidFabricIndex,
+			wantErr:     "remove fabric: device returned",
+			wantFabrics: []uint8{1, 2},
+		},
+		{
+			name:          "unreachable with force",
+			target:        "16",
+			opts:          RemoveOptions{Force: true},
+			err:           errUnreachable,
+			wantRemoteErr: true,
+			wantDevices:   []string{"lamp", "plug"},
+			wantRecorded:  true,
+			wantFabrics:   []uint8{2},
+		},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		ctrl := &removeController{status: tt.status, err: tt.err}
+		service, registryStore, stateStore := newRemoveService(t, ctrl)
+		result, err := service.Remove(ctx, tt.target, tt.opts)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: Remove = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			if len(ctrl.forgotten) != 0 {
+				t.Errorf("%s: forgot sessions of %v", tt.name, ctrl.forgotten)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("%s: Remove = %v", tt.name, err)
+				continue
+			}
+			if result.NodeID != removeHubNodeID || (result.RemoteErr != nil) != tt.wantRemoteErr ||
+				!slices.Equal(result.Devices, tt.wantDevices) || result.Recorded != tt.wantRecorded {
+				t.Errorf("%s: result = %+v", tt.name, result)
+			}
+			if !tt.wantRemoteErr && (result.FabricIndex != 3 || !slices.Equal(ctrl.removed, []uint8{3})) {
+				t.Errorf("%s: removed fabric %d, RemoveFabric requests %v", tt.name, result.FabricIndex, ctrl.removed)
+			}
+			if !slices.Equal(ctrl.forgotten, []uint64{removeHubNodeID}) {
+				t.Errorf("%s: forgot sessions of %v", tt.name, ctrl.forgotten)
+			}
+		}
+
+		var registry store.Registry
+		if err := registryStore.Load(ctx, &registry); err != nil {
+			t.Fatal(err)
+		}
+		if wantLeft := 2 - len(tt.wantDevices); len(registry.Devices) != wantLeft {
+			t.Errorf("%s: registry has %d devices, want %d", tt.name, len(registry.Devices), wantLeft)
+		}
+		state, err := commission.LoadState(ctx, stateStore)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fabrics []uint8
+		for _, f := range state.Fabrics {
+			if f.Node(removeHubNodeID) != nil {
+				fabrics = append(fabrics, f.Index)
+			}
+		}
+		if !slices.Equal(fabrics, tt.wantFabrics) {
+			t.Errorf("%s: hub recorded on fabrics %v, want %v", tt.name, fabrics, tt.wantFabrics)
+		}
+	}
+}
@@ -7,12 +7,7 @@ import (
 
 	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
 	"github.com/YashubuStudio/go-matter-pack/internal/store"
-)
-
-const (
-	doorLockClusterID   uint32 = 0x0101
-	lockDoorCommandID   uint32 = 0x00
-	unlockDoorCommandID uint32 = 0x01
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
 )
 
 // DoorLockPayload represents the optional payload for door lock commands.
@@ -33,12 +28,12 @@ func NewLockService(ctrl matterctrl.Controller, store store.Store) *LockService
 
 // Lock sends the LockDoor command to the target device.
 func (s *LockService) Lock(ctx context.Context, uniqueID string) error {
-	return s.invoke(ctx, uniqueID, "", lockDoorCommandID)
+	return s.invoke(ctx, uniqueID, "", clusters.DoorLockCmdLockDoor)
 }
 
 // Unlock sends the UnlockDoor command to the target device.
 func (s *LockService) Unlock(ctx context.Context, uniqueID, pin string) error {
-	return s.invoke(ctx, uniqueID, pin, unlockDoorCommandID)
+	return s.invoke(ctx, uniqueID, pin, clusters.DoorLockCmdUnlockDoor)
 }
 
 func (s *LockService) invoke(ctx context.Context, uniqueID, pin string, cmdID uint32) error {
@@ -83,6 +78,6 @@ func (s *LockService) invoke(ctx context.Context, uniqueID, pin string, cmdID ui
 	if pin != "" {
 		payload.PINCode = pin
 	}
-	_, err := s.ctrl.InvokeCommand(ctx, nodeID, record.Endpoint, doorLockClusterID, cmdID, payload)
+	_, err := s.ctrl.InvokeCommand(ctx, nodeID, record.Endpoint, clusters.DoorLockClusterID, cmdID, payload)
 	return err
 }
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+const (
+	lockEventKindAlarm          = "door_lock_alarm"
+	lockEventKindOperation      = "lock_operation"
+	lockEventKindOperationError = "lock_operation_error"
+
+	defaultLockWatchMinInterval = 0
+	defaultLockWatchMaxInterval = 60 * time.Second
+)
+
+// LockHistoryService collects Door Lock events into a local history.
+type LockHistoryService struct {
+	ctrl     matterctrl.Controller
+	registry store.Store
+	history  store.Store
+	limit    int
+}
+
+// NewLockHistoryService returns a new LockHistoryService. The registry store
+// resolves devices and the history store persists collected events.
+func NewLockHistoryService(ctrl matterctrl.Controller, registry store.Store, history store.Store) *LockHistoryService {
+	return &LockHistoryService{ctrl: ctrl, registry: registry, history: history, limit: store.DefaultLockHistoryLimit}
+}
+
+// Sync reads events newer than the last recorded one and appends them to the
+// history. It returns the newly recorded events.
+func (s *LockHistoryService) Sync(ctx context.Context, uniqueID string) ([]store.LockEvent, error) {
+	nodeID, endpoint, err := s.lookupDevice(ctx, uniqueID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.loadHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventMin := uint64(0)
+	if last, ok := history.LastEventNumber(uniqueID); ok {
+		eventMin = last + 1
+	}
+	events, err := s.ctrl.ReadEvents(ctx, nodeID, lockEventPaths(endpoint), eventMin)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := make([]store.LockEvent, 0, len(events))
+	for _, ev := range events {
+		lockEvent, ok, err := decodeLockEvent(ev)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", ev.EventNumber, err)
+		}
+		if ok {
+			decoded = append(decoded, lockEvent)
+		}
+	}
+
+	added := history.Append(time.Now().UTC(), uniqueID, decoded, s.limit)
+	if err := s.history.Save(ctx, history); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// History returns the recorded events for a device without contacting it.
+func (s *LockHistoryService) History(ctx context.Context, uniqueID string) ([]store.LockEvent, error) {
+	if s == nil || s.history == nil {
+		return nil, errors.New("lock history store is nil")
+	}
+	if uniqueID == "" {
+		return nil, errors.New("unique id is required")
+	}
+	history, err := s.loadHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return history.Events(uniqueID), nil
+}
+
+// Watch subscribes to lock events, records each one as it arrives and passes
+// it to onEvent. It returns when ctx is done or the subscription ends.
+func (s *LockHistoryService) Watch(ctx context.Context, uniqueID string, onEvent func(store.LockEvent)) error {
+	nodeID, endpoint, err := s.lookupDevice(ctx, uniqueID)
+	if err != nil {
+		return err
+	}
+	history, err := s.loadHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	eventMin := uint64(0)
+	if last, ok := history.LastEventNumber(uniqueID); ok {
+		eventMin = last + 1
+	}
+	ch, err := s.ctrl.SubscribeEvents(ctx, nodeID, lockEventPaths(endpoint), eventMin, defaultLockWatchMinInterval, defaultLockWatchMaxInterval)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-ch:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return errors.New("lock event subscription closed")
+			}
+			lockEvent, ok, err := decodeLockEvent(ev)
+			if err != nil {
+				return fmt.Errorf("event %d: %w", ev.EventNumber, err)
+			}
+			if !ok {
+				continue
+			}
+			added := history.Append(time.Now().UTC(), uniqueID, []store.LockEvent{lockEvent}, s.limit)
+			if len(added) == 0 {
+				continue
+			}
+			if err := s.history.Save(ctx, history); err != nil {
+				return err
+			}
+			if onEvent != nil {
+				onEvent(lockEvent)
+			}
+		}
+	}
+}
+
+func (s *LockHistoryService) loadHistory(ctx context.Context) (*store.LockHistory, error) {
+	history := store.NewLockHistory()
+	if err := s.history.Load(ctx, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (s *LockHistoryService) lookupDevice(ctx context.Context, uniqueID string) (uint64, uint16, error) {
+	if s == nil {
+		return 0, 0, errors.New("lock history service is nil")
+	}
+	if s.ctrl == nil {
+		return 0, 0, errors.New("controller is nil")
+	}
+	if s.registry == nil {
+		return 0, 0, errors.New("store is nil")
+	}
+	if s.history == nil {
+		return 0, 0, errors.New("lock history store is nil")
+	}
+	if uniqueID == "" {
+		return 0, 0, errors.New("unique id is required")
+	}
+
+	var registry store.Registry
+	if err := s.registry.Load(ctx, &registry); err != nil {
+		return 0, 0, err
+	}
+	record, ok := registry.Find(uniqueID)
+	if !ok {
+		return 0, 0, fmt.Errorf("device not found for unique_id %s", uniqueID)
+	}
+	if record.Missing {
+		return 0, 0, fmt.Errorf("device %s is marked missing", uniqueID)
+	}
+	if record.Endpoint == 0 {
+		return 0, 0, errors.New("device endpoint is not set")
+	}
+	nodeID := record.NodeID
+	if nodeID == 0 {
+		nodeID = registry.HubNodeID
+	}
+	if nodeID == 0 {
+		return 0, 0, errors.New("hub node id is not set")
+	}
+	return nodeID, record.Endpoint, nil
+}
+
+func lockEventPaths(endpoint uint16) []im.EventPath {
+	return []im.EventPath{
+		im.NewEventPath(endpoint, clusters.DoorLockClusterID, clusters.DoorLockEventLockOperation),
+		im.NewEventPath(endpoint, clusters.DoorLockClusterID, clusters.DoorLockEventLockOperationError),
+		im.NewEventPath(endpoint, clusters.DoorLockClusterID, clusters.DoorLockEventDoorLockAlarm),
+	}
+}
+
+// decodeLockEvent converts a Door Lock event report into a history entry.
+// Events from other clusters or with unknown IDs report ok=false.
+func decodeLockEvent(ev im.EventData) (store.LockEvent, bool, error) {
+	if ev.Path.Cluster == nil || *ev.Path.Cluster != clusters.DoorLockClusterID || ev.Path.Event == nil {
+		return store.LockEvent{}, false, nil
+	}
+
+	out := store.LockEvent{
+		EventNumber:  ev.EventNumber,
+		Priority:     ev.Priority.String(),
+		SystemTimeMs: ev.SystemTimestamp,
+	}
+	if ts, ok := ev.Time(); ok {
+		out.Timestamp = &ts
+	}
+
+	switch *ev.Path.Event {
+	case clusters.DoorLockEventLockOperation:
+		var payload clusters.DoorLockLockOperationEvent
+		if err := unmarshalEventData(ev.Data, &payload); err != nil {
+			return store.LockEvent{}, false, err
+		}
+		out.Kind = lockEventKindOperation
+		if len(ev.Data) > 0 {
+			out.OperationType = enumName(payload.LockOperationType)
+			out.OperationSource = enumName(payload.OperationSource)
+		}
+		out.UserIndex = payload.UserIndex
+		out.FabricIndex = payload.FabricIndex
+		out.SourceNode = payload.SourceNode
+	case clusters.DoorLockEventLockOperationError:
+		var payload clusters.DoorLockLockOperationErrorEvent
+		if err := unmarshalEventData(ev.Data, &payload); err != nil {
+			return store.LockEvent{}, false, err
+		}
+		out.Kind = lockEventKindOperationError
+		if len(ev.Data) > 0 {
+			out.OperationType = enumName(payload.LockOperationType)
+			out.OperationSource = enumName(payload.OperationSource)
+			out.OperationError = enumName(payload.OperationError)
+		}
+		out.UserIndex = payload.UserIndex
+		out.FabricIndex = payload.FabricIndex
+		out.SourceNode = payload.SourceNode
+	case clusters.DoorLockEventDoorLockAlarm:
+		var payload clusters.DoorLockDoorLockAlarmEvent
+		if err := unmarshalEventData(ev.Data, &payload); err != nil {
+			return store.LockEvent{}, false, err
+		}
+		out.Kind = lockEventKindAlarm
+		if len(ev.Data) > 0 {
+			out.AlarmCode = enumName(payload.AlarmCode)
+		}
+	default:
+		return store.LockEvent{}, false, nil
+	}
+	return out, true, nil
+}
+
+// unmarshalEventData decodes an event payload into the generated event
+// structure. A missing payload leaves v unchanged.
+func unmarshalEventData(data []byte, v any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := tlv.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode event payload: %w", err)
+	}
+	return nil
+}
+
+// enumName renders a generated enum value in the snake_case form kept in the
+// history (ProprietaryRemote becomes proprietary_remote). Values the data
+// model does not define render as unknown(N).
+func enumName[T interface {
+	~uint8
+	String() string
+}](v T) string {
+	name := v.String()
+	if strings.ContainsRune(name, '(') {
+		return fmt.Sprintf("unknown(%d)", uint8(v))
+	}
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
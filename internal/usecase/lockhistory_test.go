@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+func lockEventData(t *testing.T, cluster, event uint32, payload any) im.EventData {
+	t.Helper()
+	ev := im.EventData{
+		Path:        im.NewEventPath(1, cluster, event),
+		EventNumber: 7,
+		Priority:    im.EventPriorityCritical,
+	}
+	if payload != nil {
+		b, err := tlv.Marshal(payload)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		ev.Data = b
+	}
+	return ev
+}
+
+func TestDecodeLockEvent(t *testing.T) {
+	user := uint16(3)
+	fabric := uint8(1)
+	tests := []struct {
+		name    string
+		ev      im.EventData
+		ok      bool
+		kind    string
+		opType  string
+		source  string
+		opError string
+		alarm   string
+		user    *uint16
+		fabric  *uint8
+	}{
+		{
+			name: "lock operation",
+			ev: lockEventData(t, clusters.DoorLockClusterID, clusters.DoorLockEventLockOperation, clusters.DoorLockLockOperationEvent{
+				LockOperationType: clusters.DoorLockLockOperationTypeEnumUnlock,
+				OperationSource:   clusters.DoorLockOperationSourceEnumProprietaryRemote,
+				UserIndex:         &user,
+				FabricIndex:       &fabric,
+			}),
+			ok: true, kind: lockEventKindOperation, opType: "unlock", source: "proprietary_remote", user: &user, fabric: &fabric,
+		},
+		{
+			name: "lock operation error",
+			ev: lockEventData(t, clusters.DoorLockClusterID, clusters.DoorLockEventLockOperationError, clusters.DoorLockLockOperationErrorEvent{
+				LockOperationType: clusters.DoorLockLockOperationTypeEnumLock,
+				OperationSource:   clusters.DoorLockOperationSourceEnumRFID,
+				OperationError:    clusters.DoorLockOperationErrorEnumInvalidCredential,
+			}),
+			ok: true, kind: lockEventKindOperationError, opType: "lock", source: "rfid", opError: "invalid_credential",
+		},
+		{
+			name: "alarm",
+			ev: lockEventData(t, clusters.DoorLockClusterID, clusters.DoorLockEventDoorLockAlarm, clusters.DoorLockDoorLockAlarmEvent{
+				AlarmCode: clusters.DoorLockAlarmCodeEnumWrongCodeEntryLimit,
+			}),
+			ok: true, kind: lockEventKindAlarm, alarm: "wrong_code_entry_limit",
+		},
+		{
+			name: "undefined enum value",
+			ev: lockEventData(t, clusters.DoorLockClusterID, clusters.DoorLockEventLockOperation, clusters.DoorLockLockOperationEvent{
+				LockOperationType: clusters.DoorLockLockOperationTypeEnumLock,
+				OperationSource:   clusters.DoorLockOperationSourceEnum(0x20),
+			}),
+			ok: true, kind: lockEventKindOperation, opType: "lock", source: "unknown(32)",
+		},
+		{
+			name: "no payload",
+			ev:   lockEventData(t, clusters.DoorLockClusterID, clusters.DoorLockEventDoorLockAlarm, nil),
+			ok:   true, kind: lockEventKindAlarm,
+		},
+		{
+			name: "door state change",
+			ev:   lockEventData(t, clusters.DoorLockClusterID, clusters.DoorLockEventDoorStateChange, nil),
+		},
+		{
+			name: "other cluster",
+			ev:   lockEventData(t, clusters.OnOffClusterID, clusters.DoorLockEventLockOperation, nil),
+		},
+	}
+	for _, tt := range tests {
+		got, ok, err := decodeLockEvent(tt.ev)
+		if err != nil {
+			t.Errorf("%s: decodeLockEvent: %v", tt.name, err)
+			continue
+		}
+		if ok != tt.ok {
+			t.Errorf("%s: ok = %v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got.EventNumber != 7 || got.Priority != "critical" {
+			t.Errorf("%s: event = %+v", tt.name, got)
+		}
+		if got.Kind != tt.kind || got.OperationType != tt.opType || got.OperationSource != tt.source ||
+			got.OperationError != tt.opError || got.AlarmCode != tt.alarm {
+			t.Errorf("%s: names = %q %q %q %q %q", tt.name, got.Kind, got.OperationType, got.OperationSource, got.OperationError, got.AlarmCode)
+		}
+		if !equalPtr(got.UserIndex, tt.user) || !equalPtr(got.FabricIndex, tt.fabric) || got.SourceNode != nil {
+			t.Errorf("%s: indexes = %v %v %v", tt.name, got.UserIndex, got.FabricIndex, got.SourceNode)
+		}
+	}
+}
+
+func TestDecodeLockEventTimestamp(t *testing.T) {
+	ev := lockEventData(t, clusters.DoorLockClusterID, clusters.DoorLockEventDoorLockAlarm, nil)
+	ev.EpochTimestamp = 1700000000000
+	got, _, err := decodeLockEvent(ev)
+	if err != nil {
+		t.Fatalf("decodeLockEvent: %v", err)
+	}
+	if got.Timestamp == nil || !got.Timestamp.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("Timestamp = %v", got.Timestamp)
+	}
+}
+
+func TestDecodeLockEventMalformed(t *testing.T) {
+	ev := lockEventData(t, clusters.DoorLockClusterID, clusters.DoorLockEventLockOperation, nil)
+	ev.Data = []byte{0x15, 0x24, 0x00}
+	if _, _, err := decodeLockEvent(ev); err == nil {
+		t.Fatalf("expected error for truncated payload")
+	}
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -7,14 +7,7 @@ import (
 
 	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
 	"github.com/YashubuStudio/go-matter-pack/internal/store"
-)
-
-const (
-	onOffClusterID   uint32 = 0x0006
-	onOffAttributeID uint32 = 0x0000
-	onOffCommandID   uint32 = 0x01
-	offCommandID     uint32 = 0x00
-	toggleCommandID  uint32 = 0x02
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
 )
 
 // OnOffService executes On/Off/Toggle commands and reads on/off state.
@@ -30,17 +23,17 @@ func NewOnOffService(ctrl matterctrl.Controller, store store.Store) *OnOffServic
 
 // On sends the On command to the target device.
 func (s *OnOffService) On(ctx context.Context, uniqueID string) error {
-	return s.invoke(ctx, uniqueID, onOffCommandID)
+	return s.invoke(ctx, uniqueID, clusters.OnOffCmdOn)
 }
 
 // Off sends the Off command to the target device.
 func (s *OnOffService) Off(ctx context.Context, uniqueID string) error {
-	return s.invoke(ctx, uniqueID, offCommandID)
+	return s.invoke(ctx, uniqueID, clusters.OnOffCmdOff)
 }
 
 // Toggle sends the Toggle command to the target device.
 func (s *OnOffService) Toggle(ctx context.Context, uniqueID string) error {
-	return s.invoke(ctx, uniqueID, toggleCommandID)
+	return s.invoke(ctx, uniqueID, clusters.OnOffCmdToggle)
 }
 
 // State reads the OnOff attribute from the target device.
@@ -54,11 +47,15 @@ func (s *OnOffService) State(ctx context.Context, uniqueID string) (bool, error)
 	if nodeID == 0 {
 		nodeID = registry.HubNodeID
 	}
-	raw, err := s.ctrl.ReadAttribute(ctx, nodeID, record.Endpoint, onOffClusterID, onOffAttributeID)
+	value, err := s.ctrl.ReadAttribute(ctx, nodeID, record.Endpoint, clusters.OnOffClusterID, clusters.OnOffAttrOnOff)
 	if err != nil {
 		return false, err
 	}
-	return parseOnOffState(raw)
+	state, err := value.AsBool()
+	if err != nil {
+		return false, fmt.Errorf("onoff: %w", err)
+	}
+	return state, nil
 }
 
 func (s *OnOffService) invoke(ctx context.Context, uniqueID string, cmdID uint32) error {
@@ -71,7 +68,7 @@ func (s *OnOffService) invoke(ctx context.Context, uniqueID string, cmdID uint32
 	if nodeID == 0 {
 		nodeID = registry.HubNodeID
 	}
-	_, err = s.ctrl.InvokeCommand(ctx, nodeID, record.Endpoint, onOffClusterID, cmdID, nil)
+	_, err = s.ctrl.InvokeCommand(ctx, nodeID, record.Endpoint, clusters.OnOffClusterID, cmdID, nil)
 	return err
 }
 
@@ -114,33 +111,3 @@ func (s *OnOffService) lookupDevice(ctx context.Context, uniqueID string) (store
 	}
 	return record, registry, nil
 }
-
-func parseOnOffState(raw any) (bool, error) {
-	switch value := raw.(type) {
-	case bool:
-		return value, nil
-	case *bool:
-		if value == nil {
-			return false, errors.New("onoff value is nil")
-		}
-		return *value, nil
-	case int:
-		if value == 0 {
-			return false, nil
-		}
-		if value == 1 {
-			return true, nil
-		}
-		return false, fmt.Errorf("onoff out of range: %d", value)
-	case uint8:
-		if value == 0 {
-			return false, nil
-		}
-		if value == 1 {
-			return true, nil
-		}
-		return false, fmt.Errorf("onoff out of range: %d", value)
-	default:
-		return false, fmt.Errorf("unsupported onoff type %T", raw)
-	}
-}
@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+	"github.com/YashubuStudio/go-matter-pack/matter/pase"
+	"github.com/YashubuStudio/go-matter-pack/matter/types"
+)
+
+const (
+	// DefaultWindowTimeout is the default commissioning window duration.
+	DefaultWindowTimeout = 3 * time.Minute
+	// MinWindowTimeout is the shortest window a node must accept.
+	MinWindowTimeout = 3 * time.Minute
+	// MaxWindowTimeout is the longest window a node must accept.
+	MaxWindowTimeout = 15 * time.Minute
+	// DefaultPBKDFIterations is the PBKDF2 iteration count for new verifiers.
+	DefaultPBKDFIterations uint32 = 1000
+
+	administratorCommissioningEndpoint uint16 = 0
+	shareSaltSize                             = 32
+	// shareTimedInvokeTimeout bounds the timed interaction the Administrator
+	// Commissioning commands require.
+	shareTimedInvokeTimeout = 10 * time.Second
+)
+
+var (
+	// ErrWindowBusy is returned when a commissioning window is already open
+	// or the node is being commissioned.
+	ErrWindowBusy = errors.New("share: commissioning window is busy")
+	// ErrWindowNotOpen is returned when revoking a window that is not open.
+	ErrWindowNotOpen = errors.New("share: commissioning window is not open")
+	// ErrPAKEParameter is returned when the node rejects the PAKE parameters.
+	ErrPAKEParameter = errors.New("share: PAKE parameters rejected")
+)
+
+// ShareOptions configures a commissioning window.
+type ShareOptions struct {
+	// Timeout is how long the window stays open; zero uses
+	// DefaultWindowTimeout.
+	Timeout time.Duration
+	// Iterations is the PBKDF2 iteration count; zero uses
+	// DefaultPBKDFIterations.
+	Iterations uint32
+	// Discriminator advertises a fixed discriminator; nil picks one at random.
+	Discriminator *uint16
+	// Basic opens a Basic Commissioning Window with the original onboarding
+	// payload instead of an Enhanced Commissioning Window.
+	Basic bool
+}
+
+// CommissioningWindow describes an open commissioning window.
+type CommissioningWindow struct {
+	NodeID        uint64        `json:"node_id"`
+	Enhanced      bool          `json:"enhanced"`
+	Timeout       time.Duration `json:"timeout"`
+	Discriminator uint16        `json:"discriminator,omitempty"`
+	Passcode      uint32        `json:"passcode,omitempty"`
+	PairingCode   string        `json:"pairing_code,omitempty"`
+	QRCode        string        `json:"qr_code,omitempty"`
+	ExpiresAt     time.Time     `json:"expires_at"`
+}
+
+// ShareService opens and revokes commissioning windows so that another
+// administrator can commission an already commissioned node.
+type ShareService struct {
+	ctrl        matterctrl.Controller
+	store       store.Store
+	rand        io.Reader
+	fabricIndex uint8
+}
+
+// NewShareService returns a new ShareService. store holds the commissioning
+// state used to resolve the node and its onboarding payload.
+func NewShareService(ctrl matterctrl.Controller, store store.Store) *ShareService {
+	return &ShareService{ctrl: ctrl, store: store, rand: rand.Reader}
+}
+
+// SetFabric selects the local fabric nodes are resolved on; zero selects
+// commission.DefaultFabricIndex.
+func (s *ShareService) SetFabric(index uint8) {
+	s.fabricIndex = index
+}
+
+// OpenWindow opens a commissioning window on nodeID, or on the commissioned
+// node in the state when nodeID is zero. An Enhanced Commissioning Window
+// uses a freshly generated passcode, salt and SPAKE2+ verifier.
+func (s *ShareService) OpenWindow(ctx context.Context, nodeID uint64, opts ShareOptions) (CommissioningWindow, error) {
+	node, err := s.resolveNode(ctx, nodeID)
+	if err != nil {
+		return CommissioningWindow{}, err
+	}
+	nodeID = node.NodeID
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultWindowTimeout
+	}
+	if timeout < MinWindowTimeout || timeout > MaxWindowTimeout {
+		return CommissioningWindow{}, fmt.Errorf("commissioning window timeout must be between %s and %s", MinWindowTimeout, MaxWindowTimeout)
+	}
+	window := CommissioningWindow{
+		NodeID:  nodeID,
+		Timeout: timeout,
+	}
+
+	if opts.Basic {
+		request := clusters.AdministratorCommissioningOpenBasicCommissioningWindowRequest{
+			CommissioningTimeout: uint16(timeout / time.Second),
+		}
+		if err := s.invoke(ctx, nodeID, clusters.AdministratorCommissioningCmdOpenBasicCommissioningWindow, request); err != nil {
+			return CommissioningWindow{}, err
+		}
+		if node.Payload != nil {
+			window.Discriminator = node.Payload.Discriminator
+			window.Passcode = node.Payload.Passcode
+			window.PairingCode = node.Payload.PairingCode
+			window.QRCode = node.Payload.QRCode
+		}
+		window.ExpiresAt = time.Now().Add(timeout)
+		return window, nil
+	}
+
+	iterations := opts.Iterations
+	if iterations == 0 {
+		iterations = DefaultPBKDFIterations
+	}
+	discriminator, err := s.discriminator(opts.Discriminator)
+	if err != nil {
+		return CommissioningWindow{}, err
+	}
+	passcode, err := encoding.GeneratePasscode(s.rand)
+	if err != nil {
+		return CommissioningWindow{}, err
+	}
+	salt := make([]byte, shareSaltSize)
+	if _, err := io.ReadFull(s.rand, salt); err != nil {
+		return CommissioningWindow{}, err
+	}
+	verifier, err := pase.ComputeVerifier(passcode, salt, iterations)
+	if err != nil {
+		return CommissioningWindow{}, err
+	}
+	vendorID, productID, err := s.productIDs(ctx, node)
+	if err != nil {
+		return CommissioningWindow{}, err
+	}
+	pairingCode, err := encoding.NewPairingCode(types.CommissioningFlowStandard, 0, 0, encoding.Discriminator(discriminator), passcode)
+	if err != nil {
+		return CommissioningWindow{}, err
+	}
+	qrPayload, err := encoding.NewQRPayload(types.CommissioningFlowStandard, encoding.VendorID(vendorID), encoding.ProductID(productID), types.DiscoveryCapabilityOnNetwork, encoding.Discriminator(discriminator), passcode)
+	if err != nil {
+		return CommissioningWindow{}, err
+	}
+
+	request := clusters.AdministratorCommissioningOpenCommissioningWindowRequest{
+		CommissioningTimeout: uint16(timeout / time.Second),
+		PAKEPasscodeVerifier: verifier,
+		Discriminator:        discriminator,
+		Iterations:           iterations,
+		Salt:                 salt,
+	}
+	if err := s.invoke(ctx, nodeID, clusters.AdministratorCommissioningCmdOpenCommissioningWindow, request); err != nil {
+		return CommissioningWindow{}, err
+	}
+	window.Enhanced = true
+	window.Discriminator = discriminator
+	window.Passcode = passcode
+	window.PairingCode = pairingCode.String()
+	window.QRCode = qrPayload.String()
+	window.ExpiresAt = time.Now().Add(timeout)
+	return window, nil
+}
+
+// Revoke closes the commissioning window open on nodeID, or on the
+// commissioned node in the state when nodeID is zero.
+func (s *ShareService) Revoke(ctx context.Context, nodeID uint64) error {
+	node, err := s.resolveNode(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	return s.invoke(ctx, node.NodeID, clusters.AdministratorCommissioningCmdRevokeCommissioning, clusters.AdministratorCommissioningRevokeCommissioningRequest{})
+}
+
+// WindowStatus reads the WindowStatus attribute of nodeID, or of the
+// commissioned node in the state when nodeID is zero.
+func (s *ShareService) WindowStatus(ctx context.Context, nodeID uint64) (clusters.AdministratorCommissioningCommissioningWindowStatusEnum, error) {
+	node, err := s.resolveNode(ctx, nodeID)
+	if err != nil {
+		return 0, err
+	}
+	value, err := s.ctrl.ReadAttribute(ctx, node.NodeID, administratorCommissioningEndpoint, clusters.AdministratorCommissioningClusterID, clusters.AdministratorCommissioningAttrWindowStatus)
+	if err != nil {
+		return 0, err
+	}
+	status, err := im.UintAs[uint8](value)
+	if err != nil {
+		return 0, fmt.Errorf("share: %w", err)
+	}
+	return clusters.AdministratorCommissioningCommissioningWindowStatusEnum(status), nil
+}
+
+// resolveNode returns the record of nodeID on the selected fabric, or of the
+// last commissioned node when nodeID is zero. Nodes without a record are
+// returned as a bare NodeRecord.
+func (s *ShareService) resolveNode(ctx context.Context, nodeID uint64) (commission.NodeRecord, error) {
+	if s == nil {
+		return commission.NodeRecord{}, errors.New("share service is nil")
+	}
+	if s.ctrl == nil {
+		return commission.NodeRecord{}, errors.New("controller is nil")
+	}
+	if s.store == nil {
+		return commission.NodeRecord{}, errors.New("store is nil")
+	}
+	state, err := commission.LoadState(ctx, s.store)
+	if err != nil {
+		return commission.NodeRecord{}, err
+	}
+	fabric := state.Fabric(s.fabricIndex)
+	if fabric == nil {
+		if nodeID == 0 {
+			return commission.NodeRecord{}, errors.New("node id is required")
+		}
+		return commission.NodeRecord{NodeID: nodeID}, nil
+	}
+	if nodeID == 0 {
+		if node := fabric.LastCommissioned(); node != nil {
+			return *node, nil
+		}
+		return commission.NodeRecord{}, errors.New("node id is required")
+	}
+	if node := fabric.Node(nodeID); node != nil {
+		return *node, nil
+	}
+	return commission.NodeRecord{NodeID: nodeID}, nil
+}
+
+// invoke invokes an Administrator Commissioning command, all of which
+// require a timed interaction.
+func (s *ShareService) invoke(ctx context.Context, nodeID uint64, cmdID uint32, payload any) error {
+	_, err := s.ctrl.TimedInvokeCommand(ctx, nodeID, administratorCommissioningEndpoint, clusters.AdministratorCommissioningClusterID, cmdID, payload, shareTimedInvokeTimeout)
+	return shareError(err)
+}
+
+// productIDs returns the vendor and product ID of node from its record, or
+// reads them from the Basic Information cluster.
+func (s *ShareService) productIDs(ctx context.Context, node commission.NodeRecord) (uint16, uint16, error) {
+	if node.Result != nil && node.Result.VendorID != 0 {
+		return node.Result.VendorID, node.Result.ProductID, nil
+	}
+	if node.Payload != nil && node.Payload.VendorID != 0 {
+		return node.Payload.VendorID, node.Payload.ProductID, nil
+	}
+	nodeID := node.NodeID
+	vendorID, err := s.readUint16(ctx, nodeID, clusters.BasicInformationAttrVendorID)
+	if err != nil {
+		return 0, 0, err
+	}
+	productID, err := s.readUint16(ctx, nodeID, clusters.BasicInformationAttrProductID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return vendorID, productID, nil
+}
+
+func (s *ShareService) readUint16(ctx context.Context, nodeID uint64, attrID uint32) (uint16, error) {
+	value, err := s.ctrl.ReadAttribute(ctx, nodeID, administratorCommissioningEndpoint, clusters.BasicInformationClusterID, attrID)
+	if err != nil {
+		return 0, err
+	}
+	v, err := im.UintAs[uint16](value)
+	if err != nil {
+		return 0, fmt.Errorf("basic information: %w", err)
+	}
+	return v, nil
+}
+
+func (s *ShareService) discriminator(fixed *uint16) (uint16, error) {
+	if fixed != nil {
+		if encoding.MaxDiscriminator < encoding.Discriminator(*fixed) {
+			return 0, fmt.Errorf("discriminator must be at most %d", encoding.MaxDiscriminator)
+		}
+		return *fixed, nil
+	}
+	discriminator, err := encoding.GenerateDiscriminator(s.rand)
+	return uint16(discriminator), err
+}
+
+// shareError maps Administrator Commissioning cluster statuses to errors.
+func shareError(err error) error {
+	var statusErr *im.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ClusterStatus == nil {
+		return err
+	}
+	switch clusters.AdministratorCommissioningStatusCode(*statusErr.ClusterStatus) {
+	case clusters.AdministratorCommissioningStatusCodeBusy:
+		return fmt.Errorf("%w: %w", ErrWindowBusy, err)
+	case clusters.AdministratorCommissioningStatusCodePAKEParameterError:
+		return fmt.Errorf("%w: %w", ErrPAKEParameter, err)
+	case clusters.AdministratorCommissioningStatusCodeWindowNotOpen:
+		return fmt.Errorf("%w: %w", ErrWindowNotOpen, err)
+	}
+	return err
+}
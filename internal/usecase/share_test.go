@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+	"github.com/YashubuStudio/go-matter-pack/matter/pase"
+	"github.com/YashubuStudio/go-matter-pack/matter/types"
+)
+
+const (
+	shareRecordedNodeID = 0x20
+	shareUnknownNodeID  = 0x30
+)
+
+type shareAttr struct {
+	cluster, attr uint32
+}
+
+type shareInvoke struct {
+	nodeID  uint64
+	cmdID   uint32
+	payload any
+	timeout time.Duration
+}
+
+// shareController answers attribute reads from attrs and fails every
+// operation with err.
+type shareController struct {
+	matterctrl.Controller
+	attrs map[shareAttr]uint64
+	err   error
+
+	invokes []shareInvoke
+}
+
+func (c *shareController) ReadAttribute(_ context.Context, _ uint64, _ uint16, clusterID uint32, attrID uint32) (im.Value, error) {
+	if c.err != nil {
+		return im.Value{}, c.err
+	}
+	v, ok := c.attrs[shareAttr{clusterID, attrID}]
+	if !ok {
+		return im.Value{}, &im.StatusError{Status: im.StatusUnsupportedAttribute}
+	}
+	b, err := tlv.Marshal(v)
+	if err != nil {
+		return im.Value{}, err
+	}
+	return im.NewValue(b)
+}
+
+func (c *shareController) TimedInvokeCommand(_ context.Context, nodeID uint64, _ uint16, _ uint32, cmdID uint32, payload any, timeout time.Duration) (any, error) {
+	c.invokes = append(c.invokes, shareInvoke{nodeID: nodeID, cmdID: cmdID, payload: payload, timeout: timeout})
+	return nil, c.err
+}
+
+func shareClusterStatus(code clusters.AdministratorCommissioningStatusCode) error {
+	status := uint8(code)
+	return &im.StatusError{Status: im.StatusFailure, ClusterStatus: &status}
+}
+
+// newShareTestService returns a ShareService on ctrl whose state records
+// shareRecordedNodeID with its onboarding payload on the default fabric.
+// withState false leaves the state empty.
+func newShareTestService(t *testing.T, ctrl matterctrl.Controller, withState bool) *ShareService {
+	t.Helper()
+	ctx := context.Background()
+	stateStore := store.NewJSONFileStore(filepath.Join(t.TempDir(), "state.json"))
+	if withState {
+		code, err := encoding.NewPairingCode(types.CommissioningFlowStandard, 0, 0, 0xF00, 20202021)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := commission.ImportPayload(ctx, stateStore, commission.DefaultFabricIndex, shareRecordedNodeID, code.String()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := commission.UpdateResult(ctx, stateStore, commission.DefaultFabricIndex, commission.ResultRecord{
+			NodeID:    shareRecordedNodeID,
+			VendorID:  0xFFF1,
+			ProductID: 0x8000,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	service := NewShareService(ctrl, stateStore)
+	service.rand = bytes.NewReader(bytes.Repeat([]byte{0x5A}, 256))
+	return service
+}
+
+func TestShareServiceOpenWindow(t *testing.T) {
+	basicInfo := map[shareAttr]uint64{
+		{clusters.BasicInformationClusterID, clusters.BasicInformationAttrVendorID}:  0xFFF2,
+		{clusters.BasicInformationClusterID, clusters.BasicInformationAttrProductID}: 0x8001,
+	}
+	tests := []struct {
+		name    string
+		nodeID  uint64
+		opts    ShareOptions
+		attrs   map[shareAttr]uint64
+		err     error
+		wantErr error
+		// wantErrText is checked when wantErr is nil.
+		wantErrText string
+		wantNodeID  uint64
+		// wantInvoke expects one timed invoke of wantCmd.
+		wantInvoke  bool
+		wantCmd     uint32
+		wantTimeout time.Duration
+		wantPayload bool
+	}{
+		{
+			name:        "enhanced window on the last commissioned node",
+			wantNodeID:  shareRecordedNodeID,
+			wantInvoke:  true,
+			wantCmd:     clusters.AdministratorCommissioningCmdOpenCommissioningWindow,
+			wantTimeout: DefaultWindowTimeout,
+		},
+		{
+			name:        "enhanced window reads product IDs of an unrecorded node",
+			nodeID:      shareUnknownNodeID,
+			opts:        ShareOptions{Timeout: MaxWindowTimeout},
+			attrs:       basicInfo,
+			wantNodeID:  shareUnknownNodeID,
+			wantInvoke:  true,
+			wantCmd:     clusters.AdministratorCommissioningCmdOpenCommissioningWindow,
+			wantTimeout: MaxWindowTimeout,
+		},
+		{
+			name:        "basic window with the onboarding payload",
+			nodeID:      shareRecordedNodeID,
+			opts:        ShareOptions{Basic: true},
+			wantNodeID:  shareRecordedNodeID,
+			wantInvoke:  true,
+			wantCmd:     clusters.AdministratorCommissioningCmdOpenBasicCommissioningWindow,
+			wantTimeout: DefaultWindowTimeout,
+			wantPayload: true,
+		},
+		{
+			name:        "window too short",
+			opts:        ShareOptions{Timeout: time.Minute},
+			wantErrText: "commissioning window timeout must be between",
+		},
+		{
+			name:        "discriminator out of range",
+			opts:        ShareOptions{Discriminator: func() *uint16 { d := uint16(0x1000); return &d }()},
+			wantErrText: "discriminator must be at most",
+		},
+		{
+			name:        "vendor ID out of range",
+			nodeID:      shareUnknownNodeID,
+			attrs:       map[shareAttr]uint64{{clusters.BasicInformationClusterID, clusters.BasicInformationAttrVendorID}: 0x10000},
+			wantErrText: "basic information:",
+		},
+		{
+			name:       "busy",
+			err:        shareClusterStatus(clusters.AdministratorCommissioningStatusCodeBusy),
+			wantErr:    ErrWindowBusy,
+			wantInvoke: true,
+			wantCmd:    clusters.AdministratorCommissioningCmdOpenCommissioningWindow,
+		},
+		{
+			name:       "PAKE parameters rejected",
+			err:        shareClusterStatus(clusters.AdministratorCommissioningStatusCodePAKEParameterError),
+			wantErr:    ErrPAKEParameter,
+			wantInvoke: true,
+			wantCmd:    clusters.AdministratorCommissioningCmdOpenCommissioningWindow,
+		},
+	}
+	for _, tt := range tests {
+		ctrl := &shareController{attrs: tt.attrs, err: tt.err}
+		service := newShareTestService(t, ctrl, true)
+		window, err := service.OpenWindow(context.Background(), tt.nodeID, tt.opts)
+		switch {
+		case tt.wantErr != nil:
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: OpenWindow = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		case tt.wantErrText != "":
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrText) {
+				t.Errorf("%s: OpenWindow = %v, want %q", tt.name, err, tt.wantErrText)
+			}
+		case err != nil:
+			t.Errorf("%s: OpenWindow = %v", tt.name, err)
+			continue
+		}
+		if !tt.wantInvoke {
+			if len(ctrl.invokes) != 0 {
+				t.Errorf("%s: invoked %+v", tt.name, ctrl.invokes)
+			}
+			continue
+		}
+		if len(ctrl.invokes) != 1 {
+			t.Errorf("%s: %d invokes, want 1", tt.name, len(ctrl.invokes))
+			continue
+		}
+		invoke := ctrl.invokes[0]
+		if invoke.cmdID != tt.wantCmd || invoke.timeout != shareTimedInvokeTimeout {
+			t.Errorf("%s: invoked command %d with timeout %s", tt.name, invoke.cmdID, invoke.timeout)
+		}
+		if err != nil {
+			continue
+		}
+		if window.NodeID != tt.wantNodeID || window.Timeout != tt.wantTimeout || window.Enhanced == tt.opts.Basic ||
+			window.PairingCode == "" || (!tt.opts.Basic && window.QRCode == "") {
+			t.Errorf("%s: window = %+v", tt.name, window)
+		}
+		if tt.wantPayload && (window.Passcode != 20202021 || window.Discriminator != 0xF00) {
+			t.Errorf("%s: window passcode %d, discriminator %d", tt.name, window.Passcode, window.Discriminator)
+		}
+		if req, ok := invoke.payload.(clusters.AdministratorCommissioningOpenCommissioningWindowRequest); ok {
+			verifier, err := pase.ComputeVerifier(pase.Passcode(window.Passcode), req.Salt, req.Iterations)
+			if err != nil || !bytes.Equal(verifier, req.PAKEPasscodeVerifier) {
+				t.Errorf("%s: verifier does not match passcode %d: %v", tt.name, window.Passcode, err)
+			}
+			if req.Discriminator != window.Discriminator || time.Duration(req.CommissioningTimeout)*time.Second != window.Timeout {
+				t.Errorf("%s: request = %+v", tt.name, req)
+			}
+		}
+	}
+}
+
+func TestShareServiceRevoke(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodeID     uint64
+		withState  bool
+		err        error
+		wantErr    error
+		wantNodeID uint64
+	}{
+		{
+			name:       "last commissioned node",
+			withState:  true,
+			wantNodeID: shareRecordedNodeID,
+		},
+		{
+			name:       "node without state",
+			nodeID:     shareUnknownNodeID,
+			wantNodeID: shareUnknownNodeID,
+		},
+		{
+			name:       "window not open",
+			withState:  true,
+			err:        shareClusterStatus(clusters.AdministratorCommissioningStatusCodeWindowNotOpen),
+			wantErr:    ErrWindowNotOpen,
+			wantNodeID: shareRecordedNodeID,
+		},
+	}
+	for _, tt := range tests {
+		ctrl := &shareController{err: tt.err}
+		service := newShareTestService(t, ctrl, tt.withState)
+		err := service.Revoke(context.Background(), tt.nodeID)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: Revoke = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		} else if err != nil {
+			t.Errorf("%s: Revoke = %v", tt.name, err)
+		}
+		if len(ctrl.invokes) != 1 || ctrl.invokes[0].nodeID != tt.wantNodeID ||
+			ctrl.invokes[0].cmdID != clusters.AdministratorCommissioningCmdRevokeCommissioning ||
+			ctrl.invokes[0].timeout != shareTimedInvokeTimeout {
+			t.Errorf("%s: invokes = %+v", tt.name, ctrl.invokes)
+		}
+	}
+
+	service := newShareTestService(t, &shareController{}, false)
+	if err := service.Revoke(context.Background(), 0); err == nil || err.Error() != "node id is required" {
+		t.Errorf("Revoke without a node = %v", err)
+	}
+}
+
+func TestShareServiceWindowStatus(t *testing.T) {
+	windowStatus := shareAttr{clusters.AdministratorCommissioningClusterID, clusters.AdministratorCommissioningAttrWindowStatus}
+	errUnreachable := errors.New("node is unreachable")
+	tests := []struct {
+		name    string
+		attrs   map[shareAttr]uint64
+		err     error
+		want    clusters.AdministratorCommissioningCommissioningWindowStatusEnum
+		wantErr string
+	}{
+		{
+			name:  "enhanced window open",
+			attrs: map[shareAttr]uint64{windowStatus: 1},
+			want:  clusters.AdministratorCommissioningCommissioningWindowStatusEnumEnhancedWindowOpen,
+		},
+		{
+			name:  "window not open",
+			attrs: map[shareAttr]uint64{windowStatus: 0},
+			want:  clusters.AdministratorCommissioningCommissioningWindowStatusEnumWindowNotOpen,
+		},
+		{
+			name:    "out of range",
+			attrs:   map[shareAttr]uint64{windowStatus: 0x100},
+			wantErr: "share:",
+		},
+		{
+			name:    "unreachable",
+			err:     errUnreachable,
+			wantErr: errUnreachable.Error(),
+		},
+	}
+	for _, tt := range tests {
+		service := newShareTestService(t, &shareController{attrs: tt.attrs, err: tt.err}, true)
+		status, err := service.WindowStatus(context.Background(), 0)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: WindowStatus = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || status != tt.want {
+			t.Errorf("%s: WindowStatus = %v, %v, want %v", tt.name, status, err, tt.want)
+		}
+	}
+}
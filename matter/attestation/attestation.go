@@ -0,0 +1,248 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package attestation verifies that a commissionee is a genuine Matter
+// device from the attestation material it returns over PASE.
+// Reference: Matter Core Spec 1.5, Device Attestation
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+var (
+	// ErrInvalid is returned when the attestation material is malformed,
+	// inconsistent or not signed by the device. It is never overridden.
+	ErrInvalid = errors.New("attestation: invalid")
+	// ErrUntrusted is returned when the attestation is well formed but does
+	// not chain to the trust store or is not certified for production, as
+	// with development devices. Verifier.AllowUncertified overrides it.
+	ErrUntrusted = errors.New("attestation: untrusted")
+)
+
+// signatureSize is the length of a raw P-256 ECDSA signature (r || s).
+const signatureSize = 64
+
+// attestationElements is the TLV structure signed by the DAC.
+// Reference: Matter Core Spec 1.5, Attestation Information
+type attestationElements struct {
+	CertificationDeclaration []byte `tlv:"1"`
+	AttestationNonce         []byte `tlv:"2"`
+	Timestamp                uint32 `tlv:"3"`
+	FirmwareInformation      []byte `tlv:"4,omitempty"`
+}
+
+// Verifier checks device attestation against a TrustStore. It implements
+// commissioning.AttestationVerifier.
+type Verifier struct {
+	// Store holds the trusted PAA and Certification Declaration signing
+	// certificates. A nil Store trusts nothing.
+	Store *TrustStore
+	// AllowUncertified accepts devices failing only with ErrUntrusted, such
+	// as development devices with test certificates.
+	AllowUncertified bool
+	// Now returns the time certificates are validated at; nil uses time.Now.
+	Now func() time.Time
+}
+
+// NewVerifier returns a verifier that trusts store.
+func NewVerifier(store *TrustStore) *Verifier {
+	return &Verifier{Store: store}
+}
+
+// VerifyAttestation verifies the DAC → PAI → PAA chain, the attestation
+// signature and nonce, and the Certification Declaration with its VID/PID
+// consistency against the certificates and the onboarding payload.
+func (v *Verifier) VerifyAttestation(_ context.Context, info commissioning.AttestationInfo) error {
+	dac, err := x509.ParseCertificate(info.DAC)
+	if err != nil {
+		return fmt.Errorf("%w: DAC: %w", ErrInvalid, err)
+	}
+	pai, err := x509.ParseCertificate(info.PAI)
+	if err != nil {
+		return fmt.Errorf("%w: PAI: %w", ErrInvalid, err)
+	}
+	elements, err := v.verifyElements(dac, info)
+	if err != nil {
+		return err
+	}
+	cd, err := parseCMS(elements.CertificationDeclaration)
+	if err != nil {
+		return err
+	}
+	declaration, err := DecodeCertificationDeclaration(cd.content)
+	if err != nil {
+		return err
+	}
+	vid, pid, err := checkProductIDs(dac, pai, declaration, info.Device)
+	if err != nil {
+		return err
+	}
+
+	var untrusted []error
+	paa, err := v.verifyChain(dac, pai, vid)
+	switch {
+	case errors.Is(err, ErrInvalid):
+		return err
+	case err != nil:
+		untrusted = append(untrusted, err)
+	case !authorizedPAA(declaration, paa):
+		return fmt.Errorf("%w: PAA is not authorized by the certification declaration", ErrInvalid)
+	}
+	err = cd.verify(v.Store.cdSigners())
+	switch {
+	case errors.Is(err, ErrInvalid):
+		return err
+	case err != nil:
+		untrusted = append(untrusted, err)
+	}
+	if declaration.CertificationType == CertificationTypeDevelopment {
+		untrusted = append(untrusted, fmt.Errorf("%w: certification declaration is for development and test", ErrUntrusted))
+	}
+	if len(untrusted) == 0 {
+		return nil
+	}
+	err = errors.Join(untrusted...)
+	if !v.AllowUncertified {
+		return err
+	}
+	log.Warnf("Accepting uncertified device VID 0x%04X PID 0x%04X: %v", vid, pid, err)
+	return nil
+}
+
+// verifyElements checks the attestation signature by the DAC and the nonce.
+func (v *Verifier) verifyElements(dac *x509.Certificate, info commissioning.AttestationInfo) (*attestationElements, error) {
+	pub, ok := dac.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: DAC key is not ECDSA", ErrInvalid)
+	}
+	if len(info.AttestationSignature) != signatureSize {
+		return nil, fmt.Errorf("%w: attestation signature is %d bytes", ErrInvalid, len(info.AttestationSignature))
+	}
+	digest := sha256.Sum256(append(slices.Clone(info.AttestationElements), info.Challenge...))
+	r := new(big.Int).SetBytes(info.AttestationSignature[:signatureSize/2])
+	s := new(big.Int).SetBytes(info.AttestationSignature[signatureSize/2:])
+	if !ecdsa.Verify(pub, digest[:], r, s) {
+		return nil, fmt.Errorf("%w: attestation signature does not match the DAC", ErrInvalid)
+	}
+	var elements attestationElements
+	if err := tlv.Unmarshal(info.AttestationElements, &elements); err != nil {
+		return nil, fmt.Errorf("%w: attestation elements: %w", ErrInvalid, err)
+	}
+	if !bytes.Equal(elements.AttestationNonce, info.Nonce) {
+		return nil, fmt.Errorf("%w: attestation nonce mismatch", ErrInvalid)
+	}
+	return &elements, nil
+}
+
+// verifyChain verifies the DAC and PAI against the trusted PAAs and returns
+// the PAA, which must carry vid if it is vendor scoped.
+func (v *Verifier) verifyChain(dac, pai *x509.Certificate, vid uint16) (*x509.Certificate, error) {
+	now := time.Now
+	if v.Now != nil {
+		now = v.Now
+	}
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(pai)
+	chains, err := dac.Verify(x509.VerifyOptions{
+		Roots:         v.Store.paaPool(),
+		Intermediates: intermediates,
+		CurrentTime:   now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUntrusted, err)
+	}
+	for _, chain := range chains {
+		if len(chain) != 3 || !chain[1].Equal(pai) {
+			continue
+		}
+		paa := chain[2]
+		paaIDs, err := parseSubjectIDs(paa)
+		if err != nil {
+			return nil, fmt.Errorf("%w: PAA: %w", ErrInvalid, err)
+		}
+		if paaIDs.vendorID != nil && *paaIDs.vendorID != vid {
+			return nil, fmt.Errorf("%w: DAC VID 0x%04X differs from PAA VID 0x%04X", ErrInvalid, vid, *paaIDs.vendorID)
+		}
+		return paa, nil
+	}
+	return nil, fmt.Errorf("%w: DAC does not chain through the PAI to a PAA", ErrUntrusted)
+}
+
+// authorizedPAA reports whether the declaration allows DACs chaining to paa.
+func authorizedPAA(cd *CertificationDeclaration, paa *x509.Certificate) bool {
+	if len(cd.AuthorizedPAAList) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(cd.AuthorizedPAAList, func(id []byte) bool {
+		return bytes.Equal(id, paa.SubjectKeyId)
+	})
+}
+
+// checkProductIDs checks that the vendor and product IDs of the DAC, PAI,
+// certification declaration and onboarding payload agree, and returns the
+// DAC VID and PID. Zero payload IDs come from manual pairing codes without a
+// VID/PID and are not compared.
+func checkProductIDs(dac, pai *x509.Certificate, cd *CertificationDeclaration, device commissioning.DeviceInfo) (uint16, uint16, error) {
+	dacIDs, err := parseSubjectIDs(dac)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: DAC: %w", ErrInvalid, err)
+	}
+	paiIDs, err := parseSubjectIDs(pai)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: PAI: %w", ErrInvalid, err)
+	}
+	if dacIDs.vendorID == nil || dacIDs.productID == nil {
+		return 0, 0, fmt.Errorf("%w: DAC subject without vendor and product ID", ErrInvalid)
+	}
+	vid, pid := *dacIDs.vendorID, *dacIDs.productID
+	switch {
+	case paiIDs.vendorID == nil:
+		return 0, 0, fmt.Errorf("%w: PAI subject without vendor ID", ErrInvalid)
+	case *paiIDs.vendorID != vid:
+		return 0, 0, fmt.Errorf("%w: DAC VID 0x%04X differs from PAI VID 0x%04X", ErrInvalid, vid, *paiIDs.vendorID)
+	case paiIDs.productID != nil && *paiIDs.productID != pid:
+		return 0, 0, fmt.Errorf("%w: DAC PID 0x%04X differs from PAI PID 0x%04X", ErrInvalid, pid, *paiIDs.productID)
+	}
+
+	if cd.DACOriginVendorID != nil {
+		if *cd.DACOriginVendorID != vid || cd.DACOriginProductID == nil || *cd.DACOriginProductID != pid {
+			return 0, 0, fmt.Errorf("%w: DAC VID/PID 0x%04X/0x%04X differ from the certification declaration origin", ErrInvalid, vid, pid)
+		}
+	} else if cd.VendorID != vid || !slices.Contains(cd.ProductIDs, pid) {
+		return 0, 0, fmt.Errorf("%w: DAC VID/PID 0x%04X/0x%04X not covered by the certification declaration", ErrInvalid, vid, pid)
+	}
+
+	if device.VendorID != 0 && device.VendorID != cd.VendorID {
+		return 0, 0, fmt.Errorf("%w: onboarding payload VID 0x%04X differs from the certification declaration VID 0x%04X", ErrInvalid, device.VendorID, cd.VendorID)
+	}
+	if device.ProductID != 0 && !slices.Contains(cd.ProductIDs, device.ProductID) {
+		return 0, 0, fmt.Errorf("%w: onboarding payload PID 0x%04X not covered by the certification declaration", ErrInvalid, device.ProductID)
+	}
+	return vid, pid, nil
+}
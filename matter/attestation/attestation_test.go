@@ -0,0 +1,312 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, name string, ids map[string]string, parent *testCA, ca bool, skid []byte) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := pkix.Name{CommonName: name}
+	if vid, ok := ids["vid"]; ok {
+		subject.ExtraNames = append(subject.ExtraNames, pkix.AttributeTypeAndValue{Type: oidVendorID, Value: vid})
+	}
+	if pid, ok := ids["pid"]; ok {
+		subject.ExtraNames = append(subject.ExtraNames, pkix.AttributeTypeAndValue{Type: oidProductID, Value: pid})
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  ca,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		SubjectKeyId:          skid,
+	}
+	if ca {
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	}
+	issuer, signer := tmpl, key
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+type fixture struct {
+	paa, pai, dac, cdSigner *testCA
+	declaration             CertificationDeclaration
+	nonce, challenge        []byte
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{
+		nonce:     []byte("0123456789abcdef0123456789abcdef"),
+		challenge: []byte("attestation-challenge"),
+		declaration: CertificationDeclaration{
+			FormatVersion:     1,
+			VendorID:          0xFFF1,
+			ProductIDs:        []uint16{0x8000, 0x8001},
+			DeviceTypeID:      0x0100,
+			CertificateID:     "ZIG20141ZB330001-24",
+			VersionNumber:     0x2694,
+			CertificationType: CertificationTypeOfficial,
+		},
+	}
+	f.paa = newTestCert(t, "PAA", map[string]string{"vid": "FFF1"}, nil, true, []byte{1})
+	f.pai = newTestCert(t, "PAI", map[string]string{"vid": "FFF1"}, f.paa, true, []byte{2})
+	f.dac = newTestCert(t, "DAC", map[string]string{"vid": "FFF1", "pid": "8000"}, f.pai, false, []byte{3})
+	f.cdSigner = newTestCert(t, "CD Signing Key", nil, nil, false, []byte{4, 4, 4, 4})
+	return f
+}
+
+func (f *fixture) store() *TrustStore {
+	store := NewTrustStore()
+	store.AddPAA(f.paa.cert)
+	store.AddCDSigner(f.cdSigner.cert)
+	return store
+}
+
+// signCMS wraps content in a CMS SignedData envelope signed by signer.
+func signCMS(t *testing.T, content []byte, signer *testCA) []byte {
+	t.Helper()
+	digest := sha256.Sum256(content)
+	sig, err := ecdsa.SignASN1(rand.Reader, signer.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd, err := asn1.Marshal(signedData{
+		Version:          3,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{{Algorithm: oidSHA256}},
+		EncapContentInfo: encapsulatedContentInfo{EContentType: oidData, EContent: content},
+		SignerInfos: []signerInfo{{
+			Version:            3,
+			SubjectKeyID:       signer.cert.SubjectKeyId,
+			DigestAlgorithm:    pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256},
+			Signature:          sig,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func (f *fixture) info(t *testing.T) commissioning.AttestationInfo {
+	t.Helper()
+	cd, err := tlv.Marshal(f.declaration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	elements, err := tlv.Marshal(attestationElements{
+		CertificationDeclaration: signCMS(t, cd, f.cdSigner),
+		AttestationNonce:         f.nonce,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(append(append([]byte{}, elements...), f.challenge...))
+	r, s, err := ecdsa.Sign(rand.Reader, f.dac.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := make([]byte, signatureSize)
+	r.FillBytes(sig[:signatureSize/2])
+	s.FillBytes(sig[signatureSize/2:])
+	return commissioning.AttestationInfo{
+		Device:               commissioning.DeviceInfo{VendorID: 0xFFF1, ProductID: 0x8000},
+		DAC:                  f.dac.cert.Raw,
+		PAI:                  f.pai.cert.Raw,
+		AttestationElements:  elements,
+		AttestationSignature: sig,
+		Nonce:                f.nonce,
+		Challenge:            f.challenge,
+	}
+}
+
+func TestVerifyAttestation(t *testing.T) {
+	f := newFixture(t)
+	if err := NewVerifier(f.store()).VerifyAttestation(context.Background(), f.info(t)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyAttestationWithoutPayloadIDs(t *testing.T) {
+	f := newFixture(t)
+	info := f.info(t)
+	info.Device = commissioning.DeviceInfo{}
+	if err := NewVerifier(f.store()).VerifyAttestation(context.Background(), info); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyAttestationUntrusted(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*fixture, *TrustStore) *TrustStore
+	}{
+		{"unknown PAA", func(f *fixture, _ *TrustStore) *TrustStore {
+			store := NewTrustStore()
+			store.AddCDSigner(f.cdSigner.cert)
+			return store
+		}},
+		{"unknown CD signer", func(f *fixture, _ *TrustStore) *TrustStore {
+			store := NewTrustStore()
+			store.AddPAA(f.paa.cert)
+			return store
+		}},
+		{"development declaration", func(f *fixture, store *TrustStore) *TrustStore {
+			f.declaration.CertificationType = CertificationTypeDevelopment
+			return store
+		}},
+		{"no trust store", func(*fixture, *TrustStore) *TrustStore {
+			return nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			store := tt.modify(f, f.store())
+			info := f.info(t)
+			v := NewVerifier(store)
+			if err := v.VerifyAttestation(context.Background(), info); !errors.Is(err, ErrUntrusted) {
+				t.Fatalf("expected ErrUntrusted, got %v", err)
+			}
+			v.AllowUncertified = true
+			if err := v.VerifyAttestation(context.Background(), info); err != nil {
+				t.Errorf("AllowUncertified: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyAttestationInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*fixture, *commissioning.AttestationInfo)
+	}{
+		{"nonce mismatch", func(_ *fixture, info *commissioning.AttestationInfo) {
+			info.Nonce = []byte("fedcba9876543210fedcba9876543210")
+		}},
+		{"challenge mismatch", func(_ *fixture, info *commissioning.AttestationInfo) {
+			info.Challenge = []byte("other")
+		}},
+		{"payload vendor", func(_ *fixture, info *commissioning.AttestationInfo) {
+			info.Device.VendorID = 0xFFF2
+		}},
+		{"payload product", func(_ *fixture, info *commissioning.AttestationInfo) {
+			info.Device.ProductID = 0x8002
+		}},
+		{"PAI swapped", func(f *fixture, info *commissioning.AttestationInfo) {
+			info.PAI = f.paa.cert.Raw[:10]
+		}},
+		{"DAC not in declaration", func(f *fixture, info *commissioning.AttestationInfo) {
+			f.declaration.ProductIDs = []uint16{0x8001}
+			*info = f.info(t)
+			info.Device.ProductID = 0x8001
+		}},
+		{"PAA not authorized", func(f *fixture, info *commissioning.AttestationInfo) {
+			f.declaration.AuthorizedPAAList = [][]byte{{9, 9}}
+			*info = f.info(t)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			info := f.info(t)
+			tt.modify(f, &info)
+			v := &Verifier{Store: f.store(), AllowUncertified: true}
+			if err := v.VerifyAttestation(context.Background(), info); !errors.Is(err, ErrInvalid) {
+				t.Errorf("expected ErrInvalid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadPAADir(t *testing.T) {
+	f := newFixture(t)
+	dir := t.TempDir()
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.paa.cert.Raw})
+	files := map[string][]byte{
+		"paa.pem":    pemBytes,
+		"pai.der":    f.pai.cert.Raw,
+		"README.txt": []byte("not a certificate"),
+	}
+	for name, b := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := NewTrustStore()
+	n, err := store.LoadPAADir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("loaded %d certificates, want 2", n)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.crt"), []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.LoadPAADir(dir); err == nil {
+		t.Error("expected an error for a broken certificate file")
+	}
+}
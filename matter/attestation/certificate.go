@@ -0,0 +1,63 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"strconv"
+)
+
+// Matter DN attribute types carrying the vendor and product IDs.
+var (
+	oidVendorID  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 37244, 2, 1}
+	oidProductID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 37244, 2, 2}
+)
+
+// subjectIDs are the vendor and product IDs of a certificate subject, nil
+// when absent.
+type subjectIDs struct {
+	vendorID  *uint16
+	productID *uint16
+}
+
+// parseSubjectIDs returns the vendor and product IDs of cert, encoded as
+// four uppercase hexadecimal digits.
+func parseSubjectIDs(cert *x509.Certificate) (subjectIDs, error) {
+	var ids subjectIDs
+	for _, name := range cert.Subject.Names {
+		var dst **uint16
+		switch {
+		case name.Type.Equal(oidVendorID):
+			dst = &ids.vendorID
+		case name.Type.Equal(oidProductID):
+			dst = &ids.productID
+		default:
+			continue
+		}
+		s, ok := name.Value.(string)
+		if !ok || len(s) != 4 {
+			return ids, fmt.Errorf("invalid subject attribute %s: %v", name.Type, name.Value)
+		}
+		v, err := strconv.ParseUint(s, 16, 16)
+		if err != nil {
+			return ids, fmt.Errorf("invalid subject attribute %s: %w", name.Type, err)
+		}
+		id := uint16(v)
+		*dst = &id
+	}
+	return ids, nil
+}
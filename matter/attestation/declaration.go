@@ -0,0 +1,162 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// CertificationType values of a Certification Declaration.
+const (
+	CertificationTypeDevelopment = 0
+	CertificationTypeProvisional = 1
+	CertificationTypeOfficial    = 2
+)
+
+// CertificationDeclaration is the TLV payload of a Certification
+// Declaration, issued by the CSA for certified products.
+// Reference: Matter Core Spec 1.5, Certification Declaration
+type CertificationDeclaration struct {
+	FormatVersion       uint8    `tlv:"0"`
+	VendorID            uint16   `tlv:"1"`
+	ProductIDs          []uint16 `tlv:"2"`
+	DeviceTypeID        uint32   `tlv:"3"`
+	CertificateID       string   `tlv:"4"`
+	SecurityLevel       uint8    `tlv:"5"`
+	SecurityInformation uint16   `tlv:"6"`
+	VersionNumber       uint16   `tlv:"7"`
+	CertificationType   uint8    `tlv:"8"`
+	DACOriginVendorID   *uint16  `tlv:"9,omitempty"`
+	DACOriginProductID  *uint16  `tlv:"10,omitempty"`
+	AuthorizedPAAList   [][]byte `tlv:"11,omitempty"`
+}
+
+// DecodeCertificationDeclaration decodes the TLV payload of a
+// Certification Declaration.
+func DecodeCertificationDeclaration(b []byte) (*CertificationDeclaration, error) {
+	var cd CertificationDeclaration
+	if err := tlv.Unmarshal(b, &cd); err != nil {
+		return nil, fmt.Errorf("%w: certification declaration: %w", ErrInvalid, err)
+	}
+	if len(cd.ProductIDs) == 0 {
+		return nil, fmt.Errorf("%w: certification declaration without product IDs", ErrInvalid)
+	}
+	return &cd, nil
+}
+
+// CMS object identifiers.
+var (
+	oidSignedData      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidData            = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSHA256          = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+)
+
+// contentInfo is the CMS ContentInfo envelope (RFC 5652). Content is the
+// [0] EXPLICIT wrapper of the SignedData.
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type encapsulatedContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+// signerInfo identifies the signer by subject key identifier; Matter
+// declarations carry no signed attributes.
+type signerInfo struct {
+	Version            int
+	SubjectKeyID       []byte `asn1:"tag:0"`
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+}
+
+// signedContent is a parsed CMS SignedData envelope.
+type signedContent struct {
+	content      []byte
+	subjectKeyID []byte
+	signature    []byte
+}
+
+// parseCMS parses the CMS SignedData envelope of a Certification
+// Declaration.
+func parseCMS(b []byte) (*signedContent, error) {
+	var ci contentInfo
+	if rest, err := asn1.Unmarshal(b, &ci); err != nil || len(rest) > 0 {
+		return nil, fmt.Errorf("%w: certification declaration is not CMS: %v", ErrInvalid, err)
+	}
+	if !ci.ContentType.Equal(oidSignedData) || ci.Content.Class != asn1.ClassContextSpecific || ci.Content.Tag != 0 {
+		return nil, fmt.Errorf("%w: certification declaration content type %s", ErrInvalid, ci.ContentType)
+	}
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("%w: certification declaration SignedData: %w", ErrInvalid, err)
+	}
+	if !sd.EncapContentInfo.EContentType.Equal(oidData) || len(sd.EncapContentInfo.EContent) == 0 {
+		return nil, fmt.Errorf("%w: certification declaration without content", ErrInvalid)
+	}
+	if len(sd.SignerInfos) != 1 {
+		return nil, fmt.Errorf("%w: certification declaration has %d signers", ErrInvalid, len(sd.SignerInfos))
+	}
+	si := sd.SignerInfos[0]
+	if !si.DigestAlgorithm.Algorithm.Equal(oidSHA256) || !si.SignatureAlgorithm.Algorithm.Equal(oidECDSAWithSHA256) {
+		return nil, fmt.Errorf("%w: certification declaration is not signed with ECDSA SHA-256", ErrInvalid)
+	}
+	return &signedContent{
+		content:      sd.EncapContentInfo.EContent,
+		subjectKeyID: si.SubjectKeyID,
+		signature:    si.Signature,
+	}, nil
+}
+
+// verify checks the signature with the signer whose subject key identifier
+// matches.
+func (c *signedContent) verify(signers []*x509.Certificate) error {
+	for _, cert := range signers {
+		if !bytes.Equal(cert.SubjectKeyId, c.subjectKeyID) {
+			continue
+		}
+		pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+		if !ok {
+			continue
+		}
+		digest := sha256.Sum256(c.content)
+		if !ecdsa.VerifyASN1(pub, digest[:], c.signature) {
+			return fmt.Errorf("%w: certification declaration signature does not match signer %X", ErrInvalid, c.subjectKeyID)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown certification declaration signer %X", ErrUntrusted, c.subjectKeyID)
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TrustStore holds the Product Attestation Authority (PAA) certificates
+// that DACs must chain to, and the certificates that sign Certification
+// Declarations.
+type TrustStore struct {
+	paas    []*x509.Certificate
+	signers []*x509.Certificate
+}
+
+// NewTrustStore returns an empty trust store.
+func NewTrustStore() *TrustStore {
+	return &TrustStore{}
+}
+
+// AddPAA trusts cert as a PAA.
+func (s *TrustStore) AddPAA(cert *x509.Certificate) {
+	s.paas = append(s.paas, cert)
+}
+
+// AddCDSigner trusts cert to sign Certification Declarations.
+func (s *TrustStore) AddCDSigner(cert *x509.Certificate) {
+	s.signers = append(s.signers, cert)
+}
+
+// LoadPAADir trusts every certificate in the .pem, .crt, .cer and .der files
+// of dir as a PAA and returns the number loaded.
+func (s *TrustStore) LoadPAADir(dir string) (int, error) {
+	certs, err := loadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	s.paas = append(s.paas, certs...)
+	return len(certs), nil
+}
+
+// LoadCDSignerDir trusts every certificate in the .pem, .crt, .cer and .der
+// files of dir to sign Certification Declarations and returns the number
+// loaded.
+func (s *TrustStore) LoadCDSignerDir(dir string) (int, error) {
+	certs, err := loadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	s.signers = append(s.signers, certs...)
+	return len(certs), nil
+}
+
+// paaPool returns the PAAs as a pool, empty for a nil store.
+func (s *TrustStore) paaPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	if s == nil {
+		return pool
+	}
+	for _, cert := range s.paas {
+		pool.AddCert(cert)
+	}
+	return pool
+}
+
+// cdSigners returns the Certification Declaration signers.
+func (s *TrustStore) cdSigners() []*x509.Certificate {
+	if s == nil {
+		return nil
+	}
+	return s.signers
+}
+
+// loadDir parses the certificate files of dir.
+func loadDir(dir string) ([]*x509.Certificate, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var certs []*x509.Certificate
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".pem", ".crt", ".cer", ".der":
+		default:
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseCertificates(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		certs = append(certs, parsed...)
+	}
+	return certs, nil
+}
+
+// parseCertificates parses b as PEM blocks, or as a single DER certificate
+// when it holds no PEM.
+func parseCertificates(b []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := b
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) > 0 {
+		return certs, nil
+	}
+	if len(rest) != len(b) {
+		return nil, errors.New("no certificate in PEM file")
+	}
+	cert, err := x509.ParseCertificate(b)
+	if err != nil {
+		return nil, err
+	}
+	return []*x509.Certificate{cert}, nil
+}
@@ -0,0 +1,478 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Packet header flags.
+// 4.19.2.1. BTP Packet Format.
+const (
+	flagBegin      = 0x01
+	flagContinue   = 0x02
+	flagEnd        = 0x04
+	flagAck        = 0x08
+	flagManagement = 0x20
+	flagHandshake  = 0x40
+)
+
+const (
+	// DefaultWindowSize is the receive window advertised in the handshake.
+	DefaultWindowSize = 6
+	// minSegmentSize is the payload of the default ATT MTU of 23 bytes.
+	minSegmentSize = 20
+	// maxHeaderLen is the longest packet header: flags, ack number,
+	// sequence number and message length.
+	maxHeaderLen = 5
+	// ackTimeout bounds how long a sender waits for the peer to open its
+	// receive window.
+	// 4.19.4.7. Acknowledgements.
+	ackTimeout = 15 * time.Second
+	// ackDelay is how long a received message waits for a reply to carry
+	// its acknowledgement.
+	ackDelay = ackTimeout / 3
+)
+
+// ErrClosed is returned by operations on a closed session.
+var ErrClosed = errors.New("btp: session closed")
+
+// GATT is the link a BTP session runs over: writes to the C1
+// characteristic of the server and indications received on C2, or the
+// reverse on the server side. Close ends the connection.
+type GATT interface {
+	Read(ctx context.Context) ([]byte, error)
+	Write(ctx context.Context, b []byte) (int, error)
+	Close() error
+}
+
+// Addr is the address of a BTP peer: its Bluetooth address.
+type Addr string
+
+// Network returns "btp".
+func (a Addr) Network() string { return "btp" }
+
+// String returns the Bluetooth address.
+func (a Addr) String() string { return string(a) }
+
+// Conn is an established BTP session. It implements net.PacketConn with
+// every packet a whole Matter message, so that Matter sessions can run over
+// it; BTP delivers messages reliably and in order.
+type Conn struct {
+	gatt        GATT
+	peer        Addr
+	segmentSize int
+	peerWindow  uint8
+	window      uint8
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+
+	// writeMu keeps the segments of a message together and packetMu the
+	// sequence numbers of the packets in order.
+	writeMu  sync.Mutex
+	packetMu sync.Mutex
+	mu       sync.Mutex
+	// acked is closed and replaced when the peer acknowledges packets.
+	acked chan struct{}
+	// ackNow and ackLater ask the acknowledger for a standalone
+	// acknowledgement.
+	ackNow   chan struct{}
+	ackLater chan struct{}
+	// txNext is the next sequence number to send and txAcked the last one
+	// the peer acknowledged.
+	txNext  uint8
+	txAcked uint8
+	// rxNext is the next sequence number expected; rxUnacked counts the
+	// received packets not acknowledged yet.
+	rxNext    uint8
+	rxUnacked int
+
+	messages     chan []byte
+	readErr      error
+	readDeadline time.Time
+	assembly     []byte
+	expected     int
+}
+
+// Handshake opens a BTP session as the client over gatt with peer, for a
+// connection with the given ATT MTU (zero if unknown).
+// 4.19.4.3. Session Establishment.
+func Handshake(ctx context.Context, gatt GATT, peer Addr, mtu uint16) (*Conn, error) {
+	req := NewHandshakeRequest(mtu, DefaultWindowSize)
+	if _, err := gatt.Write(ctx, req.Bytes()); err != nil {
+		return nil, fmt.Errorf("btp: handshake request: %w", err)
+	}
+	b, err := gatt.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("btp: handshake response: %w", err)
+	}
+	res, err := NewHandshakeResponseFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if res.Version() != Version {
+		return nil, fmt.Errorf("btp: server selected version %d", res.Version())
+	}
+	if res.SegmentSize() < minSegmentSize || res.WindowSize() == 0 {
+		return nil, fmt.Errorf("btp: handshake response %s", res.String())
+	}
+	// The handshake response is packet 0 of the server, which the first
+	// packet of the client acknowledges.
+	c := newConn(gatt, peer, int(res.SegmentSize()), res.WindowSize())
+	c.rxNext = 1
+	c.rxUnacked = 1
+	return c, nil
+}
+
+// Accept waits for the handshake request of a client on gatt and opens a
+// BTP session as the server, with packets of at most segmentSize bytes.
+// It serves device simulators and tests.
+func Accept(ctx context.Context, gatt GATT, peer Addr, segmentSize uint16) (*Conn, error) {
+	b, err := gatt.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("btp: handshake request: %w", err)
+	}
+	req, err := NewHandshakeRequestFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version() != Version || req.WindowSize() == 0 {
+		return nil, fmt.Errorf("btp: unsupported handshake request %s", req.String())
+	}
+	if mtu := req.ATTMTU(); mtu > 3 && int(mtu)-3 < int(segmentSize) {
+		segmentSize = mtu - 3
+	}
+	segmentSize = max(segmentSize, minSegmentSize)
+	res := NewHandshakeResponse(Version, segmentSize, DefaultWindowSize)
+	if _, err := gatt.Write(ctx, res.Bytes()); err != nil {
+		return nil, fmt.Errorf("btp: handshake response: %w", err)
+	}
+	c := newConn(gatt, peer, int(segmentSize), req.WindowSize())
+	c.txNext = 1
+	return c, nil
+}
+
+func newConn(gatt GATT, peer Addr, segmentSize int, peerWindow uint8) *Conn {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Conn{
+		gatt:        gatt,
+		peer:        peer,
+		segmentSize: segmentSize,
+		peerWindow:  peerWindow,
+		window:      DefaultWindowSize,
+		ctx:         ctx,
+		cancel:      cancel,
+		acked:       make(chan struct{}),
+		ackNow:      make(chan struct{}, 1),
+		ackLater:    make(chan struct{}, 1),
+		txAcked:     0xFF,
+		messages:    make(chan []byte, DefaultWindowSize),
+	}
+	go c.receive()
+	go c.acknowledger()
+	return c
+}
+
+// SegmentSize returns the largest packet of the session.
+func (c *Conn) SegmentSize() int {
+	return c.segmentSize
+}
+
+// ReadFrom waits for the next message of the peer.
+func (c *Conn) ReadFrom(b []byte) (int, net.Addr, error) {
+	c.mu.Lock()
+	deadline := c.readDeadline
+	c.mu.Unlock()
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case msg, ok := <-c.messages:
+		if !ok {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return 0, nil, c.readErr
+		}
+		return copy(b, msg), c.peer, nil
+	case <-timeout:
+		return 0, nil, os.ErrDeadlineExceeded
+	}
+}
+
+// WriteTo sends b as one message to the peer; addr is ignored.
+func (c *Conn) WriteTo(b []byte, _ net.Addr) (int, error) {
+	if len(b) > 0xFFFF {
+		return 0, fmt.Errorf("btp: message of %d bytes", len(b))
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	for offset := 0; offset < len(b) || offset == 0; {
+		var flags byte
+		header := make([]byte, 0, maxHeaderLen)
+		if offset == 0 {
+			flags |= flagBegin
+			header = binary.LittleEndian.AppendUint16(header, uint16(len(b)))
+		} else {
+			flags |= flagContinue
+		}
+		n := min(len(b)-offset, c.segmentSize-len(header)-3)
+		if offset+n == len(b) {
+			flags |= flagEnd
+		}
+		if err := c.send(flags, header, b[offset:offset+n]); err != nil {
+			return 0, err
+		}
+		offset += n
+		if len(b) == 0 {
+			break
+		}
+	}
+	return len(b), nil
+}
+
+// send writes one packet once the peer's receive window has room, with the
+// acknowledgement of the packets received so far. A standalone
+// acknowledgement, flags of flagAck only, may take the last slot of the
+// window and is dropped when nothing is left to acknowledge.
+func (c *Conn) send(flags byte, header, payload []byte) error {
+	standalone := flags == flagAck
+	timeout := time.NewTimer(ackTimeout)
+	defer timeout.Stop()
+	for {
+		c.packetMu.Lock()
+		c.mu.Lock()
+		if standalone && c.rxUnacked == 0 {
+			c.mu.Unlock()
+			c.packetMu.Unlock()
+			return nil
+		}
+		inFlight := c.txNext - c.txAcked - 1
+		if inFlight < c.peerWindow-1 || (standalone && inFlight < c.peerWindow) {
+			break
+		}
+		acked := c.acked
+		c.mu.Unlock()
+		c.packetMu.Unlock()
+		select {
+		case <-acked:
+		case <-timeout.C:
+			return fmt.Errorf("btp: peer did not acknowledge %d packets", c.peerWindow)
+		case <-c.ctx.Done():
+			return ErrClosed
+		}
+	}
+	defer c.packetMu.Unlock()
+	packet := make([]byte, 0, maxHeaderLen+len(payload))
+	if c.rxUnacked > 0 {
+		packet = append(packet, flags|flagAck, c.rxNext-1)
+		c.rxUnacked = 0
+	} else {
+		packet = append(packet, flags)
+	}
+	packet = append(packet, c.txNext)
+	c.txNext++
+	c.mu.Unlock()
+	packet = append(append(packet, header...), payload...)
+	_, err := c.gatt.Write(c.ctx, packet)
+	return err
+}
+
+// acknowledger sends the standalone acknowledgements: at once when asked
+// on ackNow, and after ackDelay when asked on ackLater unless a packet has
+// carried the acknowledgement meanwhile.
+func (c *Conn) acknowledger() {
+	var delayed <-chan time.Time
+	for {
+		select {
+		case <-c.ackNow:
+		case <-c.ackLater:
+			if delayed == nil {
+				delayed = time.After(ackDelay)
+			}
+			continue
+		case <-delayed:
+		case <-c.ctx.Done():
+			return
+		}
+		delayed = nil
+		if err := c.send(flagAck, nil, nil); err != nil {
+			return
+		}
+	}
+}
+
+// receive reads the packets of the peer until the session closes.
+func (c *Conn) receive() {
+	err := c.receiveLoop()
+	c.mu.Lock()
+	c.readErr = err
+	c.mu.Unlock()
+	close(c.messages)
+	c.Close()
+}
+
+func (c *Conn) receiveLoop() error {
+	for {
+		packet, err := c.gatt.Read(c.ctx)
+		if c.ctx.Err() != nil {
+			return ErrClosed
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("btp: %w", err)
+		}
+		msg, data, err := c.handle(packet)
+		if err != nil {
+			return err
+		}
+		// Segments are acknowledged at once so that the rest of the message
+		// follows, whole messages after a delay unless a reply carries the
+		// acknowledgement, and acknowledgements when the window fills.
+		c.mu.Lock()
+		full := c.rxUnacked >= int(c.window)-1
+		c.mu.Unlock()
+		switch {
+		case full || (data && msg == nil):
+			signal(c.ackNow)
+		case data:
+			signal(c.ackLater)
+		}
+		if msg != nil {
+			select {
+			case c.messages <- msg:
+			case <-c.ctx.Done():
+				return ErrClosed
+			}
+		}
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// handle processes a packet and returns the message it completes, if any,
+// and whether it carried data.
+func (c *Conn) handle(packet []byte) ([]byte, bool, error) {
+	if len(packet) < 2 {
+		return nil, false, fmt.Errorf("btp: short packet % X", packet)
+	}
+	flags := packet[0]
+	if flags&(flagManagement|flagHandshake) != 0 {
+		return nil, false, fmt.Errorf("btp: unexpected management packet % X", packet)
+	}
+	rest := packet[1:]
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if flags&flagAck != 0 {
+		ack := rest[0]
+		rest = rest[1:]
+		if inFlight := c.txNext - c.txAcked - 1; ack-c.txAcked > inFlight {
+			return nil, false, fmt.Errorf("btp: acknowledgement %d of a packet not sent", ack)
+		}
+		c.txAcked = ack
+		close(c.acked)
+		c.acked = make(chan struct{})
+	}
+	if len(rest) < 1 {
+		return nil, false, fmt.Errorf("btp: short packet % X", packet)
+	}
+	if rest[0] != c.rxNext {
+		return nil, false, fmt.Errorf("btp: packet %d out of sequence, want %d", rest[0], c.rxNext)
+	}
+	c.rxNext++
+	c.rxUnacked++
+	rest = rest[1:]
+	data := flags&(flagBegin|flagContinue|flagEnd) != 0
+	switch {
+	case flags&flagBegin != 0:
+		if len(rest) < 2 {
+			return nil, false, fmt.Errorf("btp: short packet % X", packet)
+		}
+		c.expected = int(binary.LittleEndian.Uint16(rest))
+		c.assembly = append([]byte(nil), rest[2:]...)
+	case flags&(flagContinue|flagEnd) != 0:
+		if c.assembly == nil {
+			return nil, false, errors.New("btp: continuation without a beginning")
+		}
+		c.assembly = append(c.assembly, rest...)
+	}
+	if flags&flagEnd == 0 {
+		return nil, data, nil
+	}
+	msg := c.assembly
+	c.assembly = nil
+	if len(msg) != c.expected {
+		return nil, false, fmt.Errorf("btp: message of %d bytes, announced %d", len(msg), c.expected)
+	}
+	return msg, true, nil
+}
+
+// Close ends the session and the GATT connection.
+func (c *Conn) Close() error {
+	var err error
+	c.once.Do(func() {
+		c.cancel()
+		err = c.gatt.Close()
+	})
+	return err
+}
+
+// LocalAddr returns an empty address: the local Bluetooth address is not
+// known.
+func (c *Conn) LocalAddr() net.Addr {
+	return Addr("")
+}
+
+// RemoteAddr returns the address of the peer.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.peer
+}
+
+// SetDeadline sets the read deadline; writes wait for the peer's window
+// with their own timeout.
+func (c *Conn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+// SetReadDeadline sets the deadline of ReadFrom.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readDeadline = t
+	return nil
+}
+
+// SetWriteDeadline is a no-op.
+func (c *Conn) SetWriteDeadline(time.Time) error {
+	return nil
+}
@@ -0,0 +1,231 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pipe is one end of an in-memory GATT link.
+type pipe struct {
+	in     chan []byte
+	out    chan []byte
+	closed chan struct{}
+	once   *sync.Once
+}
+
+func newPipe() (*pipe, *pipe) {
+	a, b := make(chan []byte, 64), make(chan []byte, 64)
+	closed := make(chan struct{})
+	once := &sync.Once{}
+	return &pipe{in: a, out: b, closed: closed, once: once}, &pipe{in: b, out: a, closed: closed, once: once}
+}
+
+func (p *pipe) Read(ctx context.Context) ([]byte, error) {
+	select {
+	case b := <-p.in:
+		return b, nil
+	case <-p.closed:
+		return nil, errors.New("disconnected")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *pipe) Write(ctx context.Context, b []byte) (int, error) {
+	select {
+	case p.out <- bytes.Clone(b):
+		return len(b), nil
+	case <-p.closed:
+		return 0, errors.New("disconnected")
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (p *pipe) Close() error {
+	p.once.Do(func() { close(p.closed) })
+	return nil
+}
+
+func TestHandshakePackets(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, server := newPipe()
+	done := make(chan *Conn, 1)
+	go func() {
+		c, err := Handshake(ctx, client, "server", 0)
+		if err != nil {
+			t.Error(err)
+		}
+		done <- c
+	}()
+
+	req, err := server.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x65, 0x6C, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, DefaultWindowSize}; !bytes.Equal(req, want) {
+		t.Errorf("handshake request % X, want % X", req, want)
+	}
+	if _, err := server.Write(ctx, NewHandshakeResponse(Version, 20, 4).Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	c := <-done
+	if c == nil {
+		t.FailNow()
+	}
+	defer c.Close()
+
+	// The first packet acknowledges the handshake response, packet 0 of
+	// the server, and is packet 0 of the client.
+	if _, err := c.WriteTo([]byte{0xAA, 0xBB}, nil); err != nil {
+		t.Fatal(err)
+	}
+	packet, err := server.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{flagBegin | flagEnd | flagAck, 0x00, 0x00, 0x02, 0x00, 0xAA, 0xBB}; !bytes.Equal(packet, want) {
+		t.Errorf("first packet % X, want % X", packet, want)
+	}
+
+	// A segmented message from the server is reassembled, and its first
+	// segment acknowledged at once.
+	for _, p := range [][]byte{
+		{flagBegin | flagAck, 0x00, 0x01, 0x05, 0x00, 0x01, 0x02},
+		{flagContinue, 0x02, 0x03, 0x04},
+		{flagEnd, 0x03, 0x05},
+	} {
+		if _, err := server.Write(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buf := make([]byte, 16)
+	n, _, err := c.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x01, 0x02, 0x03, 0x04, 0x05}; !bytes.Equal(buf[:n], want) {
+		t.Errorf("message % X, want % X", buf[:n], want)
+	}
+	ack, err := server.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ack[0] != flagAck || ack[2] != 0x01 {
+		t.Errorf("acknowledgement % X", ack)
+	}
+}
+
+func TestConn(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	clientPipe, serverPipe := newPipe()
+	accepted := make(chan *Conn, 1)
+	go func() {
+		c, err := Accept(ctx, serverPipe, "client", 64)
+		if err != nil {
+			t.Error(err)
+		}
+		accepted <- c
+	}()
+	client, err := Handshake(ctx, clientPipe, "server", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	server := <-accepted
+	if server == nil {
+		t.FailNow()
+	}
+	if client.SegmentSize() != 64 || server.SegmentSize() != 64 {
+		t.Errorf("segment sizes %d, %d, want 64", client.SegmentSize(), server.SegmentSize())
+	}
+
+	// Messages longer than the window of segments need acknowledgements
+	// to get through; replies go the other way meanwhile.
+	buf := make([]byte, 4096)
+	for _, size := range []int{1, 59, 60, 300, 1280} {
+		msg := bytes.Repeat([]byte{byte(size)}, size)
+		for _, dir := range []struct {
+			name     string
+			from, to *Conn
+		}{{"client", client, server}, {"server", server, client}} {
+			errc := make(chan error, 1)
+			go func() {
+				_, err := dir.from.WriteTo(msg, nil)
+				errc <- err
+			}()
+			n, addr, err := dir.to.ReadFrom(buf)
+			if err != nil {
+				t.Fatalf("%s %d: %v", dir.name, size, err)
+			}
+			if !bytes.Equal(buf[:n], msg) {
+				t.Errorf("%s %d: received %d bytes", dir.name, size, n)
+			}
+			if addr == nil || addr.Network() != "btp" {
+				t.Errorf("%s %d: peer address %v", dir.name, size, addr)
+			}
+			if err := <-errc; err != nil {
+				t.Errorf("%s %d: %v", dir.name, size, err)
+			}
+		}
+	}
+
+	if err := client.SetReadDeadline(time.Now().Add(10 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.ReadFrom(buf); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("ReadFrom() past deadline = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+	if err := client.SetReadDeadline(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closing one end disconnects the link.
+	server.Close()
+	if _, _, err := client.ReadFrom(buf); err == nil {
+		t.Error("ReadFrom() after disconnection succeeded")
+	}
+}
+
+func TestAcceptRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		req  []byte
+	}{
+		{"other version", []byte{0x65, 0x6C, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x04}},
+		{"no window", []byte{0x65, 0x6C, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{"data packet", []byte{0x05, 0x00, 0x00, 0x00}},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		client, server := newPipe()
+		if _, err := client.Write(ctx, tt.req); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Accept(ctx, server, "client", 244); err == nil {
+			t.Errorf("%s: Accept() succeeded", tt.name)
+		}
+		cancel()
+	}
+}
@@ -12,9 +12,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package btp implements the Bluetooth Transport Protocol, which carries
+// Matter messages over the GATT characteristics of a BLE connection.
+// Reference: Matter Core Spec 1.5, Section 4.19
 package btp
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -22,14 +26,32 @@ import (
 	"github.com/YashubuStudio/go-matter-pack/matter/errors"
 )
 
+// Handshake constants.
+// 4.19.3.2. BTP Handshake Request / 4.19.3.3. BTP Handshake Response
+const (
+	// Version is the BTP version this implementation speaks.
+	Version = 4
+	// handshakeFlags are the H, M, E and B flags of the handshake packets.
+	handshakeFlags = flagHandshake | flagManagement | flagEnd | flagBegin
+	// handshakeOpcode is the management opcode of the handshake.
+	handshakeOpcode     = 0x6C
+	handshakeRequestLen = 9
+	// handshakeResponseLen is the length of the handshake response.
+	handshakeResponseLen = 6
+)
+
 // HandshakeRequest represents a BTP handshake request.
 type HandshakeRequest interface {
 	// ControlFlags returns the control flags.
 	ControlFlags() byte
 	// Opcode returns the management opcode.
 	Opcode() byte
-	// Versiond returns the BTP version.
-	Versiond() int
+	// Version returns the version preferred by the client.
+	Version() uint8
+	// ATTMTU returns the ATT MTU of the connection, zero if unknown.
+	ATTMTU() uint16
+	// WindowSize returns the receive window of the client.
+	WindowSize() uint8
 	// Bytes returns the byte representation of the handshake request.
 	Bytes() []byte
 	// String returns the string representation of the handshake request.
@@ -40,45 +62,53 @@ type handshakeRequest struct {
 	bytes []byte
 }
 
-// NewHandshakeRequest returns a new HandshakeRequest.
-func NewHandshakeRequest() HandshakeRequest {
-	// Construct handshake request frame (9 bytes)[6]
-	handshake := make([]byte, 9)
-	handshake[0] = 0x65 // Control flags: Handshake + Management + etc. (0x65)[7]
-	handshake[1] = 0x6C // Management Opcode: 0x6C (BLE transport handshake)
-	handshake[2] = 0x04 // BTP version = 4
-	// Bytes [3..7]: supported BTP version mask or reserved (set to 0)
-	for i := 3; i <= 7; i++ {
-		handshake[i] = 0x00
-	}
-	handshake[8] = computeCRC8(handshake[:8]) // CRC8 over first 8 bytes
-	return &handshakeRequest{
-		bytes: handshake,
+// NewHandshakeRequest returns a new HandshakeRequest offering Version, for
+// a connection with the given ATT MTU (zero if unknown) and a client receive
+// window of window packets.
+func NewHandshakeRequest(mtu uint16, window uint8) HandshakeRequest {
+	b := make([]byte, handshakeRequestLen)
+	b[0] = handshakeFlags
+	b[1] = handshakeOpcode
+	// Bytes 2-5 list the supported versions as 4-bit values, the preferred
+	// version in the low nibble of the first byte; zero ends the list.
+	b[2] = Version
+	binary.LittleEndian.PutUint16(b[6:8], mtu)
+	b[8] = window
+	return &handshakeRequest{bytes: b}
+}
+
+// NewHandshakeRequestFromBytes returns a new HandshakeRequest from the
+// specified bytes.
+func NewHandshakeRequestFromBytes(data []byte) (HandshakeRequest, error) {
+	if len(data) != handshakeRequestLen || data[0] != handshakeFlags || data[1] != handshakeOpcode {
+		return nil, fmt.Errorf("%w: handshake request % X", errors.ErrInvalid, data)
 	}
+	return &handshakeRequest{bytes: data}, nil
 }
 
 // ControlFlags returns the control flags.
 func (req *handshakeRequest) ControlFlags() byte {
-	if len(req.bytes) < 1 {
-		return 0
-	}
 	return req.bytes[0]
 }
 
 // Opcode returns the management opcode.
 func (req *handshakeRequest) Opcode() byte {
-	if len(req.bytes) < 2 {
-		return 0
-	}
 	return req.bytes[1]
 }
 
-// Versiond returns the BTP version.
-func (req *handshakeRequest) Versiond() int {
-	if len(req.bytes) < 6 {
-		return 0
-	}
-	return int(req.bytes[2])
+// Version returns the version preferred by the client.
+func (req *handshakeRequest) Version() uint8 {
+	return req.bytes[2] & 0x0F
+}
+
+// ATTMTU returns the ATT MTU of the connection, zero if unknown.
+func (req *handshakeRequest) ATTMTU() uint16 {
+	return binary.LittleEndian.Uint16(req.bytes[6:8])
+}
+
+// WindowSize returns the receive window of the client.
+func (req *handshakeRequest) WindowSize() uint8 {
+	return req.bytes[8]
 }
 
 // Bytes returns the byte representation of the handshake request.
@@ -97,8 +127,12 @@ type HandshakeResponse interface {
 	ControlFlags() byte
 	// Opcode returns the management opcode.
 	Opcode() byte
-	// Vendord returns the vendor ID.
-	Vendord() int
+	// Version returns the BTP version selected by the server.
+	Version() uint8
+	// SegmentSize returns the largest packet either side sends.
+	SegmentSize() uint16
+	// WindowSize returns the receive window of the server.
+	WindowSize() uint8
 	// Bytes returns the byte representation of the handshake response.
 	Bytes() []byte
 	// String returns the string representation of the handshake response.
@@ -109,39 +143,49 @@ type handshakeResponse struct {
 	bytes []byte
 }
 
+// NewHandshakeResponse returns a new HandshakeResponse selecting version,
+// segmentSize and the server receive window.
+func NewHandshakeResponse(version uint8, segmentSize uint16, window uint8) HandshakeResponse {
+	b := make([]byte, handshakeResponseLen)
+	b[0] = handshakeFlags
+	b[1] = handshakeOpcode
+	b[2] = version & 0x0F
+	binary.LittleEndian.PutUint16(b[3:5], segmentSize)
+	b[5] = window
+	return &handshakeResponse{bytes: b}
+}
+
 // NewHandshakeResponseFromBytes returns a new HandshakeResponse from the specified bytes.
 func NewHandshakeResponseFromBytes(data []byte) (HandshakeResponse, error) {
-	// 4.19.3.2. BTP Handshake Response
-	if len(data) < 6 {
-		return nil, fmt.Errorf("%w: %s", errors.ErrInvalid, "handshake response length is less than 3")
+	if len(data) != handshakeResponseLen || data[0] != handshakeFlags || data[1] != handshakeOpcode {
+		return nil, fmt.Errorf("%w: handshake response % X", errors.ErrInvalid, data)
 	}
-	return &handshakeResponse{
-		bytes: data,
-	}, nil
+	return &handshakeResponse{bytes: data}, nil
 }
 
 // ControlFlags returns the control flags.
 func (res *handshakeResponse) ControlFlags() byte {
-	if len(res.bytes) < 1 {
-		return 0
-	}
 	return res.bytes[0]
 }
 
 // Opcode returns the management opcode.
 func (res *handshakeResponse) Opcode() byte {
-	if len(res.bytes) < 2 {
-		return 0
-	}
 	return res.bytes[1]
 }
 
-// Vendord returns the vendor ID.
-func (res *handshakeResponse) Vendord() int {
-	if len(res.bytes) < 6 {
-		return 0
-	}
-	return int((res.bytes[2] & 0xF0) >> 4)
+// Version returns the BTP version selected by the server.
+func (res *handshakeResponse) Version() uint8 {
+	return res.bytes[2] & 0x0F
+}
+
+// SegmentSize returns the largest packet either side sends.
+func (res *handshakeResponse) SegmentSize() uint16 {
+	return binary.LittleEndian.Uint16(res.bytes[3:5])
+}
+
+// WindowSize returns the receive window of the server.
+func (res *handshakeResponse) WindowSize() uint8 {
+	return res.bytes[5]
 }
 
 // Bytes returns the byte representation of the handshake response.
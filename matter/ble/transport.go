@@ -24,8 +24,8 @@ import (
 // Transport represents a BLE transport.
 type Transport interface {
 	ble.Transport
-	// Handshake performs the handshake operation.
-	Handshake(ctx context.Context) (btp.HandshakeResponse, error)
+	// Handshake opens a BTP session with peer over the transport.
+	Handshake(ctx context.Context, peer btp.Addr) (*btp.Conn, error)
 }
 
 type transport struct {
@@ -38,24 +38,10 @@ func newTransport(bleTransport ble.Transport) Transport {
 	}
 }
 
-// Handshake performs the handshake operation.
-func (t *transport) Handshake(ctx context.Context) (btp.HandshakeResponse, error) {
+// Handshake opens a BTP session with peer over the transport.
+func (t *transport) Handshake(ctx context.Context, peer btp.Addr) (*btp.Conn, error) {
 	// 4.19.4.3. Session Establishment
-
-	_, err := t.WriteWithoutResponse(ctx, btp.NewHandshakeRequest().Bytes())
-	if err != nil {
-		return nil, err
-	}
-
-	resBytes, err := t.Read(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := btp.NewHandshakeResponseFromBytes(resBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	// The ATT MTU is not exposed by the BLE stack, so the server chooses
+	// the segment size.
+	return btp.Handshake(ctx, t, peer, 0)
 }
@@ -0,0 +1,96 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/YashubuStudio/go-matter-pack/matter/casesession"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+// CASEEstablisher opens CASE sessions to the nodes of a fabric over UDP,
+// authenticating with the controller's operational credentials.
+type CASEEstablisher struct {
+	fabric *credentials.Fabric
+	opts   []transport.SessionOption
+}
+
+var _ commissioning.CASEEstablisher = (*CASEEstablisher)(nil)
+
+// NewCASEEstablisher returns a CASE establisher for the nodes of fabric.
+func NewCASEEstablisher(fabric *credentials.Fabric, opts ...transport.SessionOption) *CASEEstablisher {
+	return &CASEEstablisher{fabric: fabric, opts: opts}
+}
+
+// EstablishCASE runs CASE with peer at the first of addrs that answers. The
+// peer must belong to the fabric of the establisher.
+func (e *CASEEstablisher) EstablishCASE(ctx context.Context, peer commissioning.OperationalPeer, addrs []*net.UDPAddr, _ *commissioning.NOCChain) (commissioning.Session, error) {
+	session, err := e.Establish(ctx, peer, addrs)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Establish is EstablishCASE returning the transport session.
+func (e *CASEEstablisher) Establish(ctx context.Context, peer commissioning.OperationalPeer, addrs []*net.UDPAddr) (*transport.Session, error) {
+	if peer.FabricID != e.fabric.FabricID ||
+		(len(peer.RootPublicKey) != 0 && !bytes.Equal(peer.RootPublicKey, e.fabric.RCAC.PublicKey)) {
+		return nil, fmt.Errorf("node %016X is not on fabric %016X", peer.NodeID, e.fabric.FabricID)
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("%w: no operational address for node %016X", ErrNotFound, peer.NodeID)
+	}
+	var errs []error
+	for _, addr := range addrs {
+		session, err := e.establishWith(ctx, addr, peer.NodeID)
+		if err == nil {
+			log.Infof("CASE session established with node %016X at %s", peer.NodeID, addr.String())
+			return session, nil
+		}
+		log.Warnf("Failed to establish CASE with %s: %v", addr.String(), err)
+		errs = append(errs, fmt.Errorf("%s: %w", addr.String(), err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (e *CASEEstablisher) establishWith(ctx context.Context, addr *net.UDPAddr, nodeID uint64) (*transport.Session, error) {
+	conn, err := transport.Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	ephemeral, err := ephemeralNodeID()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	session, err := casesession.Establish(ctx, transport.NewUnsecuredSession(conn, ephemeral, e.opts...), e.fabric, nodeID)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return session, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package matter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/casesession"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+func TestCASEEstablisher(t *testing.T) {
+	fabric, err := credentials.NewFabric(rand.Reader, 0xFAB000000000001D, 0x1B669, 0xFFF1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noc, err := fabric.IssueNOC(rand.Reader, 0x1234, &key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &credentials.Fabric{FabricID: fabric.FabricID, RCAC: fabric.RCAC, NodeID: 0x1234, NOC: noc, Key: key, EpochKey: fabric.EpochKey}
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	opt := transport.WithRetransmitInterval(50 * time.Millisecond)
+	go func() {
+		responder := &casesession.Responder{Fabric: device}
+		_, _ = responder.Accept(ctx, transport.NewUnsecuredSession(transport.NewConn(pc, nil), 0, opt))
+	}()
+
+	establisher := NewCASEEstablisher(fabric, opt)
+	addrs := []*net.UDPAddr{pc.LocalAddr().(*net.UDPAddr)}
+	tests := []struct {
+		name    string
+		peer    commissioning.OperationalPeer
+		wantErr bool
+	}{
+		{"other fabric", commissioning.OperationalPeer{FabricID: 1, NodeID: 0x1234}, true},
+		{"device", commissioning.OperationalPeer{FabricID: fabric.FabricID, NodeID: 0x1234, RootPublicKey: fabric.RCAC.PublicKey}, false},
+	}
+	for _, tt := range tests {
+		session, err := establisher.EstablishCASE(ctx, tt.peer, addrs, nil)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: EstablishCASE() = %v, want error %t", tt.name, err, tt.wantErr)
+		}
+		if session != nil {
+			session.Close()
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casesession
+
+import (
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// Sizes of the Sigma message fields for the P-256 / SHA-256 suite.
+const (
+	randomLength        = 32
+	destinationIDLength = 32
+	resumptionIDLength  = 16
+	micLength           = 16
+)
+
+// SessionParameters are the MRP parameters a node advertises during session
+// establishment. Intervals are in milliseconds.
+type SessionParameters struct {
+	SessionIdleInterval    *uint32 `tlv:"1,omitempty"`
+	SessionActiveInterval  *uint32 `tlv:"2,omitempty"`
+	SessionActiveThreshold *uint16 `tlv:"4,omitempty"`
+}
+
+// Sigma1 represents the first message of the CASE handshake.
+// Reference: Matter Core Spec 1.5, Section 4.14.2.3 (Sigma1)
+type Sigma1 struct {
+	InitiatorRandom        []byte             `tlv:"1"`
+	InitiatorSessionID     uint16             `tlv:"2"`
+	DestinationID          []byte             `tlv:"3"`
+	InitiatorEphPubKey     []byte             `tlv:"4"`
+	InitiatorSessionParams *SessionParameters `tlv:"5,omitempty"`
+	ResumptionID           []byte             `tlv:"6,omitempty"`
+	InitiatorResumeMIC     []byte             `tlv:"7,omitempty"`
+}
+
+// Encode returns the TLV encoding of the Sigma1 message.
+func (m *Sigma1) Encode() ([]byte, error) {
+	return tlv.Marshal(m)
+}
+
+// DecodeSigma1 parses a Sigma1 payload.
+func DecodeSigma1(b []byte) (*Sigma1, error) {
+	m := &Sigma1{}
+	if err := decode(b, m); err != nil {
+		return nil, err
+	}
+	if len(m.InitiatorRandom) != randomLength || len(m.DestinationID) != destinationIDLength ||
+		len(m.InitiatorEphPubKey) != credentials.PublicKeySize {
+		return nil, fmt.Errorf("%w: Sigma1 field sizes", ErrInvalidMessage)
+	}
+	return m, nil
+}
+
+// Sigma2 represents the second message of the CASE handshake.
+// Reference: Matter Core Spec 1.5, Section 4.14.2.4 (Sigma2)
+type Sigma2 struct {
+	ResponderRandom        []byte             `tlv:"1"`
+	ResponderSessionID     uint16             `tlv:"2"`
+	ResponderEphPubKey     []byte             `tlv:"3"`
+	Encrypted2             []byte             `tlv:"4"`
+	ResponderSessionParams *SessionParameters `tlv:"5,omitempty"`
+}
+
+// Encode returns the TLV encoding of the Sigma2 message.
+func (m *Sigma2) Encode() ([]byte, error) {
+	return tlv.Marshal(m)
+}
+
+// DecodeSigma2 parses a Sigma2 payload.
+func DecodeSigma2(b []byte) (*Sigma2, error) {
+	m := &Sigma2{}
+	if err := decode(b, m); err != nil {
+		return nil, err
+	}
+	if len(m.ResponderRandom) != randomLength || len(m.ResponderEphPubKey) != credentials.PublicKeySize ||
+		len(m.Encrypted2) <= micLength {
+		return nil, fmt.Errorf("%w: Sigma2 field sizes", ErrInvalidMessage)
+	}
+	return m, nil
+}
+
+// Sigma3 represents the third message of the CASE handshake.
+// Reference: Matter Core Spec 1.5, Section 4.14.2.5 (Sigma3)
+type Sigma3 struct {
+	Encrypted3 []byte `tlv:"1"`
+}
+
+// Encode returns the TLV encoding of the Sigma3 message.
+func (m *Sigma3) Encode() ([]byte, error) {
+	return tlv.Marshal(m)
+}
+
+// DecodeSigma3 parses a Sigma3 payload.
+func DecodeSigma3(b []byte) (*Sigma3, error) {
+	m := &Sigma3{}
+	if err := decode(b, m); err != nil {
+		return nil, err
+	}
+	if len(m.Encrypted3) <= micLength {
+		return nil, fmt.Errorf("%w: Sigma3 of %d bytes", ErrInvalidMessage, len(m.Encrypted3))
+	}
+	return m, nil
+}
+
+// tbsData is the data a party signs with its operational key: its own
+// certificates followed by its own and the peer's ephemeral public keys
+// (sigma-2-tbsdata and sigma-3-tbsdata).
+type tbsData struct {
+	NOC          []byte `tlv:"1"`
+	ICAC         []byte `tlv:"2,omitempty"`
+	SenderEphKey []byte `tlv:"3"`
+	PeerEphKey   []byte `tlv:"4"`
+}
+
+// tbeData is the encrypted part of Sigma2 and Sigma3: the certificates of
+// the sender and its signature, and the resumption ID in Sigma2
+// (sigma-2-tbedata and sigma-3-tbedata).
+type tbeData struct {
+	NOC          []byte `tlv:"1"`
+	ICAC         []byte `tlv:"2,omitempty"`
+	Signature    []byte `tlv:"3"`
+	ResumptionID []byte `tlv:"4,omitempty"`
+}
+
+func decode(b []byte, v any) error {
+	if err := tlv.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+	}
+	return nil
+}
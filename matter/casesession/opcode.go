@@ -1,10 +1,9 @@
 // Copyright (C) 2025 The go-matter Authors. All rights reserved.
-//
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//    http://www.apache.org/licenses/LICENSE-2.0
+//	http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -12,20 +11,15 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package btp
+package casesession
+
+import (
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
 
-// CRC8 computation (polynomial 0x31, initial 0xFF) for BTP frames.
-func computeCRC8(data []byte) byte {
-	crc := byte(0xFF)
-	for _, b := range data {
-		crc ^= b
-		for range 8 {
-			if crc&0x80 != 0 {
-				crc = byte((uint16(crc) << 1) ^ 0x31)
-			} else {
-				crc <<= 1
-			}
-		}
-	}
-	return crc
-}
+// CASE opcodes of the Secure Channel protocol.
+const (
+	opSigma1 protocol.Opcode = 0x30
+	opSigma2 protocol.Opcode = 0x31
+	opSigma3 protocol.Opcode = 0x32
+)
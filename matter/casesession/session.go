@@ -0,0 +1,466 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package casesession implements Certificate Authenticated Session
+// Establishment (CASE), the Sigma handshake nodes of a fabric open
+// operational sessions with.
+// Reference: Matter Core Spec 1.5, Section 4.14.2
+package casesession
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+	"github.com/YashubuStudio/go-matter-pack/matter/crypto/ccm"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+var (
+	// ErrInvalidMessage is returned when a Sigma message is malformed or
+	// unexpected.
+	ErrInvalidMessage = errors.New("case: invalid message")
+	// ErrAuthentication is returned when the peer's certificates or
+	// signature do not check out against the fabric.
+	ErrAuthentication = errors.New("case: peer authentication failed")
+)
+
+// Key derivation labels and the nonces of the encrypted Sigma payloads.
+const (
+	sigma2Info  = "Sigma2"
+	sigma3Info  = "Sigma3"
+	sessionInfo = "SessionKeys"
+	sigma2Nonce = "NCASE_Sigma2N"
+	sigma3Nonce = "NCASE_Sigma3N"
+	keySize     = 16
+)
+
+// Establish runs the CASE handshake as the initiator over the unsecured
+// session s with the node peerNodeID of fabric, authenticating with the
+// controller's NOC, and returns the resulting secure session, which shares
+// the connection of s.
+func Establish(ctx context.Context, s *transport.Session, fabric *credentials.Fabric, peerNodeID uint64) (*transport.Session, error) {
+	if s.IsSecure() {
+		return nil, errors.New("case: handshake must run over the unsecured session")
+	}
+	ipk, err := fabric.OperationalIPK()
+	if err != nil {
+		return nil, err
+	}
+	noc, icac, err := encodeChain(fabric)
+	if err != nil {
+		return nil, err
+	}
+	eph, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	ephPub := eph.PublicKey().Bytes()
+	localSessionID, err := randomSessionID()
+	if err != nil {
+		return nil, err
+	}
+	initiatorRandom := make([]byte, randomLength)
+	if _, err := rand.Read(initiatorRandom); err != nil {
+		return nil, err
+	}
+	sigma1, err := (&Sigma1{
+		InitiatorRandom:    initiatorRandom,
+		InitiatorSessionID: localSessionID,
+		DestinationID:      destinationID(ipk, initiatorRandom, fabric.RCAC.PublicKey, fabric.FabricID, peerNodeID),
+		InitiatorEphPubKey: ephPub,
+	}).Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	ex := s.NewExchange()
+	defer ex.Close()
+	msg, err := ex.Request(ctx, protocol.SecureChannelProtocol, opSigma1, sigma1)
+	if err := expect(msg, err, opSigma2); err != nil {
+		return nil, fmt.Errorf("Sigma1: %w", err)
+	}
+	sigma2 := msg.Payload
+	res, err := DecodeSigma2(sigma2)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := sharedSecret(eph, res.ResponderEphPubKey)
+	if err != nil {
+		return nil, sendFailure(ctx, ex, err)
+	}
+	s2k, err := hkdf.Key(sha256.New, shared,
+		concat(ipk, res.ResponderRandom, res.ResponderEphPubKey, transcriptHash(sigma1)), sigma2Info, keySize)
+	if err != nil {
+		return nil, err
+	}
+	var tbe2 tbeData
+	if err := open(s2k, sigma2Nonce, res.Encrypted2, &tbe2); err != nil {
+		return nil, sendFailure(ctx, ex, err)
+	}
+	peerNOC, err := verifyPeer(fabric, &tbe2, res.ResponderEphPubKey, ephPub)
+	if err != nil {
+		return nil, sendFailure(ctx, ex, err)
+	}
+	if id, _ := peerNOC.Subject.Value(credentials.DNNodeID); id != peerNodeID {
+		return nil, sendFailure(ctx, ex, fmt.Errorf("%w: responder is node %016X, want %016X", ErrAuthentication, id, peerNodeID))
+	}
+
+	encrypted3, err := seal(fabric, noc, icac, ephPub, res.ResponderEphPubKey, nil, shared,
+		concat(ipk, transcriptHash(sigma1, sigma2)), sigma3Info, sigma3Nonce)
+	if err != nil {
+		return nil, err
+	}
+	sigma3, err := (&Sigma3{Encrypted3: encrypted3}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	msg, err = ex.Request(ctx, protocol.SecureChannelProtocol, opSigma3, sigma3)
+	if err := expect(msg, err, protocol.StatusReportMessage); err != nil {
+		return nil, fmt.Errorf("Sigma3: %w", err)
+	}
+	if err := sessionEstablished(msg.Payload); err != nil {
+		return nil, err
+	}
+
+	keys, err := sessionKeys(shared, ipk, sigma1, sigma2, sigma3)
+	if err != nil {
+		return nil, err
+	}
+	return s.NewSecureSession(transport.SecureSessionParams{
+		LocalSessionID:       localSessionID,
+		PeerSessionID:        res.ResponderSessionID,
+		LocalNodeID:          fabric.NodeID,
+		PeerNodeID:           peerNodeID,
+		EncryptKey:           keys[:keySize],
+		DecryptKey:           keys[keySize : 2*keySize],
+		AttestationChallenge: keys[2*keySize:],
+	})
+}
+
+// Responder answers CASE handshakes as a node of Fabric, for device
+// simulators and tests. Session resumption is not supported.
+type Responder struct {
+	Fabric *credentials.Fabric
+}
+
+// Accept waits for a Sigma1 addressed to the node on the unsecured session
+// s, runs the handshake as the responder and returns the resulting secure
+// session.
+func (r *Responder) Accept(ctx context.Context, s *transport.Session) (*transport.Session, error) {
+	fabric := r.Fabric
+	ipk, err := fabric.OperationalIPK()
+	if err != nil {
+		return nil, err
+	}
+	noc, icac, err := encodeChain(fabric)
+	if err != nil {
+		return nil, err
+	}
+	ex, msg, err := s.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer ex.Close()
+	if err := expect(msg, nil, opSigma1); err != nil {
+		return nil, err
+	}
+	sigma1 := msg.Payload
+	req, err := DecodeSigma1(sigma1)
+	if err != nil {
+		return nil, err
+	}
+	want := destinationID(ipk, req.InitiatorRandom, fabric.RCAC.PublicKey, fabric.FabricID, fabric.NodeID)
+	if !hmac.Equal(req.DestinationID, want) {
+		report := protocol.StatusReport{
+			GeneralCode:  protocol.GeneralCodeFailure,
+			ProtocolID:   protocol.SecureChannelProtocol,
+			ProtocolCode: protocol.NoSharedTrustRoots,
+		}
+		_ = ex.Send(ctx, protocol.SecureChannelProtocol, protocol.StatusReportMessage, report.Bytes())
+		return nil, fmt.Errorf("%w: Sigma1 is not addressed to node %016X", ErrAuthentication, fabric.NodeID)
+	}
+
+	eph, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	ephPub := eph.PublicKey().Bytes()
+	shared, err := sharedSecret(eph, req.InitiatorEphPubKey)
+	if err != nil {
+		return nil, sendFailure(ctx, ex, err)
+	}
+	localSessionID, err := randomSessionID()
+	if err != nil {
+		return nil, err
+	}
+	responderRandom := make([]byte, randomLength)
+	if _, err := rand.Read(responderRandom); err != nil {
+		return nil, err
+	}
+	resumptionID := make([]byte, resumptionIDLength)
+	if _, err := rand.Read(resumptionID); err != nil {
+		return nil, err
+	}
+	encrypted2, err := seal(fabric, noc, icac, ephPub, req.InitiatorEphPubKey, resumptionID, shared,
+		concat(ipk, responderRandom, ephPub, transcriptHash(sigma1)), sigma2Info, sigma2Nonce)
+	if err != nil {
+		return nil, err
+	}
+	sigma2, err := (&Sigma2{
+		ResponderRandom:    responderRandom,
+		ResponderSessionID: localSessionID,
+		ResponderEphPubKey: ephPub,
+		Encrypted2:         encrypted2,
+	}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	msg, err = ex.Request(ctx, protocol.SecureChannelProtocol, opSigma2, sigma2)
+	if err := expect(msg, err, opSigma3); err != nil {
+		return nil, fmt.Errorf("Sigma2: %w", err)
+	}
+	sigma3 := msg.Payload
+	res, err := DecodeSigma3(sigma3)
+	if err != nil {
+		return nil, err
+	}
+	s3k, err := hkdf.Key(sha256.New, shared, concat(ipk, transcriptHash(sigma1, sigma2)), sigma3Info, keySize)
+	if err != nil {
+		return nil, err
+	}
+	var tbe3 tbeData
+	if err := open(s3k, sigma3Nonce, res.Encrypted3, &tbe3); err != nil {
+		return nil, sendFailure(ctx, ex, err)
+	}
+	peerNOC, err := verifyPeer(fabric, &tbe3, req.InitiatorEphPubKey, ephPub)
+	if err != nil {
+		return nil, sendFailure(ctx, ex, err)
+	}
+	success := protocol.StatusReport{
+		GeneralCode:  protocol.GeneralCodeSuccess,
+		ProtocolID:   protocol.SecureChannelProtocol,
+		ProtocolCode: protocol.SessionEstablishmentSuccess,
+	}
+	if err := ex.Send(ctx, protocol.SecureChannelProtocol, protocol.StatusReportMessage, success.Bytes()); err != nil {
+		return nil, err
+	}
+
+	keys, err := sessionKeys(shared, ipk, sigma1, sigma2, sigma3)
+	if err != nil {
+		return nil, err
+	}
+	peerNodeID, _ := peerNOC.Subject.Value(credentials.DNNodeID)
+	return s.NewSecureSession(transport.SecureSessionParams{
+		LocalSessionID:       localSessionID,
+		PeerSessionID:        req.InitiatorSessionID,
+		LocalNodeID:          fabric.NodeID,
+		PeerNodeID:           peerNodeID,
+		EncryptKey:           keys[keySize : 2*keySize],
+		DecryptKey:           keys[:keySize],
+		AttestationChallenge: keys[2*keySize:],
+	})
+}
+
+// destinationID identifies the fabric and node a Sigma1 is addressed to
+// without revealing them.
+// Reference: Matter Core Spec 1.5, Section 4.14.2.4 (Destination Identifier)
+func destinationID(ipk, initiatorRandom, rootPublicKey []byte, fabricID, nodeID uint64) []byte {
+	mac := hmac.New(sha256.New, ipk)
+	mac.Write(initiatorRandom)
+	mac.Write(rootPublicKey)
+	mac.Write(binary.LittleEndian.AppendUint64(nil, fabricID))
+	mac.Write(binary.LittleEndian.AppendUint64(nil, nodeID))
+	return mac.Sum(nil)
+}
+
+// encodeChain returns the TLV encoded NOC and optional ICAC of fabric.
+func encodeChain(fabric *credentials.Fabric) (noc, icac []byte, err error) {
+	if fabric.NOC == nil || fabric.Key == nil {
+		return nil, nil, errors.New("case: fabric has no operational certificate")
+	}
+	if noc, err = fabric.NOC.Encode(); err != nil {
+		return nil, nil, err
+	}
+	if fabric.ICAC != nil {
+		if icac, err = fabric.ICAC.Encode(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return noc, icac, nil
+}
+
+// seal signs the TBS data of the sender and returns its TBE data encrypted
+// with the key derived from shared with salt and info.
+func seal(fabric *credentials.Fabric, noc, icac, senderEph, peerEph, resumptionID, shared, salt []byte, info, nonce string) ([]byte, error) {
+	tbs, err := tlv.Marshal(&tbsData{NOC: noc, ICAC: icac, SenderEphKey: senderEph, PeerEphKey: peerEph})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := credentials.Sign(rand.Reader, fabric.Key, tbs)
+	if err != nil {
+		return nil, err
+	}
+	tbe, err := tlv.Marshal(&tbeData{NOC: noc, ICAC: icac, Signature: sig, ResumptionID: resumptionID})
+	if err != nil {
+		return nil, err
+	}
+	key, err := hkdf.Key(sha256.New, shared, salt, info, keySize)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nil, []byte(nonce), tbe, nil), nil
+}
+
+// open decrypts an encrypted Sigma payload into v.
+func open(key []byte, nonce string, encrypted []byte, v *tbeData) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	tbe, err := aead.Open(nil, []byte(nonce), encrypted, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAuthentication, err)
+	}
+	return decode(tbe, v)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return ccm.New(block, micLength, len(sigma2Nonce))
+}
+
+// verifyPeer checks that the peer's NOC chains to the root of fabric and
+// signed the TBS data over the ephemeral keys, and returns the NOC.
+func verifyPeer(fabric *credentials.Fabric, tbe *tbeData, peerEph, localEph []byte) (*credentials.Certificate, error) {
+	noc, err := credentials.VerifyNOC(fabric.RCAC, tbe.NOC, tbe.ICAC, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAuthentication, err)
+	}
+	if id, _ := noc.Subject.Value(credentials.DNFabricID); id != fabric.FabricID {
+		return nil, fmt.Errorf("%w: peer NOC of fabric %016X", ErrAuthentication, id)
+	}
+	tbs, err := tlv.Marshal(&tbsData{NOC: tbe.NOC, ICAC: tbe.ICAC, SenderEphKey: peerEph, PeerEphKey: localEph})
+	if err != nil {
+		return nil, err
+	}
+	if err := credentials.Verify(noc.PublicKey, tbs, tbe.Signature); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAuthentication, err)
+	}
+	return noc, nil
+}
+
+// sharedSecret returns the ECDH shared secret with the peer's ephemeral key.
+func sharedSecret(eph *ecdh.PrivateKey, peer []byte) ([]byte, error) {
+	pub, err := ecdh.P256().NewPublicKey(peer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ephemeral key: %w", ErrInvalidMessage, err)
+	}
+	return eph.ECDH(pub)
+}
+
+// sessionKeys derives I2RKey || R2IKey || AttestationChallenge from the
+// shared secret and the transcript of the handshake.
+func sessionKeys(shared, ipk []byte, messages ...[]byte) ([]byte, error) {
+	return hkdf.Key(sha256.New, shared, concat(ipk, transcriptHash(messages...)), sessionInfo, 3*keySize)
+}
+
+// transcriptHash returns the SHA-256 hash of the messages exchanged so far.
+func transcriptHash(messages ...[]byte) []byte {
+	h := sha256.New()
+	for _, m := range messages {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+// expect checks that msg, received with err, has the given Secure Channel
+// opcode. A failure StatusReport from the peer is returned as the error.
+func expect(msg *transport.Message, err error, opcode protocol.Opcode) error {
+	if err != nil {
+		return err
+	}
+	if msg.Is(protocol.SecureChannelProtocol, opcode) {
+		return nil
+	}
+	if msg.Is(protocol.SecureChannelProtocol, protocol.StatusReportMessage) {
+		if report, err := protocol.DecodeStatusReport(msg.Payload); err == nil {
+			return fmt.Errorf("case: peer aborted the handshake: %w", report)
+		}
+	}
+	return fmt.Errorf("%w: unexpected protocol 0x%04X opcode 0x%02X", ErrInvalidMessage,
+		uint16(msg.Protocol.ProtocolID), uint8(msg.Protocol.Opcode))
+}
+
+// sessionEstablished checks the StatusReport concluding the handshake.
+func sessionEstablished(payload []byte) error {
+	report, err := protocol.DecodeStatusReport(payload)
+	if err != nil {
+		return err
+	}
+	if !report.IsSuccess() || report.ProtocolID != protocol.SecureChannelProtocol ||
+		report.ProtocolCode != protocol.SessionEstablishmentSuccess {
+		return fmt.Errorf("case: handshake failed: %w", report)
+	}
+	return nil
+}
+
+// sendFailure tells the peer the handshake failed with an InvalidParameter
+// StatusReport and returns err.
+func sendFailure(ctx context.Context, ex *transport.Exchange, err error) error {
+	report := protocol.StatusReport{
+		GeneralCode:  protocol.GeneralCodeFailure,
+		ProtocolID:   protocol.SecureChannelProtocol,
+		ProtocolCode: protocol.InvalidParameter,
+	}
+	_ = ex.Send(ctx, protocol.SecureChannelProtocol, protocol.StatusReportMessage, report.Bytes())
+	return err
+}
+
+// randomSessionID returns a random non-zero session ID.
+func randomSessionID() (uint16, error) {
+	var b [2]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, err
+		}
+		if id := binary.LittleEndian.Uint16(b[:]); id != 0 {
+			return id, nil
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casesession
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+const (
+	testFabricID     uint64 = 0xFAB000000000001D
+	testControllerID uint64 = 0x1B669
+	testDeviceID     uint64 = 0x1234
+)
+
+// newTestFabrics returns the controller credentials of a new fabric and
+// the credentials of a device commissioned into it.
+func newTestFabrics(t *testing.T) (*credentials.Fabric, *credentials.Fabric) {
+	t.Helper()
+	controller, err := credentials.NewFabric(rand.Reader, testFabricID, testControllerID, 0xFFF1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noc, err := controller.IssueNOC(rand.Reader, testDeviceID, &key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := &credentials.Fabric{
+		FabricID: testFabricID,
+		RCAC:     controller.RCAC,
+		NodeID:   testDeviceID,
+		NOC:      noc,
+		Key:      key,
+		EpochKey: controller.EpochKey,
+	}
+	return controller, device
+}
+
+// handshake runs Establish against a Responder over loopback UDP.
+func handshake(t *testing.T, controller, device *credentials.Fabric, peerNodeID uint64) (*transport.Session, *transport.Session, error, error) {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pc.Close() })
+	conn, err := transport.Dial(pc.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	opt := transport.WithRetransmitInterval(50 * time.Millisecond)
+	responder := &Responder{Fabric: device}
+	type result struct {
+		s   *transport.Session
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		s, err := responder.Accept(ctx, transport.NewUnsecuredSession(transport.NewConn(pc, nil), 0, opt))
+		done <- result{s, err}
+	}()
+	initiator, initiatorErr := Establish(ctx, transport.NewUnsecuredSession(conn, 0x1122334455667788, opt), controller, peerNodeID)
+	r := <-done
+	return initiator, r.s, initiatorErr, r.err
+}
+
+func TestEstablish(t *testing.T) {
+	controller, device := newTestFabrics(t)
+	initiator, responder, err, rerr := handshake(t, controller, device, testDeviceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	if !bytes.Equal(initiator.AttestationChallenge(), responder.AttestationChallenge()) {
+		t.Error("attestation challenges differ")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go func() {
+		ex, msg, err := responder.Accept(ctx)
+		if err != nil {
+			return
+		}
+		defer ex.Close()
+		_ = ex.Send(ctx, msg.Protocol.ProtocolID, msg.Protocol.Opcode+1, append([]byte("re:"), msg.Payload...))
+	}()
+	ex := initiator.NewExchange()
+	defer ex.Close()
+	msg, err := ex.Request(ctx, protocol.InteractionModelProtocol, 0x08, []byte("ping"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !msg.Is(protocol.InteractionModelProtocol, 0x09) || string(msg.Payload) != "re:ping" {
+		t.Errorf("unexpected reply %+v %q", msg.Protocol, msg.Payload)
+	}
+}
+
+func TestEstablishRejects(t *testing.T) {
+	controller, device := newTestFabrics(t)
+	other, _ := newTestFabrics(t)
+	// A device whose NOC names another node than the one dialed.
+	impostor := *device
+	impostor.NodeID = testDeviceID + 1
+
+	tests := []struct {
+		name       string
+		controller *credentials.Fabric
+		device     *credentials.Fabric
+		peerNodeID uint64
+	}{
+		{"other node", controller, device, testDeviceID + 1},
+		{"other fabric", other, device, testDeviceID},
+		{"NOC of another node", controller, &impostor, testDeviceID + 1},
+	}
+	for _, tt := range tests {
+		_, _, err, rerr := handshake(t, tt.controller, tt.device, tt.peerNodeID)
+		if err == nil || rerr == nil {
+			t.Errorf("%s: handshake succeeded: initiator %v, responder %v", tt.name, err, rerr)
+		}
+		if !errors.Is(rerr, ErrAuthentication) && !errors.Is(err, ErrAuthentication) {
+			t.Errorf("%s: errors %v, %v, want %v", tt.name, err, rerr, ErrAuthentication)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clusters provides identifiers and TLV payload types for Matter
+// application clusters. Everything except this file is generated from the
+// connectedhomeip data model XML files in gen/testdata; add a file there and
+// run go generate to cover another cluster.
+package clusters
+
+//go:generate go run ./gen -out . ./gen/testdata
+
+// Global attribute IDs present on every cluster.
+// Reference: Matter Core Spec 1.5, Section 7.13 (Global Elements)
+const (
+	AttrGeneratedCommandList uint32 = 0xFFF8
+	AttrAcceptedCommandList  uint32 = 0xFFF9
+	AttrAttributeList        uint32 = 0xFFFB
+	AttrFeatureMap           uint32 = 0xFFFC
+	AttrClusterRevision      uint32 = 0xFFFD
+)
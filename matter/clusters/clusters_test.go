@@ -0,0 +1,68 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusters
+
+import (
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+func TestAttributesDecode(t *testing.T) {
+	var attrs DescriptorAttributes
+	parts, _ := im.MarshalValue([]uint16{1, 2})
+	if err := attrs.Decode(DescriptorAttrPartsList, parts); err != nil {
+		t.Fatalf("Decode(PartsList): %v", err)
+	}
+	if len(attrs.PartsList) != 2 || attrs.PartsList[1] != 2 {
+		t.Errorf("PartsList = %v", attrs.PartsList)
+	}
+
+	var lock DoorLockAttributes
+	state, _ := im.MarshalValue(uint8(DoorLockDlLockStateLocked))
+	if err := lock.Decode(DoorLockAttrLockState, state); err != nil {
+		t.Fatalf("Decode(LockState): %v", err)
+	}
+	if lock.LockState == nil || *lock.LockState != DoorLockDlLockStateLocked || lock.LockState.String() != "Locked" {
+		t.Errorf("LockState = %v", lock.LockState)
+	}
+	if err := lock.Decode(0x7FFF, state); err != nil {
+		t.Errorf("unknown attribute: %v", err)
+	}
+}
+
+func TestEventPayloadRoundTrip(t *testing.T) {
+	user := uint16(3)
+	in := DoorLockLockOperationEvent{
+		LockOperationType: DoorLockLockOperationTypeEnumUnlock,
+		OperationSource:   DoorLockOperationSourceEnumKeypad,
+		UserIndex:         &user,
+	}
+	b, err := tlv.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var out DoorLockLockOperationEvent
+	if err := tlv.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out.LockOperationType != in.LockOperationType || out.UserIndex == nil || *out.UserIndex != 3 || out.FabricIndex != nil {
+		t.Errorf("out = %+v", out)
+	}
+	if (DoorLockLockOperationEvent{}).EventID() != DoorLockEventLockOperation {
+		t.Errorf("EventID mismatch")
+	}
+}
@@ -0,0 +1,523 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by go run ./gen from door-lock-cluster.xml; DO NOT EDIT.
+
+package clusters
+
+import (
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+// DoorLockClusterID identifies the Door Lock cluster.
+//
+// An interface to a generic way to secure a door
+const DoorLockClusterID uint32 = 0x0101
+
+// DoorLockClusterRevision is the Door Lock cluster revision described by the data model.
+const DoorLockClusterRevision uint16 = 7
+
+// Door Lock attribute IDs.
+const (
+	DoorLockAttrLockState       uint32 = 0x0000
+	DoorLockAttrLockType        uint32 = 0x0001
+	DoorLockAttrActuatorEnabled uint32 = 0x0002
+	DoorLockAttrDoorState       uint32 = 0x0003
+	DoorLockAttrAutoRelockTime  uint32 = 0x0023
+	DoorLockAttrOperatingMode   uint32 = 0x0025
+)
+
+// Door Lock command IDs.
+const (
+	DoorLockCmdLockDoor          uint32 = 0x00
+	DoorLockCmdUnlockDoor        uint32 = 0x01
+	DoorLockCmdUnlockWithTimeout uint32 = 0x03
+)
+
+// Door Lock event IDs.
+const (
+	DoorLockEventDoorLockAlarm      uint32 = 0x00
+	DoorLockEventDoorStateChange    uint32 = 0x01
+	DoorLockEventLockOperation      uint32 = 0x02
+	DoorLockEventLockOperationError uint32 = 0x03
+)
+
+// DoorLockDlLockState is the Door Lock DlLockState enumeration.
+type DoorLockDlLockState uint8
+
+// DoorLockDlLockState values.
+const (
+	DoorLockDlLockStateNotFullyLocked DoorLockDlLockState = 0x00
+	DoorLockDlLockStateLocked         DoorLockDlLockState = 0x01
+	DoorLockDlLockStateUnlocked       DoorLockDlLockState = 0x02
+	DoorLockDlLockStateUnlatched      DoorLockDlLockState = 0x03
+)
+
+// String returns the data model name of the value.
+func (v DoorLockDlLockState) String() string {
+	switch v {
+	case DoorLockDlLockStateNotFullyLocked:
+		return "NotFullyLocked"
+	case DoorLockDlLockStateLocked:
+		return "Locked"
+	case DoorLockDlLockStateUnlocked:
+		return "Unlocked"
+	case DoorLockDlLockStateUnlatched:
+		return "Unlatched"
+	}
+	return fmt.Sprintf("DoorLockDlLockState(%d)", uint8(v))
+}
+
+// DoorLockDlLockType is the Door Lock DlLockType enumeration.
+type DoorLockDlLockType uint8
+
+// DoorLockDlLockType values.
+const (
+	DoorLockDlLockTypeDeadBolt           DoorLockDlLockType = 0x00
+	DoorLockDlLockTypeMagnetic           DoorLockDlLockType = 0x01
+	DoorLockDlLockTypeOther              DoorLockDlLockType = 0x02
+	DoorLockDlLockTypeMortise            DoorLockDlLockType = 0x03
+	DoorLockDlLockTypeRim                DoorLockDlLockType = 0x04
+	DoorLockDlLockTypeLatchBolt          DoorLockDlLockType = 0x05
+	DoorLockDlLockTypeCylindricalLock    DoorLockDlLockType = 0x06
+	DoorLockDlLockTypeTubularLock        DoorLockDlLockType = 0x07
+	DoorLockDlLockTypeInterconnectedLock DoorLockDlLockType = 0x08
+	DoorLockDlLockTypeDeadLatch          DoorLockDlLockType = 0x09
+	DoorLockDlLockTypeDoorFurniture      DoorLockDlLockType = 0x0A
+	DoorLockDlLockTypeEurocylinder       DoorLockDlLockType = 0x0B
+)
+
+// String returns the data model name of the value.
+func (v DoorLockDlLockType) String() string {
+	switch v {
+	case DoorLockDlLockTypeDeadBolt:
+		return "DeadBolt"
+	case DoorLockDlLockTypeMagnetic:
+		return "Magnetic"
+	case DoorLockDlLockTypeOther:
+		return "Other"
+	case DoorLockDlLockTypeMortise:
+		return "Mortise"
+	case DoorLockDlLockTypeRim:
+		return "Rim"
+	case DoorLockDlLockTypeLatchBolt:
+		return "LatchBolt"
+	case DoorLockDlLockTypeCylindricalLock:
+		return "CylindricalLock"
+	case DoorLockDlLockTypeTubularLock:
+		return "TubularLock"
+	case DoorLockDlLockTypeInterconnectedLock:
+		return "InterconnectedLock"
+	case DoorLockDlLockTypeDeadLatch:
+		return "DeadLatch"
+	case DoorLockDlLockTypeDoorFurniture:
+		return "DoorFurniture"
+	case DoorLockDlLockTypeEurocylinder:
+		return "Eurocylinder"
+	}
+	return fmt.Sprintf("DoorLockDlLockType(%d)", uint8(v))
+}
+
+// DoorLockDoorStateEnum is the Door Lock DoorStateEnum enumeration.
+type DoorLockDoorStateEnum uint8
+
+// DoorLockDoorStateEnum values.
+const (
+	DoorLockDoorStateEnumDoorOpen             DoorLockDoorStateEnum = 0x00
+	DoorLockDoorStateEnumDoorClosed           DoorLockDoorStateEnum = 0x01
+	DoorLockDoorStateEnumDoorJammed           DoorLockDoorStateEnum = 0x02
+	DoorLockDoorStateEnumDoorForcedOpen       DoorLockDoorStateEnum = 0x03
+	DoorLockDoorStateEnumDoorUnspecifiedError DoorLockDoorStateEnum = 0x04
+	DoorLockDoorStateEnumDoorAjar             DoorLockDoorStateEnum = 0x05
+)
+
+// String returns the data model name of the value.
+func (v DoorLockDoorStateEnum) String() string {
+	switch v {
+	case DoorLockDoorStateEnumDoorOpen:
+		return "DoorOpen"
+	case DoorLockDoorStateEnumDoorClosed:
+		return "DoorClosed"
+	case DoorLockDoorStateEnumDoorJammed:
+		return "DoorJammed"
+	case DoorLockDoorStateEnumDoorForcedOpen:
+		return "DoorForcedOpen"
+	case DoorLockDoorStateEnumDoorUnspecifiedError:
+		return "DoorUnspecifiedError"
+	case DoorLockDoorStateEnumDoorAjar:
+		return "DoorAjar"
+	}
+	return fmt.Sprintf("DoorLockDoorStateEnum(%d)", uint8(v))
+}
+
+// DoorLockOperatingModeEnum is the Door Lock OperatingModeEnum enumeration.
+type DoorLockOperatingModeEnum uint8
+
+// DoorLockOperatingModeEnum values.
+const (
+	DoorLockOperatingModeEnumNormal             DoorLockOperatingModeEnum = 0x00
+	DoorLockOperatingModeEnumVacation           DoorLockOperatingModeEnum = 0x01
+	DoorLockOperatingModeEnumPrivacy            DoorLockOperatingModeEnum = 0x02
+	DoorLockOperatingModeEnumNoRemoteLockUnlock DoorLockOperatingModeEnum = 0x03
+	DoorLockOperatingModeEnumPassage            DoorLockOperatingModeEnum = 0x04
+)
+
+// String returns the data model name of the value.
+func (v DoorLockOperatingModeEnum) String() string {
+	switch v {
+	case DoorLockOperatingModeEnumNormal:
+		return "Normal"
+	case DoorLockOperatingModeEnumVacation:
+		return "Vacation"
+	case DoorLockOperatingModeEnumPrivacy:
+		return "Privacy"
+	case DoorLockOperatingModeEnumNoRemoteLockUnlock:
+		return "NoRemoteLockUnlock"
+	case DoorLockOperatingModeEnumPassage:
+		return "Passage"
+	}
+	return fmt.Sprintf("DoorLockOperatingModeEnum(%d)", uint8(v))
+}
+
+// DoorLockAlarmCodeEnum is the Door Lock AlarmCodeEnum enumeration.
+type DoorLockAlarmCodeEnum uint8
+
+// DoorLockAlarmCodeEnum values.
+const (
+	DoorLockAlarmCodeEnumLockJammed              DoorLockAlarmCodeEnum = 0x00
+	DoorLockAlarmCodeEnumLockFactoryReset        DoorLockAlarmCodeEnum = 0x01
+	DoorLockAlarmCodeEnumLockRadioPowerCycled    DoorLockAlarmCodeEnum = 0x03
+	DoorLockAlarmCodeEnumWrongCodeEntryLimit     DoorLockAlarmCodeEnum = 0x04
+	DoorLockAlarmCodeEnumFrontEsceutcheonRemoved DoorLockAlarmCodeEnum = 0x05
+	DoorLockAlarmCodeEnumDoorForcedOpen          DoorLockAlarmCodeEnum = 0x06
+	DoorLockAlarmCodeEnumDoorAjar                DoorLockAlarmCodeEnum = 0x07
+	DoorLockAlarmCodeEnumForcedUser              DoorLockAlarmCodeEnum = 0x08
+)
+
+// String returns the data model name of the value.
+func (v DoorLockAlarmCodeEnum) String() string {
+	switch v {
+	case DoorLockAlarmCodeEnumLockJammed:
+		return "LockJammed"
+	case DoorLockAlarmCodeEnumLockFactoryReset:
+		return "LockFactoryReset"
+	case DoorLockAlarmCodeEnumLockRadioPowerCycled:
+		return "LockRadioPowerCycled"
+	case DoorLockAlarmCodeEnumWrongCodeEntryLimit:
+		return "WrongCodeEntryLimit"
+	case DoorLockAlarmCodeEnumFrontEsceutcheonRemoved:
+		return "FrontEsceutcheonRemoved"
+	case DoorLockAlarmCodeEnumDoorForcedOpen:
+		return "DoorForcedOpen"
+	case DoorLockAlarmCodeEnumDoorAjar:
+		return "DoorAjar"
+	case DoorLockAlarmCodeEnumForcedUser:
+		return "ForcedUser"
+	}
+	return fmt.Sprintf("DoorLockAlarmCodeEnum(%d)", uint8(v))
+}
+
+// DoorLockLockOperationTypeEnum is the Door Lock LockOperationTypeEnum enumeration.
+type DoorLockLockOperationTypeEnum uint8
+
+// DoorLockLockOperationTypeEnum values.
+const (
+	DoorLockLockOperationTypeEnumLock               DoorLockLockOperationTypeEnum = 0x00
+	DoorLockLockOperationTypeEnumUnlock             DoorLockLockOperationTypeEnum = 0x01
+	DoorLockLockOperationTypeEnumNonAccessUserEvent DoorLockLockOperationTypeEnum = 0x02
+	DoorLockLockOperationTypeEnumForcedUserEvent    DoorLockLockOperationTypeEnum = 0x03
+	DoorLockLockOperationTypeEnumUnlatch            DoorLockLockOperationTypeEnum = 0x04
+)
+
+// String returns the data model name of the value.
+func (v DoorLockLockOperationTypeEnum) String() string {
+	switch v {
+	case DoorLockLockOperationTypeEnumLock:
+		return "Lock"
+	case DoorLockLockOperationTypeEnumUnlock:
+		return "Unlock"
+	case DoorLockLockOperationTypeEnumNonAccessUserEvent:
+		return "NonAccessUserEvent"
+	case DoorLockLockOperationTypeEnumForcedUserEvent:
+		return "ForcedUserEvent"
+	case DoorLockLockOperationTypeEnumUnlatch:
+		return "Unlatch"
+	}
+	return fmt.Sprintf("DoorLockLockOperationTypeEnum(%d)", uint8(v))
+}
+
+// DoorLockOperationSourceEnum is the Door Lock OperationSourceEnum enumeration.
+type DoorLockOperationSourceEnum uint8
+
+// DoorLockOperationSourceEnum values.
+const (
+	DoorLockOperationSourceEnumUnspecified       DoorLockOperationSourceEnum = 0x00
+	DoorLockOperationSourceEnumManual            DoorLockOperationSourceEnum = 0x01
+	DoorLockOperationSourceEnumProprietaryRemote DoorLockOperationSourceEnum = 0x02
+	DoorLockOperationSourceEnumKeypad            DoorLockOperationSourceEnum = 0x03
+	DoorLockOperationSourceEnumAuto              DoorLockOperationSourceEnum = 0x04
+	DoorLockOperationSourceEnumButton            DoorLockOperationSourceEnum = 0x05
+	DoorLockOperationSourceEnumSchedule          DoorLockOperationSourceEnum = 0x06
+	DoorLockOperationSourceEnumRemote            DoorLockOperationSourceEnum = 0x07
+	DoorLockOperationSourceEnumRFID              DoorLockOperationSourceEnum = 0x08
+	DoorLockOperationSourceEnumBiometric         DoorLockOperationSourceEnum = 0x09
+	DoorLockOperationSourceEnumAliro             DoorLockOperationSourceEnum = 0x0A
+)
+
+// String returns the data model name of the value.
+func (v DoorLockOperationSourceEnum) String() string {
+	switch v {
+	case DoorLockOperationSourceEnumUnspecified:
+		return "Unspecified"
+	case DoorLockOperationSourceEnumManual:
+		return "Manual"
+	case DoorLockOperationSourceEnumProprietaryRemote:
+		return "ProprietaryRemote"
+	case DoorLockOperationSourceEnumKeypad:
+		return "Keypad"
+	case DoorLockOperationSourceEnumAuto:
+		return "Auto"
+	case DoorLockOperationSourceEnumButton:
+		return "Button"
+	case DoorLockOperationSourceEnumSchedule:
+		return "Schedule"
+	case DoorLockOperationSourceEnumRemote:
+		return "Remote"
+	case DoorLockOperationSourceEnumRFID:
+		return "RFID"
+	case DoorLockOperationSourceEnumBiometric:
+		return "Biometric"
+	case DoorLockOperationSourceEnumAliro:
+		return "Aliro"
+	}
+	return fmt.Sprintf("DoorLockOperationSourceEnum(%d)", uint8(v))
+}
+
+// DoorLockOperationErrorEnum is the Door Lock OperationErrorEnum enumeration.
+type DoorLockOperationErrorEnum uint8
+
+// DoorLockOperationErrorEnum values.
+const (
+	DoorLockOperationErrorEnumUnspecified         DoorLockOperationErrorEnum = 0x00
+	DoorLockOperationErrorEnumInvalidCredential   DoorLockOperationErrorEnum = 0x01
+	DoorLockOperationErrorEnumDisabledUserDenied  DoorLockOperationErrorEnum = 0x02
+	DoorLockOperationErrorEnumRestricted          DoorLockOperationErrorEnum = 0x03
+	DoorLockOperationErrorEnumInsufficientBattery DoorLockOperationErrorEnum = 0x04
+)
+
+// String returns the data model name of the value.
+func (v DoorLockOperationErrorEnum) String() string {
+	switch v {
+	case DoorLockOperationErrorEnumUnspecified:
+		return "Unspecified"
+	case DoorLockOperationErrorEnumInvalidCredential:
+		return "InvalidCredential"
+	case DoorLockOperationErrorEnumDisabledUserDenied:
+		return "DisabledUserDenied"
+	case DoorLockOperationErrorEnumRestricted:
+		return "Restricted"
+	case DoorLockOperationErrorEnumInsufficientBattery:
+		return "InsufficientBattery"
+	}
+	return fmt.Sprintf("DoorLockOperationErrorEnum(%d)", uint8(v))
+}
+
+// DoorLockCredentialTypeEnum is the Door Lock CredentialTypeEnum enumeration.
+type DoorLockCredentialTypeEnum uint8
+
+// DoorLockCredentialTypeEnum values.
+const (
+	DoorLockCredentialTypeEnumProgrammingPIN               DoorLockCredentialTypeEnum = 0x00
+	DoorLockCredentialTypeEnumPIN                          DoorLockCredentialTypeEnum = 0x01
+	DoorLockCredentialTypeEnumRFID                         DoorLockCredentialTypeEnum = 0x02
+	DoorLockCredentialTypeEnumFingerprint                  DoorLockCredentialTypeEnum = 0x03
+	DoorLockCredentialTypeEnumFingerVein                   DoorLockCredentialTypeEnum = 0x04
+	DoorLockCredentialTypeEnumFace                         DoorLockCredentialTypeEnum = 0x05
+	DoorLockCredentialTypeEnumAliroCredentialIssuerKey     DoorLockCredentialTypeEnum = 0x06
+	DoorLockCredentialTypeEnumAliroEvictableEndpointKey    DoorLockCredentialTypeEnum = 0x07
+	DoorLockCredentialTypeEnumAliroNonEvictableEndpointKey DoorLockCredentialTypeEnum = 0x08
+)
+
+// String returns the data model name of the value.
+func (v DoorLockCredentialTypeEnum) String() string {
+	switch v {
+	case DoorLockCredentialTypeEnumProgrammingPIN:
+		return "ProgrammingPIN"
+	case DoorLockCredentialTypeEnumPIN:
+		return "PIN"
+	case DoorLockCredentialTypeEnumRFID:
+		return "RFID"
+	case DoorLockCredentialTypeEnumFingerprint:
+		return "Fingerprint"
+	case DoorLockCredentialTypeEnumFingerVein:
+		return "FingerVein"
+	case DoorLockCredentialTypeEnumFace:
+		return "Face"
+	case DoorLockCredentialTypeEnumAliroCredentialIssuerKey:
+		return "AliroCredentialIssuerKey"
+	case DoorLockCredentialTypeEnumAliroEvictableEndpointKey:
+		return "AliroEvictableEndpointKey"
+	case DoorLockCredentialTypeEnumAliroNonEvictableEndpointKey:
+		return "AliroNonEvictableEndpointKey"
+	}
+	return fmt.Sprintf("DoorLockCredentialTypeEnum(%d)", uint8(v))
+}
+
+// DoorLockFeature is the Door Lock Feature bitmap.
+type DoorLockFeature uint32
+
+// DoorLockFeature bits.
+const (
+	DoorLockFeaturePINCredential              DoorLockFeature = 0x1
+	DoorLockFeatureRFIDCredential             DoorLockFeature = 0x2
+	DoorLockFeatureFingerCredentials          DoorLockFeature = 0x4
+	DoorLockFeatureWeekDayAccessSchedules     DoorLockFeature = 0x10
+	DoorLockFeatureDoorPositionSensor         DoorLockFeature = 0x20
+	DoorLockFeatureFaceCredentials            DoorLockFeature = 0x40
+	DoorLockFeatureCredentialOverTheAirAccess DoorLockFeature = 0x80
+	DoorLockFeatureUser                       DoorLockFeature = 0x100
+	DoorLockFeatureYearDayAccessSchedules     DoorLockFeature = 0x400
+	DoorLockFeatureHolidaySchedules           DoorLockFeature = 0x800
+	DoorLockFeatureUnbolting                  DoorLockFeature = 0x1000
+)
+
+// Has reports whether all bits of mask are set.
+func (v DoorLockFeature) Has(mask DoorLockFeature) bool { return v&mask == mask }
+
+// DoorLockCredentialStruct is the Door Lock CredentialStruct structure.
+type DoorLockCredentialStruct struct {
+	CredentialType  DoorLockCredentialTypeEnum `tlv:"0"`
+	CredentialIndex uint16                     `tlv:"1"`
+}
+
+// DoorLockAttributes holds Door Lock attribute values. A nil field was not read or
+// holds null.
+type DoorLockAttributes struct {
+	LockState       *DoorLockDlLockState
+	LockType        *DoorLockDlLockType
+	ActuatorEnabled *bool
+	DoorState       *DoorLockDoorStateEnum
+	AutoRelockTime  *uint32
+	OperatingMode   *DoorLockOperatingModeEnum
+}
+
+// Decode stores the value of attribute attrID. Unknown attributes are ignored.
+func (a *DoorLockAttributes) Decode(attrID uint32, v im.Value) error {
+	switch attrID {
+	case DoorLockAttrLockState:
+		return v.Unmarshal(&a.LockState)
+	case DoorLockAttrLockType:
+		return v.Unmarshal(&a.LockType)
+	case DoorLockAttrActuatorEnabled:
+		return v.Unmarshal(&a.ActuatorEnabled)
+	case DoorLockAttrDoorState:
+		return v.Unmarshal(&a.DoorState)
+	case DoorLockAttrAutoRelockTime:
+		return v.Unmarshal(&a.AutoRelockTime)
+	case DoorLockAttrOperatingMode:
+		return v.Unmarshal(&a.OperatingMode)
+	}
+	return nil
+}
+
+// DoorLockLockDoorRequest is the Door Lock LockDoor command payload.
+type DoorLockLockDoorRequest struct {
+	PINCode []byte `tlv:"0,omitempty"`
+}
+
+// ClusterID returns DoorLockClusterID.
+func (DoorLockLockDoorRequest) ClusterID() uint32 { return DoorLockClusterID }
+
+// CommandID returns DoorLockCmdLockDoor.
+func (DoorLockLockDoorRequest) CommandID() uint32 { return DoorLockCmdLockDoor }
+
+// DoorLockUnlockDoorRequest is the Door Lock UnlockDoor command payload.
+type DoorLockUnlockDoorRequest struct {
+	PINCode []byte `tlv:"0,omitempty"`
+}
+
+// ClusterID returns DoorLockClusterID.
+func (DoorLockUnlockDoorRequest) ClusterID() uint32 { return DoorLockClusterID }
+
+// CommandID returns DoorLockCmdUnlockDoor.
+func (DoorLockUnlockDoorRequest) CommandID() uint32 { return DoorLockCmdUnlockDoor }
+
+// DoorLockUnlockWithTimeoutRequest is the Door Lock UnlockWithTimeout command payload.
+type DoorLockUnlockWithTimeoutRequest struct {
+	Timeout uint16 `tlv:"0"`
+	PINCode []byte `tlv:"1,omitempty"`
+}
+
+// ClusterID returns DoorLockClusterID.
+func (DoorLockUnlockWithTimeoutRequest) ClusterID() uint32 { return DoorLockClusterID }
+
+// CommandID returns DoorLockCmdUnlockWithTimeout.
+func (DoorLockUnlockWithTimeoutRequest) CommandID() uint32 { return DoorLockCmdUnlockWithTimeout }
+
+// DoorLockDoorLockAlarmEvent is the payload of the Door Lock DoorLockAlarm event (priority critical).
+type DoorLockDoorLockAlarmEvent struct {
+	AlarmCode DoorLockAlarmCodeEnum `tlv:"0"`
+}
+
+// ClusterID returns DoorLockClusterID.
+func (DoorLockDoorLockAlarmEvent) ClusterID() uint32 { return DoorLockClusterID }
+
+// EventID returns DoorLockEventDoorLockAlarm.
+func (DoorLockDoorLockAlarmEvent) EventID() uint32 { return DoorLockEventDoorLockAlarm }
+
+// DoorLockDoorStateChangeEvent is the payload of the Door Lock DoorStateChange event (priority critical).
+type DoorLockDoorStateChangeEvent struct {
+	DoorState DoorLockDoorStateEnum `tlv:"0"`
+}
+
+// ClusterID returns DoorLockClusterID.
+func (DoorLockDoorStateChangeEvent) ClusterID() uint32 { return DoorLockClusterID }
+
+// EventID returns DoorLockEventDoorStateChange.
+func (DoorLockDoorStateChangeEvent) EventID() uint32 { return DoorLockEventDoorStateChange }
+
+// DoorLockLockOperationEvent is the payload of the Door Lock LockOperation event (priority critical).
+type DoorLockLockOperationEvent struct {
+	LockOperationType DoorLockLockOperationTypeEnum `tlv:"0"`
+	OperationSource   DoorLockOperationSourceEnum   `tlv:"1"`
+	UserIndex         *uint16                       `tlv:"2,nullable"`
+	FabricIndex       *uint8                        `tlv:"3,nullable"`
+	SourceNode        *uint64                       `tlv:"4,nullable"`
+	Credentials       []DoorLockCredentialStruct    `tlv:"5,omitempty,nullable"`
+}
+
+// ClusterID returns DoorLockClusterID.
+func (DoorLockLockOperationEvent) ClusterID() uint32 { return DoorLockClusterID }
+
+// EventID returns DoorLockEventLockOperation.
+func (DoorLockLockOperationEvent) EventID() uint32 { return DoorLockEventLockOperation }
+
+// DoorLockLockOperationErrorEvent is the payload of the Door Lock LockOperationError event (priority critical).
+type DoorLockLockOperationErrorEvent struct {
+	LockOperationType DoorLockLockOperationTypeEnum `tlv:"0"`
+	OperationSource   DoorLockOperationSourceEnum   `tlv:"1"`
+	OperationError    DoorLockOperationErrorEnum    `tlv:"2"`
+	UserIndex         *uint16                       `tlv:"3,nullable"`
+	FabricIndex       *uint8                        `tlv:"4,nullable"`
+	SourceNode        *uint64                       `tlv:"5,nullable"`
+	Credentials       []DoorLockCredentialStruct    `tlv:"6,omitempty,nullable"`
+}
+
+// ClusterID returns DoorLockClusterID.
+func (DoorLockLockOperationErrorEvent) ClusterID() uint32 { return DoorLockClusterID }
+
+// EventID returns DoorLockEventLockOperationError.
+func (DoorLockLockOperationErrorEvent) EventID() uint32 { return DoorLockEventLockOperationError }
@@ -0,0 +1,121 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedUpToDate fails when the committed matter/clusters files differ
+// from the generator output, i.e. go generate was not re-run.
+func TestGeneratedUpToDate(t *testing.T) {
+	files, err := generateAll([]string{"testdata"})
+	if err != nil {
+		t.Fatalf("generateAll: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no fixtures")
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join("..", name))
+		if err != nil {
+			t.Fatalf("%s: %v (run go generate in matter/clusters)", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is stale; run go generate in matter/clusters", name)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	const doc = `<configurator>
+  <enum name="ModeEnum" type="enum8">
+    <cluster code="0x0100"/>
+    <item name="Auto" value="0x00"/>
+  </enum>
+  <struct name="EntryStruct">
+    <cluster code="0x0100"/>
+    <item fieldId="3" name="Mode" type="ModeEnum"/>
+  </struct>
+  <cluster>
+    <name>Test Cluster</name>
+    <code>0x0100</code>
+    <globalAttribute side="either" code="0xFFFD" value="2"/>
+    <attribute side="server" code="0x0001" type="array" entryType="EntryStruct">Entries</attribute>
+    <attribute side="server" code="0x0000" name="label" type="char_string" isNullable="true"/>
+    <command source="client" code="0x00" name="Set">
+      <arg name="Mode" type="ModeEnum"/>
+      <arg name="Delay" type="int16u" optional="true"/>
+      <arg name="Label" type="char_string" isNullable="true"/>
+    </command>
+  </cluster>
+</configurator>`
+	clusters, err := parseFile(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("parseFile: %v", err)
+	}
+	if len(clusters) != 1 {
+		t.Fatalf("clusters = %d", len(clusters))
+	}
+	c := clusters[0]
+	if c.Name != "TestCluster" || c.Code != 0x0100 || c.Revision != 2 {
+		t.Errorf("cluster = %+v", c)
+	}
+	if len(c.Attributes) != 2 || c.Attributes[0].Name != "Label" || c.Attributes[1].Type != "[]TestClusterEntryStruct" {
+		t.Errorf("attributes = %+v", c.Attributes)
+	}
+	if f := c.Structs[0].Fields[0]; f.Tag != 3 || f.Type != "TestClusterModeEnum" {
+		t.Errorf("struct field = %+v", f)
+	}
+	fields := c.Commands[0].Fields
+	want := []field{
+		{Name: "Mode", Tag: 0, Type: "TestClusterModeEnum"},
+		{Name: "Delay", Tag: 1, Type: "*uint16", Opts: ",omitempty"},
+		{Name: "Label", Tag: 2, Type: "*string", Opts: ",nullable"},
+	}
+	for i, f := range want {
+		if fields[i] != f {
+			t.Errorf("arg %d = %+v, want %+v", i, fields[i], f)
+		}
+	}
+	if _, err := render("test.xml", clusters); err != nil {
+		t.Errorf("render: %v", err)
+	}
+}
+
+func TestParseFileUnknownType(t *testing.T) {
+	const doc = `<configurator><cluster><name>X</name><code>1</code>
+    <attribute code="0" name="A" type="mystery"/></cluster></configurator>`
+	if _, err := parseFile(strings.NewReader(doc)); err == nil || !strings.Contains(err.Error(), "mystery") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for in, want := range map[string]string{
+		"On/Off":                           "OnOff",
+		"Bridged Device Basic Information": "BridgedDeviceBasicInformation",
+		"UniqueID":                         "UniqueID",
+		"onOff":                            "OnOff",
+	} {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
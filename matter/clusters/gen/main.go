@@ -0,0 +1,95 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command gen generates the matter/clusters package from connectedhomeip
+// data model XML files:
+//
+//	go run ./gen -out . ./gen/testdata
+//
+// Each XML file produces one Go file with cluster, attribute, command and
+// event IDs, enums, bitmaps, structures, a typed attribute struct and TLV
+// tagged command and event payloads.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+func main() {
+	out := flag.String("out", ".", "output directory")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: gen [-out dir] <xml file or directory>...")
+		os.Exit(2)
+	}
+	files, err := generateAll(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*out, name), src, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generateAll renders every XML file found in paths, keyed by output file name.
+func generateAll(paths []string) (map[string][]byte, error) {
+	var inputs []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			inputs = append(inputs, p)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(p, "*.xml"))
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, matches...)
+	}
+	sort.Strings(inputs)
+
+	files := map[string][]byte{}
+	for _, in := range inputs {
+		src, err := generateFile(in)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", in, err)
+		}
+		files[outputName(filepath.Base(in))] = src
+	}
+	return files, nil
+}
+
+func generateFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	clusters, err := parseFile(f)
+	if err != nil {
+		return nil, err
+	}
+	return render(filepath.Base(path), clusters)
+}
@@ -0,0 +1,529 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The xml* types mirror the subset of the connectedhomeip data model XML
+// (src/app/zap-templates/zcl/data-model/chip) used by the generator.
+
+type xmlConfigurator struct {
+	Enums    []xmlEnum    `xml:"enum"`
+	Bitmaps  []xmlBitmap  `xml:"bitmap"`
+	Structs  []xmlStruct  `xml:"struct"`
+	Clusters []xmlCluster `xml:"cluster"`
+}
+
+type xmlClusterRef struct {
+	Code string `xml:"code,attr"`
+}
+
+type xmlEnum struct {
+	Name     string          `xml:"name,attr"`
+	Type     string          `xml:"type,attr"`
+	Clusters []xmlClusterRef `xml:"cluster"`
+	Items    []xmlEnumItem   `xml:"item"`
+}
+
+type xmlEnumItem struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type xmlBitmap struct {
+	Name     string          `xml:"name,attr"`
+	Type     string          `xml:"type,attr"`
+	Clusters []xmlClusterRef `xml:"cluster"`
+	Fields   []xmlBitmapBit  `xml:"field"`
+}
+
+type xmlBitmapBit struct {
+	Name string `xml:"name,attr"`
+	Mask string `xml:"mask,attr"`
+}
+
+type xmlStruct struct {
+	Name     string          `xml:"name,attr"`
+	Clusters []xmlClusterRef `xml:"cluster"`
+	Items    []xmlField      `xml:"item"`
+}
+
+// xmlField is a struct item, command argument or event field.
+type xmlField struct {
+	ID         string `xml:"id,attr"`
+	FieldID    string `xml:"fieldId,attr"`
+	Name       string `xml:"name,attr"`
+	Type       string `xml:"type,attr"`
+	Array      bool   `xml:"array,attr"`
+	IsNullable bool   `xml:"isNullable,attr"`
+	Optional   bool   `xml:"optional,attr"`
+}
+
+type xmlCluster struct {
+	Name             string               `xml:"name"`
+	Code             string               `xml:"code"`
+	Description      string               `xml:"description"`
+	GlobalAttributes []xmlGlobalAttribute `xml:"globalAttribute"`
+	Attributes       []xmlAttribute       `xml:"attribute"`
+	Commands         []xmlCommand         `xml:"command"`
+	Events           []xmlEvent           `xml:"event"`
+}
+
+type xmlGlobalAttribute struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type xmlAttribute struct {
+	Code      string `xml:"code,attr"`
+	Name      string `xml:"name,attr"`
+	Text      string `xml:",chardata"`
+	Type      string `xml:"type,attr"`
+	EntryType string `xml:"entryType,attr"`
+}
+
+type xmlCommand struct {
+	Source   string     `xml:"source,attr"`
+	Code     string     `xml:"code,attr"`
+	Name     string     `xml:"name,attr"`
+	Response string     `xml:"response,attr"`
+	Args     []xmlField `xml:"arg"`
+}
+
+type xmlEvent struct {
+	Code     string     `xml:"code,attr"`
+	Name     string     `xml:"name,attr"`
+	Priority string     `xml:"priority,attr"`
+	Fields   []xmlField `xml:"field"`
+}
+
+// globalClusterRevisionID is the ClusterRevision global attribute.
+const globalClusterRevisionID = 0xFFFD
+
+// cluster is the resolved model of one cluster.
+type cluster struct {
+	Name        string // Go identifier prefix, e.g. "OnOff"
+	Title       string // data model name, e.g. "On/Off"
+	Code        uint32
+	Revision    uint16
+	Description string
+	Attributes  []attribute
+	Commands    []command
+	Events      []event
+	Enums       []enum
+	Bitmaps     []bitmap
+	Structs     []structType
+}
+
+type attribute struct {
+	Name string
+	Code uint32
+	Type string // Go type of the value
+}
+
+type command struct {
+	Name     string
+	Code     uint32
+	Response bool
+	Fields   []field
+}
+
+type event struct {
+	Name     string
+	Code     uint32
+	Priority string
+	Fields   []field
+}
+
+type field struct {
+	Name string
+	Tag  uint8
+	Type string // Go type including pointer or slice
+	Opts string // `tlv` tag options, e.g. ",omitempty"
+}
+
+type enum struct {
+	Name  string
+	Type  string // underlying Go type
+	Items []enumItem
+}
+
+type enumItem struct {
+	Name  string
+	Value uint64
+}
+
+type bitmap struct {
+	Name  string
+	Type  string
+	Items []enumItem
+}
+
+type structType struct {
+	Name   string
+	Fields []field
+}
+
+// baseTypes maps data model primitive types to Go types.
+var baseTypes = map[string]string{
+	"boolean":           "bool",
+	"int8u":             "uint8",
+	"int16u":            "uint16",
+	"int24u":            "uint32",
+	"int32u":            "uint32",
+	"int40u":            "uint64",
+	"int48u":            "uint64",
+	"int56u":            "uint64",
+	"int64u":            "uint64",
+	"int8s":             "int8",
+	"int16s":            "int16",
+	"int24s":            "int32",
+	"int32s":            "int32",
+	"int40s":            "int64",
+	"int48s":            "int64",
+	"int56s":            "int64",
+	"int64s":            "int64",
+	"enum8":             "uint8",
+	"enum16":            "uint16",
+	"bitmap8":           "uint8",
+	"bitmap16":          "uint16",
+	"bitmap32":          "uint32",
+	"bitmap64":          "uint64",
+	"single":            "float32",
+	"double":            "float64",
+	"char_string":       "string",
+	"long_char_string":  "string",
+	"octet_string":      "[]byte",
+	"long_octet_string": "[]byte",
+	"ipadr":             "[]byte",
+	"ipv4adr":           "[]byte",
+	"ipv6adr":           "[]byte",
+	"ipv6pre":           "[]byte",
+	"hwadr":             "[]byte",
+	"percent":           "uint8",
+	"percent100ths":     "uint16",
+	"fabric_idx":        "uint8",
+	"action_id":         "uint8",
+	"status":            "uint8",
+	"endpoint_no":       "uint16",
+	"group_id":          "uint16",
+	"vendor_id":         "uint16",
+	"entry_idx":         "uint16",
+	"cluster_id":        "uint32",
+	"attrib_id":         "uint32",
+	"command_id":        "uint32",
+	"event_id":          "uint32",
+	"devtype_id":        "uint32",
+	"field_id":          "uint32",
+	"data_ver":          "uint32",
+	"trans_id":          "uint32",
+	"epoch_s":           "uint32",
+	"elapsed_s":         "uint32",
+	"utc":               "uint32",
+	"node_id":           "uint64",
+	"fabric_id":         "uint64",
+	"event_no":          "uint64",
+	"epoch_us":          "uint64",
+	"systime_ms":        "uint64",
+	"systime_us":        "uint64",
+	"posix_ms":          "uint64",
+	"temperature":       "int16",
+	"amperage_ma":       "int64",
+	"voltage_mv":        "int64",
+	"power_mw":          "int64",
+	"energy_mwh":        "int64",
+}
+
+// parseFile decodes one data model XML file into clusters.
+func parseFile(r io.Reader) ([]*cluster, error) {
+	var doc xmlConfigurator
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	clusters := make([]*cluster, 0, len(doc.Clusters))
+	byCode := map[uint32]*cluster{}
+	for _, xc := range doc.Clusters {
+		code, err := parseUint(xc.Code, 32)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %q: code: %w", xc.Name, err)
+		}
+		c := &cluster{
+			Name:        goName(xc.Name),
+			Title:       strings.TrimSpace(xc.Name),
+			Code:        uint32(code),
+			Description: strings.Join(strings.Fields(xc.Description), " "),
+		}
+		for _, ga := range xc.GlobalAttributes {
+			if id, err := parseUint(ga.Code, 32); err == nil && id == globalClusterRevisionID {
+				rev, err := parseUint(ga.Value, 16)
+				if err != nil {
+					return nil, fmt.Errorf("cluster %q: revision: %w", xc.Name, err)
+				}
+				c.Revision = uint16(rev)
+			}
+		}
+		clusters = append(clusters, c)
+		byCode[c.Code] = c
+	}
+
+	// Data types are declared at the top level and scoped to clusters by code.
+	owner := func(kind, name string, refs []xmlClusterRef) (*cluster, error) {
+		for _, ref := range refs {
+			code, err := parseUint(ref.Code, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s %q: cluster code: %w", kind, name, err)
+			}
+			if c, ok := byCode[uint32(code)]; ok {
+				return c, nil
+			}
+		}
+		return nil, fmt.Errorf("%s %q is not scoped to a cluster in this file", kind, name)
+	}
+	for _, xe := range doc.Enums {
+		c, err := owner("enum", xe.Name, xe.Clusters)
+		if err != nil {
+			return nil, err
+		}
+		e, err := parseEnum(xe.Name, xe.Type, xe.Items)
+		if err != nil {
+			return nil, err
+		}
+		c.Enums = append(c.Enums, e)
+	}
+	for _, xb := range doc.Bitmaps {
+		c, err := owner("bitmap", xb.Name, xb.Clusters)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]xmlEnumItem, len(xb.Fields))
+		for i, f := range xb.Fields {
+			items[i] = xmlEnumItem{Name: f.Name, Value: f.Mask}
+		}
+		e, err := parseEnum(xb.Name, xb.Type, items)
+		if err != nil {
+			return nil, err
+		}
+		c.Bitmaps = append(c.Bitmaps, bitmap(e))
+	}
+	// Structs are resolved after enums and bitmaps so their fields can use them.
+	for _, xs := range doc.Structs {
+		c, err := owner("struct", xs.Name, xs.Clusters)
+		if err != nil {
+			return nil, err
+		}
+		c.Structs = append(c.Structs, structType{Name: goName(xs.Name)})
+	}
+	for _, xs := range doc.Structs {
+		c, _ := owner("struct", xs.Name, xs.Clusters)
+		fields, err := c.fields(xs.Items)
+		if err != nil {
+			return nil, fmt.Errorf("struct %q: %w", xs.Name, err)
+		}
+		for i := range c.Structs {
+			if c.Structs[i].Name == goName(xs.Name) {
+				c.Structs[i].Fields = fields
+			}
+		}
+	}
+
+	for i, xc := range doc.Clusters {
+		if err := clusters[i].resolve(xc); err != nil {
+			return nil, fmt.Errorf("cluster %q: %w", xc.Name, err)
+		}
+	}
+	return clusters, nil
+}
+
+func parseEnum(name, xmlType string, items []xmlEnumItem) (enum, error) {
+	base, ok := baseTypes[xmlType]
+	if !ok || !strings.HasPrefix(base, "uint") {
+		return enum{}, fmt.Errorf("enum %q: unsupported type %q", name, xmlType)
+	}
+	e := enum{Name: goName(name), Type: base}
+	for _, item := range items {
+		v, err := parseUint(item.Value, 64)
+		if err != nil {
+			return enum{}, fmt.Errorf("enum %q item %q: %w", name, item.Name, err)
+		}
+		e.Items = append(e.Items, enumItem{Name: goName(item.Name), Value: v})
+	}
+	return e, nil
+}
+
+// resolve fills attributes, commands and events once data types are known.
+func (c *cluster) resolve(xc xmlCluster) error {
+	for _, xa := range xc.Attributes {
+		code, err := parseUint(xa.Code, 32)
+		if err != nil {
+			return fmt.Errorf("attribute %q: code: %w", xa.Name, err)
+		}
+		name := xa.Name
+		if name == "" {
+			name = strings.TrimSpace(xa.Text)
+		}
+		if name == "" {
+			return fmt.Errorf("attribute 0x%04X has no name", code)
+		}
+		var typ string
+		if xa.Type == "array" {
+			elem, err := c.goType(xa.EntryType)
+			if err != nil {
+				return fmt.Errorf("attribute %q: %w", name, err)
+			}
+			typ = "[]" + elem
+		} else if typ, err = c.goType(xa.Type); err != nil {
+			return fmt.Errorf("attribute %q: %w", name, err)
+		}
+		c.Attributes = append(c.Attributes, attribute{Name: goName(name), Code: uint32(code), Type: typ})
+	}
+	sort.SliceStable(c.Attributes, func(i, j int) bool { return c.Attributes[i].Code < c.Attributes[j].Code })
+
+	for _, xcmd := range xc.Commands {
+		code, err := parseUint(xcmd.Code, 32)
+		if err != nil {
+			return fmt.Errorf("command %q: code: %w", xcmd.Name, err)
+		}
+		fields, err := c.fields(xcmd.Args)
+		if err != nil {
+			return fmt.Errorf("command %q: %w", xcmd.Name, err)
+		}
+		c.Commands = append(c.Commands, command{
+			Name:     goName(xcmd.Name),
+			Code:     uint32(code),
+			Response: xcmd.Source == "server",
+			Fields:   fields,
+		})
+	}
+	sort.SliceStable(c.Commands, func(i, j int) bool {
+		if c.Commands[i].Response != c.Commands[j].Response {
+			return !c.Commands[i].Response
+		}
+		return c.Commands[i].Code < c.Commands[j].Code
+	})
+
+	for _, xe := range xc.Events {
+		code, err := parseUint(xe.Code, 32)
+		if err != nil {
+			return fmt.Errorf("event %q: code: %w", xe.Name, err)
+		}
+		fields, err := c.fields(xe.Fields)
+		if err != nil {
+			return fmt.Errorf("event %q: %w", xe.Name, err)
+		}
+		c.Events = append(c.Events, event{
+			Name:     goName(xe.Name),
+			Code:     uint32(code),
+			Priority: xe.Priority,
+			Fields:   fields,
+		})
+	}
+	sort.SliceStable(c.Events, func(i, j int) bool { return c.Events[i].Code < c.Events[j].Code })
+	return nil
+}
+
+// fields resolves struct items, command arguments or event fields. Tags come
+// from the id or fieldId attribute, defaulting to the position.
+func (c *cluster) fields(items []xmlField) ([]field, error) {
+	fields := make([]field, 0, len(items))
+	for i, item := range items {
+		tag := uint64(i)
+		if id := item.ID + item.FieldID; id != "" {
+			var err error
+			if tag, err = parseUint(id, 8); err != nil {
+				return nil, fmt.Errorf("field %q: id: %w", item.Name, err)
+			}
+		}
+		typ, err := c.goType(item.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", item.Name, err)
+		}
+		var opts string
+		switch {
+		case item.Array:
+			typ = "[]" + typ
+		case item.IsNullable || (item.Optional && !isReference(typ)):
+			typ = "*" + typ
+		}
+		if item.Optional {
+			opts += ",omitempty"
+		}
+		if item.IsNullable {
+			opts += ",nullable"
+		}
+		fields = append(fields, field{Name: goName(item.Name), Tag: uint8(tag), Type: typ, Opts: opts})
+	}
+	return fields, nil
+}
+
+// goType maps a data model type name to a Go type, preferring the cluster's
+// own enums, bitmaps and structs.
+func (c *cluster) goType(xmlType string) (string, error) {
+	name := goName(xmlType)
+	for _, e := range c.Enums {
+		if e.Name == name {
+			return c.Name + name, nil
+		}
+	}
+	for _, b := range c.Bitmaps {
+		if b.Name == name {
+			return c.Name + name, nil
+		}
+	}
+	for _, s := range c.Structs {
+		if s.Name == name {
+			return c.Name + name, nil
+		}
+	}
+	if t, ok := baseTypes[strings.ToLower(xmlType)]; ok {
+		return t, nil
+	}
+	return "", fmt.Errorf("unknown type %q", xmlType)
+}
+
+// isReference reports whether the zero value of typ already means "absent".
+func isReference(typ string) bool {
+	return strings.HasPrefix(typ, "[]")
+}
+
+// goName converts a data model name such as "On/Off" or "Door Lock" into an
+// exported Go identifier, keeping existing capitalisation (e.g. "UniqueID").
+func goName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func parseUint(s string, bits int) (uint64, error) {
+	return strconv.ParseUint(strings.TrimSpace(s), 0, bits)
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+const fileHeader = `// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+`
+
+// render returns the gofmt'd Go source for the clusters of one XML file.
+func render(source string, clusters []*cluster) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(fileHeader)
+	fmt.Fprintf(&b, "// Code generated by go run ./gen from %s; DO NOT EDIT.\n\n", source)
+	b.WriteString("package clusters\n\n")
+
+	var imports []string
+	for _, c := range clusters {
+		if len(c.Enums) > 0 {
+			imports = appendUnique(imports, "fmt")
+		}
+		if len(c.Attributes) > 0 {
+			imports = appendUnique(imports, "github.com/YashubuStudio/go-matter-pack/matter/im")
+		}
+	}
+	if len(imports) > 0 {
+		b.WriteString("import (\n")
+		for i, imp := range imports {
+			if i > 0 && strings.Contains(imp, ".") && !strings.Contains(imports[i-1], ".") {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "\t%q\n", imp)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	for _, c := range clusters {
+		renderCluster(&b, c)
+	}
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format %s: %w\n%s", source, err, b.String())
+	}
+	return out, nil
+}
+
+func renderCluster(b *bytes.Buffer, c *cluster) {
+	fmt.Fprintf(b, "// %sClusterID identifies the %s cluster.\n", c.Name, c.Title)
+	if c.Description != "" {
+		fmt.Fprintf(b, "//\n// %s\n", c.Description)
+	}
+	fmt.Fprintf(b, "const %sClusterID uint32 = 0x%04X\n\n", c.Name, c.Code)
+	if c.Revision != 0 {
+		fmt.Fprintf(b, "// %sClusterRevision is the %s cluster revision described by the data model.\n", c.Name, c.Title)
+		fmt.Fprintf(b, "const %sClusterRevision uint16 = %d\n\n", c.Name, c.Revision)
+	}
+
+	if len(c.Attributes) > 0 {
+		fmt.Fprintf(b, "// %s attribute IDs.\nconst (\n", c.Title)
+		for _, a := range c.Attributes {
+			fmt.Fprintf(b, "\t%sAttr%s uint32 = 0x%04X\n", c.Name, a.Name, a.Code)
+		}
+		b.WriteString(")\n\n")
+	}
+	if len(c.Commands) > 0 {
+		fmt.Fprintf(b, "// %s command IDs.\nconst (\n", c.Title)
+		for _, cmd := range c.Commands {
+			fmt.Fprintf(b, "\t%sCmd%s uint32 = 0x%02X\n", c.Name, cmd.Name, cmd.Code)
+		}
+		b.WriteString(")\n\n")
+	}
+	if len(c.Events) > 0 {
+		fmt.Fprintf(b, "// %s event IDs.\nconst (\n", c.Title)
+		for _, ev := range c.Events {
+			fmt.Fprintf(b, "\t%sEvent%s uint32 = 0x%02X\n", c.Name, ev.Name, ev.Code)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	for _, e := range c.Enums {
+		renderEnum(b, c, e)
+	}
+	for _, bm := range c.Bitmaps {
+		renderBitmap(b, c, bm)
+	}
+	for _, s := range c.Structs {
+		fmt.Fprintf(b, "// %s%s is the %s %s structure.\n", c.Name, s.Name, c.Title, s.Name)
+		renderStruct(b, c.Name+s.Name, s.Fields)
+	}
+	if len(c.Attributes) > 0 {
+		renderAttributes(b, c)
+	}
+	for _, cmd := range c.Commands {
+		renderCommand(b, c, cmd)
+	}
+	for _, ev := range c.Events {
+		typ := c.Name + ev.Name + "Event"
+		fmt.Fprintf(b, "// %s is the payload of the %s %s event (priority %s).\n", typ, c.Title, ev.Name, ev.Priority)
+		renderStruct(b, typ, ev.Fields)
+		fmt.Fprintf(b, "// ClusterID returns %sClusterID.\n", c.Name)
+		fmt.Fprintf(b, "func (%s) ClusterID() uint32 { return %sClusterID }\n\n", typ, c.Name)
+		fmt.Fprintf(b, "// EventID returns %sEvent%s.\n", c.Name, ev.Name)
+		fmt.Fprintf(b, "func (%s) EventID() uint32 { return %sEvent%s }\n\n", typ, c.Name, ev.Name)
+	}
+}
+
+func renderEnum(b *bytes.Buffer, c *cluster, e enum) {
+	typ := c.Name + e.Name
+	fmt.Fprintf(b, "// %s is the %s %s enumeration.\n", typ, c.Title, e.Name)
+	fmt.Fprintf(b, "type %s %s\n\n", typ, e.Type)
+	fmt.Fprintf(b, "// %s values.\nconst (\n", typ)
+	for _, item := range e.Items {
+		fmt.Fprintf(b, "\t%s%s %s = 0x%02X\n", typ, item.Name, typ, item.Value)
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(b, "// String returns the data model name of the value.\n")
+	fmt.Fprintf(b, "func (v %s) String() string {\n\tswitch v {\n", typ)
+	seen := map[uint64]bool{}
+	for _, item := range e.Items {
+		if seen[item.Value] {
+			continue
+		}
+		seen[item.Value] = true
+		fmt.Fprintf(b, "\tcase %s%s:\n\t\treturn %q\n", typ, item.Name, item.Name)
+	}
+	fmt.Fprintf(b, "\t}\n\treturn fmt.Sprintf(\"%s(%%d)\", %s(v))\n}\n\n", typ, e.Type)
+}
+
+func renderBitmap(b *bytes.Buffer, c *cluster, bm bitmap) {
+	typ := c.Name + bm.Name
+	fmt.Fprintf(b, "// %s is the %s %s bitmap.\n", typ, c.Title, bm.Name)
+	fmt.Fprintf(b, "type %s %s\n\n", typ, bm.Type)
+	fmt.Fprintf(b, "// %s bits.\nconst (\n", typ)
+	for _, item := range bm.Items {
+		fmt.Fprintf(b, "\t%s%s %s = 0x%X\n", typ, item.Name, typ, item.Value)
+	}
+	b.WriteString(")\n\n")
+	fmt.Fprintf(b, "// Has reports whether all bits of mask are set.\n")
+	fmt.Fprintf(b, "func (v %s) Has(mask %s) bool { return v&mask == mask }\n\n", typ, typ)
+}
+
+func renderStruct(b *bytes.Buffer, typ string, fields []field) {
+	if len(fields) == 0 {
+		fmt.Fprintf(b, "type %s struct{}\n\n", typ)
+		return
+	}
+	fmt.Fprintf(b, "type %s struct {\n", typ)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\t%s %s `tlv:\"%d%s\"`\n", f.Name, f.Type, f.Tag, f.Opts)
+	}
+	b.WriteString("}\n\n")
+}
+
+func renderAttributes(b *bytes.Buffer, c *cluster) {
+	typ := c.Name + "Attributes"
+	fmt.Fprintf(b, "// %s holds %s attribute values. A nil field was not read or\n// holds null.\n", typ, c.Title)
+	fmt.Fprintf(b, "type %s struct {\n", typ)
+	for _, a := range c.Attributes {
+		fmt.Fprintf(b, "\t%s %s\n", a.Name, attributeFieldType(a))
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(b, "// Decode stores the value of attribute attrID. Unknown attributes are ignored.\n")
+	fmt.Fprintf(b, "func (a *%s) Decode(attrID uint32, v im.Value) error {\n\tswitch attrID {\n", typ)
+	for _, a := range c.Attributes {
+		fmt.Fprintf(b, "\tcase %sAttr%s:\n\t\treturn v.Unmarshal(&a.%s)\n", c.Name, a.Name, a.Name)
+	}
+	b.WriteString("\t}\n\treturn nil\n}\n\n")
+}
+
+func attributeFieldType(a attribute) string {
+	if isReference(a.Type) {
+		return a.Type
+	}
+	return "*" + a.Type
+}
+
+func renderCommand(b *bytes.Buffer, c *cluster, cmd command) {
+	typ := c.Name + cmd.Name
+	if cmd.Response {
+		fmt.Fprintf(b, "// %s is the %s %s command payload sent by the server.\n", typ, c.Title, cmd.Name)
+	} else {
+		typ += "Request"
+		fmt.Fprintf(b, "// %s is the %s %s command payload.\n", typ, c.Title, cmd.Name)
+	}
+	renderStruct(b, typ, cmd.Fields)
+	fmt.Fprintf(b, "// ClusterID returns %sClusterID.\n", c.Name)
+	fmt.Fprintf(b, "func (%s) ClusterID() uint32 { return %sClusterID }\n\n", typ, c.Name)
+	fmt.Fprintf(b, "// CommandID returns %sCmd%s.\n", c.Name, cmd.Name)
+	fmt.Fprintf(b, "func (%s) CommandID() uint32 { return %sCmd%s }\n\n", typ, c.Name, cmd.Name)
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// outputName derives the Go file name from an XML file name, e.g.
+// "door-lock-cluster.xml" becomes "door_lock.go".
+func outputName(xmlName string) string {
+	name := strings.TrimSuffix(xmlName, ".xml")
+	name = strings.TrimSuffix(name, "-cluster")
+	return strings.ReplaceAll(name, "-", "_") + ".go"
+}
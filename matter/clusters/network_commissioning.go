@@ -0,0 +1,389 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by go run ./gen from network-commissioning-cluster.xml; DO NOT EDIT.
+
+package clusters
+
+import (
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+// NetworkCommissioningClusterID identifies the Network Commissioning cluster.
+//
+// Functionality to configure, enable, disable network credentials and access on a Matter device.
+const NetworkCommissioningClusterID uint32 = 0x0031
+
+// NetworkCommissioningClusterRevision is the Network Commissioning cluster revision described by the data model.
+const NetworkCommissioningClusterRevision uint16 = 1
+
+// Network Commissioning attribute IDs.
+const (
+	NetworkCommissioningAttrMaxNetworks           uint32 = 0x0000
+	NetworkCommissioningAttrNetworks              uint32 = 0x0001
+	NetworkCommissioningAttrScanMaxTimeSeconds    uint32 = 0x0002
+	NetworkCommissioningAttrConnectMaxTimeSeconds uint32 = 0x0003
+	NetworkCommissioningAttrInterfaceEnabled      uint32 = 0x0004
+	NetworkCommissioningAttrLastNetworkingStatus  uint32 = 0x0005
+	NetworkCommissioningAttrLastNetworkID         uint32 = 0x0006
+	NetworkCommissioningAttrLastConnectErrorValue uint32 = 0x0007
+)
+
+// Network Commissioning command IDs.
+const (
+	NetworkCommissioningCmdScanNetworks             uint32 = 0x00
+	NetworkCommissioningCmdAddOrUpdateWiFiNetwork   uint32 = 0x02
+	NetworkCommissioningCmdAddOrUpdateThreadNetwork uint32 = 0x03
+	NetworkCommissioningCmdRemoveNetwork            uint32 = 0x04
+	NetworkCommissioningCmdConnectNetwork           uint32 = 0x06
+	NetworkCommissioningCmdReorderNetwork           uint32 = 0x08
+	NetworkCommissioningCmdScanNetworksResponse     uint32 = 0x01
+	NetworkCommissioningCmdNetworkConfigResponse    uint32 = 0x05
+	NetworkCommissioningCmdConnectNetworkResponse   uint32 = 0x07
+)
+
+// NetworkCommissioningNetworkCommissioningStatusEnum is the Network Commissioning NetworkCommissioningStatusEnum enumeration.
+type NetworkCommissioningNetworkCommissioningStatusEnum uint8
+
+// NetworkCommissioningNetworkCommissioningStatusEnum values.
+const (
+	NetworkCommissioningNetworkCommissioningStatusEnumSuccess                NetworkCommissioningNetworkCommissioningStatusEnum = 0x00
+	NetworkCommissioningNetworkCommissioningStatusEnumOutOfRange             NetworkCommissioningNetworkCommissioningStatusEnum = 0x01
+	NetworkCommissioningNetworkCommissioningStatusEnumBoundsExceeded         NetworkCommissioningNetworkCommissioningStatusEnum = 0x02
+	NetworkCommissioningNetworkCommissioningStatusEnumNetworkIDNotFound      NetworkCommissioningNetworkCommissioningStatusEnum = 0x03
+	NetworkCommissioningNetworkCommissioningStatusEnumDuplicateNetworkID     NetworkCommissioningNetworkCommissioningStatusEnum = 0x04
+	NetworkCommissioningNetworkCommissioningStatusEnumNetworkNotFound        NetworkCommissioningNetworkCommissioningStatusEnum = 0x05
+	NetworkCommissioningNetworkCommissioningStatusEnumRegulatoryError        NetworkCommissioningNetworkCommissioningStatusEnum = 0x06
+	NetworkCommissioningNetworkCommissioningStatusEnumAuthFailure            NetworkCommissioningNetworkCommissioningStatusEnum = 0x07
+	NetworkCommissioningNetworkCommissioningStatusEnumUnsupportedSecurity    NetworkCommissioningNetworkCommissioningStatusEnum = 0x08
+	NetworkCommissioningNetworkCommissioningStatusEnumOtherConnectionFailure NetworkCommissioningNetworkCommissioningStatusEnum = 0x09
+	NetworkCommissioningNetworkCommissioningStatusEnumIPV6Failed             NetworkCommissioningNetworkCommissioningStatusEnum = 0x0A
+	NetworkCommissioningNetworkCommissioningStatusEnumIPBindFailed           NetworkCommissioningNetworkCommissioningStatusEnum = 0x0B
+	NetworkCommissioningNetworkCommissioningStatusEnumUnknownError           NetworkCommissioningNetworkCommissioningStatusEnum = 0x0C
+)
+
+// String returns the data model name of the value.
+func (v NetworkCommissioningNetworkCommissioningStatusEnum) String() string {
+	switch v {
+	case NetworkCommissioningNetworkCommissioningStatusEnumSuccess:
+		return "Success"
+	case NetworkCommissioningNetworkCommissioningStatusEnumOutOfRange:
+		return "OutOfRange"
+	case NetworkCommissioningNetworkCommissioningStatusEnumBoundsExceeded:
+		return "BoundsExceeded"
+	case NetworkCommissioningNetworkCommissioningStatusEnumNetworkIDNotFound:
+		return "NetworkIDNotFound"
+	case NetworkCommissioningNetworkCommissioningStatusEnumDuplicateNetworkID:
+		return "DuplicateNetworkID"
+	case NetworkCommissioningNetworkCommissioningStatusEnumNetworkNotFound:
+		return "NetworkNotFound"
+	case NetworkCommissioningNetworkCommissioningStatusEnumRegulatoryError:
+		return "RegulatoryError"
+	case NetworkCommissioningNetworkCommissioningStatusEnumAuthFailure:
+		return "AuthFailure"
+	case NetworkCommissioningNetworkCommissioningStatusEnumUnsupportedSecurity:
+		return "UnsupportedSecurity"
+	case NetworkCommissioningNetworkCommissioningStatusEnumOtherConnectionFailure:
+		return "OtherConnectionFailure"
+	case NetworkCommissioningNetworkCommissioningStatusEnumIPV6Failed:
+		return "IPV6Failed"
+	case NetworkCommissioningNetworkCommissioningStatusEnumIPBindFailed:
+		return "IPBindFailed"
+	case NetworkCommissioningNetworkCommissioningStatusEnumUnknownError:
+		return "UnknownError"
+	}
+	return fmt.Sprintf("NetworkCommissioningNetworkCommissioningStatusEnum(%d)", uint8(v))
+}
+
+// NetworkCommissioningWiFiBandEnum is the Network Commissioning WiFiBandEnum enumeration.
+type NetworkCommissioningWiFiBandEnum uint8
+
+// NetworkCommissioningWiFiBandEnum values.
+const (
+	NetworkCommissioningWiFiBandEnum2G4  NetworkCommissioningWiFiBandEnum = 0x00
+	NetworkCommissioningWiFiBandEnum3G65 NetworkCommissioningWiFiBandEnum = 0x01
+	NetworkCommissioningWiFiBandEnum5G   NetworkCommissioningWiFiBandEnum = 0x02
+	NetworkCommissioningWiFiBandEnum6G   NetworkCommissioningWiFiBandEnum = 0x03
+	NetworkCommissioningWiFiBandEnum60G  NetworkCommissioningWiFiBandEnum = 0x04
+	NetworkCommissioningWiFiBandEnum1G   NetworkCommissioningWiFiBandEnum = 0x05
+)
+
+// String returns the data model name of the value.
+func (v NetworkCommissioningWiFiBandEnum) String() string {
+	switch v {
+	case NetworkCommissioningWiFiBandEnum2G4:
+		return "2G4"
+	case NetworkCommissioningWiFiBandEnum3G65:
+		return "3G65"
+	case NetworkCommissioningWiFiBandEnum5G:
+		return "5G"
+	case NetworkCommissioningWiFiBandEnum6G:
+		return "6G"
+	case NetworkCommissioningWiFiBandEnum60G:
+		return "60G"
+	case NetworkCommissioningWiFiBandEnum1G:
+		return "1G"
+	}
+	return fmt.Sprintf("NetworkCommissioningWiFiBandEnum(%d)", uint8(v))
+}
+
+// NetworkCommissioningFeature is the Network Commissioning Feature bitmap.
+type NetworkCommissioningFeature uint32
+
+// NetworkCommissioningFeature bits.
+const (
+	NetworkCommissioningFeatureWiFiNetworkInterface     NetworkCommissioningFeature = 0x1
+	NetworkCommissioningFeatureThreadNetworkInterface   NetworkCommissioningFeature = 0x2
+	NetworkCommissioningFeatureEthernetNetworkInterface NetworkCommissioningFeature = 0x4
+	NetworkCommissioningFeaturePerDeviceCredentials     NetworkCommissioningFeature = 0x8
+)
+
+// Has reports whether all bits of mask are set.
+func (v NetworkCommissioningFeature) Has(mask NetworkCommissioningFeature) bool {
+	return v&mask == mask
+}
+
+// NetworkCommissioningWiFiSecurityBitmap is the Network Commissioning WiFiSecurityBitmap bitmap.
+type NetworkCommissioningWiFiSecurityBitmap uint8
+
+// NetworkCommissioningWiFiSecurityBitmap bits.
+const (
+	NetworkCommissioningWiFiSecurityBitmapUnencrypted   NetworkCommissioningWiFiSecurityBitmap = 0x1
+	NetworkCommissioningWiFiSecurityBitmapWEP           NetworkCommissioningWiFiSecurityBitmap = 0x2
+	NetworkCommissioningWiFiSecurityBitmapWPAPersonal   NetworkCommissioningWiFiSecurityBitmap = 0x4
+	NetworkCommissioningWiFiSecurityBitmapWPA2Personal  NetworkCommissioningWiFiSecurityBitmap = 0x8
+	NetworkCommissioningWiFiSecurityBitmapWPA3Personal  NetworkCommissioningWiFiSecurityBitmap = 0x10
+	NetworkCommissioningWiFiSecurityBitmapWPA3MatterPDC NetworkCommissioningWiFiSecurityBitmap = 0x20
+)
+
+// Has reports whether all bits of mask are set.
+func (v NetworkCommissioningWiFiSecurityBitmap) Has(mask NetworkCommissioningWiFiSecurityBitmap) bool {
+	return v&mask == mask
+}
+
+// NetworkCommissioningNetworkInfoStruct is the Network Commissioning NetworkInfoStruct structure.
+type NetworkCommissioningNetworkInfoStruct struct {
+	NetworkID []byte `tlv:"0"`
+	Connected bool   `tlv:"1"`
+}
+
+// NetworkCommissioningWiFiInterfaceScanResultStruct is the Network Commissioning WiFiInterfaceScanResultStruct structure.
+type NetworkCommissioningWiFiInterfaceScanResultStruct struct {
+	Security NetworkCommissioningWiFiSecurityBitmap `tlv:"0"`
+	SSID     []byte                                 `tlv:"1"`
+	BSSID    []byte                                 `tlv:"2"`
+	Channel  uint16                                 `tlv:"3"`
+	WiFiBand NetworkCommissioningWiFiBandEnum       `tlv:"4"`
+	RSSI     int8                                   `tlv:"5"`
+}
+
+// NetworkCommissioningThreadInterfaceScanResultStruct is the Network Commissioning ThreadInterfaceScanResultStruct structure.
+type NetworkCommissioningThreadInterfaceScanResultStruct struct {
+	PanId           uint16 `tlv:"0"`
+	ExtendedPanId   uint64 `tlv:"1"`
+	NetworkName     string `tlv:"2"`
+	Channel         uint16 `tlv:"3"`
+	Version         uint8  `tlv:"4"`
+	ExtendedAddress []byte `tlv:"5"`
+	RSSI            int8   `tlv:"6"`
+	LQI             uint8  `tlv:"7"`
+}
+
+// NetworkCommissioningAttributes holds Network Commissioning attribute values. A nil field was not read or
+// holds null.
+type NetworkCommissioningAttributes struct {
+	MaxNetworks           *uint8
+	Networks              []NetworkCommissioningNetworkInfoStruct
+	ScanMaxTimeSeconds    *uint8
+	ConnectMaxTimeSeconds *uint8
+	InterfaceEnabled      *bool
+	LastNetworkingStatus  *NetworkCommissioningNetworkCommissioningStatusEnum
+	LastNetworkID         []byte
+	LastConnectErrorValue *int32
+}
+
+// Decode stores the value of attribute attrID. Unknown attributes are ignored.
+func (a *NetworkCommissioningAttributes) Decode(attrID uint32, v im.Value) error {
+	switch attrID {
+	case NetworkCommissioningAttrMaxNetworks:
+		return v.Unmarshal(&a.MaxNetworks)
+	case NetworkCommissioningAttrNetworks:
+		return v.Unmarshal(&a.Networks)
+	case NetworkCommissioningAttrScanMaxTimeSeconds:
+		return v.Unmarshal(&a.ScanMaxTimeSeconds)
+	case NetworkCommissioningAttrConnectMaxTimeSeconds:
+		return v.Unmarshal(&a.ConnectMaxTimeSeconds)
+	case NetworkCommissioningAttrInterfaceEnabled:
+		return v.Unmarshal(&a.InterfaceEnabled)
+	case NetworkCommissioningAttrLastNetworkingStatus:
+		return v.Unmarshal(&a.LastNetworkingStatus)
+	case NetworkCommissioningAttrLastNetworkID:
+		return v.Unmarshal(&a.LastNetworkID)
+	case NetworkCommissioningAttrLastConnectErrorValue:
+		return v.Unmarshal(&a.LastConnectErrorValue)
+	}
+	return nil
+}
+
+// NetworkCommissioningScanNetworksRequest is the Network Commissioning ScanNetworks command payload.
+type NetworkCommissioningScanNetworksRequest struct {
+	SSID       *[]byte `tlv:"0,omitempty,nullable"`
+	Breadcrumb *uint64 `tlv:"1,omitempty"`
+}
+
+// ClusterID returns NetworkCommissioningClusterID.
+func (NetworkCommissioningScanNetworksRequest) ClusterID() uint32 {
+	return NetworkCommissioningClusterID
+}
+
+// CommandID returns NetworkCommissioningCmdScanNetworks.
+func (NetworkCommissioningScanNetworksRequest) CommandID() uint32 {
+	return NetworkCommissioningCmdScanNetworks
+}
+
+// NetworkCommissioningAddOrUpdateWiFiNetworkRequest is the Network Commissioning AddOrUpdateWiFiNetwork command payload.
+type NetworkCommissioningAddOrUpdateWiFiNetworkRequest struct {
+	SSID        []byte  `tlv:"0"`
+	Credentials []byte  `tlv:"1"`
+	Breadcrumb  *uint64 `tlv:"2,omitempty"`
+}
+
+// ClusterID returns NetworkCommissioningClusterID.
+func (NetworkCommissioningAddOrUpdateWiFiNetworkRequest) ClusterID() uint32 {
+	return NetworkCommissioningClusterID
+}
+
+// CommandID returns NetworkCommissioningCmdAddOrUpdateWiFiNetwork.
+func (NetworkCommissioningAddOrUpdateWiFiNetworkRequest) CommandID() uint32 {
+	return NetworkCommissioningCmdAddOrUpdateWiFiNetwork
+}
+
+// NetworkCommissioningAddOrUpdateThreadNetworkRequest is the Network Commissioning AddOrUpdateThreadNetwork command payload.
+type NetworkCommissioningAddOrUpdateThreadNetworkRequest struct {
+	OperationalDataset []byte  `tlv:"0"`
+	Breadcrumb         *uint64 `tlv:"1,omitempty"`
+}
+
+// ClusterID returns NetworkCommissioningClusterID.
+func (NetworkCommissioningAddOrUpdateThreadNetworkRequest) ClusterID() uint32 {
+	return NetworkCommissioningClusterID
+}
+
+// CommandID returns NetworkCommissioningCmdAddOrUpdateThreadNetwork.
+func (NetworkCommissioningAddOrUpdateThreadNetworkRequest) CommandID() uint32 {
+	return NetworkCommissioningCmdAddOrUpdateThreadNetwork
+}
+
+// NetworkCommissioningRemoveNetworkRequest is the Network Commissioning RemoveNetwork command payload.
+type NetworkCommissioningRemoveNetworkRequest struct {
+	NetworkID  []byte  `tlv:"0"`
+	Breadcrumb *uint64 `tlv:"1,omitempty"`
+}
+
+// ClusterID returns NetworkCommissioningClusterID.
+func (NetworkCommissioningRemoveNetworkRequest) ClusterID() uint32 {
+	return NetworkCommissioningClusterID
+}
+
+// CommandID returns NetworkCommissioningCmdRemoveNetwork.
+func (NetworkCommissioningRemoveNetworkRequest) CommandID() uint32 {
+	return NetworkCommissioningCmdRemoveNetwork
+}
+
+// NetworkCommissioningConnectNetworkRequest is the Network Commissioning ConnectNetwork command payload.
+type NetworkCommissioningConnectNetworkRequest struct {
+	NetworkID  []byte  `tlv:"0"`
+	Breadcrumb *uint64 `tlv:"1,omitempty"`
+}
+
+// ClusterID returns NetworkCommissioningClusterID.
+func (NetworkCommissioningConnectNetworkRequest) ClusterID() uint32 {
+	return NetworkCommissioningClusterID
+}
+
+// CommandID returns NetworkCommissioningCmdConnectNetwork.
+func (NetworkCommissioningConnectNetworkRequest) CommandID() uint32 {
+	return NetworkCommissioningCmdConnectNetwork
+}
+
+// NetworkCommissioningReorderNetworkRequest is the Network Commissioning ReorderNetwork command payload.
+type NetworkCommissioningReorderNetworkRequest struct {
+	NetworkID    []byte  `tlv:"0"`
+	NetworkIndex uint8   `tlv:"1"`
+	Breadcrumb   *uint64 `tlv:"2,omitempty"`
+}
+
+// ClusterID returns NetworkCommissioningClusterID.
+func (NetworkCommissioningReorderNetworkRequest) ClusterID() uint32 {
+	return NetworkCommissioningClusterID
+}
+
+// CommandID returns NetworkCommissioningCmdReorderNetwork.
+func (NetworkCommissioningReorderNetworkRequest) CommandID() uint32 {
+	return NetworkCommissioningCmdReorderNetwork
+}
+
+// NetworkCommissioningScanNetworksResponse is the Network Commissioning ScanNetworksResponse command payload sent by the server.
+type NetworkCommissioningScanNetworksResponse struct {
+	NetworkingStatus  NetworkCommissioningNetworkCommissioningStatusEnum    `tlv:"0"`
+	DebugText         *string                                               `tlv:"1,omitempty"`
+	WiFiScanResults   []NetworkCommissioningWiFiInterfaceScanResultStruct   `tlv:"2,omitempty"`
+	ThreadScanResults []NetworkCommissioningThreadInterfaceScanResultStruct `tlv:"3,omitempty"`
+}
+
+// ClusterID returns NetworkCommissioningClusterID.
+func (NetworkCommissioningScanNetworksResponse) ClusterID() uint32 {
+	return NetworkCommissioningClusterID
+}
+
+// CommandID returns NetworkCommissioningCmdScanNetworksResponse.
+func (NetworkCommissioningScanNetworksResponse) CommandID() uint32 {
+	return NetworkCommissioningCmdScanNetworksResponse
+}
+
+// NetworkCommissioningNetworkConfigResponse is the Network Commissioning NetworkConfigResponse command payload sent by the server.
+type NetworkCommissioningNetworkConfigResponse struct {
+	NetworkingStatus NetworkCommissioningNetworkCommissioningStatusEnum `tlv:"0"`
+	DebugText        *string                                            `tlv:"1,omitempty"`
+	NetworkIndex     *uint8                                             `tlv:"2,omitempty"`
+}
+
+// ClusterID returns NetworkCommissioningClusterID.
+func (NetworkCommissioningNetworkConfigResponse) ClusterID() uint32 {
+	return NetworkCommissioningClusterID
+}
+
+// CommandID returns NetworkCommissioningCmdNetworkConfigResponse.
+func (NetworkCommissioningNetworkConfigResponse) CommandID() uint32 {
+	return NetworkCommissioningCmdNetworkConfigResponse
+}
+
+// NetworkCommissioningConnectNetworkResponse is the Network Commissioning ConnectNetworkResponse command payload sent by the server.
+type NetworkCommissioningConnectNetworkResponse struct {
+	NetworkingStatus NetworkCommissioningNetworkCommissioningStatusEnum `tlv:"0"`
+	DebugText        *string                                            `tlv:"1,omitempty"`
+	ErrorValue       *int32                                             `tlv:"2,nullable"`
+}
+
+// ClusterID returns NetworkCommissioningClusterID.
+func (NetworkCommissioningConnectNetworkResponse) ClusterID() uint32 {
+	return NetworkCommissioningClusterID
+}
+
+// CommandID returns NetworkCommissioningCmdConnectNetworkResponse.
+func (NetworkCommissioningConnectNetworkResponse) CommandID() uint32 {
+	return NetworkCommissioningCmdConnectNetworkResponse
+}
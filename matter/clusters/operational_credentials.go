@@ -0,0 +1,350 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by go run ./gen from operational-credentials-cluster.xml; DO NOT EDIT.
+
+package clusters
+
+import (
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+// OperationalCredentialsClusterID identifies the Operational Credentials cluster.
+//
+// This cluster is used to add or remove Operational Credentials on a Commissionee or Node, as well as manage the associated Fabrics.
+const OperationalCredentialsClusterID uint32 = 0x003E
+
+// OperationalCredentialsClusterRevision is the Operational Credentials cluster revision described by the data model.
+const OperationalCredentialsClusterRevision uint16 = 1
+
+// Operational Credentials attribute IDs.
+const (
+	OperationalCredentialsAttrNOCs                    uint32 = 0x0000
+	OperationalCredentialsAttrFabrics                 uint32 = 0x0001
+	OperationalCredentialsAttrSupportedFabrics        uint32 = 0x0002
+	OperationalCredentialsAttrCommissionedFabrics     uint32 = 0x0003
+	OperationalCredentialsAttrTrustedRootCertificates uint32 = 0x0004
+	OperationalCredentialsAttrCurrentFabricIndex      uint32 = 0x0005
+)
+
+// Operational Credentials command IDs.
+const (
+	OperationalCredentialsCmdAttestationRequest        uint32 = 0x00
+	OperationalCredentialsCmdCertificateChainRequest   uint32 = 0x02
+	OperationalCredentialsCmdCSRRequest                uint32 = 0x04
+	OperationalCredentialsCmdAddNOC                    uint32 = 0x06
+	OperationalCredentialsCmdUpdateNOC                 uint32 = 0x07
+	OperationalCredentialsCmdUpdateFabricLabel         uint32 = 0x09
+	OperationalCredentialsCmdRemoveFabric              uint32 = 0x0A
+	OperationalCredentialsCmdAddTrustedRootCertificate uint32 = 0x0B
+	OperationalCredentialsCmdAttestationResponse       uint32 = 0x01
+	OperationalCredentialsCmdCertificateChainResponse  uint32 = 0x03
+	OperationalCredentialsCmdCSRResponse               uint32 = 0x05
+	OperationalCredentialsCmdNOCResponse               uint32 = 0x08
+)
+
+// OperationalCredentialsCertificateChainTypeEnum is the Operational Credentials CertificateChainTypeEnum enumeration.
+type OperationalCredentialsCertificateChainTypeEnum uint8
+
+// OperationalCredentialsCertificateChainTypeEnum values.
+const (
+	OperationalCredentialsCertificateChainTypeEnumDACCertificate OperationalCredentialsCertificateChainTypeEnum = 0x01
+	OperationalCredentialsCertificateChainTypeEnumPAICertificate OperationalCredentialsCertificateChainTypeEnum = 0x02
+)
+
+// String returns the data model name of the value.
+func (v OperationalCredentialsCertificateChainTypeEnum) String() string {
+	switch v {
+	case OperationalCredentialsCertificateChainTypeEnumDACCertificate:
+		return "DACCertificate"
+	case OperationalCredentialsCertificateChainTypeEnumPAICertificate:
+		return "PAICertificate"
+	}
+	return fmt.Sprintf("OperationalCredentialsCertificateChainTypeEnum(%d)", uint8(v))
+}
+
+// OperationalCredentialsNodeOperationalCertStatusEnum is the Operational Credentials NodeOperationalCertStatusEnum enumeration.
+type OperationalCredentialsNodeOperationalCertStatusEnum uint8
+
+// OperationalCredentialsNodeOperationalCertStatusEnum values.
+const (
+	OperationalCredentialsNodeOperationalCertStatusEnumOK                  OperationalCredentialsNodeOperationalCertStatusEnum = 0x00
+	OperationalCredentialsNodeOperationalCertStatusEnumInvalidPublicKey    OperationalCredentialsNodeOperationalCertStatusEnum = 0x01
+	OperationalCredentialsNodeOperationalCertStatusEnumInvalidNodeOpId     OperationalCredentialsNodeOperationalCertStatusEnum = 0x02
+	OperationalCredentialsNodeOperationalCertStatusEnumInvalidNOC          OperationalCredentialsNodeOperationalCertStatusEnum = 0x03
+	OperationalCredentialsNodeOperationalCertStatusEnumMissingCsr          OperationalCredentialsNodeOperationalCertStatusEnum = 0x04
+	OperationalCredentialsNodeOperationalCertStatusEnumTableFull           OperationalCredentialsNodeOperationalCertStatusEnum = 0x05
+	OperationalCredentialsNodeOperationalCertStatusEnumInvalidAdminSubject OperationalCredentialsNodeOperationalCertStatusEnum = 0x06
+	OperationalCredentialsNodeOperationalCertStatusEnumFabricConflict      OperationalCredentialsNodeOperationalCertStatusEnum = 0x09
+	OperationalCredentialsNodeOperationalCertStatusEnumLabelConflict       OperationalCredentialsNodeOperationalCertStatusEnum = 0x0A
+	OperationalCredentialsNodeOperationalCertStatusEnumInvalidFabricIndex  OperationalCredentialsNodeOperationalCertStatusEnum = 0x0B
+)
+
+// String returns the data model name of the value.
+func (v OperationalCredentialsNodeOperationalCertStatusEnum) String() string {
+	switch v {
+	case OperationalCredentialsNodeOperationalCertStatusEnumOK:
+		return "OK"
+	case OperationalCredentialsNodeOperationalCertStatusEnumInvalidPublicKey:
+		return "InvalidPublicKey"
+	case OperationalCredentialsNodeOperationalCertStatusEnumInvalidNodeOpId:
+		return "InvalidNodeOpId"
+	case OperationalCredentialsNodeOperationalCertStatusEnumInvalidNOC:
+		return "InvalidNOC"
+	case OperationalCredentialsNodeOperationalCertStatusEnumMissingCsr:
+		return "MissingCsr"
+	case OperationalCredentialsNodeOperationalCertStatusEnumTableFull:
+		return "TableFull"
+	case OperationalCredentialsNodeOperationalCertStatusEnumInvalidAdminSubject:
+		return "InvalidAdminSubject"
+	case OperationalCredentialsNodeOperationalCertStatusEnumFabricConflict:
+		return "FabricConflict"
+	case OperationalCredentialsNodeOperationalCertStatusEnumLabelConflict:
+		return "LabelConflict"
+	case OperationalCredentialsNodeOperationalCertStatusEnumInvalidFabricIndex:
+		return "InvalidFabricIndex"
+	}
+	return fmt.Sprintf("OperationalCredentialsNodeOperationalCertStatusEnum(%d)", uint8(v))
+}
+
+// OperationalCredentialsFabricDescriptorStruct is the Operational Credentials FabricDescriptorStruct structure.
+type OperationalCredentialsFabricDescriptorStruct struct {
+	RootPublicKey []byte `tlv:"1"`
+	VendorID      uint16 `tlv:"2"`
+	FabricID      uint64 `tlv:"3"`
+	NodeID        uint64 `tlv:"4"`
+	Label         string `tlv:"5"`
+	FabricIndex   uint8  `tlv:"254"`
+}
+
+// OperationalCredentialsNOCStruct is the Operational Credentials NOCStruct structure.
+type OperationalCredentialsNOCStruct struct {
+	NOC         []byte  `tlv:"1"`
+	ICAC        *[]byte `tlv:"2,nullable"`
+	FabricIndex uint8   `tlv:"254"`
+}
+
+// OperationalCredentialsAttributes holds Operational Credentials attribute values. A nil field was not read or
+// holds null.
+type OperationalCredentialsAttributes struct {
+	NOCs                    []OperationalCredentialsNOCStruct
+	Fabrics                 []OperationalCredentialsFabricDescriptorStruct
+	SupportedFabrics        *uint8
+	CommissionedFabrics     *uint8
+	TrustedRootCertificates [][]byte
+	CurrentFabricIndex      *uint8
+}
+
+// Decode stores the value of attribute attrID. Unknown attributes are ignored.
+func (a *OperationalCredentialsAttributes) Decode(attrID uint32, v im.Value) error {
+	switch attrID {
+	case OperationalCredentialsAttrNOCs:
+		return v.Unmarshal(&a.NOCs)
+	case OperationalCredentialsAttrFabrics:
+		return v.Unmarshal(&a.Fabrics)
+	case OperationalCredentialsAttrSupportedFabrics:
+		return v.Unmarshal(&a.SupportedFabrics)
+	case OperationalCredentialsAttrCommissionedFabrics:
+		return v.Unmarshal(&a.CommissionedFabrics)
+	case OperationalCredentialsAttrTrustedRootCertificates:
+		return v.Unmarshal(&a.TrustedRootCertificates)
+	case OperationalCredentialsAttrCurrentFabricIndex:
+		return v.Unmarshal(&a.CurrentFabricIndex)
+	}
+	return nil
+}
+
+// OperationalCredentialsAttestationRequestRequest is the Operational Credentials AttestationRequest command payload.
+type OperationalCredentialsAttestationRequestRequest struct {
+	AttestationNonce []byte `tlv:"0"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsAttestationRequestRequest) ClusterID() uint32 {
+	return OperationalCredentialsClusterID
+}
+
+// CommandID returns OperationalCredentialsCmdAttestationRequest.
+func (OperationalCredentialsAttestationRequestRequest) CommandID() uint32 {
+	return OperationalCredentialsCmdAttestationRequest
+}
+
+// OperationalCredentialsCertificateChainRequestRequest is the Operational Credentials CertificateChainRequest command payload.
+type OperationalCredentialsCertificateChainRequestRequest struct {
+	CertificateType OperationalCredentialsCertificateChainTypeEnum `tlv:"0"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsCertificateChainRequestRequest) ClusterID() uint32 {
+	return OperationalCredentialsClusterID
+}
+
+// CommandID returns OperationalCredentialsCmdCertificateChainRequest.
+func (OperationalCredentialsCertificateChainRequestRequest) CommandID() uint32 {
+	return OperationalCredentialsCmdCertificateChainRequest
+}
+
+// OperationalCredentialsCSRRequestRequest is the Operational Credentials CSRRequest command payload.
+type OperationalCredentialsCSRRequestRequest struct {
+	CSRNonce       []byte `tlv:"0"`
+	IsForUpdateNOC *bool  `tlv:"1,omitempty"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsCSRRequestRequest) ClusterID() uint32 {
+	return OperationalCredentialsClusterID
+}
+
+// CommandID returns OperationalCredentialsCmdCSRRequest.
+func (OperationalCredentialsCSRRequestRequest) CommandID() uint32 {
+	return OperationalCredentialsCmdCSRRequest
+}
+
+// OperationalCredentialsAddNOCRequest is the Operational Credentials AddNOC command payload.
+type OperationalCredentialsAddNOCRequest struct {
+	NOCValue         []byte `tlv:"0"`
+	ICACValue        []byte `tlv:"1,omitempty"`
+	IPKValue         []byte `tlv:"2"`
+	CaseAdminSubject uint64 `tlv:"3"`
+	AdminVendorId    uint16 `tlv:"4"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsAddNOCRequest) ClusterID() uint32 { return OperationalCredentialsClusterID }
+
+// CommandID returns OperationalCredentialsCmdAddNOC.
+func (OperationalCredentialsAddNOCRequest) CommandID() uint32 { return OperationalCredentialsCmdAddNOC }
+
+// OperationalCredentialsUpdateNOCRequest is the Operational Credentials UpdateNOC command payload.
+type OperationalCredentialsUpdateNOCRequest struct {
+	NOCValue  []byte `tlv:"0"`
+	ICACValue []byte `tlv:"1,omitempty"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsUpdateNOCRequest) ClusterID() uint32 {
+	return OperationalCredentialsClusterID
+}
+
+// CommandID returns OperationalCredentialsCmdUpdateNOC.
+func (OperationalCredentialsUpdateNOCRequest) CommandID() uint32 {
+	return OperationalCredentialsCmdUpdateNOC
+}
+
+// OperationalCredentialsUpdateFabricLabelRequest is the Operational Credentials UpdateFabricLabel command payload.
+type OperationalCredentialsUpdateFabricLabelRequest struct {
+	Label string `tlv:"0"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsUpdateFabricLabelRequest) ClusterID() uint32 {
+	return OperationalCredentialsClusterID
+}
+
+// CommandID returns OperationalCredentialsCmdUpdateFabricLabel.
+func (OperationalCredentialsUpdateFabricLabelRequest) CommandID() uint32 {
+	return OperationalCredentialsCmdUpdateFabricLabel
+}
+
+// OperationalCredentialsRemoveFabricRequest is the Operational Credentials RemoveFabric command payload.
+type OperationalCredentialsRemoveFabricRequest struct {
+	FabricIndex uint8 `tlv:"0"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsRemoveFabricRequest) ClusterID() uint32 {
+	return OperationalCredentialsClusterID
+}
+
+// CommandID returns OperationalCredentialsCmdRemoveFabric.
+func (OperationalCredentialsRemoveFabricRequest) CommandID() uint32 {
+	return OperationalCredentialsCmdRemoveFabric
+}
+
+// OperationalCredentialsAddTrustedRootCertificateRequest is the Operational Credentials AddTrustedRootCertificate command payload.
+type OperationalCredentialsAddTrustedRootCertificateRequest struct {
+	RootCACertificate []byte `tlv:"0"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsAddTrustedRootCertificateRequest) ClusterID() uint32 {
+	return OperationalCredentialsClusterID
+}
+
+// CommandID returns OperationalCredentialsCmdAddTrustedRootCertificate.
+func (OperationalCredentialsAddTrustedRootCertificateRequest) CommandID() uint32 {
+	return OperationalCredentialsCmdAddTrustedRootCertificate
+}
+
+// OperationalCredentialsAttestationResponse is the Operational Credentials AttestationResponse command payload sent by the server.
+type OperationalCredentialsAttestationResponse struct {
+	AttestationElements  []byte `tlv:"0"`
+	AttestationSignature []byte `tlv:"1"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsAttestationResponse) ClusterID() uint32 {
+	return OperationalCredentialsClusterID
+}
+
+// CommandID returns OperationalCredentialsCmdAttestationResponse.
+func (OperationalCredentialsAttestationResponse) CommandID() uint32 {
+	return OperationalCredentialsCmdAttestationResponse
+}
+
+// OperationalCredentialsCertificateChainResponse is the Operational Credentials CertificateChainResponse command payload sent by the server.
+type OperationalCredentialsCertificateChainResponse struct {
+	Certificate []byte `tlv:"0"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsCertificateChainResponse) ClusterID() uint32 {
+	return OperationalCredentialsClusterID
+}
+
+// CommandID returns OperationalCredentialsCmdCertificateChainResponse.
+func (OperationalCredentialsCertificateChainResponse) CommandID() uint32 {
+	return OperationalCredentialsCmdCertificateChainResponse
+}
+
+// OperationalCredentialsCSRResponse is the Operational Credentials CSRResponse command payload sent by the server.
+type OperationalCredentialsCSRResponse struct {
+	NOCSRElements        []byte `tlv:"0"`
+	AttestationSignature []byte `tlv:"1"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsCSRResponse) ClusterID() uint32 { return OperationalCredentialsClusterID }
+
+// CommandID returns OperationalCredentialsCmdCSRResponse.
+func (OperationalCredentialsCSRResponse) CommandID() uint32 {
+	return OperationalCredentialsCmdCSRResponse
+}
+
+// OperationalCredentialsNOCResponse is the Operational Credentials NOCResponse command payload sent by the server.
+type OperationalCredentialsNOCResponse struct {
+	StatusCode  OperationalCredentialsNodeOperationalCertStatusEnum `tlv:"0"`
+	FabricIndex *uint8                                              `tlv:"1,omitempty"`
+	DebugText   *string                                             `tlv:"2,omitempty"`
+}
+
+// ClusterID returns OperationalCredentialsClusterID.
+func (OperationalCredentialsNOCResponse) ClusterID() uint32 { return OperationalCredentialsClusterID }
+
+// CommandID returns OperationalCredentialsCmdNOCResponse.
+func (OperationalCredentialsNOCResponse) CommandID() uint32 {
+	return OperationalCredentialsCmdNOCResponse
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	devicesCmd.AddCommand(devicesRemoveCmd)
+	rootCmd.AddCommand(devicesCmd)
+
+	devicesCmd.PersistentFlags().String("state-dir", "", "state directory (defaults to XDG state home)")
+	devicesCmd.PersistentFlags().Duration("timeout", 10*time.Second, "command timeout")
+	devicesRemoveCmd.Flags().Bool("force", false, "drop the local records even if the device cannot remove the fabric, e.g. when it was reset or is gone for good")
+	devicesRemoveCmd.Flags().Bool("all-bridged", false, "allow a bridged device, removing its hub and every device behind it")
+	devicesRemoveCmd.Flags().Uint8(fabricFlag, commission.DefaultFabricIndex, "local fabric index the node is recorded on")
+}
+
+var devicesCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "devices",
+	Short: "Manage commissioned devices.",
+	Long:  "Manage commissioned devices.",
+}
+
+var devicesRemoveCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "remove <unique id|node id>",
+	Short: "Decommission a device by removing our fabric from it.",
+	Long: "Decommission a device by invoking Operational Credentials RemoveFabric on the node, " +
+		"over a CASE session authenticated with the local fabric credentials, " +
+		"then drop its cached sessions and its records in the registry and the commissioning state. " +
+		"If the node cannot be reached or refuses, nothing is changed; " +
+		"--force drops the local records anyway and leaves our fabric on a node that is still around. " +
+		"A bridged device is removed through its hub, which removes every device behind the hub, " +
+		"so its unique ID is accepted only with --all-bridged.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			return err
+		}
+		allBridged, err := cmd.Flags().GetBool("all-bridged")
+		if err != nil {
+			return err
+		}
+		fabric, err := cmd.Flags().GetUint8(fabricFlag)
+		if err != nil {
+			return err
+		}
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+		statePath := commissionStatePath(cmd)
+		registryStore := store.NewJSONFileStore(filepath.Join(filepath.Dir(statePath), defaultRegistryFilename))
+		ctrl, err := operationalController(cmd, fabric)
+		if err != nil {
+			return err
+		}
+		service := usecase.NewDeviceService(ctrl, registryStore, store.NewJSONFileStore(statePath))
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		result, err := service.Remove(ctx, args[0], usecase.RemoveOptions{
+			Force:       force,
+			FabricIndex: fabric,
+			AllBridged:  allBridged,
+		})
+		if err != nil {
+			return err
+		}
+		if result.RemoteErr != nil {
+			log.Warnf("Node 0x%016X did not remove the fabric: %v", result.NodeID, result.RemoteErr)
+		}
+		outputf("Removed node 0x%016X", result.NodeID)
+		if result.FabricIndex != 0 && result.RemoteErr == nil {
+			outputf(" (fabric index %d)", result.FabricIndex)
+		}
+		outputf("\n")
+		if len(result.Devices) != 0 {
+			outputf("Removed registry devices: %s\n", strings.Join(result.Devices, ", "))
+		}
+		if result.Recorded {
+			outputf("Removed from local fabric %d\n", fabric)
+		} else {
+			log.Warnf("Node 0x%016X is not recorded on local fabric %d", result.NodeID, fabric)
+		}
+		return nil
+	},
+}
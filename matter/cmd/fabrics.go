@@ -0,0 +1,260 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/YashubuStudio/go-matter-pack/internal/app"
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/matter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultCommissionFilename = "commission.json"
+	fabricFlag                = "fabric"
+)
+
+func init() {
+	fabricsCmd.AddCommand(fabricsListCmd)
+	fabricsCmd.AddCommand(fabricsShowCmd)
+	fabricsCmd.AddCommand(fabricsRemoveCmd)
+	rootCmd.AddCommand(fabricsCmd)
+
+	fabricsCmd.PersistentFlags().String("state-dir", "", "state directory (defaults to XDG state home)")
+}
+
+var fabricsCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "fabrics",
+	Short: "Manage the fabrics recorded in the local state.",
+	Long:  "Manage the fabrics recorded in the local state. Each fabric keeps its own credentials, commissioned nodes and results.",
+}
+
+var fabricsListCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "list",
+	Short: "List local fabrics.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		format, err := NewFormatFromString(viper.GetString(FormatParamStr))
+		if err != nil {
+			return err
+		}
+		state, err := loadCommissionState(cmd)
+		if err != nil {
+			return err
+		}
+		columns := []string{"INDEX", "FABRIC ID", "NODES", "COMMISSIONED", "BUNDLE", "CREATED"}
+		rows := make([][]string, 0, len(state.Fabrics))
+		for _, fabric := range state.Fabrics {
+			commissioned := 0
+			for _, node := range fabric.Nodes {
+				if node.Result != nil {
+					commissioned++
+				}
+			}
+			rows = append(rows, []string{
+				strconv.Itoa(int(fabric.Index)),
+				formatFabricID(fabric.FabricID),
+				strconv.Itoa(len(fabric.Nodes)),
+				strconv.Itoa(commissioned),
+				strconv.FormatBool(fabric.Bundle != nil),
+				formatTime(fabric.CreatedAt),
+			})
+		}
+		if state.Fabrics == nil {
+			state.Fabrics = []commission.Fabric{}
+		}
+		return printRecords(format, columns, rows, state.Fabrics)
+	},
+}
+
+var fabricsShowCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "show <index>",
+	Short: "Show the nodes commissioned on a local fabric.",
+	Long:  "Show the nodes commissioned on a local fabric together with its fabric ID and the compressed fabric ID derived from the root public key, which names the fabric in operational discovery.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := NewFormatFromString(viper.GetString(FormatParamStr))
+		if err != nil {
+			return err
+		}
+		index, err := parseFabricIndex(args[0])
+		if err != nil {
+			return err
+		}
+		state, err := loadCommissionState(cmd)
+		if err != nil {
+			return err
+		}
+		fabric := state.Fabric(index)
+		if fabric == nil {
+			return fmt.Errorf("fabric %d not found", index)
+		}
+		if format == FormatJSON {
+			return printRecords(format, nil, nil, fabric)
+		}
+		if format == FormatTable {
+			outputf("Fabric %d (fabric ID %s, compressed fabric ID %s)\n",
+				fabric.Index, formatFabricID(fabric.FabricID), formatFabricID(fabric.CompressedFabricID))
+		}
+		columns := []string{"NODE ID", "VENDOR ID", "PRODUCT ID", "DEVICE", "COMMISSIONED AT", "CHECKPOINT", "PAYLOAD"}
+		rows := make([][]string, 0, len(fabric.Nodes))
+		for _, node := range fabric.Nodes {
+			row := []string{fmt.Sprintf("0x%016X", node.NodeID), "", "", "", "", "", ""}
+			if node.Payload != nil {
+				row[1] = fmt.Sprintf("0x%04X", node.Payload.VendorID)
+				row[2] = fmt.Sprintf("0x%04X", node.Payload.ProductID)
+				row[6] = node.Payload.QRCode
+				if row[6] == "" {
+					row[6] = node.Payload.PairingCode
+				}
+			}
+			if node.Checkpoint != nil {
+				row[5] = node.Checkpoint.Stage.String()
+			}
+			if node.Result != nil {
+				row[1] = fmt.Sprintf("0x%04X", node.Result.VendorID)
+				row[2] = fmt.Sprintf("0x%04X", node.Result.ProductID)
+				row[3] = node.Result.Device
+				row[4] = formatTime(node.Result.CommissionedAt)
+			}
+			rows = append(rows, row)
+		}
+		return printRecords(format, columns, rows, fabric)
+	},
+}
+
+var fabricsRemoveCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "remove <index>",
+	Short: "Remove a fabric and its nodes from the local state.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		index, err := parseFabricIndex(args[0])
+		if err != nil {
+			return err
+		}
+		_, err = commission.RemoveFabric(context.Background(), commissionStateStore(cmd), index)
+		return err
+	},
+}
+
+// commissionStatePath returns the commissioning state path under the
+// --state-dir of cmd.
+func commissionStatePath(cmd *cobra.Command) string {
+	stateDir, _ := cmd.Flags().GetString("state-dir")
+	stateDir = strings.TrimSpace(stateDir)
+	if stateDir == "" {
+		stateDir = app.StateDir(defaultAppName)
+	}
+	return filepath.Join(stateDir, defaultCommissionFilename)
+}
+
+// commissionStateStore returns the commissioning state store under the
+// --state-dir of cmd.
+func commissionStateStore(cmd *cobra.Command) store.Store {
+	return store.NewJSONFileStore(commissionStatePath(cmd))
+}
+
+// commandFabricIndex returns the local fabric selected by the --fabric flag
+// of cmd, or commission.DefaultFabricIndex.
+func commandFabricIndex(cmd *cobra.Command) uint8 {
+	index, err := cmd.Flags().GetUint8(fabricFlag)
+	if err != nil || index == 0 {
+		return commission.DefaultFabricIndex
+	}
+	return index
+}
+
+func loadCommissionState(cmd *cobra.Command) (commission.State, error) {
+	return commission.LoadState(context.Background(), commissionStateStore(cmd))
+}
+
+// operationalController returns the controller of the commands that reach
+// nodes of the local fabric with index over CASE, authenticating with the
+// fabric credentials. With mDNS enabled nodes are resolved through
+// operational discovery with the commissioner's discoverer; otherwise they
+// are reached at the addresses recorded when they were commissioned.
+func operationalController(cmd *cobra.Command, index uint8) (matterctrl.Controller, error) {
+	state, err := loadCommissionState(cmd)
+	if err != nil {
+		return nil, err
+	}
+	fabric := state.Fabric(index)
+	if fabric == nil || fabric.Bundle == nil {
+		return nil, fmt.Errorf("%w: fabric %d; commission a device or import a bundle first", commission.ErrNoCredentials, index)
+	}
+	credentials, err := fabric.Bundle.Credentials()
+	if err != nil {
+		return nil, err
+	}
+	establisher := matter.NewCASEEstablisher(credentials)
+	if viper.GetBool(EnableMDNSParamStr) && SharedCommissioner() != nil && fabric.CompressedFabricID != 0 {
+		resolver := matterctrl.NewOperationalResolver(SharedCommissioner().Discoverer(), fabric.CompressedFabricID)
+		ctrl := matterctrl.NewOperationalController(establisher, resolver, credentials.FabricID)
+		return matterctrl.NewResolvingController(ctrl, resolver), nil
+	}
+	return matterctrl.NewOperationalController(establisher, recordedAddresses(fabric), credentials.FabricID), nil
+}
+
+// recordedAddresses returns the first operational address recorded for
+// each commissioned node of fabric.
+func recordedAddresses(fabric *commission.Fabric) matterctrl.AddressBook {
+	book := matterctrl.AddressBook{}
+	for _, node := range fabric.Nodes {
+		if node.Result == nil || len(node.Result.Addresses) == 0 {
+			continue
+		}
+		addr, err := net.ResolveUDPAddr("udp", node.Result.Addresses[0])
+		if err != nil {
+			log.Warnf("Ignoring the recorded address of node 0x%016X: %v", node.NodeID, err)
+			continue
+		}
+		book[node.NodeID] = addr
+	}
+	return book
+}
+
+func parseFabricIndex(s string) (uint8, error) {
+	index, err := strconv.ParseUint(strings.TrimSpace(s), 10, 8)
+	if err != nil || index == 0 {
+		return 0, fmt.Errorf("invalid fabric index: %s", s)
+	}
+	return uint8(index), nil
+}
+
+func formatFabricID(id uint64) string {
+	if id == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("0x%016X", id)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -15,8 +15,12 @@
 package cmd
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
+	"text/tabwriter"
 )
 
 // Format represents the output format.
@@ -67,3 +71,35 @@ func (f Format) String() string {
 	}
 	return "unknown"
 }
+
+// printRecords prints rows under columns as a table or CSV, or objects as
+// indented JSON.
+func printRecords(format Format, columns []string, rows [][]string, objects any) error {
+	switch format {
+	case FormatJSON:
+		b, err := json.MarshalIndent(objects, "", "  ")
+		if err != nil {
+			return err
+		}
+		outputf("%s\n", string(b))
+	case FormatCSV:
+		w := csv.NewWriter(os.Stdout)
+		if err := w.Write(columns); err != nil {
+			return err
+		}
+		if err := w.WriteAll(rows); err != nil {
+			return err
+		}
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+		outputRow := func(cols []string) {
+			_, _ = w.Write([]byte(strings.Join(cols, "\t") + "\n"))
+		}
+		outputRow(columns)
+		for _, row := range rows {
+			outputRow(row)
+		}
+		return w.Flush()
+	}
+	return nil
+}
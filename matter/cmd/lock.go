@@ -0,0 +1,171 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/app"
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/internal/usecase"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const defaultLockHistoryFilename = "lock_history.json"
+
+func init() {
+	lockCmd.AddCommand(lockHistoryCmd)
+	lockCmd.AddCommand(lockWatchCmd)
+	rootCmd.AddCommand(lockCmd)
+
+	lockCmd.PersistentFlags().String("state-dir", "", "state directory (defaults to XDG state home)")
+	lockHistoryCmd.Flags().Duration("timeout", 10*time.Second, "command timeout")
+	lockHistoryCmd.Flags().Bool("sync", false, "read the events newer than the recorded ones from the lock first")
+}
+
+var lockCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "lock",
+	Short: "Follow the events of bridged Door Lock devices.",
+	Long:  "Follow the events of bridged Door Lock devices.",
+}
+
+var lockHistoryCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "history <unique id>",
+	Short: "Show the recorded lock events of a device.",
+	Long: "Show the lock operations, operation errors and alarms recorded for a device by unique ID. " +
+		"With --sync the events the lock logged since the last recorded one are read and recorded first.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := NewFormatFromString(viper.GetString(FormatParamStr))
+		if err != nil {
+			return err
+		}
+		sync, err := cmd.Flags().GetBool("sync")
+		if err != nil {
+			return err
+		}
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+		service, err := newLockHistoryService(cmd, sync)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if sync {
+			if _, err := service.Sync(ctx, args[0]); err != nil {
+				return err
+			}
+		}
+		events, err := service.History(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		columns := []string{"EVENT", "TIME", "KIND", "OPERATION", "SOURCE", "ERROR", "USER"}
+		rows := make([][]string, 0, len(events))
+		for _, ev := range events {
+			rows = append(rows, lockEventRow(ev))
+		}
+		return printRecords(format, columns, rows, events)
+	},
+}
+
+var lockWatchCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "watch <unique id>",
+	Short: "Record and print lock events as they occur.",
+	Long: "Subscribe to the lock events of a device by unique ID, catching up from the last recorded event, " +
+		"and record and print each one as a JSON line until interrupted.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service, err := newLockHistoryService(cmd, true)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		var printErr error
+		err = service.Watch(ctx, args[0], func(ev store.LockEvent) {
+			b, err := json.Marshal(ev)
+			if err != nil {
+				printErr = err
+				stop()
+				return
+			}
+			outputf("%s\n", string(b))
+		})
+		if printErr != nil {
+			return printErr
+		}
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
+	},
+}
+
+// newLockHistoryService returns the lock history service of the state
+// directory. The operational controller is set up only when reachNodes is
+// set, so the recorded history is shown without fabric credentials.
+func newLockHistoryService(cmd *cobra.Command, reachNodes bool) (*usecase.LockHistoryService, error) {
+	stateDir, err := cmd.Flags().GetString("state-dir")
+	if err != nil {
+		return nil, err
+	}
+	stateDir = strings.TrimSpace(stateDir)
+	if stateDir == "" {
+		stateDir = app.StateDir(defaultAppName)
+	}
+	var ctrl matterctrl.Controller
+	if reachNodes {
+		ctrl, err = operationalController(cmd, commission.DefaultFabricIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+	registryStore := store.NewJSONFileStore(filepath.Join(stateDir, defaultRegistryFilename))
+	historyStore := store.NewJSONFileStore(filepath.Join(stateDir, defaultLockHistoryFilename))
+	return usecase.NewLockHistoryService(ctrl, registryStore, historyStore), nil
+}
+
+func lockEventRow(ev store.LockEvent) []string {
+	row := []string{strconv.FormatUint(ev.EventNumber, 10), "-", ev.Kind, ev.OperationType, ev.OperationSource, ev.OperationError, ""}
+	switch {
+	case ev.Timestamp != nil:
+		row[1] = ev.Timestamp.Local().Format(time.RFC3339)
+	case ev.SystemTimeMs != 0:
+		row[1] = fmt.Sprintf("+%s", time.Duration(ev.SystemTimeMs)*time.Millisecond)
+	}
+	if ev.AlarmCode != "" {
+		row[5] = ev.AlarmCode
+	}
+	if ev.UserIndex != nil {
+		row[6] = strconv.FormatUint(uint64(*ev.UserIndex), 10)
+	}
+	return row
+}
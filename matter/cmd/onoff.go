@@ -16,13 +16,12 @@ package cmd
 
 import (
 	"context"
-	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/YashubuStudio/go-matter-pack/internal/app"
-	"github.com/YashubuStudio/go-matter-pack/internal/matterctrl"
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
 	"github.com/YashubuStudio/go-matter-pack/internal/store"
 	"github.com/YashubuStudio/go-matter-pack/internal/usecase"
 	"github.com/spf13/cobra"
@@ -128,9 +127,9 @@ func newOnOffService(cmd *cobra.Command) (*usecase.OnOffService, context.Context
 	registryPath := filepath.Join(stateDir, defaultRegistryFilename)
 	stateStore := store.NewJSONFileStore(registryPath)
 
-	ctrl := matterctrl.NewNoopController()
-	if ctrl == nil {
-		return nil, nil, nil, fmt.Errorf("failed to create controller")
+	ctrl, err := operationalController(cmd, commission.DefaultFabricIndex)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	service := usecase.NewOnOffService(ctrl, stateStore)
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
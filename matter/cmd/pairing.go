@@ -16,9 +16,12 @@ package cmd
 
 import (
 	"context"
-	"time"
+	"fmt"
+	"strconv"
 
 	"github.com/cybergarage/go-logger/log"
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/matter"
 	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +30,12 @@ func init() {
 	pairingCmd.AddCommand(pairingCodeCmd)
 	pairingCmd.AddCommand(pairingCodeWifiCmd)
 	rootCmd.AddCommand(pairingCmd)
+
+	pairingCmd.PersistentFlags().String(threadDatasetFlag, "", "Thread operational dataset (hex) to provision")
+	pairingCmd.PersistentFlags().Duration("timeout", matter.DefaultCommissioningTimeout, "time allowed for discovery and the whole commissioning flow")
+	pairingCmd.PersistentFlags().String("state-dir", "", "state directory (defaults to XDG state home)")
+	pairingCmd.PersistentFlags().Uint8(fabricFlag, commission.DefaultFabricIndex, "local fabric to commission the device onto")
+	pairingCmd.PersistentFlags().Bool(allowUncertifiedFlag, false, "accept devices failing attestation trust checks (development devices)")
 }
 
 var pairingCmd = &cobra.Command{ // nolint:exhaustruct
@@ -36,25 +45,45 @@ var pairingCmd = &cobra.Command{ // nolint:exhaustruct
 }
 
 var pairingCodeCmd = &cobra.Command{ // nolint:exhaustruct
-	Use:   "code <node ID> <pairing code>",
-	Short: "Pair using node ID and pairing code.",
-	Args:  cobra.ExactArgs(2),
+	Use:         "code <node ID> <pairing code>",
+	Short:       "Pair using node ID and pairing code.",
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{commissionAnnotation: ""},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		nodeID := args[0]
+		nodeID, err := parseNodeID(args[0])
+		if err != nil {
+			return err
+		}
 		passcode := args[1]
 
-		log.Infof("Pairing nodeID=%s, passcode=%s", nodeID, passcode)
+		log.Infof("Pairing nodeID=%d", nodeID)
 
 		pairingCode, err := encoding.NewPairingCodeFromString(passcode)
 		if err != nil {
 			return err
 		}
 
+		opts, err := threadDatasetOptions(cmd)
+		if err != nil {
+			return err
+		}
+		attestationOpts, err := attestationOptions(cmd)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, attestationOpts...)
+		opts = append(opts, matter.WithNodeID(nodeID))
+
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+
 		cmr := SharedCommissioner()
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
-		cme, err := cmr.Commission(ctx, pairingCode)
+		cme, err := cmr.Commission(ctx, pairingCode, opts...)
 		if err != nil {
 			log.Error(err)
 			return err
@@ -67,27 +96,46 @@ var pairingCodeCmd = &cobra.Command{ // nolint:exhaustruct
 }
 
 var pairingCodeWifiCmd = &cobra.Command{ // nolint:exhaustruct
-	Use:   "code-wifi <node ID> <pairing code> <WIFI SSID> <WIFI password>",
-	Short: "Pair using node ID, pairing code, and WiFi credentials.",
-	Args:  cobra.ExactArgs(4),
+	Use:         "code-wifi <node ID> <pairing code> <WIFI SSID> <WIFI password>",
+	Short:       "Pair using node ID, pairing code, and WiFi credentials.",
+	Args:        cobra.ExactArgs(4),
+	Annotations: map[string]string{commissionAnnotation: ""},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		nodeID := args[0]
+		nodeID, err := parseNodeID(args[0])
+		if err != nil {
+			return err
+		}
 		passcode := args[1]
 		wifiSSID := args[2]
 		wifiPasswd := args[3]
 
-		log.Infof("Pairing nodeID=%s, passcode=%s, ssid=%s, passwd=%s", nodeID, passcode, wifiSSID, wifiPasswd)
+		log.Infof("Pairing nodeID=%d, ssid=%q", nodeID, wifiSSID)
+
+		if cmd.Flags().Changed(threadDatasetFlag) {
+			return fmt.Errorf("--%s cannot be combined with Wi-Fi credentials", threadDatasetFlag)
+		}
 
 		pairingCode, err := encoding.NewPairingCodeFromString(passcode)
 		if err != nil {
 			return err
 		}
 
+		opts, err := attestationOptions(cmd)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, matter.WithNodeID(nodeID), matter.WithWiFiCredentials(wifiSSID, []byte(wifiPasswd)))
+
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+
 		cmr := SharedCommissioner()
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
-		cme, err := cmr.Commission(ctx, pairingCode)
+		cme, err := cmr.Commission(ctx, pairingCode, opts...)
 		if err != nil {
 			log.Error(err)
 			return err
@@ -98,3 +146,12 @@ var pairingCodeWifiCmd = &cobra.Command{ // nolint:exhaustruct
 		return nil
 	},
 }
+
+// parseNodeID parses a node ID given as a decimal or 0x-prefixed hexadecimal number.
+func parseNodeID(s string) (uint64, error) {
+	nodeID, err := strconv.ParseUint(s, 0, 64)
+	if err != nil || nodeID == 0 {
+		return 0, fmt.Errorf("invalid node ID %q", s)
+	}
+	return nodeID, nil
+}
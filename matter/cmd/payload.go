@@ -0,0 +1,439 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/qrcode"
+	"github.com/YashubuStudio/go-matter-pack/matter/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	payloadCmd.AddCommand(payloadGenerateCmd)
+	payloadCmd.AddCommand(payloadShowCmd)
+	payloadCmd.AddCommand(payloadInspectCmd)
+	rootCmd.AddCommand(payloadCmd)
+
+	payloadGenerateCmd.Flags().String("vendor-id", "0xFFF1", "vendor ID")
+	payloadGenerateCmd.Flags().String("product-id", "0x8000", "product ID")
+	payloadGenerateCmd.Flags().String("flow", types.CommissioningFlowStandard.String(), "commissioning flow (standard, user-action, custom)")
+	payloadGenerateCmd.Flags().String("capabilities", "ble", "discovery capabilities (comma separated soft-ap, ble, on-network, wifi-paf)")
+	payloadGenerateCmd.Flags().Int("discriminator", -1, "12-bit discriminator (random when unset)")
+	payloadGenerateCmd.Flags().Int64("passcode", -1, "setup passcode (random when unset)")
+
+	payloadShowCmd.Flags().String("qr-out", "", "write the QR code to a .png or .svg file instead of the terminal")
+	payloadShowCmd.Flags().String("qr-level", qrcode.LevelM.String(), "QR code error correction level (L, M, Q, H)")
+	payloadShowCmd.Flags().Int("qr-scale", 8, "pixels per QR code module in image output")
+	payloadShowCmd.Flags().Bool("invert", false, "draw the QR code for a light terminal background")
+}
+
+var payloadCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "payload",
+	Short: "Work with onboarding payloads.",
+	Long:  "Generate and decode onboarding payloads (QR codes and manual pairing codes).",
+}
+
+// generatedPayload is the output of payload generate.
+type generatedPayload struct {
+	VendorID              uint16 `json:"vendor_id"`
+	ProductID             uint16 `json:"product_id"`
+	Flow                  string `json:"flow"`
+	DiscoveryCapabilities string `json:"discovery_capabilities"`
+	Discriminator         uint16 `json:"discriminator"`
+	Passcode              uint32 `json:"passcode"`
+	ManualCode            string `json:"manual_code"`
+	QRCode                string `json:"qr_code"`
+}
+
+var payloadGenerateCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "generate",
+	Short: "Generate a QR code payload and manual pairing code.",
+	Long: "Generate the onboarding payloads of a device from its vendor ID, product ID, commissioning flow, " +
+		"discriminator and passcode. The discriminator and passcode are drawn from a secure random source " +
+		"when they are not given; passcodes forbidden by the specification are rejected.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		format, err := NewFormatFromString(viper.GetString(FormatParamStr))
+		if err != nil {
+			return err
+		}
+		vendorID, err := parseUint16Flag(cmd, "vendor-id")
+		if err != nil {
+			return err
+		}
+		productID, err := parseUint16Flag(cmd, "product-id")
+		if err != nil {
+			return err
+		}
+		flowName, err := cmd.Flags().GetString("flow")
+		if err != nil {
+			return err
+		}
+		flow, err := types.NewCommissioningFlowFromString(flowName)
+		if err != nil {
+			return err
+		}
+		capabilitiesName, err := cmd.Flags().GetString("capabilities")
+		if err != nil {
+			return err
+		}
+		capabilities, err := types.NewDiscoveryCapabilitiesFromString(capabilitiesName)
+		if err != nil {
+			return err
+		}
+		discriminator, err := generateDiscriminator(cmd)
+		if err != nil {
+			return err
+		}
+		passcode, err := generatePasscode(cmd)
+		if err != nil {
+			return err
+		}
+
+		qrPayload, err := encoding.NewQRPayload(flow, encoding.VendorID(vendorID), encoding.ProductID(productID), capabilities, discriminator, passcode)
+		if err != nil {
+			return err
+		}
+		pairingCode, err := encoding.NewPairingCode(flow, encoding.VendorID(vendorID), encoding.ProductID(productID), discriminator, passcode)
+		if err != nil {
+			return err
+		}
+
+		generated := generatedPayload{
+			VendorID:              vendorID,
+			ProductID:             productID,
+			Flow:                  flow.String(),
+			DiscoveryCapabilities: capabilities.String(),
+			Discriminator:         uint16(discriminator),
+			Passcode:              passcode,
+			ManualCode:            pairingCode.String(),
+			QRCode:                qrPayload.String(),
+		}
+		if format == FormatTable {
+			outputf("Vendor ID:     0x%04X\n", generated.VendorID)
+			outputf("Product ID:    0x%04X\n", generated.ProductID)
+			outputf("Flow:          %s\n", generated.Flow)
+			outputf("Capabilities:  %s\n", generated.DiscoveryCapabilities)
+			outputf("Discriminator: %d\n", generated.Discriminator)
+			outputf("Passcode:      %08d\n", generated.Passcode)
+			outputf("Manual code:   %s\n", generated.ManualCode)
+			outputf("QR payload:    %s\n", generated.QRCode)
+			return nil
+		}
+		columns := []string{"VENDOR_ID", "PRODUCT_ID", "FLOW", "CAPABILITIES", "DISCRIMINATOR", "PASSCODE", "MANUAL_CODE", "QR_CODE"}
+		rows := [][]string{{
+			fmt.Sprintf("0x%04X", generated.VendorID),
+			fmt.Sprintf("0x%04X", generated.ProductID),
+			generated.Flow,
+			generated.DiscoveryCapabilities,
+			strconv.Itoa(int(generated.Discriminator)),
+			fmt.Sprintf("%08d", generated.Passcode),
+			generated.ManualCode,
+			generated.QRCode,
+		}}
+		return printRecords(format, columns, rows, generated)
+	},
+}
+
+// parseUint16Flag parses a decimal or 0x-prefixed hexadecimal flag value.
+func parseUint16Flag(cmd *cobra.Command, name string) (uint16, error) {
+	s, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(s, 0, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", name, s)
+	}
+	return uint16(v), nil
+}
+
+func generateDiscriminator(cmd *cobra.Command) (encoding.Discriminator, error) {
+	v, err := cmd.Flags().GetInt("discriminator")
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 {
+		return encoding.GenerateDiscriminator(nil)
+	}
+	if int(encoding.MaxDiscriminator) < v {
+		return 0, fmt.Errorf("discriminator must be at most %d", encoding.MaxDiscriminator)
+	}
+	return encoding.Discriminator(v), nil
+}
+
+func generatePasscode(cmd *cobra.Command) (encoding.Passcode, error) {
+	v, err := cmd.Flags().GetInt64("passcode")
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 {
+		return encoding.GeneratePasscode(nil)
+	}
+	if int64(encoding.MaxPasscode) < v {
+		return 0, fmt.Errorf("passcode must be at most %d", encoding.MaxPasscode)
+	}
+	passcode := encoding.Passcode(v)
+	if err := encoding.ValidatePasscode(passcode); err != nil {
+		return 0, err
+	}
+	return passcode, nil
+}
+
+var payloadShowCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "show <payload>",
+	Short: "Show an onboarding payload as a scannable QR code.",
+	Long: "Show the QR code payload and manual pairing code of an \"MT:\" onboarding payload and render it as a QR code, " +
+		"in the terminal or, with --qr-out, as a PNG or SVG image.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		payload := strings.TrimSpace(args[0])
+		payloads, err := encoding.NewQRPayloadsFromString(payload)
+		if err != nil {
+			return err
+		}
+		out, err := cmd.Flags().GetString("qr-out")
+		if err != nil {
+			return err
+		}
+		levelName, err := cmd.Flags().GetString("qr-level")
+		if err != nil {
+			return err
+		}
+		level, err := qrcode.NewLevelFromString(levelName)
+		if err != nil {
+			return err
+		}
+		scale, err := cmd.Flags().GetInt("qr-scale")
+		if err != nil {
+			return err
+		}
+		invert, err := cmd.Flags().GetBool("invert")
+		if err != nil {
+			return err
+		}
+		code, err := qrcode.Encode(payload, level)
+		if err != nil {
+			return err
+		}
+
+		outputf("QR payload:  %s\n", payload)
+		for _, p := range payloads {
+			pairingCode, err := encoding.NewPairingCode(p.CommissioningFlow(), p.VendorID(), p.ProductID(), p.Discriminator(), p.Passcode())
+			if err != nil {
+				return err
+			}
+			outputf("Manual code: %s\n", pairingCode.String())
+		}
+		if out == "" {
+			return code.WriteTerminal(os.Stdout, invert)
+		}
+		if err := writeQRCode(code, out, scale); err != nil {
+			return err
+		}
+		outputf("QR code (version %d-%s) written to %s\n", code.Version(), code.Level(), out)
+		return nil
+	},
+}
+
+// writeQRCode writes code to path as a PNG or SVG image, chosen by the
+// file extension.
+func writeQRCode(code *qrcode.Code, path string, scale int) error {
+	var buf bytes.Buffer
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		if err := code.WritePNG(&buf, scale); err != nil {
+			return err
+		}
+	case ".svg":
+		if err := code.WriteSVG(&buf, scale); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported QR code image format: %s (use .png or .svg)", path)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// payloadInspection describes a decoded onboarding payload.
+type payloadInspection struct {
+	Payload               string                `json:"payload"`
+	Format                string                `json:"format"`
+	Version               uint8                 `json:"version"`
+	VendorID              uint16                `json:"vendor_id"`
+	ProductID             uint16                `json:"product_id"`
+	Flow                  string                `json:"flow"`
+	DiscoveryCapabilities string                `json:"discovery_capabilities,omitempty"`
+	Discriminator         uint16                `json:"discriminator"`
+	DiscriminatorLength   string                `json:"discriminator_length"`
+	ShortDiscriminator    uint8                 `json:"short_discriminator"`
+	Passcode              uint32                `json:"passcode"`
+	PasscodeValid         bool                  `json:"passcode_valid"`
+	PasscodeError         string                `json:"passcode_error,omitempty"`
+	Extensions            []extensionInspection `json:"extensions,omitempty"`
+}
+
+// extensionInspection describes a QR code extension data element.
+type extensionInspection struct {
+	Tag   uint8  `json:"tag"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+var payloadInspectCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "inspect <code>",
+	Short: "Decode and validate an onboarding payload.",
+	Long: "Decode a QR code payload (\"MT:\", including concatenated payloads) or an 11/21-digit manual pairing code, " +
+		"validating its base38 encoding or Verhoeff check digit, and print its fields, passcode validity and TLV extensions.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := NewFormatFromString(viper.GetString(FormatParamStr))
+		if err != nil {
+			return err
+		}
+		inspections, err := inspectPayload(strings.TrimSpace(args[0]))
+		if err != nil {
+			return err
+		}
+
+		columns := []string{"FIELD", "VALUE"}
+		rows := [][]string{}
+		for n, in := range inspections {
+			prefix := ""
+			if 1 < len(inspections) {
+				prefix = fmt.Sprintf("[%d] ", n+1)
+			}
+			rows = append(rows, inspectionRows(prefix, in)...)
+		}
+		var objects any = inspections
+		if len(inspections) == 1 {
+			objects = inspections[0]
+		}
+		return printRecords(format, columns, rows, objects)
+	},
+}
+
+// inspectPayload decodes a QR code payload, which may hold concatenated
+// payloads, or a manual pairing code.
+func inspectPayload(code string) ([]payloadInspection, error) {
+	if strings.HasPrefix(code, encoding.QRPayloadPrefix) {
+		payloads, err := encoding.NewQRPayloadsFromString(code)
+		if err != nil {
+			return nil, err
+		}
+		inspections := make([]payloadInspection, 0, len(payloads))
+		for _, p := range payloads {
+			in := newPayloadInspection(p, "qr")
+			in.Payload = p.String()
+			in.DiscoveryCapabilities = p.DiscoveryCapabilities().String()
+			in.DiscriminatorLength = "long"
+			for _, ext := range p.Extensions() {
+				in.Extensions = append(in.Extensions, extensionInspection{
+					Tag:   ext.Tag,
+					Name:  ext.Name(),
+					Type:  extensionType(ext),
+					Value: ext.String(),
+				})
+			}
+			inspections = append(inspections, in)
+		}
+		return inspections, nil
+	}
+
+	pairingCode, err := encoding.NewPairingCodeFromString(code)
+	if err != nil {
+		return nil, err
+	}
+	in := newPayloadInspection(pairingCode, "manual-11")
+	if pairingCode.VendorID() != 0 || pairingCode.ProductID() != 0 {
+		in.Format = "manual-21"
+	}
+	in.DiscriminatorLength = "short"
+	return []payloadInspection{in}, nil
+}
+
+func newPayloadInspection(p encoding.OnboardingPayload, format string) payloadInspection {
+	in := payloadInspection{
+		Payload:            p.String(),
+		Format:             format,
+		Version:            p.Version(),
+		VendorID:           uint16(p.VendorID()),
+		ProductID:          uint16(p.ProductID()),
+		Flow:               p.CommissioningFlow().String(),
+		Discriminator:      uint16(p.Discriminator()),
+		ShortDiscriminator: uint8(p.Discriminator().Short()),
+		Passcode:           p.Passcode(),
+		PasscodeValid:      true,
+	}
+	if err := encoding.ValidatePasscode(p.Passcode()); err != nil {
+		in.PasscodeValid = false
+		in.PasscodeError = err.Error()
+	}
+	return in
+}
+
+func extensionType(ext encoding.QRExtension) string {
+	switch ext.Value.(type) {
+	case string:
+		return "string"
+	case []byte:
+		return "bytes"
+	case int64:
+		return "int"
+	default:
+		return "uint"
+	}
+}
+
+func inspectionRows(prefix string, in payloadInspection) [][]string {
+	passcode := "valid"
+	if !in.PasscodeValid {
+		passcode = "invalid: " + in.PasscodeError
+	}
+	rows := [][]string{
+		{prefix + "Payload", in.Payload},
+		{prefix + "Format", in.Format},
+		{prefix + "Version", strconv.Itoa(int(in.Version))},
+		{prefix + "Vendor ID", fmt.Sprintf("0x%04X (%d)", in.VendorID, in.VendorID)},
+		{prefix + "Product ID", fmt.Sprintf("0x%04X (%d)", in.ProductID, in.ProductID)},
+		{prefix + "Flow", in.Flow},
+	}
+	if in.DiscoveryCapabilities != "" {
+		rows = append(rows, []string{prefix + "Discovery capabilities", in.DiscoveryCapabilities})
+	}
+	if in.DiscriminatorLength == "long" {
+		rows = append(rows, []string{prefix + "Discriminator", fmt.Sprintf("%d (long, short %d)", in.Discriminator, in.ShortDiscriminator)})
+	} else {
+		rows = append(rows, []string{prefix + "Discriminator", fmt.Sprintf("%d (short)", in.ShortDiscriminator)})
+	}
+	rows = append(rows,
+		[]string{prefix + "Passcode", fmt.Sprintf("%08d", in.Passcode)},
+		[]string{prefix + "Passcode check", passcode},
+	)
+	for _, ext := range in.Extensions {
+		rows = append(rows, []string{fmt.Sprintf("%sExtension 0x%02X %s", prefix, ext.Tag, ext.Name), fmt.Sprintf("%s (%s)", ext.Value, ext.Type)})
+	}
+	return rows
+}
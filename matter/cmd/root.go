@@ -15,6 +15,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -22,7 +23,11 @@ import (
 	"strings"
 
 	"github.com/cybergarage/go-logger/log"
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
 	"github.com/YashubuStudio/go-matter-pack/matter"
+	"github.com/YashubuStudio/go-matter-pack/matter/attestation"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -33,11 +38,17 @@ var (
 	ConfigParamStr      = "config"
 	EnableBLEParamStr   = "enable-ble"
 	EnableMDNSParamStr  = "enable-mdns"
+	PAADirParamStr      = "attestation.paa-dir"
+	CDSignerDirParamStr = "attestation.cd-signer-dir"
 	commissionerStarted = false
 )
 
 const defaultConfigFilename = "matterctl.yaml"
 
+// commissionAnnotation marks the commands that commission devices onto the
+// local fabric selected by their --state-dir and --fabric flags.
+const commissionAnnotation = "commission"
+
 const defaultConfigTemplate = `# matterctl configuration
 # Set enable-ble or enable-mdns to true for discovery.
 format: table
@@ -45,6 +56,13 @@ verbose: false
 debug: false
 enable-ble: false
 enable-mdns: false
+# Device attestation trust store. Commissioning requires paa-dir, a
+# directory of PAA certificates (.pem/.der), unless --allow-uncertified is
+# given. cd-signer-dir holds the Certification Declaration signing
+# certificates.
+attestation:
+  paa-dir: ""
+  cd-signer-dir: ""
 `
 
 var rootCmd = &cobra.Command{ // nolint:exhaustruct
@@ -119,7 +137,7 @@ func configureLogging() {
 	}
 }
 
-func initCommissioner() error {
+func initCommissioner(cmd *cobra.Command) error {
 	if commissionerStarted {
 		return nil
 	}
@@ -131,9 +149,43 @@ func initCommissioner() error {
 
 	enableBLE := viper.GetBool(EnableBLEParamStr)
 	enableMDNS := viper.GetBool(EnableMDNSParamStr)
+	verifier, err := attestationVerifier()
+	if err != nil {
+		return err
+	}
+	config := commissioning.Config{ // nolint:exhaustruct
+		Progress: logCommissioningProgress,
+	}
+	if verifier != nil {
+		config.Verifier = verifier
+	}
+	// Commands that commission devices issue NOCs with the root CA of their
+	// local fabric and reach the commissioned node over CASE with the
+	// controller's NOC; the fabric is created on first use.
+	if _, ok := cmd.Annotations[commissionAnnotation]; ok {
+		fabric, err := commission.EnsureCredentials(context.Background(), commissionStateStore(cmd), commandFabricIndex(cmd))
+		if err != nil {
+			return fmt.Errorf("failed to load fabric credentials: %w", err)
+		}
+		log.Infof("Commissioning onto fabric 0x%016X as node 0x%016X", fabric.FabricID, fabric.NodeID)
+		issuer, err := matter.NewNOCIssuer(fabric)
+		if err != nil {
+			return err
+		}
+		config.Issuer = issuer
+		config.CASE = matter.NewCASEEstablisher(fabric)
+	}
+	// Commissioned nodes are found again through operational discovery,
+	// sharing the answer cache of the commissioner's discoverer.
+	discoverer := mdns.NewDiscoverer()
+	if enableMDNS {
+		config.Resolver = matter.NewOperationalResolver(discoverer)
+	}
 	sharedCommissioner = matter.NewCommissionerWithOptions(
 		matter.WithCommissionerBLEEnabled(enableBLE),
 		matter.WithCommissionerMDNSEnabled(enableMDNS),
+		matter.WithCommissionerDiscoverer(discoverer),
+		matter.WithCommissioningConfig(config),
 	)
 
 	if err := sharedCommissioner.Start(); err != nil {
@@ -143,10 +195,45 @@ func initCommissioner() error {
 	return nil
 }
 
+// attestationVerifier returns the verifier trusting the configured PAA and
+// Certification Declaration signer directories, or nil when no PAA
+// directory is configured.
+func attestationVerifier() (*attestation.Verifier, error) {
+	paaDir := viper.GetString(PAADirParamStr)
+	if paaDir == "" {
+		return nil, nil
+	}
+	store := attestation.NewTrustStore()
+	n, err := store.LoadPAADir(paaDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PAA certificates: %w", err)
+	}
+	log.Infof("Loaded %d PAA certificates from %s", n, paaDir)
+	if dir := viper.GetString(CDSignerDirParamStr); dir != "" {
+		n, err := store.LoadCDSignerDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load certification declaration signers: %w", err)
+		}
+		log.Infof("Loaded %d certification declaration signers from %s", n, dir)
+	}
+	return attestation.NewVerifier(store), nil
+}
+
+func logCommissioningProgress(p commissioning.Progress) {
+	switch p.Status {
+	case commissioning.StatusFailed:
+		log.Errorf("Commissioning %s failed: %v", p.Stage, p.Err)
+	case commissioning.StatusStarted:
+		log.Debugf("Commissioning %s started", p.Stage)
+	default:
+		log.Infof("Commissioning %s %s", p.Stage, p.Status)
+	}
+}
+
 func init() {
 	viper.SetEnvPrefix("matter_ctl")
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		if err := initCommissioner(); err != nil {
+		if err := initCommissioner(cmd); err != nil {
 			log.Error(err)
 			return err
 		}
@@ -17,8 +17,10 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -31,12 +33,15 @@ import (
 
 func init() {
 	rootCmd.AddCommand(scanCmd)
+
+	scanCmd.Flags().Bool("watch", false, "keep scanning and print added/changed/expired events as JSON lines")
+	scanCmd.Flags().Duration("duration", 0, "stop watching after this duration (0 watches until interrupted)")
 }
 
 var scanCmd = &cobra.Command{ // nolint:exhaustruct
 	Use:   "scan",
 	Short: "Scan for Matter devices.",
-	Long:  "Scan for Matter devices.",
+	Long:  "Scan for Matter devices. With --watch, keep browsing mDNS and scanning BLE and print one JSON line per added, changed or expired device, e.g. to wait for a device to enter pairing mode.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := NewFormatFromString(viper.GetString(FormatParamStr))
 		if err != nil {
@@ -44,6 +49,10 @@ var scanCmd = &cobra.Command{ // nolint:exhaustruct
 		}
 
 		cmr := SharedCommissioner()
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			duration, _ := cmd.Flags().GetDuration("duration")
+			return watchDevices(cmr, duration)
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		devs, err := cmr.Discover(ctx, matter.NewQuery())
@@ -133,6 +142,51 @@ var scanCmd = &cobra.Command{ // nolint:exhaustruct
 	},
 }
 
+// discoveryEventObject is the JSON line printed for a discovery event.
+type discoveryEventObject struct {
+	Event   string `json:"event"`
+	Time    string `json:"time"`
+	Key     string `json:"key"`
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address,omitempty"`
+	Device  any    `json:"device"`
+}
+
+// watchDevices prints discovery events of cmr as JSON lines until
+// interrupted or, when positive, until duration elapses.
+func watchDevices(cmr matter.Commissioner, duration time.Duration) error {
+	streamer, ok := cmr.(matter.DiscoveryStreamer)
+	if !ok {
+		return fmt.Errorf("%w: commissioner cannot watch discovery", matter.ErrNotImplemented)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if 0 < duration {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+	events, err := streamer.DiscoverStream(ctx, matter.NewQuery())
+	if err != nil {
+		return err
+	}
+	for event := range events {
+		b, err := json.Marshal(discoveryEventObject{
+			Event:   event.Type.String(),
+			Time:    event.Time.Format(time.RFC3339),
+			Key:     event.Key,
+			Name:    deviceName(event.Device),
+			Address: deviceAddress(event.Device),
+			Device:  event.Device.MarshalObject(),
+		})
+		if err != nil {
+			return err
+		}
+		outputf("%s\n", string(b))
+	}
+	return nil
+}
+
 type deviceNameProvider interface {
 	DeviceName() (string, bool)
 }
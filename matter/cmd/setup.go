@@ -16,8 +16,10 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"net"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -29,11 +31,16 @@ import (
 	"github.com/YashubuStudio/go-matter-pack/internal/store"
 	"github.com/YashubuStudio/go-matter-pack/internal/usecase"
 	"github.com/YashubuStudio/go-matter-pack/matter"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/qrcode"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/thread"
 	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
 	"github.com/spf13/cobra"
 )
 
-const defaultAppName = "go-matter-pack"
+const (
+	defaultAppName = "go-matter-pack"
+	qrImageFlag    = "qr-image"
+)
 
 func init() {
 	setupCmd.AddCommand(setupCommissionCmd)
@@ -41,11 +48,19 @@ func init() {
 
 	setupCommissionCmd.Flags().String("qr", "", "QR onboarding payload")
 	setupCommissionCmd.Flags().String("code", "", "manual pairing code")
+	setupCommissionCmd.Flags().String(qrImageFlag, "", "PNG or JPEG photo of the device QR code to read the onboarding payload from")
 	setupCommissionCmd.Flags().Uint64("node-id", 0, "target node ID")
 	setupCommissionCmd.Flags().String("state-dir", "", "state directory (defaults to XDG state home)")
-	setupCommissionCmd.Flags().Duration("timeout", 30*time.Second, "commissioning timeout")
+	setupCommissionCmd.Flags().Uint8(fabricFlag, commission.DefaultFabricIndex, "local fabric index to record the node on")
+	setupCommissionCmd.Flags().Duration("timeout", matter.DefaultCommissioningTimeout, "commissioning timeout")
 	setupCommissionCmd.Flags().Bool("import-only", false, "only store onboarding payload without commissioning")
+	setupCommissionCmd.Flags().String(manifestFlag, "", "YAML manifest of devices to commission in a batch")
+	setupCommissionCmd.Flags().Int("concurrency", usecase.DefaultBatchConcurrency, "devices commissioned at once with --manifest")
+	setupCommissionCmd.Flags().Bool("retry-all", false, "with --manifest, also recommission devices that already succeeded")
+	setupCommissionCmd.Flags().Bool("resume", false, "continue an interrupted commissioning of --node-id from its last completed stage")
 	setupCommissionCmd.Flags().String("address", "", "on-network device address (ip or ip:port)")
+	setupCommissionCmd.Flags().String(threadDatasetFlag, "", "Thread operational dataset (hex) to provision")
+	setupCommissionCmd.Flags().Bool(allowUncertifiedFlag, false, "accept devices failing attestation trust checks (development devices)")
 }
 
 var setupCmd = &cobra.Command{ // nolint:exhaustruct
@@ -57,6 +72,10 @@ var setupCmd = &cobra.Command{ // nolint:exhaustruct
 var setupCommissionCmd = &cobra.Command{ // nolint:exhaustruct
 	Use:   "commission",
 	Short: "Commission a Matter bridge and store onboarding data.",
+	Long: "Commission a Matter bridge and store onboarding data.\n\n" +
+		"The device is commissioned onto the local fabric selected by --fabric, which is\n" +
+		"created with a new root CA on first use. Use --import-only to only record the payload.",
+	Annotations: map[string]string{commissionAnnotation: ""},
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		qrPayload, err := cmd.Flags().GetString("qr")
 		if err != nil {
@@ -66,8 +85,35 @@ var setupCommissionCmd = &cobra.Command{ // nolint:exhaustruct
 		if err != nil {
 			return err
 		}
-		if (qrPayload == "" && codePayload == "") || (qrPayload != "" && codePayload != "") {
-			return fmt.Errorf("specify exactly one of --qr or --code")
+		qrImage, err := cmd.Flags().GetString(qrImageFlag)
+		if err != nil {
+			return err
+		}
+		if qrImage != "" {
+			if qrPayload != "" {
+				return fmt.Errorf("specify only one of --qr or --%s", qrImageFlag)
+			}
+			if qrPayload, err = qrImagePayload(qrImage); err != nil {
+				return err
+			}
+		}
+		resume, err := cmd.Flags().GetBool("resume")
+		if err != nil {
+			return err
+		}
+		manifest, err := cmd.Flags().GetString(manifestFlag)
+		if err != nil {
+			return err
+		}
+		switch {
+		case manifest != "" && (qrPayload != "" || codePayload != "" || resume):
+			return fmt.Errorf("--%s cannot be combined with --qr, --%s, --code or --resume", manifestFlag, qrImageFlag)
+		case manifest != "":
+			return commissionManifest(cmd, manifest)
+		case resume && (qrPayload != "" || codePayload != ""):
+			return fmt.Errorf("--resume uses the stored payload; do not specify --qr, --%s or --code", qrImageFlag)
+		case !resume && ((qrPayload == "" && codePayload == "") || (qrPayload != "" && codePayload != "")):
+			return fmt.Errorf("specify exactly one of --qr, --%s or --code", qrImageFlag)
 		}
 		nodeID, err := cmd.Flags().GetUint64("node-id")
 		if err != nil {
@@ -89,6 +135,10 @@ var setupCommissionCmd = &cobra.Command{ // nolint:exhaustruct
 		if err != nil {
 			return err
 		}
+		fabric, err := cmd.Flags().GetUint8(fabricFlag)
+		if err != nil {
+			return err
+		}
 
 		payload := codePayload
 		if qrPayload != "" {
@@ -97,11 +147,15 @@ var setupCommissionCmd = &cobra.Command{ // nolint:exhaustruct
 		if stateDir == "" {
 			stateDir = app.StateDir(defaultAppName)
 		}
-		statePath := filepath.Join(stateDir, "commission.json")
+		statePath := filepath.Join(stateDir, defaultCommissionFilename)
 		stateStore := store.NewJSONFileStore(statePath)
 
 		service := usecase.NewCommissionService(SharedCommissioner(), stateStore)
+		service.SetFabric(fabric)
 		if importOnly {
+			if resume {
+				return fmt.Errorf("--import-only cannot be combined with --resume")
+			}
 			if _, err := service.ImportPayload(context.Background(), nodeID, payload); err != nil {
 				return err
 			}
@@ -115,25 +169,61 @@ var setupCommissionCmd = &cobra.Command{ // nolint:exhaustruct
 		if err != nil {
 			return err
 		}
+		opts, err := threadDatasetOptions(cmd)
+		if err != nil {
+			return err
+		}
+		attestationOpts, err := attestationOptions(cmd)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, attestationOpts...)
 
 		var state commission.State
 		var commissionee matter.Commissionee
-		if ip != nil {
-			state, commissionee, err = service.CommissionOnNetwork(ctx, nodeID, payload, ip, port)
-		} else {
-			state, commissionee, err = service.Commission(ctx, nodeID, payload)
+		switch {
+		case resume:
+			state, commissionee, err = service.Resume(ctx, nodeID, ip, port, opts...)
+		case ip != nil:
+			state, commissionee, err = service.CommissionOnNetwork(ctx, nodeID, payload, ip, port, opts...)
+		default:
+			state, commissionee, err = service.Commission(ctx, nodeID, payload, opts...)
 		}
 		if err != nil {
+			logResumeHint(stateStore, fabric, nodeID)
 			return err
 		}
 		log.Infof("Successfully commissioned device: %s", commissionee.String())
-		if state.Result != nil {
-			log.Infof("Saved commissioning result to %s", statePath)
+		if res := commissionee.Result(); res != nil && res.NodeID != 0 {
+			nodeID = res.NodeID
+		}
+		if f := state.Fabric(fabric); f != nil {
+			if node := f.Node(nodeID); node != nil && node.Result != nil {
+				log.Infof("Saved commissioning result of node %d to fabric %d in %s", nodeID, f.Index, statePath)
+			}
 		}
 		return nil
 	},
 }
 
+// logResumeHint tells how to continue an attempt that left a live checkpoint.
+func logResumeHint(stateStore store.Store, fabric uint8, nodeID uint64) {
+	state, err := commission.LoadState(context.Background(), stateStore)
+	if err != nil {
+		return
+	}
+	f := state.Fabric(fabric)
+	if f == nil {
+		return
+	}
+	node := f.Node(nodeID)
+	if node == nil || node.Checkpoint == nil || node.Checkpoint.Expired(time.Now()) {
+		return
+	}
+	log.Infof("Commissioning stopped after %s; rerun with --resume before %s to continue",
+		node.Checkpoint.Stage, node.Checkpoint.FailSafeExpiresAt.Format(time.RFC3339))
+}
+
 func parseOnNetworkAddress(address string) (net.IP, int, error) {
 	address = strings.TrimSpace(address)
 	if address == "" {
@@ -156,3 +246,69 @@ func parseOnNetworkAddress(address string) (net.IP, int, error) {
 	}
 	return ip, port, nil
 }
+
+const threadDatasetFlag = "thread-dataset"
+
+// threadDatasetOptions returns the commissioning options for --thread-dataset.
+func threadDatasetOptions(cmd *cobra.Command) ([]matter.CommissionOption, error) {
+	value, err := cmd.Flags().GetString(threadDatasetFlag)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	opt, err := threadDatasetOption(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", threadDatasetFlag, err)
+	}
+	return []matter.CommissionOption{opt}, nil
+}
+
+// threadDatasetOption returns the commissioning option provisioning the hex
+// encoded Thread operational dataset.
+func threadDatasetOption(value string) (matter.CommissionOption, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, err
+	}
+	dataset, err := thread.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := dataset.Validate(); err != nil {
+		return nil, err
+	}
+	log.Infof("Provisioning %s", dataset)
+	return matter.WithThreadDataset(raw), nil
+}
+
+const allowUncertifiedFlag = "allow-uncertified"
+
+// attestationOptions returns the commissioning options for --allow-uncertified.
+func attestationOptions(cmd *cobra.Command) ([]matter.CommissionOption, error) {
+	allow, err := cmd.Flags().GetBool(allowUncertifiedFlag)
+	if err != nil || !allow {
+		return nil, err
+	}
+	log.Warnf("--%s: devices that are not certified will be commissioned", allowUncertifiedFlag)
+	return []matter.CommissionOption{matter.WithAllowUncertified()}, nil
+}
+
+// qrImagePayload reads the onboarding payload from a photo of a QR code.
+func qrImagePayload(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	text, err := qrcode.DecodeImage(f)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	payload, _, err := commission.ParseOnboardingPayload(text)
+	if err != nil {
+		return "", fmt.Errorf("%s: QR code is not a Matter onboarding payload: %w", path, err)
+	}
+	// The payload carries the setup passcode, so only its identifiers are logged.
+	log.Infof("Read onboarding payload (VID 0x%04X, PID 0x%04X, discriminator %d) from %s",
+		uint16(payload.VendorID()), uint16(payload.ProductID()), uint16(payload.Discriminator()), path)
+	return text, nil
+}
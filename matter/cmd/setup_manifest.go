@@ -0,0 +1,160 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/store"
+	"github.com/YashubuStudio/go-matter-pack/internal/usecase"
+	"github.com/YashubuStudio/go-matter-pack/matter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const manifestFlag = "manifest"
+
+// commissionManifest commissions the devices listed in the manifest at path
+// and prints a summary of the report kept in the state directory.
+func commissionManifest(cmd *cobra.Command, path string) error {
+	format, err := NewFormatFromString(viper.GetString(FormatParamStr))
+	if err != nil {
+		return err
+	}
+	manifest, err := commission.LoadManifest(path)
+	if err != nil {
+		return err
+	}
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return err
+	}
+	retryAll, err := cmd.Flags().GetBool("retry-all")
+	if err != nil {
+		return err
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return err
+	}
+	fabric, err := cmd.Flags().GetUint8(fabricFlag)
+	if err != nil {
+		return err
+	}
+	if manifest.Fabric != 0 {
+		fabric = manifest.Fabric
+	}
+	common, err := attestationOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	devices := make([]usecase.BatchDevice, 0, len(manifest.Devices))
+	for i, d := range manifest.Devices {
+		device, err := batchDevice(manifest, d, common)
+		if err != nil {
+			return fmt.Errorf("manifest device %d: %w", i+1, err)
+		}
+		devices = append(devices, device)
+	}
+
+	stateStore := commissionStateStore(cmd)
+	reportPath := manifestReportPath(cmd, path)
+	service := usecase.NewCommissionService(SharedCommissioner(), stateStore)
+	service.SetFabric(fabric)
+	report, err := service.CommissionBatch(context.Background(), path, devices, store.NewJSONFileStore(reportPath), usecase.BatchOptions{
+		Concurrency: concurrency,
+		Timeout:     timeout,
+		RetryAll:    retryAll,
+	})
+	if err != nil {
+		return err
+	}
+
+	columns := []string{"KEY", "NODE ID", "STATUS", "ATTEMPTS", "DEVICE", "ERROR"}
+	rows := [][]string{}
+	outcomes := []commission.ManifestDeviceOutcome{}
+	failed := 0
+	for _, d := range manifest.Devices {
+		outcome := report.Outcome(d.Key())
+		if outcome == nil {
+			continue
+		}
+		if outcome.Status != commission.ManifestStatusCommissioned {
+			failed++
+		}
+		outcomes = append(outcomes, *outcome)
+		rows = append(rows, []string{
+			outcome.Key,
+			strconv.FormatUint(outcome.NodeID, 10),
+			outcome.Status,
+			strconv.Itoa(outcome.Attempts),
+			outcome.Device,
+			outcome.Error,
+		})
+	}
+	if err := printRecords(format, columns, rows, outcomes); err != nil {
+		return err
+	}
+	log.Infof("Saved manifest report to %s", reportPath)
+	if failed != 0 {
+		return fmt.Errorf("%d of %d devices failed; re-run with --%s %s to retry them", failed, len(manifest.Devices), manifestFlag, path)
+	}
+	return nil
+}
+
+// batchDevice converts a manifest device, adding its network credentials to
+// the common options.
+func batchDevice(manifest commission.Manifest, d commission.ManifestDevice, common []matter.CommissionOption) (usecase.BatchDevice, error) {
+	ip, port, err := parseOnNetworkAddress(d.Address)
+	if err != nil {
+		return usecase.BatchDevice{}, err
+	}
+	opts := append([]matter.CommissionOption{}, common...)
+	if network := manifest.NetworkFor(d); network != nil {
+		switch {
+		case network.WiFi != nil:
+			opts = append(opts, matter.WithWiFiCredentials(network.WiFi.SSID, []byte(network.WiFi.Passphrase)))
+		case network.ThreadDataset != "":
+			opt, err := threadDatasetOption(network.ThreadDataset)
+			if err != nil {
+				return usecase.BatchDevice{}, fmt.Errorf("invalid thread-dataset: %w", err)
+			}
+			opts = append(opts, opt)
+		}
+	}
+	return usecase.BatchDevice{
+		Key:     d.Key(),
+		NodeID:  d.NodeID,
+		Payload: d.Payload(),
+		Address: ip,
+		Port:    port,
+		Options: opts,
+	}, nil
+}
+
+// manifestReportPath returns the report path of the manifest at path under
+// the manifests directory of the state directory.
+func manifestReportPath(cmd *cobra.Command, path string) string {
+	stateDir := filepath.Dir(commissionStatePath(cmd))
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return filepath.Join(stateDir, "manifests", name+".report.json")
+}
@@ -0,0 +1,201 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/matter"
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	setupCmd.AddCommand(setupScanNetworksCmd)
+
+	setupScanNetworksCmd.Flags().String("qr", "", "QR onboarding payload")
+	setupScanNetworksCmd.Flags().String("code", "", "manual pairing code")
+	setupScanNetworksCmd.Flags().String("address", "", "on-network device address (ip or ip:port)")
+	setupScanNetworksCmd.Flags().String("ssid", "", "only scan for this Wi-Fi SSID")
+	setupScanNetworksCmd.Flags().Duration("timeout", 60*time.Second, "scan timeout")
+}
+
+var setupScanNetworksCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "scan-networks",
+	Short: "List the Wi-Fi or Thread networks a commissionable device can see.",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		format, err := NewFormatFromString(viper.GetString(FormatParamStr))
+		if err != nil {
+			return err
+		}
+		qrPayload, err := cmd.Flags().GetString("qr")
+		if err != nil {
+			return err
+		}
+		codePayload, err := cmd.Flags().GetString("code")
+		if err != nil {
+			return err
+		}
+		if (qrPayload == "" && codePayload == "") || (qrPayload != "" && codePayload != "") {
+			return fmt.Errorf("specify exactly one of --qr or --code")
+		}
+		payload := codePayload
+		if qrPayload != "" {
+			payload = qrPayload
+		}
+		address, err := cmd.Flags().GetString("address")
+		if err != nil {
+			return err
+		}
+		ssid, err := cmd.Flags().GetString("ssid")
+		if err != nil {
+			return err
+		}
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+
+		onboarding, _, err := commission.ParseOnboardingPayload(payload)
+		if err != nil {
+			return err
+		}
+		queryOpts := []matter.QueryOption{matter.WithQueryOnboardingPayload(onboarding)}
+		ip, port, err := parseOnNetworkAddress(address)
+		if err != nil {
+			return err
+		}
+		if ip != nil {
+			queryOpts = append(queryOpts, matter.WithQueryOnNetworkAddress(ip, port))
+		}
+		var ssidFilter []byte
+		if ssid != "" {
+			ssidFilter = []byte(ssid)
+		}
+
+		scanner, ok := SharedCommissioner().(matter.NetworkScanner)
+		if !ok {
+			return errors.New("commissioner does not support network scanning")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		results, err := scanner.ScanNetworks(ctx, matter.NewQuery(queryOpts...), ssidFilter)
+		if err != nil {
+			return err
+		}
+		if len(results.Thread) > 0 {
+			return printThreadScanResults(format, results.Thread)
+		}
+		return printWiFiScanResults(format, results.WiFi)
+	},
+}
+
+func printWiFiScanResults(format Format, results []clusters.NetworkCommissioningWiFiInterfaceScanResultStruct) error {
+	columns := []string{"SSID", "BSSID", "RSSI", "Band", "Channel", "Security"}
+	rows := make([][]string, 0, len(results))
+	objects := make([]map[string]any, 0, len(results))
+	for _, r := range results {
+		bssid := net.HardwareAddr(r.BSSID).String()
+		band := wifiBandName(r.WiFiBand)
+		security := wifiSecurityName(r.Security)
+		rows = append(rows, []string{
+			string(r.SSID),
+			bssid,
+			strconv.Itoa(int(r.RSSI)),
+			band,
+			strconv.Itoa(int(r.Channel)),
+			security,
+		})
+		objects = append(objects, map[string]any{
+			"ssid":     string(r.SSID),
+			"bssid":    bssid,
+			"rssi":     r.RSSI,
+			"band":     band,
+			"channel":  r.Channel,
+			"security": security,
+		})
+	}
+	return printRecords(format, columns, rows, objects)
+}
+
+func printThreadScanResults(format Format, results []clusters.NetworkCommissioningThreadInterfaceScanResultStruct) error {
+	columns := []string{"NetworkName", "PANID", "ExtendedPANID", "Channel", "RSSI", "LQI"}
+	rows := make([][]string, 0, len(results))
+	objects := make([]map[string]any, 0, len(results))
+	for _, r := range results {
+		panID := fmt.Sprintf("0x%04X", r.PanId)
+		extPANID := fmt.Sprintf("%016X", r.ExtendedPanId)
+		rows = append(rows, []string{
+			r.NetworkName,
+			panID,
+			extPANID,
+			strconv.Itoa(int(r.Channel)),
+			strconv.Itoa(int(r.RSSI)),
+			strconv.Itoa(int(r.LQI)),
+		})
+		objects = append(objects, map[string]any{
+			"networkName":   r.NetworkName,
+			"panId":         panID,
+			"extendedPanId": extPANID,
+			"channel":       r.Channel,
+			"rssi":          r.RSSI,
+			"lqi":           r.LQI,
+		})
+	}
+	return printRecords(format, columns, rows, objects)
+}
+
+var wifiBandNames = map[clusters.NetworkCommissioningWiFiBandEnum]string{
+	clusters.NetworkCommissioningWiFiBandEnum2G4:  "2.4GHz",
+	clusters.NetworkCommissioningWiFiBandEnum3G65: "3.65GHz",
+	clusters.NetworkCommissioningWiFiBandEnum5G:   "5GHz",
+	clusters.NetworkCommissioningWiFiBandEnum6G:   "6GHz",
+	clusters.NetworkCommissioningWiFiBandEnum60G:  "60GHz",
+	clusters.NetworkCommissioningWiFiBandEnum1G:   "1GHz",
+}
+
+func wifiBandName(band clusters.NetworkCommissioningWiFiBandEnum) string {
+	if name, ok := wifiBandNames[band]; ok {
+		return name
+	}
+	return band.String()
+}
+
+// wifiSecurityName returns the strongest security type advertised.
+func wifiSecurityName(security clusters.NetworkCommissioningWiFiSecurityBitmap) string {
+	for _, s := range []struct {
+		bit  clusters.NetworkCommissioningWiFiSecurityBitmap
+		name string
+	}{
+		{clusters.NetworkCommissioningWiFiSecurityBitmapWPA3MatterPDC, "WPA3-PDC"},
+		{clusters.NetworkCommissioningWiFiSecurityBitmapWPA3Personal, "WPA3"},
+		{clusters.NetworkCommissioningWiFiSecurityBitmapWPA2Personal, "WPA2"},
+		{clusters.NetworkCommissioningWiFiSecurityBitmapWPAPersonal, "WPA"},
+		{clusters.NetworkCommissioningWiFiSecurityBitmapWEP, "WEP"},
+		{clusters.NetworkCommissioningWiFiSecurityBitmapUnencrypted, "open"},
+	} {
+		if security.Has(s.bit) {
+			return s.name
+		}
+	}
+	return "unknown"
+}
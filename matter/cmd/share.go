@@ -0,0 +1,162 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/internal/commission"
+	"github.com/YashubuStudio/go-matter-pack/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	shareCmd.AddCommand(shareOpenCmd)
+	shareCmd.AddCommand(shareRevokeCmd)
+	shareCmd.AddCommand(shareStatusCmd)
+	rootCmd.AddCommand(shareCmd)
+
+	shareCmd.PersistentFlags().String("state-dir", "", "state directory (defaults to XDG state home)")
+	shareCmd.PersistentFlags().Uint64("node-id", 0, "target node ID (defaults to the last node commissioned on the fabric)")
+	shareCmd.PersistentFlags().Uint8(fabricFlag, commission.DefaultFabricIndex, "local fabric index the node is recorded on")
+	shareCmd.PersistentFlags().Duration("timeout", 10*time.Second, "command timeout")
+
+	shareOpenCmd.Flags().Bool("basic", false, "open a Basic Commissioning Window with the original onboarding payload")
+	shareOpenCmd.Flags().Duration("window", usecase.DefaultWindowTimeout, "how long the commissioning window stays open (3m-15m)")
+	shareOpenCmd.Flags().Uint32("iterations", usecase.DefaultPBKDFIterations, "PBKDF2 iterations for the new passcode verifier")
+	shareOpenCmd.Flags().Int("discriminator", -1, "discriminator to advertise (random when unset)")
+}
+
+var shareCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "share",
+	Short: "Share a commissioned node with another ecosystem.",
+	Long:  "Open or revoke a commissioning window so that another administrator can commission an already commissioned node.",
+}
+
+var shareOpenCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "open",
+	Short: "Open a commissioning window and print the new onboarding codes.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		service, nodeID, ctx, cancel, err := newShareService(cmd)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		basic, err := cmd.Flags().GetBool("basic")
+		if err != nil {
+			return err
+		}
+		window, err := cmd.Flags().GetDuration("window")
+		if err != nil {
+			return err
+		}
+		iterations, err := cmd.Flags().GetUint32("iterations")
+		if err != nil {
+			return err
+		}
+		discriminator, err := cmd.Flags().GetInt("discriminator")
+		if err != nil {
+			return err
+		}
+		opts := usecase.ShareOptions{
+			Timeout:    window,
+			Iterations: iterations,
+			Basic:      basic,
+		}
+		if 0 <= discriminator {
+			if 0xFFF < discriminator {
+				return fmt.Errorf("discriminator must be at most %d", 0xFFF)
+			}
+			d := uint16(discriminator)
+			opts.Discriminator = &d
+		}
+		result, err := service.OpenWindow(ctx, nodeID, opts)
+		if err != nil {
+			return err
+		}
+		if result.Enhanced {
+			outputf("Enhanced commissioning window open on node 0x%016X until %s\n", result.NodeID, result.ExpiresAt.Format(time.RFC3339))
+		} else {
+			outputf("Basic commissioning window open on node 0x%016X until %s\n", result.NodeID, result.ExpiresAt.Format(time.RFC3339))
+		}
+		if result.PairingCode != "" {
+			outputf("Manual code: %s\n", result.PairingCode)
+		}
+		if result.QRCode != "" {
+			outputf("QR payload:  %s\n", result.QRCode)
+		}
+		return nil
+	},
+}
+
+var shareRevokeCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "revoke",
+	Short: "Close an open commissioning window.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		service, nodeID, ctx, cancel, err := newShareService(cmd)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		return service.Revoke(ctx, nodeID)
+	},
+}
+
+var shareStatusCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "status",
+	Short: "Read the commissioning window status.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		service, nodeID, ctx, cancel, err := newShareService(cmd)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		status, err := service.WindowStatus(ctx, nodeID)
+		if err != nil {
+			return err
+		}
+		outputf("%s\n", status.String())
+		return nil
+	},
+}
+
+func newShareService(cmd *cobra.Command) (*usecase.ShareService, uint64, context.Context, context.CancelFunc, error) {
+	nodeID, err := cmd.Flags().GetUint64("node-id")
+	if err != nil {
+		return nil, 0, nil, nil, err
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return nil, 0, nil, nil, err
+	}
+	fabric, err := cmd.Flags().GetUint8(fabricFlag)
+	if err != nil {
+		return nil, 0, nil, nil, err
+	}
+
+	ctrl, err := operationalController(cmd, fabric)
+	if err != nil {
+		return nil, 0, nil, nil, err
+	}
+	service := usecase.NewShareService(ctrl, commissionStateStore(cmd))
+	service.SetFabric(fabric)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	return service, nodeID, ctx, cancel, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/spf13/cobra"
+)
+
+const (
+	tlvEncodingAuto   = "auto"
+	tlvEncodingHex    = "hex"
+	tlvEncodingBase64 = "base64"
+)
+
+func init() {
+	tlvCmd.AddCommand(tlvDecodeCmd)
+	tlvCmd.AddCommand(tlvEncodeCmd)
+	rootCmd.AddCommand(tlvCmd)
+
+	tlvDecodeCmd.Flags().String("encoding", tlvEncodingAuto, "input encoding (auto, hex, base64)")
+	tlvDecodeCmd.Flags().Bool("pretty", false, "print an indented element tree instead of TLV-JSON")
+	tlvEncodeCmd.Flags().String("encoding", tlvEncodingHex, "output encoding (hex, base64)")
+}
+
+var tlvCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "tlv",
+	Short: "Convert between Matter TLV and TLV-JSON.",
+	Long:  "Convert between Matter TLV and TLV-JSON (\"1:UINT\": 5, \"2:STRUCT\": {...}).",
+}
+
+var tlvDecodeCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "decode [hex or base64 TLV | -]",
+	Short: "Decode TLV into TLV-JSON.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		encoding, err := cmd.Flags().GetString("encoding")
+		if err != nil {
+			return err
+		}
+		input, err := readTLVInput(cmd, args)
+		if err != nil {
+			return err
+		}
+		pretty, err := cmd.Flags().GetBool("pretty")
+		if err != nil {
+			return err
+		}
+		data, err := decodeTLVInput(input, encoding)
+		if err != nil {
+			return err
+		}
+		if pretty {
+			out, err := tlv.PrettyString(data)
+			if err != nil {
+				return err
+			}
+			outputf("%s", out)
+			return nil
+		}
+		js, err := tlv.ToJSON(data)
+		if err != nil {
+			return err
+		}
+		var out bytes.Buffer
+		if err := json.Indent(&out, js, "", "  "); err != nil {
+			return err
+		}
+		outputf("%s\n", out.String())
+		return nil
+	},
+}
+
+var tlvEncodeCmd = &cobra.Command{ // nolint:exhaustruct
+	Use:   "encode [TLV-JSON | -]",
+	Short: "Encode TLV-JSON into TLV.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		encoding, err := cmd.Flags().GetString("encoding")
+		if err != nil {
+			return err
+		}
+		input, err := readTLVInput(cmd, args)
+		if err != nil {
+			return err
+		}
+		data, err := tlv.FromJSON([]byte(input))
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(encoding) {
+		case tlvEncodingHex:
+			outputf("%s\n", hex.EncodeToString(data))
+		case tlvEncodingBase64:
+			outputf("%s\n", base64.StdEncoding.EncodeToString(data))
+		default:
+			return fmt.Errorf("invalid output encoding: %s", encoding)
+		}
+		return nil
+	},
+}
+
+// readTLVInput returns the argument, or standard input when the argument is
+// missing or "-".
+func readTLVInput(cmd *cobra.Command, args []string) (string, error) {
+	if len(args) == 1 && args[0] != "-" {
+		return strings.TrimSpace(args[0]), nil
+	}
+	in := cmd.InOrStdin()
+	if in == nil {
+		in = os.Stdin
+	}
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// decodeTLVInput decodes hex (whitespace and a 0x prefix are ignored) or
+// base64 input. In auto mode hex is tried first.
+func decodeTLVInput(input, encoding string) ([]byte, error) {
+	cleanHex := strings.Join(strings.Fields(strings.TrimPrefix(strings.TrimPrefix(input, "0x"), "0X")), "")
+	switch strings.ToLower(encoding) {
+	case tlvEncodingHex:
+		return hex.DecodeString(cleanHex)
+	case tlvEncodingBase64:
+		return base64.StdEncoding.DecodeString(input)
+	case tlvEncodingAuto:
+		if b, err := hex.DecodeString(cleanHex); err == nil {
+			return b, nil
+		}
+		if b, err := base64.StdEncoding.DecodeString(input); err == nil {
+			return b, nil
+		}
+		return nil, fmt.Errorf("input is neither hex nor base64")
+	default:
+		return nil, fmt.Errorf("invalid input encoding: %s", encoding)
+	}
+}
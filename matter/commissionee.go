@@ -14,6 +14,10 @@
 
 package matter
 
+import (
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+)
+
 // Commissionee An entity that is being Commissioned to become a Node.
 type Commissionee interface {
 	// VendorID represents a vendor ID.
@@ -24,4 +28,7 @@ type Commissionee interface {
 	ProductID() ProductID
 	// String returns the string representation of the device.
 	String() string
+	// Result returns the outcome of the commissioning flow: the node ID
+	// assigned to the device, its fabric and operational addresses.
+	Result() *commissioning.Result
 }
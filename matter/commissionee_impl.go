@@ -14,11 +14,23 @@
 
 package matter
 
-func newCommissioneeWithDevice(dev Device) Commissionee {
-	type commissionee struct {
-		Device
-	}
+import (
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+)
+
+type commissionee struct {
+	Device
+	result *commissioning.Result
+}
+
+func newCommissioneeWithDevice(dev Device, result *commissioning.Result) Commissionee {
 	return &commissionee{
 		Device: dev,
+		result: result,
 	}
 }
+
+// Result returns the outcome of the commissioning flow.
+func (c *commissionee) Result() *commissioning.Result {
+	return c.result
+}
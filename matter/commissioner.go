@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/YashubuStudio/go-matter-pack/matter/ble"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
 	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
 )
 
@@ -27,7 +28,7 @@ const (
 	// DefaultDiscoveryTimeout is the default discovery timeout.
 	DefaultDiscoveryTimeout = time.Duration(5 * time.Second)
 	// DefaultCommissioningTimeout is the default commissioning timeout.
-	DefaultCommissioningTimeout = time.Duration(5 * time.Second)
+	DefaultCommissioningTimeout = time.Duration(30 * time.Second)
 )
 
 // Commissioner represents a commissioner interface.
@@ -40,16 +41,33 @@ type Commissioner interface {
 	// 5.4.3. Discovery by Commissioner
 	Discover(ctx context.Context, query Query) ([]CommissionableDevice, error)
 	// Commission commissions a device with the given onboarding payload.
-	Commission(ctx context.Context, payload OnboardingPayload) (Commissionee, error)
+	Commission(ctx context.Context, payload OnboardingPayload, opts ...CommissionOption) (Commissionee, error)
 	// Start starts the commissioner.
 	Start() error
 	// Stop stops the commissioner.
 	Stop() error
 }
 
+// NetworkScanner represents a commissioner that can list the networks a
+// commissionee sees before it is commissioned.
+type NetworkScanner interface {
+	// ScanNetworks finds the device matching the onboarding payload of query,
+	// opens a PASE session and returns the result of Network Commissioning
+	// ScanNetworks. A nil ssid scans for all networks.
+	ScanNetworks(ctx context.Context, query Query, ssid []byte) (*commissioning.ScanResults, error)
+}
+
+// DiscoveryStreamer represents a commissioner that can watch commissionable
+// devices appear, change and expire.
+type DiscoveryStreamer interface {
+	// DiscoverStream discovers devices matching query continuously and emits
+	// a DiscoveryEvent for every change until ctx is done.
+	DiscoverStream(ctx context.Context, query Query) (<-chan DiscoveryEvent, error)
+}
+
 // OnNetworkCommissioner represents a commissioner capable of direct on-network commissioning.
 type OnNetworkCommissioner interface {
 	Commissioner
 	// CommissionOnNetwork commissions a device with the given onboarding payload and on-network address.
-	CommissionOnNetwork(ctx context.Context, payload OnboardingPayload, address net.IP, port int) (Commissionee, error)
+	CommissionOnNetwork(ctx context.Context, payload OnboardingPayload, address net.IP, port int, opts ...CommissionOption) (Commissionee, error)
 }
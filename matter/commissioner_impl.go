@@ -21,7 +21,9 @@ import (
 	"runtime"
 
 	"github.com/cybergarage/go-logger/log"
+	"github.com/YashubuStudio/go-matter-pack/matter/attestation"
 	"github.com/YashubuStudio/go-matter-pack/matter/ble"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
 	"github.com/YashubuStudio/go-matter-pack/matter/errors"
 	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
 )
@@ -32,6 +34,7 @@ type commissioner struct {
 	discoverer mdns.Discoverer
 	enableBLE  bool
 	enableMDNS bool
+	config     commissioning.Config
 }
 
 // CommissionerOption represents a configuration option for a commissioner.
@@ -40,6 +43,8 @@ type CommissionerOption func(*commissionerOptions)
 type commissionerOptions struct {
 	enableBLE  bool
 	enableMDNS bool
+	discoverer mdns.Discoverer
+	config     commissioning.Config
 }
 
 // WithCommissionerBLEEnabled toggles BLE scanning.
@@ -56,6 +61,90 @@ func WithCommissionerMDNSEnabled(enabled bool) CommissionerOption {
 	}
 }
 
+// WithCommissionerDiscoverer sets the mDNS discoverer, so that it can be
+// shared with an OperationalResolver.
+func WithCommissionerDiscoverer(discoverer mdns.Discoverer) CommissionerOption {
+	return func(opts *commissionerOptions) {
+		opts.discoverer = discoverer
+	}
+}
+
+// WithCommissioningConfig sets the credential issuer, attestation verifier,
+// network and CASE plug-ins and progress callback used after PASE.
+func WithCommissioningConfig(config commissioning.Config) CommissionerOption {
+	return func(opts *commissionerOptions) {
+		opts.config = config
+	}
+}
+
+// CommissionOption represents a configuration option for a single commissioning attempt.
+type CommissionOption func(*commissioning.Config)
+
+// WithNodeID assigns nodeID as the operational node ID of the commissionee
+// instead of a random one.
+func WithNodeID(nodeID uint64) CommissionOption {
+	return func(config *commissioning.Config) {
+		config.NodeID = nodeID
+	}
+}
+
+// WithWiFiCredentials provisions the Wi-Fi network ssid with passphrase through
+// the Network Commissioning cluster. An empty passphrase selects an open network.
+func WithWiFiCredentials(ssid string, passphrase []byte) CommissionOption {
+	return func(config *commissioning.Config) {
+		config.Network = commissioning.WiFiNetwork{
+			SSID:        []byte(ssid),
+			Credentials: passphrase,
+		}
+	}
+}
+
+// WithThreadDataset provisions the Thread network described by the encoded
+// operational dataset through the Network Commissioning cluster.
+func WithThreadDataset(dataset []byte) CommissionOption {
+	return func(config *commissioning.Config) {
+		config.Network = commissioning.ThreadNetwork{
+			Dataset: dataset,
+		}
+	}
+}
+
+// WithAllowUncertified accepts devices whose attestation does not chain to a
+// trusted PAA or whose certification declaration is untrusted or for
+// development, as with development devices. Malformed or inconsistent
+// attestation is still rejected. A custom attestation verifier is left as is.
+func WithAllowUncertified() CommissionOption {
+	return func(config *commissioning.Config) {
+		verifier := &attestation.Verifier{}
+		switch v := config.Verifier.(type) {
+		case nil:
+		case *attestation.Verifier:
+			*verifier = *v
+		default:
+			return
+		}
+		verifier.AllowUncertified = true
+		config.Verifier = verifier
+	}
+}
+
+// WithCheckpoint calls save with the progress of the attempt after every
+// resumable stage. A failed attempt then leaves the fail-safe timer armed so
+// that it can be continued with WithResume before the timer expires.
+func WithCheckpoint(save func(commissioning.Checkpoint)) CommissionOption {
+	return func(config *commissioning.Config) {
+		config.Checkpoint = save
+	}
+}
+
+// WithResume continues the interrupted attempt recorded in checkpoint,
+// skipping the stages it completed.
+func WithResume(checkpoint commissioning.Checkpoint) CommissionOption {
+	return func(config *commissioning.Config) {
+		config.Resume = &checkpoint
+	}
+}
+
 // NewCommissioner returns a new commissioner.
 func NewCommissioner() Commissioner {
 	return NewCommissionerWithOptions()
@@ -73,11 +162,16 @@ func NewCommissionerWithOptions(options ...CommissionerOption) Commissioner {
 		opts.enableBLE = false
 	}
 
+	if opts.discoverer == nil {
+		opts.discoverer = mdns.NewDiscoverer()
+	}
+
 	com := &commissioner{
 		Central:    ble.NewCentral(),
-		discoverer: mdns.NewDiscoverer(),
+		discoverer: opts.discoverer,
 		enableBLE:  opts.enableBLE,
 		enableMDNS: opts.enableMDNS,
+		config:     opts.config,
 	}
 	return com
 }
@@ -194,23 +288,87 @@ func (cmr *commissioner) Discover(ctx context.Context, query Query) ([]Commissio
 }
 
 // 5.5. Commissioning Flows.
-func (cmr *commissioner) Commission(ctx context.Context, payload OnboardingPayload) (Commissionee, error) {
+func (cmr *commissioner) Commission(ctx context.Context, payload OnboardingPayload, opts ...CommissionOption) (Commissionee, error) {
 	query := NewQuery(
 		WithQueryOnboardingPayload(payload),
 	)
-	return cmr.commissionWithQuery(ctx, payload, query)
+	return cmr.commissionWithQuery(ctx, payload, query, opts...)
 }
 
 // CommissionOnNetwork commissions a device with a direct on-network address.
-func (cmr *commissioner) CommissionOnNetwork(ctx context.Context, payload OnboardingPayload, address net.IP, port int) (Commissionee, error) {
+func (cmr *commissioner) CommissionOnNetwork(ctx context.Context, payload OnboardingPayload, address net.IP, port int, opts ...CommissionOption) (Commissionee, error) {
 	query := NewQuery(
 		WithQueryOnboardingPayload(payload),
 		WithQueryOnNetworkAddress(address, port),
 	)
-	return cmr.commissionWithQuery(ctx, payload, query)
+	return cmr.commissionWithQuery(ctx, payload, query, opts...)
 }
 
-func (cmr *commissioner) commissionWithQuery(ctx context.Context, payload OnboardingPayload, query Query) (Commissionee, error) {
+func (cmr *commissioner) commissionWithQuery(ctx context.Context, payload OnboardingPayload, query Query, opts ...CommissionOption) (Commissionee, error) {
+	config := cmr.config
+	for _, apply := range opts {
+		apply(&config)
+	}
+	// A device commissioned by address answers on its operational network
+	// at the same address, which stands in for operational discovery when
+	// mDNS is disabled.
+	if address, port, ok := onNetworkAddress(query); ok && config.Resolver == nil {
+		if port <= 0 {
+			port = mdns.Port
+		}
+		config.Resolver = addressResolver{addr: &net.UDPAddr{IP: address, Port: port}}
+	}
+	// Fail before discovery when a plug-in the flow needs after PASE, such
+	// as the credential issuer or the CASE establisher, is not configured.
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%w to commission device: %w", ErrFailed, err)
+	}
+
+	dev, err := cmr.findDevice(ctx, payload, query)
+	if err != nil {
+		return nil, err
+	}
+	info, establishPASE := paseEstablisher(dev, payload)
+	res, err := commissioning.Run(ctx, info, establishPASE, config)
+	if err != nil {
+		return nil, fmt.Errorf("%w to commission device (%s): %w", ErrFailed, dev.String(), err)
+	}
+	log.Infof("Commissioned node %016X on fabric %016X (index %d)", res.NodeID, res.FabricID, res.FabricIndex)
+	return newCommissioneeWithDevice(dev, res), nil
+}
+
+// ScanNetworks lists the networks the device matching the query can see.
+func (cmr *commissioner) ScanNetworks(ctx context.Context, query Query, ssid []byte) (*commissioning.ScanResults, error) {
+	payload, ok := query.OnboardingPayload()
+	if !ok {
+		return nil, fmt.Errorf("%w: onboarding payload required for network scan", errors.ErrInvalid)
+	}
+	dev, err := cmr.findDevice(ctx, payload, query)
+	if err != nil {
+		return nil, err
+	}
+	info, establishPASE := paseEstablisher(dev, payload)
+	results, err := commissioning.Scan(ctx, info, establishPASE, ssid, cmr.config)
+	if err != nil {
+		return nil, fmt.Errorf("%w to scan networks (%s): %w", ErrFailed, dev.String(), err)
+	}
+	return results, nil
+}
+
+// paseEstablisher returns the commissionee identity and the PASE step for
+// the commissioning package.
+func paseEstablisher(dev CommissionableDevice, payload OnboardingPayload) (commissioning.DeviceInfo, func(context.Context) (commissioning.Session, error)) {
+	info := commissioning.DeviceInfo{
+		VendorID:  uint16(payload.VendorID()),
+		ProductID: uint16(payload.ProductID()),
+	}
+	return info, func(ctx context.Context) (commissioning.Session, error) {
+		return dev.EstablishPASE(ctx, payload)
+	}
+}
+
+// findDevice discovers the device that matches the onboarding payload.
+func (cmr *commissioner) findDevice(ctx context.Context, payload OnboardingPayload, query Query) (CommissionableDevice, error) {
 	devs, err := cmr.Discover(ctx, query)
 	if err != nil {
 		return nil, err
@@ -227,17 +385,26 @@ func (cmr *commissioner) commissionWithQuery(ctx context.Context, payload Onboar
 			dev.Discriminator().Equal(Discriminator(payload.Discriminator()))
 	}
 
+	// Prefer a device found on the network: PASE over BLE is not supported yet.
+	var bleMatch CommissionableDevice
 	for _, dev := range devs {
-		if isCommissionableDevicePayload(dev, payload) {
-			err = dev.Commission(ctx, payload)
-			if err != nil {
-				return nil, fmt.Errorf("%w to commission device (%s): %w", ErrFailed, dev.String(), err)
-			}
-			return newCommissioneeWithDevice(dev), nil
+		if !isCommissionableDevicePayload(dev, payload) {
+			continue
+		}
+		if _, ok := dev.(*bleDevice); !ok {
+			return dev, nil
 		}
+		if bleMatch == nil {
+			bleMatch = dev
+		}
+	}
+	if bleMatch != nil {
+		return bleMatch, nil
 	}
 
-	return nil, fmt.Errorf("%w: no matching commissionable device found (payload=%s)", ErrNotFound, payload.String())
+	// The payload string carries the passcode: report only what identifies the device.
+	return nil, fmt.Errorf("%w: no matching commissionable device found (discriminator=%s, vid=%s, pid=%s)",
+		ErrNotFound, payload.Discriminator(), payload.VendorID(), payload.ProductID())
 }
 
 // Start starts the commissioner.
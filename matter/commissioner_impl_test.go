@@ -0,0 +1,378 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/ble"
+	"github.com/YashubuStudio/go-matter-pack/matter/ble/btp"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
+	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
+	"github.com/YashubuStudio/go-matter-pack/matter/pase"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+// fakeSearchDiscoverer counts the commissionable node searches.
+type fakeSearchDiscoverer struct {
+	mdns.Discoverer
+	searches int
+}
+
+func (d *fakeSearchDiscoverer) Search(context.Context, mdns.Query) ([]mdns.CommissionableNode, error) {
+	d.searches++
+	return nil, nil
+}
+
+type fakeVerifier struct{}
+
+func (fakeVerifier) VerifyAttestation(context.Context, commissioning.AttestationInfo) error {
+	return nil
+}
+
+func TestCommissionRequiresPlugins(t *testing.T) {
+	payload, err := encoding.NewPairingCodeFromString("30357507966")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		config commissioning.Config
+		err    error
+	}{
+		{"no verifier", commissioning.Config{}, commissioning.ErrNoAttestationVerifier},
+		{"no issuer", commissioning.Config{Verifier: fakeVerifier{}}, commissioning.ErrNoCredentialIssuer},
+	}
+	for _, tt := range tests {
+		discoverer := &fakeSearchDiscoverer{}
+		cmr := NewCommissionerWithOptions(
+			WithCommissionerMDNSEnabled(true),
+			WithCommissionerDiscoverer(discoverer),
+			WithCommissioningConfig(tt.config),
+		)
+		_, err := cmr.Commission(context.Background(), payload)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: Commission() = %v, want %v", tt.name, err, tt.err)
+		}
+		if discoverer.searches != 0 {
+			t.Errorf("%s: discovered devices before failing", tt.name)
+		}
+	}
+}
+
+func TestFindDeviceRedactsPasscode(t *testing.T) {
+	payload, err := encoding.NewPairingCodeFromString("30357507966")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmr := NewCommissionerWithOptions(
+		WithCommissionerMDNSEnabled(true),
+		WithCommissionerDiscoverer(&fakeSearchDiscoverer{}),
+	)
+	query := NewQuery(WithQueryOnboardingPayload(payload))
+	_, err = cmr.(*commissioner).findDevice(context.Background(), payload, query)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("findDevice() = %v, want %v", err, ErrNotFound)
+	}
+	if passcode := fmt.Sprint(payload.Passcode()); strings.Contains(err.Error(), passcode) {
+		t.Errorf("findDevice() error %q leaks passcode %s", err, passcode)
+	}
+}
+
+// gattPipe is one end of an in-memory GATT link.
+type gattPipe struct {
+	in, out chan []byte
+	closed  chan struct{}
+	once    *sync.Once
+}
+
+func newGATTPipe() (*gattPipe, *gattPipe) {
+	a, b := make(chan []byte, 64), make(chan []byte, 64)
+	closed, once := make(chan struct{}), &sync.Once{}
+	return &gattPipe{a, b, closed, once}, &gattPipe{b, a, closed, once}
+}
+
+func (p *gattPipe) Read(ctx context.Context) ([]byte, error) {
+	select {
+	case b := <-p.in:
+		return b, nil
+	case <-p.closed:
+		return nil, errors.New("disconnected")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *gattPipe) Write(ctx context.Context, b []byte) (int, error) {
+	select {
+	case p.out <- bytes.Clone(b):
+		return len(b), nil
+	case <-p.closed:
+		return 0, errors.New("disconnected")
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (p *gattPipe) Close() error {
+	p.once.Do(func() { close(p.closed) })
+	return nil
+}
+
+// fakeBLEPeripheral is a connectable BLE device whose Matter service runs
+// a PASE responder over BTP.
+type fakeBLEPeripheral struct {
+	fakeBLEDevice
+	connected    bool
+	disconnected chan struct{}
+}
+
+func (d *fakeBLEPeripheral) Connect(context.Context) error {
+	d.connected = true
+	return nil
+}
+
+func (d *fakeBLEPeripheral) Disconnect() error {
+	if d.connected {
+		d.connected = false
+		close(d.disconnected)
+	}
+	return nil
+}
+
+type fakeBLEPASEService struct {
+	fakeBLEService
+	ctx       context.Context
+	responder *pase.Responder
+	accepted  chan error
+}
+
+func (s *fakeBLEPASEService) Open() (ble.Transport, error) {
+	central, peripheral := newGATTPipe()
+	go func() {
+		conn, err := btp.Accept(s.ctx, peripheral, "central", 244)
+		if err != nil {
+			s.accepted <- err
+			return
+		}
+		session := transport.NewUnsecuredSession(transport.NewConn(conn, nil), 0, transport.WithoutMRP())
+		_, err = s.responder.Accept(s.ctx, session)
+		s.accepted <- err
+	}()
+	return &fakeBLETransport{gatt: central}, nil
+}
+
+type fakeBLETransport struct {
+	ble.Transport
+	gatt *gattPipe
+}
+
+func (t *fakeBLETransport) Handshake(ctx context.Context, peer btp.Addr) (*btp.Conn, error) {
+	return btp.Handshake(ctx, t.gatt, peer, 0)
+}
+
+func (t *fakeBLETransport) Close() error {
+	return nil
+}
+
+func TestBLEDeviceEstablishPASE(t *testing.T) {
+	payload, err := encoding.NewPairingCodeFromString("30357507966")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		passcode pase.Passcode
+		ok       bool
+	}{
+		{"matching passcode", payload.Passcode(), true},
+		{"other passcode", 20202021, false},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		dev := &fakeBLEPeripheral{
+			fakeBLEDevice: fakeBLEDevice{addr: ble.Address{0xC0, 0x01, 0x02, 0x03, 0x04, 0x05}},
+			disconnected:  make(chan struct{}),
+		}
+		srv := &fakeBLEPASEService{
+			ctx:       ctx,
+			responder: &pase.Responder{Passcode: tt.passcode, Salt: []byte("SPAKE2P Key Salt"), Iterations: 1000},
+			accepted:  make(chan error, 1),
+		}
+		session, err := newBLEDevice(dev, srv).EstablishPASE(ctx, payload)
+		if tt.ok {
+			if err != nil {
+				t.Fatalf("%s: EstablishPASE() = %v", tt.name, err)
+			}
+			if err := <-srv.accepted; err != nil {
+				t.Errorf("%s: responder: %v", tt.name, err)
+			}
+			if len(session.AttestationChallenge()) == 0 {
+				t.Errorf("%s: session has no attestation challenge", tt.name)
+			}
+			session.Close()
+		} else if err == nil {
+			t.Errorf("%s: EstablishPASE() succeeded", tt.name)
+		}
+		select {
+		case <-dev.disconnected:
+		case <-ctx.Done():
+			t.Errorf("%s: device not disconnected", tt.name)
+		}
+		cancel()
+	}
+}
+
+func TestCommissionOnNetwork(t *testing.T) {
+	payload, err := encoding.NewPairingCodeFromString("30357507966")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	device := newFakeDevice(t, ctx, payload.Passcode())
+	fabric, err := credentials.NewFabric(rand.Reader, 0xFAB000000000001D, 0x1B669, 0xFFF1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := NewNOCIssuer(fabric)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var completed []commissioning.Stage
+	cmr := NewCommissionerWithOptions(WithCommissioningConfig(commissioning.Config{
+		Verifier: fakeVerifier{},
+		Issuer:   issuer,
+		Resolver: device,
+		CASE:     NewCASEEstablisher(fabric),
+		Progress: func(p commissioning.Progress) {
+			if p.Status == commissioning.StatusCompleted {
+				completed = append(completed, p.Stage)
+			}
+		},
+	}))
+	const nodeID uint64 = 0x1234
+	if _, err := cmr.(OnNetworkCommissioner).CommissionOnNetwork(ctx, payload, net.IPv4(127, 0, 0, 1), device.port(), WithNodeID(nodeID)); err != nil {
+		t.Fatalf("CommissionOnNetwork() = %v", err)
+	}
+	select {
+	case id := <-device.commissions:
+		if id != nodeID {
+			t.Errorf("device commissioned as node %016X, want %016X", id, nodeID)
+		}
+	default:
+		t.Error("device did not receive CommissioningComplete")
+	}
+	if n := len(completed); n == 0 || completed[n-1] != commissioning.StageCommissioningComplete {
+		t.Errorf("completed stages %v", completed)
+	}
+}
+
+// unreachableOnce fails the first operational discovery.
+type unreachableOnce struct {
+	commissioning.Resolver
+	failed bool
+}
+
+func (r *unreachableOnce) ResolveOperational(ctx context.Context, peer commissioning.OperationalPeer) ([]*net.UDPAddr, error) {
+	if !r.failed {
+		r.failed = true
+		return nil, errors.New("node not advertised yet")
+	}
+	return r.Resolver.ResolveOperational(ctx, peer)
+}
+
+func TestCommissionResume(t *testing.T) {
+	payload, err := encoding.NewPairingCodeFromString("30357507966")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	device := newFakeDevice(t, ctx, payload.Passcode())
+	fabric, err := credentials.NewFabric(rand.Reader, 0xFAB000000000001D, 0x1B669, 0xFFF1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := NewNOCIssuer(fabric)
+	if err != nil {
+		t.Fatal(err)
+	}
+	progress := map[commissioning.Stage]commissioning.Status{}
+	cmr := NewCommissionerWithOptions(WithCommissioningConfig(commissioning.Config{
+		Verifier: fakeVerifier{},
+		Issuer:   issuer,
+		Resolver: &unreachableOnce{Resolver: device},
+		CASE:     NewCASEEstablisher(fabric),
+		Progress: func(p commissioning.Progress) { progress[p.Stage] = p.Status },
+	})).(OnNetworkCommissioner)
+	const nodeID uint64 = 0x1234
+	var checkpoint *commissioning.Checkpoint
+	save := WithCheckpoint(func(cp commissioning.Checkpoint) { checkpoint = &cp })
+	addr := net.IPv4(127, 0, 0, 1)
+
+	// The first attempt stops at operational discovery with the fail-safe
+	// armed and the NOC installed.
+	_, err = cmr.CommissionOnNetwork(ctx, payload, addr, device.port(), WithNodeID(nodeID), save)
+	var stageErr *commissioning.StageError
+	if !errors.As(err, &stageErr) || stageErr.Stage != commissioning.StageOperationalDiscovery {
+		t.Fatalf("CommissionOnNetwork() = %v, want a %s failure", err, commissioning.StageOperationalDiscovery)
+	}
+	if !device.failSafeArmed() {
+		t.Fatal("fail-safe disarmed after the checkpointed attempt")
+	}
+	if checkpoint == nil || !checkpoint.Completed(commissioning.StageAddNOC) {
+		t.Fatalf("checkpoint %+v does not record AddNOC", checkpoint)
+	}
+
+	// The device accepts a new PASE session while the fail-safe is armed,
+	// and the resumed attempt completes without adding the NOC again.
+	clear(progress)
+	if _, err := cmr.CommissionOnNetwork(ctx, payload, addr, device.port(), WithNodeID(nodeID), save, WithResume(*checkpoint)); err != nil {
+		t.Fatalf("resumed CommissionOnNetwork() = %v", err)
+	}
+	if n := device.sessions(); n != 2 {
+		t.Errorf("device accepted %d PASE sessions, want 2", n)
+	}
+	for stage, want := range map[commissioning.Stage]commissioning.Status{
+		commissioning.StageCSR:                   commissioning.StatusSkipped,
+		commissioning.StageAddNOC:                commissioning.StatusSkipped,
+		commissioning.StageCASE:                  commissioning.StatusCompleted,
+		commissioning.StageCommissioningComplete: commissioning.StatusCompleted,
+	} {
+		if got := progress[stage]; got != want {
+			t.Errorf("%s %s, want %s", stage, got, want)
+		}
+	}
+	select {
+	case id := <-device.commissions:
+		if id != nodeID {
+			t.Errorf("device commissioned as node %016X, want %016X", id, nodeID)
+		}
+	default:
+		t.Error("device did not receive CommissioningComplete")
+	}
+}
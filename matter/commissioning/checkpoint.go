@@ -0,0 +1,83 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commissioning
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrCheckpointExpired is returned when resuming from a checkpoint whose
+	// fail-safe timer has expired, after which the device has rolled back.
+	ErrCheckpointExpired = errors.New("commissioning: checkpoint fail-safe expired")
+	// ErrInvalidCheckpoint is returned when resuming from a checkpoint that
+	// lacks the artifacts of the stages it claims to have completed.
+	ErrInvalidCheckpoint = errors.New("commissioning: invalid checkpoint")
+)
+
+// Checkpoint records the progress of a commissioning attempt so that it can
+// be resumed with Config.Resume while the fail-safe timer is still armed.
+// Stage is the last resumable stage completed; the operational discovery and
+// CASE stages always run again.
+type Checkpoint struct {
+	NodeID            uint64    `json:"node_id"`
+	Stage             Stage     `json:"stage"`
+	FailSafeExpiresAt time.Time `json:"fail_safe_expires_at"`
+	DAC               []byte    `json:"dac,omitempty"`
+	NOCSRElements     []byte    `json:"nocsr_elements,omitempty"`
+	Chain             *NOCChain `json:"noc_chain,omitempty"`
+	FabricIndex       uint8     `json:"fabric_index,omitempty"`
+}
+
+// Expired reports whether the fail-safe timer armed by the attempt has
+// expired at now.
+func (cp *Checkpoint) Expired(now time.Time) bool {
+	return !now.Before(cp.FailSafeExpiresAt)
+}
+
+// Completed reports whether the attempt completed stage s.
+func (cp *Checkpoint) Completed(s Stage) bool {
+	return s <= cp.Stage
+}
+
+func (cp *Checkpoint) validate(now time.Time) error {
+	switch {
+	case cp.Stage < StageArmFailSafe:
+		return fmt.Errorf("%w: no stage completed", ErrInvalidCheckpoint)
+	case cp.Expired(now):
+		return fmt.Errorf("%w at %s", ErrCheckpointExpired, cp.FailSafeExpiresAt.Format(time.RFC3339))
+	case cp.NodeID == 0:
+		return fmt.Errorf("%w: no node ID", ErrInvalidCheckpoint)
+	case cp.Completed(StageAttestation) && len(cp.DAC) == 0:
+		return fmt.Errorf("%w: no DAC", ErrInvalidCheckpoint)
+	case cp.Completed(StageCSR) && cp.Chain == nil:
+		return fmt.Errorf("%w: no NOC chain", ErrInvalidCheckpoint)
+	}
+	return nil
+}
+
+// resumable reports whether completing s is recorded in the checkpoint. The
+// selected network of a scan is not persisted, so a resumed attempt scans
+// again unless the network setup also completed.
+func (s Stage) resumable() bool {
+	switch s {
+	case StageArmFailSafe, StageRegulatoryConfig, StageAttestation, StageCSR,
+		StageAddTrustedRoot, StageAddNOC, StageNetworkSetup:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,327 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package commissioning drives the commissioner side of the Matter
+// commissioning flow once a PASE session has been established.
+// Reference: Matter Core Spec 1.5, Section 5.5 (Commissioning Flows)
+package commissioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+var (
+	// ErrNoAttestationVerifier is returned when Config.Verifier is nil.
+	ErrNoAttestationVerifier = errors.New("commissioning: no attestation verifier configured")
+	// ErrNoCredentialIssuer is returned when Config.Issuer is nil.
+	ErrNoCredentialIssuer = errors.New("commissioning: no credential issuer configured")
+	// ErrNoResolver is returned when Config.Resolver is nil.
+	ErrNoResolver = errors.New("commissioning: no operational resolver configured")
+	// ErrCASEUnavailable is returned when Config.CASE is nil.
+	ErrCASEUnavailable = errors.New("commissioning: CASE session establishment unavailable")
+)
+
+// Stage identifies a step of the commissioning flow.
+type Stage int
+
+// Commissioning stages in execution order. StageDisarmFailSafe only runs when
+// a stage fails after the fail-safe timer was armed.
+const (
+	StagePASE Stage = iota + 1
+	StageArmFailSafe
+	StageRegulatoryConfig
+	StageAttestation
+	StageCSR
+	StageAddTrustedRoot
+	StageAddNOC
+	StageNetworkScan
+	StageNetworkSetup
+	StageOperationalDiscovery
+	StageCASE
+	StageCommissioningComplete
+	StageDisarmFailSafe
+)
+
+var stageNames = map[Stage]string{
+	StagePASE:                  "pase",
+	StageArmFailSafe:           "arm-fail-safe",
+	StageRegulatoryConfig:      "regulatory-config",
+	StageAttestation:           "attestation",
+	StageCSR:                   "csr",
+	StageAddTrustedRoot:        "add-trusted-root",
+	StageAddNOC:                "add-noc",
+	StageNetworkScan:           "network-scan",
+	StageNetworkSetup:          "network-setup",
+	StageOperationalDiscovery:  "operational-discovery",
+	StageCASE:                  "case",
+	StageCommissioningComplete: "commissioning-complete",
+	StageDisarmFailSafe:        "disarm-fail-safe",
+}
+
+// String returns the stage name.
+func (s Stage) String() string {
+	if name, ok := stageNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("stage(%d)", int(s))
+}
+
+// MarshalText encodes the stage as its name.
+func (s Stage) MarshalText() ([]byte, error) {
+	if _, ok := stageNames[s]; !ok && s != 0 {
+		return nil, fmt.Errorf("commissioning: unknown %s", s)
+	}
+	return []byte(stageNames[s]), nil
+}
+
+// UnmarshalText decodes a stage name.
+func (s *Stage) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*s = 0
+		return nil
+	}
+	for stage, name := range stageNames {
+		if name == string(text) {
+			*s = stage
+			return nil
+		}
+	}
+	return fmt.Errorf("commissioning: unknown stage %q", text)
+}
+
+// Status is the state of a stage reported through Config.Progress.
+type Status int
+
+const (
+	StatusStarted Status = iota
+	StatusCompleted
+	StatusSkipped
+	StatusFailed
+)
+
+// String returns the status name.
+func (s Status) String() string {
+	switch s {
+	case StatusStarted:
+		return "started"
+	case StatusCompleted:
+		return "completed"
+	case StatusSkipped:
+		return "skipped"
+	case StatusFailed:
+		return "failed"
+	}
+	return fmt.Sprintf("status(%d)", int(s))
+}
+
+// Progress is a stage transition. Err is set when Status is StatusFailed.
+type Progress struct {
+	Stage  Stage
+	Status Status
+	Err    error
+}
+
+// Command is a cluster command payload, such as the request types generated
+// in the clusters package.
+type Command = im.Command
+
+// Session is a secure session to the commissionee.
+type Session interface {
+	// Invoke sends cmd to endpoint and returns the response command fields.
+	// Commands answered with a bare success status return the zero Value; a
+	// failure status is reported as *im.StatusError.
+	Invoke(ctx context.Context, endpoint uint16, cmd Command) (im.Value, error)
+	// AttestationChallenge returns the attestation challenge derived from the
+	// session keys.
+	AttestationChallenge() []byte
+	// Close closes the session.
+	Close() error
+}
+
+// DeviceInfo identifies the commissionee from its onboarding payload.
+type DeviceInfo struct {
+	VendorID  uint16
+	ProductID uint16
+}
+
+// AttestationInfo is the device attestation material collected over PASE.
+// Reference: Matter Core Spec 1.5, Section 6.2.3 (Device Attestation Procedure)
+type AttestationInfo struct {
+	Device               DeviceInfo
+	DAC                  []byte
+	PAI                  []byte
+	AttestationElements  []byte
+	AttestationSignature []byte
+	Nonce                []byte
+	Challenge            []byte
+}
+
+// AttestationVerifier decides whether the commissionee is a genuine device.
+type AttestationVerifier interface {
+	VerifyAttestation(ctx context.Context, info AttestationInfo) error
+}
+
+// NOCRequest carries the commissionee CSR to the credential issuer.
+type NOCRequest struct {
+	NodeID               uint64
+	Device               DeviceInfo
+	DAC                  []byte
+	NOCSRElements        []byte
+	AttestationSignature []byte
+	Nonce                []byte
+	Challenge            []byte
+}
+
+// NOCChain is the operational credential set installed on the commissionee.
+type NOCChain struct {
+	RCAC             []byte
+	ICAC             []byte
+	NOC              []byte
+	IPK              []byte
+	CaseAdminSubject uint64
+	AdminVendorID    uint16
+	FabricID         uint64
+	RootPublicKey    []byte
+}
+
+// CredentialIssuer signs a node operational certificate for the CSR.
+type CredentialIssuer interface {
+	IssueNOC(ctx context.Context, req NOCRequest) (*NOCChain, error)
+}
+
+// NetworkConfigurer provisions the operational network over the PASE
+// session. breadcrumb must be passed along with the network commands.
+type NetworkConfigurer interface {
+	ConfigureNetwork(ctx context.Context, s Session, breadcrumb uint64) error
+}
+
+// OperationalPeer identifies the commissionee on its new fabric.
+type OperationalPeer struct {
+	FabricID      uint64
+	NodeID        uint64
+	RootPublicKey []byte
+}
+
+// Resolver finds the operational addresses of a node.
+type Resolver interface {
+	ResolveOperational(ctx context.Context, peer OperationalPeer) ([]*net.UDPAddr, error)
+}
+
+// CASEEstablisher opens a CASE session to a node on its operational network.
+type CASEEstablisher interface {
+	EstablishCASE(ctx context.Context, peer OperationalPeer, addrs []*net.UDPAddr, chain *NOCChain) (Session, error)
+}
+
+// Defaults applied to a zero Config.
+const (
+	DefaultFailSafeExpiry = 60 * time.Second
+	DefaultCountryCode    = "XX"
+)
+
+// Config parameterizes Run. Verifier, Issuer, Resolver and CASE are
+// required; a nil Network skips network setup, which suits devices that are
+// already on the operational network. When Selector is set, the network
+// scan stage runs first and the selected network replaces Network.
+type Config struct {
+	// NodeID is the operational node ID to assign; zero picks a random one.
+	NodeID uint64
+	// FailSafeExpiry is the ArmFailSafe expiry length.
+	FailSafeExpiry time.Duration
+	// RegulatoryLocation defaults to IndoorOutdoor.
+	RegulatoryLocation *clusters.GeneralCommissioningRegulatoryLocationTypeEnum
+	// CountryCode is the ISO 3166-1 alpha-2 code; "XX" means unknown.
+	CountryCode string
+
+	Verifier AttestationVerifier
+	Issuer   CredentialIssuer
+	Network  NetworkConfigurer
+	Selector NetworkSelector
+	Resolver Resolver
+	CASE     CASEEstablisher
+
+	// Progress, if set, is called on every stage transition.
+	Progress func(Progress)
+	// Checkpoint, if set, is called with the updated checkpoint whenever a
+	// resumable stage completes so that it can be persisted. A failed attempt
+	// then leaves the fail-safe timer armed instead of disarming it, so that
+	// it can be resumed until the timer expires.
+	Checkpoint func(Checkpoint)
+	// Resume continues the attempt recorded in the checkpoint: its node ID,
+	// DAC and NOC chain are reused and the stages it completed are skipped.
+	Resume *Checkpoint
+	// Rand is the nonce source; nil uses crypto/rand.
+	Rand io.Reader
+}
+
+// Result describes a commissioned node.
+type Result struct {
+	NodeID      uint64
+	FabricID    uint64
+	FabricIndex uint8
+	Addresses   []*net.UDPAddr
+}
+
+// StageError reports the stage at which commissioning failed.
+type StageError struct {
+	Stage Stage
+	Err   error
+}
+
+// Error implements error.
+func (e *StageError) Error() string {
+	return fmt.Sprintf("commissioning: %s: %v", e.Stage, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// CommissioningError reports a General Commissioning response with a
+// non-OK ErrorCode.
+type CommissioningError struct {
+	Code      clusters.GeneralCommissioningCommissioningErrorEnum
+	DebugText string
+}
+
+// Error implements error.
+func (e *CommissioningError) Error() string {
+	if e.DebugText != "" {
+		return fmt.Sprintf("device returned %s: %s", e.Code, e.DebugText)
+	}
+	return fmt.Sprintf("device returned %s", e.Code)
+}
+
+// NOCStatusError reports an Operational Credentials NOCResponse with a
+// non-OK StatusCode.
+type NOCStatusError struct {
+	Status    clusters.OperationalCredentialsNodeOperationalCertStatusEnum
+	DebugText string
+}
+
+// Error implements error.
+func (e *NOCStatusError) Error() string {
+	if e.DebugText != "" {
+		return fmt.Sprintf("device returned %s: %s", e.Status, e.DebugText)
+	}
+	return fmt.Sprintf("device returned %s", e.Status)
+}
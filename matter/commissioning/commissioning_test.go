@@ -0,0 +1,349 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commissioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+)
+
+// commandKey identifies a command across clusters.
+type commandKey struct{ cluster, command uint32 }
+
+func keyOf(cmd Command) commandKey { return commandKey{cmd.ClusterID(), cmd.CommandID()} }
+
+// fakeSession answers commands from a table and records the requests it
+// received.
+type fakeSession struct {
+	t         *testing.T
+	responses map[commandKey]any
+	fail      map[commandKey]error
+	sent      []Command
+	closed    bool
+}
+
+func (s *fakeSession) Invoke(_ context.Context, endpoint uint16, cmd Command) (im.Value, error) {
+	if endpoint != 0 {
+		s.t.Errorf("endpoint = %d", endpoint)
+	}
+	s.sent = append(s.sent, cmd)
+	if err := s.fail[keyOf(cmd)]; err != nil {
+		return im.Value{}, err
+	}
+	resp, ok := s.responses[keyOf(cmd)]
+	if !ok {
+		return im.Value{}, nil
+	}
+	return im.MarshalValue(resp)
+}
+
+func (s *fakeSession) AttestationChallenge() []byte { return []byte("challenge") }
+
+func (s *fakeSession) Close() error {
+	s.closed = true
+	return nil
+}
+
+type fakePlugins struct {
+	attestation AttestationInfo
+	nocRequest  NOCRequest
+	operational *fakeSession
+}
+
+func (p *fakePlugins) VerifyAttestation(_ context.Context, info AttestationInfo) error {
+	p.attestation = info
+	return nil
+}
+
+func (p *fakePlugins) IssueNOC(_ context.Context, req NOCRequest) (*NOCChain, error) {
+	p.nocRequest = req
+	return &NOCChain{RCAC: []byte("rcac"), NOC: []byte("noc"), IPK: make([]byte, 16), FabricID: 1}, nil
+}
+
+func (p *fakePlugins) ResolveOperational(context.Context, OperationalPeer) ([]*net.UDPAddr, error) {
+	return []*net.UDPAddr{{IP: net.IPv6loopback, Port: 5540}}, nil
+}
+
+func (p *fakePlugins) EstablishCASE(context.Context, OperationalPeer, []*net.UDPAddr, *NOCChain) (Session, error) {
+	return p.operational, nil
+}
+
+func newPASESession(t *testing.T) *fakeSession {
+	fabric := uint8(2)
+	return &fakeSession{t: t, responses: map[commandKey]any{
+		{clusters.GeneralCommissioningClusterID, clusters.GeneralCommissioningCmdArmFailSafe}:                 clusters.GeneralCommissioningArmFailSafeResponse{},
+		{clusters.GeneralCommissioningClusterID, clusters.GeneralCommissioningCmdSetRegulatoryConfig}:         clusters.GeneralCommissioningSetRegulatoryConfigResponse{},
+		{clusters.OperationalCredentialsClusterID, clusters.OperationalCredentialsCmdCertificateChainRequest}: clusters.OperationalCredentialsCertificateChainResponse{Certificate: []byte("cert")},
+		{clusters.OperationalCredentialsClusterID, clusters.OperationalCredentialsCmdAttestationRequest}:      clusters.OperationalCredentialsAttestationResponse{AttestationElements: []byte("elements"), AttestationSignature: []byte("sig")},
+		{clusters.OperationalCredentialsClusterID, clusters.OperationalCredentialsCmdCSRRequest}:              clusters.OperationalCredentialsCSRResponse{NOCSRElements: []byte("csr")},
+		{clusters.OperationalCredentialsClusterID, clusters.OperationalCredentialsCmdAddNOC}:                  clusters.OperationalCredentialsNOCResponse{FabricIndex: &fabric},
+	}}
+}
+
+func newConfig(p *fakePlugins, stages *[]Progress) Config {
+	return Config{
+		NodeID:   0x1234,
+		Verifier: p,
+		Issuer:   p,
+		Resolver: p,
+		CASE:     p,
+		Progress: func(pr Progress) { *stages = append(*stages, pr) },
+	}
+}
+
+func TestRun(t *testing.T) {
+	pase := newPASESession(t)
+	p := &fakePlugins{operational: &fakeSession{t: t, responses: map[commandKey]any{
+		{clusters.GeneralCommissioningClusterID, clusters.GeneralCommissioningCmdCommissioningComplete}: clusters.GeneralCommissioningCommissioningCompleteResponse{},
+	}}}
+	var progress []Progress
+	establish := func(context.Context) (Session, error) { return pase, nil }
+
+	res, err := Run(context.Background(), DeviceInfo{VendorID: 0xFFF1, ProductID: 0x8000}, establish, newConfig(p, &progress))
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.NodeID != 0x1234 || res.FabricIndex != 2 || res.FabricID != 1 || len(res.Addresses) != 1 {
+		t.Errorf("result = %+v", res)
+	}
+
+	want := []Command{
+		clusters.GeneralCommissioningArmFailSafeRequest{},
+		clusters.GeneralCommissioningSetRegulatoryConfigRequest{},
+		clusters.OperationalCredentialsCertificateChainRequestRequest{},
+		clusters.OperationalCredentialsCertificateChainRequestRequest{},
+		clusters.OperationalCredentialsAttestationRequestRequest{},
+		clusters.OperationalCredentialsCSRRequestRequest{},
+		clusters.OperationalCredentialsAddTrustedRootCertificateRequest{},
+		clusters.OperationalCredentialsAddNOCRequest{},
+	}
+	if len(pase.sent) != len(want) {
+		t.Fatalf("sent %d commands over PASE, want %d", len(pase.sent), len(want))
+	}
+	for i, cmd := range pase.sent {
+		if keyOf(cmd) != keyOf(want[i]) {
+			t.Errorf("command %d = %T, want %T", i, cmd, want[i])
+		}
+	}
+	if arm := pase.sent[0].(clusters.GeneralCommissioningArmFailSafeRequest); arm.ExpiryLengthSeconds != 60 {
+		t.Errorf("ArmFailSafe = %+v", arm)
+	}
+	if reg := pase.sent[1].(clusters.GeneralCommissioningSetRegulatoryConfigRequest); reg.CountryCode != "XX" ||
+		reg.NewRegulatoryConfig != clusters.GeneralCommissioningRegulatoryLocationTypeEnumIndoorOutdoor {
+		t.Errorf("SetRegulatoryConfig = %+v", reg)
+	}
+	if len(p.operational.sent) != 1 || p.operational.sent[0].CommandID() != clusters.GeneralCommissioningCmdCommissioningComplete {
+		t.Errorf("CASE commands = %v", p.operational.sent)
+	}
+	if !pase.closed || !p.operational.closed {
+		t.Errorf("sessions not closed")
+	}
+
+	if !bytes.Equal(p.attestation.DAC, []byte("cert")) || len(p.attestation.Nonce) != 32 || p.attestation.Device.VendorID != 0xFFF1 {
+		t.Errorf("attestation = %+v", p.attestation)
+	}
+	if !bytes.Equal(p.nocRequest.NOCSRElements, []byte("csr")) || p.nocRequest.NodeID != 0x1234 {
+		t.Errorf("NOC request = %+v", p.nocRequest)
+	}
+
+	var skipped bool
+	for _, pr := range progress {
+		if pr.Status == StatusFailed {
+			t.Errorf("progress %v", pr)
+		}
+		if pr.Stage == StageNetworkSetup && pr.Status == StatusSkipped {
+			skipped = true
+		}
+	}
+	if !skipped {
+		t.Errorf("network setup not reported as skipped")
+	}
+	if last := progress[len(progress)-1]; last.Stage != StageCommissioningComplete || last.Status != StatusCompleted {
+		t.Errorf("last progress = %+v", last)
+	}
+}
+
+func TestRunDisarmsOnFailure(t *testing.T) {
+	pase := newPASESession(t)
+	pase.responses[commandKey{clusters.OperationalCredentialsClusterID, clusters.OperationalCredentialsCmdAddNOC}] = clusters.OperationalCredentialsNOCResponse{
+		StatusCode: clusters.OperationalCredentialsNodeOperationalCertStatusEnumInvalidNOC,
+	}
+	p := &fakePlugins{}
+	var progress []Progress
+	establish := func(context.Context) (Session, error) { return pase, nil }
+
+	_, err := Run(context.Background(), DeviceInfo{}, establish, newConfig(p, &progress))
+	var stageErr *StageError
+	if !errors.As(err, &stageErr) || stageErr.Stage != StageAddNOC {
+		t.Fatalf("err = %v", err)
+	}
+	var nocErr *NOCStatusError
+	if !errors.As(err, &nocErr) || nocErr.Status != clusters.OperationalCredentialsNodeOperationalCertStatusEnumInvalidNOC {
+		t.Fatalf("err = %v", err)
+	}
+
+	last := pase.sent[len(pase.sent)-1]
+	disarm, ok := last.(clusters.GeneralCommissioningArmFailSafeRequest)
+	if !ok || disarm.ExpiryLengthSeconds != 0 {
+		t.Errorf("last command = %+v, want ArmFailSafe(0)", last)
+	}
+	if pr := progress[len(progress)-1]; pr.Stage != StageDisarmFailSafe || pr.Status != StatusCompleted {
+		t.Errorf("last progress = %+v", pr)
+	}
+}
+
+// unreachable is a Resolver that never finds the node.
+type unreachable struct{}
+
+func (unreachable) ResolveOperational(context.Context, OperationalPeer) ([]*net.UDPAddr, error) {
+	return nil, context.DeadlineExceeded
+}
+
+func TestRunResume(t *testing.T) {
+	pase := newPASESession(t)
+	p := &fakePlugins{}
+	var progress []Progress
+	var checkpoints []Checkpoint
+	cfg := newConfig(p, &progress)
+	cfg.Resolver = unreachable{}
+	cfg.Checkpoint = func(cp Checkpoint) { checkpoints = append(checkpoints, cp) }
+	establish := func(context.Context) (Session, error) { return pase, nil }
+
+	_, err := Run(context.Background(), DeviceInfo{}, establish, cfg)
+	var stageErr *StageError
+	if !errors.As(err, &stageErr) || stageErr.Stage != StageOperationalDiscovery {
+		t.Fatalf("err = %v", err)
+	}
+	if last := pase.sent[len(pase.sent)-1]; keyOf(last) != keyOf(clusters.OperationalCredentialsAddNOCRequest{}) {
+		t.Errorf("last command = %T, want no disarm", last)
+	}
+	if len(checkpoints) == 0 {
+		t.Fatal("no checkpoint")
+	}
+	cp := checkpoints[len(checkpoints)-1]
+	if cp.Stage != StageAddNOC || cp.NodeID != 0x1234 || cp.FabricIndex != 2 || cp.Chain == nil ||
+		!bytes.Equal(cp.DAC, []byte("cert")) || !bytes.Equal(cp.NOCSRElements, []byte("csr")) || cp.Expired(time.Now()) {
+		t.Fatalf("checkpoint = %+v", cp)
+	}
+
+	resumed := newPASESession(t)
+	p.nocRequest = NOCRequest{}
+	cfg = newConfig(p, &progress)
+	cfg.NodeID = 0
+	cfg.Resume = &cp
+	p.operational = &fakeSession{t: t, responses: map[commandKey]any{
+		{clusters.GeneralCommissioningClusterID, clusters.GeneralCommissioningCmdCommissioningComplete}: clusters.GeneralCommissioningCommissioningCompleteResponse{},
+	}}
+	res, err := Run(context.Background(), DeviceInfo{}, func(context.Context) (Session, error) { return resumed, nil }, cfg)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.NodeID != 0x1234 || res.FabricIndex != 2 {
+		t.Errorf("result = %+v", res)
+	}
+	if len(resumed.sent) != 1 || keyOf(resumed.sent[0]) != keyOf(clusters.GeneralCommissioningArmFailSafeRequest{}) {
+		t.Errorf("resumed PASE commands = %v", resumed.sent)
+	}
+	if p.nocRequest.NodeID != 0 {
+		t.Errorf("NOC issued again: %+v", p.nocRequest)
+	}
+
+	cp.FailSafeExpiresAt = time.Now().Add(-time.Second)
+	_, err = Run(context.Background(), DeviceInfo{}, func(context.Context) (Session, error) {
+		t.Fatal("PASE attempted with an expired checkpoint")
+		return nil, nil
+	}, cfg)
+	if !errors.Is(err, ErrCheckpointExpired) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestStageText(t *testing.T) {
+	b, err := json.Marshal(Checkpoint{Stage: StageAddNOC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(b, &cp); err != nil || cp.Stage != StageAddNOC {
+		t.Errorf("round trip %s = %+v, %v", b, cp, err)
+	}
+	if !bytes.Contains(b, []byte(`"stage":"add-noc"`)) {
+		t.Errorf("encoded %s", b)
+	}
+}
+
+func TestRunFailsBeforeArming(t *testing.T) {
+	pase := newPASESession(t)
+	pase.fail = map[commandKey]error{{clusters.GeneralCommissioningClusterID, clusters.GeneralCommissioningCmdArmFailSafe}: &im.StatusError{Status: im.StatusBusy}}
+	var progress []Progress
+	establish := func(context.Context) (Session, error) { return pase, nil }
+
+	_, err := Run(context.Background(), DeviceInfo{}, establish, newConfig(&fakePlugins{}, &progress))
+	var status *im.StatusError
+	if !errors.As(err, &status) {
+		t.Fatalf("err = %v", err)
+	}
+	if len(pase.sent) != 1 {
+		t.Errorf("sent %d commands, want no disarm", len(pase.sent))
+	}
+}
+
+func TestRunRequiresPlugins(t *testing.T) {
+	establish := func(context.Context) (Session, error) {
+		t.Fatal("PASE attempted without plugins")
+		return nil, nil
+	}
+	_, err := Run(context.Background(), DeviceInfo{}, establish, Config{})
+	if !errors.Is(err, ErrNoAttestationVerifier) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	p := &fakePlugins{}
+	var progress []Progress
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    error
+	}{
+		{"complete", func(*Config) {}, nil},
+		{"no verifier", func(c *Config) { c.Verifier = nil }, ErrNoAttestationVerifier},
+		{"no issuer", func(c *Config) { c.Issuer = nil }, ErrNoCredentialIssuer},
+		{"no resolver", func(c *Config) { c.Resolver = nil }, ErrNoResolver},
+		{"no CASE", func(c *Config) { c.CASE = nil }, ErrCASEUnavailable},
+	}
+	for _, tt := range tests {
+		cfg := newConfig(p, &progress)
+		tt.modify(&cfg)
+		if err := cfg.Validate(); !errors.Is(err, tt.err) {
+			t.Errorf("%s: Validate() = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestStageString(t *testing.T) {
+	if StageArmFailSafe.String() != "arm-fail-safe" || Stage(99).String() != "stage(99)" {
+		t.Errorf("unexpected stage names")
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commissioning
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/thread"
+)
+
+// Wi-Fi credential limits.
+// Reference: Matter Core Spec 1.5, Section 11.9.7.3 (AddOrUpdateWiFiNetwork Command)
+const (
+	maxSSIDLength       = 32
+	minPassphraseLength = 8
+	maxPassphraseLength = 63
+	pskLength           = 64
+)
+
+// NetworkingStatusError reports a Network Commissioning response with a
+// NetworkingStatus other than Success.
+type NetworkingStatusError struct {
+	Status    clusters.NetworkCommissioningNetworkCommissioningStatusEnum
+	DebugText string
+	// ErrorValue is the vendor specific connect error, when reported.
+	ErrorValue *int32
+}
+
+// Error implements error.
+func (e *NetworkingStatusError) Error() string {
+	msg := fmt.Sprintf("device returned networking status %s", e.Status)
+	if e.ErrorValue != nil {
+		msg += fmt.Sprintf(" (error value %d)", *e.ErrorValue)
+	}
+	if e.DebugText != "" {
+		msg += ": " + e.DebugText
+	}
+	return msg
+}
+
+// WiFiNetwork provisions a Wi-Fi network with AddOrUpdateWiFiNetwork and
+// joins it with ConnectNetwork.
+type WiFiNetwork struct {
+	SSID []byte
+	// Credentials is the WPA passphrase or the 64 hex digit PSK; empty
+	// selects an unencrypted network.
+	Credentials []byte
+	// Endpoint hosts the Wi-Fi Network Commissioning cluster.
+	Endpoint uint16
+}
+
+// String returns a description of the network that omits the credentials.
+func (n WiFiNetwork) String() string {
+	return fmt.Sprintf("Wi-Fi %q", n.SSID)
+}
+
+// Validate checks the SSID and credential lengths.
+func (n WiFiNetwork) Validate() error {
+	if len(n.SSID) == 0 || len(n.SSID) > maxSSIDLength {
+		return fmt.Errorf("commissioning: Wi-Fi SSID must be 1 to %d bytes", maxSSIDLength)
+	}
+	switch l := len(n.Credentials); {
+	case l == 0:
+	case l == pskLength:
+		if _, err := hex.DecodeString(string(n.Credentials)); err != nil {
+			return fmt.Errorf("commissioning: 64 character Wi-Fi credentials must be a hex PSK")
+		}
+	case l < minPassphraseLength || l > maxPassphraseLength:
+		return fmt.Errorf("commissioning: Wi-Fi passphrase must be %d to %d bytes", minPassphraseLength, maxPassphraseLength)
+	}
+	return nil
+}
+
+// ConfigureNetwork implements NetworkConfigurer.
+func (n WiFiNetwork) ConfigureNetwork(ctx context.Context, s Session, breadcrumb uint64) error {
+	var config clusters.NetworkCommissioningNetworkConfigResponse
+	v, err := s.Invoke(ctx, n.Endpoint, clusters.NetworkCommissioningAddOrUpdateWiFiNetworkRequest{
+		SSID:        n.SSID,
+		Credentials: n.Credentials,
+		Breadcrumb:  &breadcrumb,
+	})
+	if err != nil {
+		return fmt.Errorf("AddOrUpdateWiFiNetwork: %w", err)
+	}
+	if err := v.Unmarshal(&config); err != nil {
+		return fmt.Errorf("AddOrUpdateWiFiNetwork: decode response: %w", err)
+	}
+	if err := networkingStatusError(config.NetworkingStatus, config.DebugText, nil); err != nil {
+		return fmt.Errorf("AddOrUpdateWiFiNetwork: %w", err)
+	}
+	return connectNetwork(ctx, s, n.Endpoint, n.SSID, breadcrumb)
+}
+
+// ThreadNetwork provisions a Thread network with AddOrUpdateThreadNetwork and
+// joins it with ConnectNetwork. The network ID is the extended PAN ID.
+type ThreadNetwork struct {
+	// Dataset is the encoded operational dataset.
+	Dataset []byte
+	// Endpoint hosts the Thread Network Commissioning cluster.
+	Endpoint uint16
+}
+
+// String returns a description of the network that omits the network key.
+func (n ThreadNetwork) String() string {
+	d, err := thread.Parse(n.Dataset)
+	if err != nil {
+		return "Thread (invalid dataset)"
+	}
+	return d.String()
+}
+
+// Validate checks that the dataset holds what the device needs to join.
+func (n ThreadNetwork) Validate() error {
+	d, err := thread.Parse(n.Dataset)
+	if err != nil {
+		return fmt.Errorf("commissioning: %w", err)
+	}
+	if err := d.Validate(); err != nil {
+		return fmt.Errorf("commissioning: %w", err)
+	}
+	return nil
+}
+
+// ConfigureNetwork implements NetworkConfigurer.
+func (n ThreadNetwork) ConfigureNetwork(ctx context.Context, s Session, breadcrumb uint64) error {
+	d, err := thread.Parse(n.Dataset)
+	if err != nil {
+		return err
+	}
+	var config clusters.NetworkCommissioningNetworkConfigResponse
+	v, err := s.Invoke(ctx, n.Endpoint, clusters.NetworkCommissioningAddOrUpdateThreadNetworkRequest{
+		OperationalDataset: n.Dataset,
+		Breadcrumb:         &breadcrumb,
+	})
+	if err != nil {
+		return fmt.Errorf("AddOrUpdateThreadNetwork: %w", err)
+	}
+	if err := v.Unmarshal(&config); err != nil {
+		return fmt.Errorf("AddOrUpdateThreadNetwork: decode response: %w", err)
+	}
+	if err := networkingStatusError(config.NetworkingStatus, config.DebugText, nil); err != nil {
+		return fmt.Errorf("AddOrUpdateThreadNetwork: %w", err)
+	}
+	return connectNetwork(ctx, s, n.Endpoint, d.ExtendedPANID, breadcrumb)
+}
+
+// connectNetwork joins the network identified by networkID.
+func connectNetwork(ctx context.Context, s Session, endpoint uint16, networkID []byte, breadcrumb uint64) error {
+	var resp clusters.NetworkCommissioningConnectNetworkResponse
+	v, err := s.Invoke(ctx, endpoint, clusters.NetworkCommissioningConnectNetworkRequest{
+		NetworkID:  networkID,
+		Breadcrumb: &breadcrumb,
+	})
+	if err != nil {
+		return fmt.Errorf("ConnectNetwork: %w", err)
+	}
+	if err := v.Unmarshal(&resp); err != nil {
+		return fmt.Errorf("ConnectNetwork: decode response: %w", err)
+	}
+	if err := networkingStatusError(resp.NetworkingStatus, resp.DebugText, resp.ErrorValue); err != nil {
+		return fmt.Errorf("ConnectNetwork: %w", err)
+	}
+	return nil
+}
+
+func networkingStatusError(status clusters.NetworkCommissioningNetworkCommissioningStatusEnum, debugText *string, errorValue *int32) error {
+	if status == clusters.NetworkCommissioningNetworkCommissioningStatusEnumSuccess {
+		return nil
+	}
+	e := &NetworkingStatusError{Status: status, ErrorValue: errorValue}
+	if debugText != nil {
+		e.DebugText = *debugText
+	}
+	return e
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commissioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+)
+
+func TestWiFiNetwork(t *testing.T) {
+	network := WiFiNetwork{SSID: []byte("home"), Credentials: []byte("secret-passphrase")}
+	s := &fakeSession{t: t, responses: map[commandKey]any{
+		{clusters.NetworkCommissioningClusterID, clusters.NetworkCommissioningCmdAddOrUpdateWiFiNetwork}: clusters.NetworkCommissioningNetworkConfigResponse{},
+		{clusters.NetworkCommissioningClusterID, clusters.NetworkCommissioningCmdConnectNetwork}:         clusters.NetworkCommissioningConnectNetworkResponse{},
+	}}
+	if err := network.ConfigureNetwork(context.Background(), s, 8); err != nil {
+		t.Fatalf("ConfigureNetwork: %v", err)
+	}
+	if len(s.sent) != 2 {
+		t.Fatalf("sent %d commands", len(s.sent))
+	}
+	add := s.sent[0].(clusters.NetworkCommissioningAddOrUpdateWiFiNetworkRequest)
+	if string(add.SSID) != "home" || string(add.Credentials) != "secret-passphrase" || *add.Breadcrumb != 8 {
+		t.Errorf("AddOrUpdateWiFiNetwork = %+v", add)
+	}
+	connect := s.sent[1].(clusters.NetworkCommissioningConnectNetworkRequest)
+	if string(connect.NetworkID) != "home" {
+		t.Errorf("ConnectNetwork = %+v", connect)
+	}
+
+	for _, out := range []string{network.String(), fmt.Sprintf("%v", network), fmt.Sprintf("%+v", network)} {
+		if strings.Contains(out, "secret") {
+			t.Errorf("formatted network leaks credentials: %s", out)
+		}
+	}
+}
+
+func TestWiFiNetworkStatus(t *testing.T) {
+	errorValue := int32(-7)
+	s := &fakeSession{t: t, responses: map[commandKey]any{
+		{clusters.NetworkCommissioningClusterID, clusters.NetworkCommissioningCmdAddOrUpdateWiFiNetwork}: clusters.NetworkCommissioningNetworkConfigResponse{},
+		{clusters.NetworkCommissioningClusterID, clusters.NetworkCommissioningCmdConnectNetwork}: clusters.NetworkCommissioningConnectNetworkResponse{
+			NetworkingStatus: clusters.NetworkCommissioningNetworkCommissioningStatusEnumAuthFailure,
+			ErrorValue:       &errorValue,
+		},
+	}}
+	err := WiFiNetwork{SSID: []byte("home")}.ConfigureNetwork(context.Background(), s, 0)
+	var statusErr *NetworkingStatusError
+	if !errors.As(err, &statusErr) || statusErr.Status != clusters.NetworkCommissioningNetworkCommissioningStatusEnumAuthFailure ||
+		statusErr.ErrorValue == nil || *statusErr.ErrorValue != -7 {
+		t.Fatalf("err = %v", err)
+	}
+	if !strings.Contains(err.Error(), "AuthFailure") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestWiFiNetworkValidate(t *testing.T) {
+	for _, tt := range []struct {
+		network WiFiNetwork
+		ok      bool
+	}{
+		{WiFiNetwork{SSID: []byte("open")}, true},
+		{WiFiNetwork{SSID: []byte("home"), Credentials: []byte("12345678")}, true},
+		{WiFiNetwork{SSID: []byte("home"), Credentials: []byte(strings.Repeat("ab", 32))}, true},
+		{WiFiNetwork{SSID: []byte("home"), Credentials: []byte(strings.Repeat("zz", 32))}, false},
+		{WiFiNetwork{SSID: []byte("home"), Credentials: []byte("short")}, false},
+		{WiFiNetwork{}, false},
+		{WiFiNetwork{SSID: []byte(strings.Repeat("s", 33))}, false},
+	} {
+		if err := tt.network.Validate(); (err == nil) != tt.ok {
+			t.Errorf("Validate(%v) = %v", tt.network, err)
+		}
+	}
+}
+
+func TestThreadNetwork(t *testing.T) {
+	dataset, _ := hex.DecodeString("000300000f" + "01021234" + "02081111111122222222" +
+		"051000112233445566778899aabbccddeeff" + "0708fd11111111222222")
+	network := ThreadNetwork{Dataset: dataset}
+	if err := network.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	s := &fakeSession{t: t, responses: map[commandKey]any{
+		{clusters.NetworkCommissioningClusterID, clusters.NetworkCommissioningCmdAddOrUpdateThreadNetwork}: clusters.NetworkCommissioningNetworkConfigResponse{},
+		{clusters.NetworkCommissioningClusterID, clusters.NetworkCommissioningCmdConnectNetwork}:           clusters.NetworkCommissioningConnectNetworkResponse{},
+	}}
+	if err := network.ConfigureNetwork(context.Background(), s, 8); err != nil {
+		t.Fatalf("ConfigureNetwork: %v", err)
+	}
+	add := s.sent[0].(clusters.NetworkCommissioningAddOrUpdateThreadNetworkRequest)
+	if !bytes.Equal(add.OperationalDataset, dataset) {
+		t.Errorf("AddOrUpdateThreadNetwork = %+v", add)
+	}
+	connect := s.sent[1].(clusters.NetworkCommissioningConnectNetworkRequest)
+	if hex.EncodeToString(connect.NetworkID) != "1111111122222222" {
+		t.Errorf("ConnectNetwork = %x", connect.NetworkID)
+	}
+	if strings.Contains(network.String(), "00112233") {
+		t.Errorf("String() leaks the network key: %s", network)
+	}
+
+	if err := (ThreadNetwork{Dataset: dataset[:9]}).Validate(); err == nil {
+		t.Errorf("Validate(partial) succeeded")
+	}
+}
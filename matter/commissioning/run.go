@@ -0,0 +1,472 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commissioning
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+)
+
+const (
+	// rootEndpoint hosts the General Commissioning and Operational
+	// Credentials clusters.
+	rootEndpoint uint16 = 0
+	// nonceSize is the length of the attestation and CSR nonces.
+	nonceSize = 32
+	// ipkSize is the length of an epoch key.
+	ipkSize = 16
+	// maxOperationalNodeID is the upper bound of the operational node ID range.
+	// Reference: Matter Core Spec 1.5, Section 2.5.5.1 (Operational Node ID)
+	maxOperationalNodeID uint64 = 0xFFFFFFEFFFFFFFFF
+	// disarmTimeout bounds the best-effort fail-safe disarm after a failure.
+	disarmTimeout = 5 * time.Second
+)
+
+// Run completes commissioning of the device reachable through establish,
+// which opens the PASE session. Every stage is reported to cfg.Progress and a
+// failure is returned as *StageError. When a stage fails after ArmFailSafe
+// succeeded, the fail-safe timer is expired so that the device rolls back,
+// unless cfg.Checkpoint records the attempt for a later cfg.Resume.
+func Run(ctx context.Context, info DeviceInfo, establish func(context.Context) (Session, error), cfg Config) (*Result, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg = cfg.withDefaults()
+	r := &runner{cfg: cfg, info: info}
+	if cfg.Resume != nil {
+		if err := cfg.Resume.validate(time.Now()); err != nil {
+			return nil, err
+		}
+		if cfg.NodeID != 0 && cfg.NodeID != cfg.Resume.NodeID {
+			return nil, fmt.Errorf("%w: node ID %016X differs from %016X", ErrInvalidCheckpoint, cfg.Resume.NodeID, cfg.NodeID)
+		}
+		cfg.NodeID = cfg.Resume.NodeID
+		r.cp = *cfg.Resume
+	}
+	if cfg.NodeID == 0 {
+		id, err := randomNodeID(cfg.Rand)
+		if err != nil {
+			return nil, err
+		}
+		cfg.NodeID = id
+	}
+	r.cfg = cfg
+	r.cp.NodeID = cfg.NodeID
+
+	if err := r.open(ctx, establish); err != nil {
+		return nil, err
+	}
+	defer r.pase.Close()
+
+	res, err := r.run(ctx)
+	if err != nil && r.armed && cfg.Checkpoint == nil {
+		r.disarm(ctx)
+	}
+	return res, err
+}
+
+type runner struct {
+	cfg   Config
+	info  DeviceInfo
+	pase  Session
+	armed bool
+	cp    Checkpoint
+}
+
+func (r *runner) run(ctx context.Context) (*Result, error) {
+	if err := r.stage(StageArmFailSafe, func() error {
+		if err := r.armFailSafe(ctx, r.cfg.FailSafeExpiry); err != nil {
+			return err
+		}
+		r.cp.FailSafeExpiresAt = time.Now().Add(r.cfg.FailSafeExpiry)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	r.armed = true
+
+	if err := r.resume(StageRegulatoryConfig, func() error {
+		var resp clusters.GeneralCommissioningSetRegulatoryConfigResponse
+		err := invoke(ctx, r.pase, clusters.GeneralCommissioningSetRegulatoryConfigRequest{
+			NewRegulatoryConfig: *r.cfg.RegulatoryLocation,
+			CountryCode:         r.cfg.CountryCode,
+			Breadcrumb:          uint64(StageRegulatoryConfig),
+		}, &resp)
+		if err != nil {
+			return err
+		}
+		return commissioningError(resp.ErrorCode, resp.DebugText)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := r.resume(StageAttestation, func() error {
+		info, err := r.attest(ctx)
+		if err != nil {
+			return err
+		}
+		if err := r.cfg.Verifier.VerifyAttestation(ctx, *info); err != nil {
+			return err
+		}
+		r.cp.DAC = info.DAC
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := r.resume(StageCSR, func() error {
+		chain, err := r.requestNOC(ctx, r.cp.DAC)
+		if err != nil {
+			return err
+		}
+		r.cp.Chain = chain
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	chain := r.cp.Chain
+
+	if err := r.resume(StageAddTrustedRoot, func() error {
+		return invoke(ctx, r.pase, clusters.OperationalCredentialsAddTrustedRootCertificateRequest{
+			RootCACertificate: chain.RCAC,
+		}, nil)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := r.resume(StageAddNOC, func() error {
+		var resp clusters.OperationalCredentialsNOCResponse
+		err := invoke(ctx, r.pase, clusters.OperationalCredentialsAddNOCRequest{
+			NOCValue:         chain.NOC,
+			ICACValue:        chain.ICAC,
+			IPKValue:         chain.IPK,
+			CaseAdminSubject: chain.CaseAdminSubject,
+			AdminVendorId:    chain.AdminVendorID,
+		}, &resp)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != clusters.OperationalCredentialsNodeOperationalCertStatusEnumOK {
+			e := &NOCStatusError{Status: resp.StatusCode}
+			if resp.DebugText != nil {
+				e.DebugText = *resp.DebugText
+			}
+			return e
+		}
+		if resp.FabricIndex != nil {
+			r.cp.FabricIndex = *resp.FabricIndex
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	res := &Result{NodeID: r.cfg.NodeID, FabricID: chain.FabricID, FabricIndex: r.cp.FabricIndex}
+
+	network := r.cfg.Network
+	if r.cfg.Selector == nil {
+		r.report(StageNetworkScan, StatusSkipped, nil)
+	} else if err := r.resume(StageNetworkScan, func() error {
+		results, err := ScanNetworks(ctx, r.pase, rootEndpoint, nil, uint64(StageNetworkScan))
+		if err != nil {
+			return err
+		}
+		network, err = r.cfg.Selector.SelectNetwork(ctx, results)
+		if err != nil {
+			return err
+		}
+		if v, ok := network.(interface{ Validate() error }); ok {
+			return v.Validate()
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if network == nil {
+		r.report(StageNetworkSetup, StatusSkipped, nil)
+	} else if err := r.resume(StageNetworkSetup, func() error {
+		return network.ConfigureNetwork(ctx, r.pase, uint64(StageNetworkSetup))
+	}); err != nil {
+		return nil, err
+	}
+
+	peer := OperationalPeer{FabricID: chain.FabricID, NodeID: r.cfg.NodeID, RootPublicKey: chain.RootPublicKey}
+	if err := r.stage(StageOperationalDiscovery, func() error {
+		addrs, err := r.cfg.Resolver.ResolveOperational(ctx, peer)
+		if err != nil {
+			return err
+		}
+		if len(addrs) == 0 {
+			return fmt.Errorf("node %016X not found on the operational network", peer.NodeID)
+		}
+		res.Addresses = addrs
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var operational Session
+	if err := r.stage(StageCASE, func() error {
+		var err error
+		operational, err = r.cfg.CASE.EstablishCASE(ctx, peer, res.Addresses, chain)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	defer operational.Close()
+
+	if err := r.stage(StageCommissioningComplete, func() error {
+		var resp clusters.GeneralCommissioningCommissioningCompleteResponse
+		err := invoke(ctx, operational, clusters.GeneralCommissioningCommissioningCompleteRequest{}, &resp)
+		if err != nil {
+			return err
+		}
+		return commissioningError(resp.ErrorCode, resp.DebugText)
+	}); err != nil {
+		return nil, err
+	}
+	r.armed = false
+	return res, nil
+}
+
+// open runs establish as the PASE stage.
+func (r *runner) open(ctx context.Context, establish func(context.Context) (Session, error)) error {
+	return r.stage(StagePASE, func() error {
+		s, err := establish(ctx)
+		r.pase = s
+		return err
+	})
+}
+
+// attest collects the DAC, PAI and attestation response.
+func (r *runner) attest(ctx context.Context) (*AttestationInfo, error) {
+	info := &AttestationInfo{Device: r.info, Challenge: r.pase.AttestationChallenge()}
+	for _, cert := range []struct {
+		typ clusters.OperationalCredentialsCertificateChainTypeEnum
+		dst *[]byte
+	}{
+		{clusters.OperationalCredentialsCertificateChainTypeEnumDACCertificate, &info.DAC},
+		{clusters.OperationalCredentialsCertificateChainTypeEnumPAICertificate, &info.PAI},
+	} {
+		var resp clusters.OperationalCredentialsCertificateChainResponse
+		err := invoke(ctx, r.pase, clusters.OperationalCredentialsCertificateChainRequestRequest{CertificateType: cert.typ}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		*cert.dst = resp.Certificate
+	}
+
+	nonce, err := r.nonce()
+	if err != nil {
+		return nil, err
+	}
+	var resp clusters.OperationalCredentialsAttestationResponse
+	err = invoke(ctx, r.pase, clusters.OperationalCredentialsAttestationRequestRequest{AttestationNonce: nonce}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	info.Nonce = nonce
+	info.AttestationElements = resp.AttestationElements
+	info.AttestationSignature = resp.AttestationSignature
+	return info, nil
+}
+
+// requestNOC asks the device for a CSR and has it signed by the issuer.
+func (r *runner) requestNOC(ctx context.Context, dac []byte) (*NOCChain, error) {
+	nonce, err := r.nonce()
+	if err != nil {
+		return nil, err
+	}
+	var resp clusters.OperationalCredentialsCSRResponse
+	err = invoke(ctx, r.pase, clusters.OperationalCredentialsCSRRequestRequest{CSRNonce: nonce}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	r.cp.NOCSRElements = resp.NOCSRElements
+	chain, err := r.cfg.Issuer.IssueNOC(ctx, NOCRequest{
+		NodeID:               r.cfg.NodeID,
+		Device:               r.info,
+		DAC:                  dac,
+		NOCSRElements:        resp.NOCSRElements,
+		AttestationSignature: resp.AttestationSignature,
+		Nonce:                nonce,
+		Challenge:            r.pase.AttestationChallenge(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case chain == nil || len(chain.NOC) == 0:
+		return nil, errors.New("issuer returned no NOC")
+	case len(chain.RCAC) == 0:
+		return nil, errors.New("issuer returned no root certificate")
+	case len(chain.IPK) != ipkSize:
+		return nil, fmt.Errorf("issuer returned a %d byte IPK, want %d", len(chain.IPK), ipkSize)
+	}
+	return chain, nil
+}
+
+func (r *runner) armFailSafe(ctx context.Context, expiry time.Duration) error {
+	secs := expiry / time.Second
+	if secs > 0xFFFF {
+		secs = 0xFFFF
+	}
+	breadcrumb := uint64(StageArmFailSafe)
+	if expiry == 0 {
+		breadcrumb = 0
+	}
+	var resp clusters.GeneralCommissioningArmFailSafeResponse
+	err := invoke(ctx, r.pase, clusters.GeneralCommissioningArmFailSafeRequest{
+		ExpiryLengthSeconds: uint16(secs),
+		Breadcrumb:          breadcrumb,
+	}, &resp)
+	if err != nil {
+		return err
+	}
+	return commissioningError(resp.ErrorCode, resp.DebugText)
+}
+
+// disarm expires the fail-safe timer, which makes the device discard the
+// credentials and configuration added so far. It runs even when ctx is done.
+func (r *runner) disarm(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), disarmTimeout)
+	defer cancel()
+	_ = r.stage(StageDisarmFailSafe, func() error {
+		return r.armFailSafe(ctx, 0)
+	})
+}
+
+// stage runs fn as stage s and reports its outcome.
+func (r *runner) stage(s Stage, fn func() error) error {
+	r.report(s, StatusStarted, nil)
+	if err := fn(); err != nil {
+		r.report(s, StatusFailed, err)
+		return &StageError{Stage: s, Err: err}
+	}
+	r.report(s, StatusCompleted, nil)
+	r.checkpoint(s)
+	return nil
+}
+
+// resume runs fn as stage s unless the resumed attempt completed it, in
+// which case s is reported as skipped.
+func (r *runner) resume(s Stage, fn func() error) error {
+	if r.cfg.Resume != nil && r.cfg.Resume.Completed(s) {
+		r.report(s, StatusSkipped, nil)
+		return nil
+	}
+	return r.stage(s, fn)
+}
+
+// checkpoint records the completion of s and hands the checkpoint to
+// cfg.Checkpoint.
+func (r *runner) checkpoint(s Stage) {
+	if !s.resumable() {
+		return
+	}
+	r.cp.Stage = max(r.cp.Stage, s)
+	if r.cfg.Checkpoint != nil {
+		r.cfg.Checkpoint(r.cp)
+	}
+}
+
+func (r *runner) report(s Stage, status Status, err error) {
+	if r.cfg.Progress != nil {
+		r.cfg.Progress(Progress{Stage: s, Status: status, Err: err})
+	}
+}
+
+func (r *runner) nonce() ([]byte, error) {
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(r.cfg.Rand, nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return nonce, nil
+}
+
+// invoke sends cmd on the root endpoint and decodes the response into resp
+// unless resp is nil.
+func invoke(ctx context.Context, s Session, cmd Command, resp any) error {
+	v, err := s.Invoke(ctx, rootEndpoint, cmd)
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return nil
+	}
+	if err := v.Unmarshal(resp); err != nil {
+		return fmt.Errorf("decode %T: %w", resp, err)
+	}
+	return nil
+}
+
+func commissioningError(code clusters.GeneralCommissioningCommissioningErrorEnum, debugText string) error {
+	if code == clusters.GeneralCommissioningCommissioningErrorEnumOK {
+		return nil
+	}
+	return &CommissioningError{Code: code, DebugText: debugText}
+}
+
+func randomNodeID(rnd io.Reader) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(rnd, b[:]); err != nil {
+		return 0, fmt.Errorf("commissioning: generate node ID: %w", err)
+	}
+	return binary.LittleEndian.Uint64(b[:])%maxOperationalNodeID + 1, nil
+}
+
+// Validate reports the first plug-in Run requires that cfg lacks, or the
+// error of an invalid network configuration, so that callers can fail before
+// discovering and connecting to the device.
+func (cfg Config) Validate() error {
+	switch {
+	case cfg.Verifier == nil:
+		return ErrNoAttestationVerifier
+	case cfg.Issuer == nil:
+		return ErrNoCredentialIssuer
+	case cfg.Resolver == nil:
+		return ErrNoResolver
+	case cfg.CASE == nil:
+		return ErrCASEUnavailable
+	}
+	if v, ok := cfg.Network.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.FailSafeExpiry <= 0 {
+		cfg.FailSafeExpiry = DefaultFailSafeExpiry
+	}
+	if cfg.RegulatoryLocation == nil {
+		loc := clusters.GeneralCommissioningRegulatoryLocationTypeEnumIndoorOutdoor
+		cfg.RegulatoryLocation = &loc
+	}
+	if cfg.CountryCode == "" {
+		cfg.CountryCode = DefaultCountryCode
+	}
+	if cfg.Rand == nil {
+		cfg.Rand = rand.Reader
+	}
+	return cfg
+}
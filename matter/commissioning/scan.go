@@ -0,0 +1,88 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commissioning
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+)
+
+// ScanResults are the networks a commissionee reported in ScanNetworksResponse.
+// Only one list is populated, depending on the interface type of the device.
+type ScanResults struct {
+	WiFi   []clusters.NetworkCommissioningWiFiInterfaceScanResultStruct
+	Thread []clusters.NetworkCommissioningThreadInterfaceScanResultStruct
+}
+
+// NetworkSelector picks the network to provision once the commissionee has
+// reported the networks it can see. Returning a nil NetworkConfigurer skips
+// network setup.
+type NetworkSelector interface {
+	SelectNetwork(ctx context.Context, results *ScanResults) (NetworkConfigurer, error)
+}
+
+// ScanNetworks asks the commissionee for the networks it can see. A nil ssid
+// scans for all Wi-Fi networks; Thread devices ignore it. The fail-safe timer
+// must be armed.
+// Reference: Matter Core Spec 1.5, Section 11.9.7.1 (ScanNetworks Command)
+func ScanNetworks(ctx context.Context, s Session, endpoint uint16, ssid []byte, breadcrumb uint64) (*ScanResults, error) {
+	req := clusters.NetworkCommissioningScanNetworksRequest{Breadcrumb: &breadcrumb}
+	if ssid != nil {
+		req.SSID = &ssid
+	}
+	v, err := s.Invoke(ctx, endpoint, req)
+	if err != nil {
+		return nil, fmt.Errorf("ScanNetworks: %w", err)
+	}
+	var resp clusters.NetworkCommissioningScanNetworksResponse
+	if err := v.Unmarshal(&resp); err != nil {
+		return nil, fmt.Errorf("ScanNetworks: decode response: %w", err)
+	}
+	if err := networkingStatusError(resp.NetworkingStatus, resp.DebugText, nil); err != nil {
+		return nil, fmt.Errorf("ScanNetworks: %w", err)
+	}
+	return &ScanResults{WiFi: resp.WiFiScanResults, Thread: resp.ThreadScanResults}, nil
+}
+
+// Scan opens a PASE session, arms the fail-safe timer, runs ScanNetworks and
+// disarms the timer again without commissioning the device. Only the Rand,
+// FailSafeExpiry and Progress fields of cfg are used.
+func Scan(ctx context.Context, info DeviceInfo, establish func(context.Context) (Session, error), ssid []byte, cfg Config) (*ScanResults, error) {
+	r := &runner{cfg: cfg.withDefaults(), info: info}
+	if err := r.open(ctx, establish); err != nil {
+		return nil, err
+	}
+	defer r.pase.Close()
+
+	if err := r.stage(StageArmFailSafe, func() error {
+		return r.armFailSafe(ctx, r.cfg.FailSafeExpiry)
+	}); err != nil {
+		return nil, err
+	}
+	r.armed = true
+	defer r.disarm(ctx)
+
+	var results *ScanResults
+	if err := r.stage(StageNetworkScan, func() error {
+		var err error
+		results, err = ScanNetworks(ctx, r.pase, rootEndpoint, ssid, uint64(StageNetworkScan))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
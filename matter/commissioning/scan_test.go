@@ -0,0 +1,121 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commissioning
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+)
+
+var scanKey = commandKey{clusters.NetworkCommissioningClusterID, clusters.NetworkCommissioningCmdScanNetworks}
+
+func wifiScanResponse() clusters.NetworkCommissioningScanNetworksResponse {
+	return clusters.NetworkCommissioningScanNetworksResponse{
+		WiFiScanResults: []clusters.NetworkCommissioningWiFiInterfaceScanResultStruct{{
+			Security: clusters.NetworkCommissioningWiFiSecurityBitmapWPA2Personal,
+			SSID:     []byte("home"),
+			BSSID:    []byte{0, 1, 2, 3, 4, 5},
+			Channel:  6,
+			WiFiBand: clusters.NetworkCommissioningWiFiBandEnum2G4,
+			RSSI:     -42,
+		}},
+	}
+}
+
+func TestScan(t *testing.T) {
+	pase := newPASESession(t)
+	pase.responses[scanKey] = wifiScanResponse()
+	var progress []Progress
+	establish := func(context.Context) (Session, error) { return pase, nil }
+
+	results, err := Scan(context.Background(), DeviceInfo{}, establish, nil, Config{
+		Progress: func(p Progress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(results.WiFi) != 1 || string(results.WiFi[0].SSID) != "home" || results.WiFi[0].RSSI != -42 {
+		t.Errorf("results = %+v", results)
+	}
+	if len(pase.sent) != 3 {
+		t.Fatalf("sent %d commands", len(pase.sent))
+	}
+	if req := pase.sent[1].(clusters.NetworkCommissioningScanNetworksRequest); req.SSID != nil {
+		t.Errorf("ScanNetworks = %+v", req)
+	}
+	if disarm := pase.sent[2].(clusters.GeneralCommissioningArmFailSafeRequest); disarm.ExpiryLengthSeconds != 0 {
+		t.Errorf("last command = %+v", disarm)
+	}
+	if !pase.closed {
+		t.Errorf("PASE session not closed")
+	}
+	if last := progress[len(progress)-1]; last.Stage != StageDisarmFailSafe || last.Status != StatusCompleted {
+		t.Errorf("last progress = %+v", last)
+	}
+}
+
+func TestScanNetworksStatus(t *testing.T) {
+	s := &fakeSession{t: t, responses: map[commandKey]any{
+		scanKey: clusters.NetworkCommissioningScanNetworksResponse{
+			NetworkingStatus: clusters.NetworkCommissioningNetworkCommissioningStatusEnumUnknownError,
+		},
+	}}
+	_, err := ScanNetworks(context.Background(), s, 0, []byte("home"), 0)
+	var statusErr *NetworkingStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("err = %v", err)
+	}
+	if req := s.sent[0].(clusters.NetworkCommissioningScanNetworksRequest); req.SSID == nil || string(*req.SSID) != "home" {
+		t.Errorf("ScanNetworks = %+v", req)
+	}
+}
+
+type selectFunc func(context.Context, *ScanResults) (NetworkConfigurer, error)
+
+func (f selectFunc) SelectNetwork(ctx context.Context, results *ScanResults) (NetworkConfigurer, error) {
+	return f(ctx, results)
+}
+
+func TestRunSelectsScannedNetwork(t *testing.T) {
+	pase := newPASESession(t)
+	pase.responses[scanKey] = wifiScanResponse()
+	pase.responses[commandKey{clusters.NetworkCommissioningClusterID, clusters.NetworkCommissioningCmdAddOrUpdateWiFiNetwork}] = clusters.NetworkCommissioningNetworkConfigResponse{}
+	pase.responses[commandKey{clusters.NetworkCommissioningClusterID, clusters.NetworkCommissioningCmdConnectNetwork}] = clusters.NetworkCommissioningConnectNetworkResponse{}
+	p := &fakePlugins{operational: &fakeSession{t: t, responses: map[commandKey]any{
+		{clusters.GeneralCommissioningClusterID, clusters.GeneralCommissioningCmdCommissioningComplete}: clusters.GeneralCommissioningCommissioningCompleteResponse{},
+	}}}
+	var progress []Progress
+	cfg := newConfig(p, &progress)
+	cfg.Selector = selectFunc(func(_ context.Context, results *ScanResults) (NetworkConfigurer, error) {
+		return WiFiNetwork{SSID: results.WiFi[0].SSID, Credentials: []byte("passphrase")}, nil
+	})
+	establish := func(context.Context) (Session, error) { return pase, nil }
+
+	if _, err := Run(context.Background(), DeviceInfo{}, establish, cfg); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	var added bool
+	for _, cmd := range pase.sent {
+		if add, ok := cmd.(clusters.NetworkCommissioningAddOrUpdateWiFiNetworkRequest); ok && string(add.SSID) == "home" {
+			added = true
+		}
+	}
+	if !added {
+		t.Errorf("selected network not provisioned")
+	}
+}
@@ -0,0 +1,444 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package credentials implements Matter operational credentials: the TLV
+// certificate encoding and its X.509 form, issuing root and node operational
+// certificates for a fabric, and the operational group key.
+// Reference: Matter Core Spec 1.5, Section 6.5 (Operational Certificate Encoding)
+package credentials
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha1"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+var (
+	// ErrInvalidCertificate is returned when a certificate is malformed or
+	// does not meet the Matter certificate profile.
+	ErrInvalidCertificate = errors.New("credentials: invalid certificate")
+	// ErrSignature is returned when a certificate or message signature does
+	// not verify.
+	ErrSignature = errors.New("credentials: signature verification failed")
+)
+
+// TLV values of the only algorithms Matter certificates use.
+const (
+	signatureAlgoECDSAWithSHA256 = 1
+	publicKeyAlgoEC              = 1
+	ellipticCurvePrime256v1      = 1
+)
+
+// Sizes of the P-256 key and signature encodings.
+const (
+	// PublicKeySize is the length of an uncompressed P-256 public key.
+	PublicKeySize = 65
+	// SignatureSize is the length of a raw P-256 ECDSA signature (r || s).
+	SignatureSize = 64
+	// keyIDSize is the length of the subject and authority key identifiers.
+	keyIDSize = 20
+)
+
+// Certificate field tags.
+// 6.5.2. Matter certificate.
+const (
+	tagSerialNumber = 1
+	tagSignatureAlg = 2
+	tagIssuer       = 3
+	tagNotBefore    = 4
+	tagNotAfter     = 5
+	tagSubject      = 6
+	tagPublicKeyAlg = 7
+	tagCurve        = 8
+	tagPublicKey    = 9
+	tagExtensions   = 10
+	tagSignature    = 11
+)
+
+// Extension tags.
+// 6.5.11. Extensions.
+const (
+	tagBasicConstraints = 1
+	tagKeyUsage         = 2
+	tagExtKeyUsage      = 3
+	tagSubjectKeyID     = 4
+	tagAuthorityKeyID   = 5
+
+	tagIsCA    = 1
+	tagPathLen = 2
+)
+
+// Key usage flags.
+// 6.5.11.2. Key Usage Extension.
+const (
+	KeyUsageDigitalSignature uint16 = 0x0001
+	KeyUsageKeyCertSign      uint16 = 0x0020
+	KeyUsageCRLSign          uint16 = 0x0040
+)
+
+// Extended key usage purposes.
+// 6.5.11.3. Extended Key Usage Extension.
+const (
+	ExtKeyUsageServerAuth uint8 = 1
+	ExtKeyUsageClientAuth uint8 = 2
+)
+
+// matterEpoch is the origin of the certificate validity times.
+var matterEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// BasicConstraints is the basic constraints extension.
+type BasicConstraints struct {
+	IsCA bool
+	// PathLen is nil when the path length is not constrained.
+	PathLen *uint8
+}
+
+// Extensions holds the certificate extensions. A zero KeyUsage and nil
+// slices stand for absent extensions.
+type Extensions struct {
+	BasicConstraints *BasicConstraints
+	KeyUsage         uint16
+	ExtKeyUsage      []uint8
+	SubjectKeyID     []byte
+	AuthorityKeyID   []byte
+
+	// order keeps the tags of decoded extensions, which the X.509 form must
+	// list in the same order.
+	order []uint8
+}
+
+// Certificate is a Matter operational certificate: a root (RCAC),
+// intermediate (ICAC) or node operational certificate (NOC).
+type Certificate struct {
+	SerialNumber []byte
+	Issuer       Name
+	NotBefore    time.Time
+	// NotAfter is the zero time for certificates that do not expire.
+	NotAfter   time.Time
+	Subject    Name
+	PublicKey  []byte
+	Extensions Extensions
+	// Signature is the raw ECDSA signature of the X.509 TBSCertificate.
+	Signature []byte
+}
+
+// ECDSAPublicKey returns the subject public key.
+func (c *Certificate) ECDSAPublicKey() (*ecdsa.PublicKey, error) {
+	pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), c.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: public key: %w", ErrInvalidCertificate, err)
+	}
+	return pub, nil
+}
+
+// IsCA reports whether the certificate is a CA certificate.
+func (c *Certificate) IsCA() bool {
+	return c.Extensions.BasicConstraints != nil && c.Extensions.BasicConstraints.IsCA
+}
+
+// Encode returns the Matter TLV encoding of the certificate.
+func (c *Certificate) Encode() ([]byte, error) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	err := errors.Join(
+		enc.PutBytes(tlv.ContextTag(tagSerialNumber), c.SerialNumber),
+		enc.PutUnsigned(tlv.ContextTag(tagSignatureAlg), signatureAlgoECDSAWithSHA256),
+		c.Issuer.encode(enc, tagIssuer),
+		enc.PutUnsigned(tlv.ContextTag(tagNotBefore), matterTime(c.NotBefore)),
+		enc.PutUnsigned(tlv.ContextTag(tagNotAfter), matterTime(c.NotAfter)),
+		c.Subject.encode(enc, tagSubject),
+		enc.PutUnsigned(tlv.ContextTag(tagPublicKeyAlg), publicKeyAlgoEC),
+		enc.PutUnsigned(tlv.ContextTag(tagCurve), ellipticCurvePrime256v1),
+		enc.PutBytes(tlv.ContextTag(tagPublicKey), c.PublicKey),
+		c.Extensions.encode(enc),
+		enc.PutBytes(tlv.ContextTag(tagSignature), c.Signature),
+		enc.EndContainer(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+func (e *Extensions) encode(enc tlv.Encoder) error {
+	enc.StartList(tlv.ContextTag(tagExtensions))
+	var errs []error
+	for _, tag := range e.tags() {
+		switch tag {
+		case tagBasicConstraints:
+			enc.StartStructure(tlv.ContextTag(tagBasicConstraints))
+			enc.PutBool(tlv.ContextTag(tagIsCA), e.BasicConstraints.IsCA)
+			if e.BasicConstraints.PathLen != nil {
+				errs = append(errs, enc.PutUnsigned(tlv.ContextTag(tagPathLen), uint64(*e.BasicConstraints.PathLen)))
+			}
+			errs = append(errs, enc.EndContainer())
+		case tagKeyUsage:
+			errs = append(errs, enc.PutUnsigned(tlv.ContextTag(tagKeyUsage), uint64(e.KeyUsage)))
+		case tagExtKeyUsage:
+			enc.StartArray(tlv.ContextTag(tagExtKeyUsage))
+			for _, purpose := range e.ExtKeyUsage {
+				errs = append(errs, enc.PutUnsigned(tlv.AnonymousTag(), uint64(purpose)))
+			}
+			errs = append(errs, enc.EndContainer())
+		case tagSubjectKeyID:
+			errs = append(errs, enc.PutBytes(tlv.ContextTag(tagSubjectKeyID), e.SubjectKeyID))
+		case tagAuthorityKeyID:
+			errs = append(errs, enc.PutBytes(tlv.ContextTag(tagAuthorityKeyID), e.AuthorityKeyID))
+		}
+	}
+	errs = append(errs, enc.EndContainer())
+	return errors.Join(errs...)
+}
+
+// tags returns the tags of the present extensions, in decoded order or in
+// the order of the certificate profiles.
+func (e *Extensions) tags() []uint8 {
+	if e.order != nil {
+		return e.order
+	}
+	var tags []uint8
+	if e.BasicConstraints != nil {
+		tags = append(tags, tagBasicConstraints)
+	}
+	if e.KeyUsage != 0 {
+		tags = append(tags, tagKeyUsage)
+	}
+	if len(e.ExtKeyUsage) != 0 {
+		tags = append(tags, tagExtKeyUsage)
+	}
+	if len(e.SubjectKeyID) != 0 {
+		tags = append(tags, tagSubjectKeyID)
+	}
+	if len(e.AuthorityKeyID) != 0 {
+		tags = append(tags, tagAuthorityKeyID)
+	}
+	return tags
+}
+
+// DecodeCertificate parses a Matter TLV certificate.
+func DecodeCertificate(b []byte) (*Certificate, error) {
+	c, err := decodeCertificate(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+	return c, nil
+}
+
+func decodeCertificate(b []byte) (*Certificate, error) {
+	r := tlv.NewReader(b)
+	if !r.Next() {
+		return nil, errors.Join(errors.New("empty certificate"), r.Err())
+	}
+	if r.Element().Type() != tlv.ETStructure {
+		return nil, errors.New("certificate is not a structure")
+	}
+	if err := r.EnterContainer(); err != nil {
+		return nil, err
+	}
+	c := &Certificate{}
+	seen := map[uint8]bool{}
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			return nil, fmt.Errorf("unexpected tag %s", r.Tag())
+		}
+		seen[num] = true
+		el := r.Element()
+		var err error
+		switch num {
+		case tagSerialNumber:
+			c.SerialNumber, err = elementBytes(el)
+		case tagSignatureAlg:
+			err = expectUnsigned(el, signatureAlgoECDSAWithSHA256, "signature algorithm")
+		case tagIssuer:
+			c.Issuer, err = decodeName(r)
+		case tagNotBefore:
+			c.NotBefore, err = elementTime(el)
+		case tagNotAfter:
+			c.NotAfter, err = elementTime(el)
+		case tagSubject:
+			c.Subject, err = decodeName(r)
+		case tagPublicKeyAlg:
+			err = expectUnsigned(el, publicKeyAlgoEC, "public key algorithm")
+		case tagCurve:
+			err = expectUnsigned(el, ellipticCurvePrime256v1, "elliptic curve")
+		case tagPublicKey:
+			c.PublicKey, err = elementBytes(el)
+		case tagExtensions:
+			c.Extensions, err = decodeExtensions(r)
+		case tagSignature:
+			c.Signature, err = elementBytes(el)
+		default:
+			err = fmt.Errorf("unknown field %d", num)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := r.ExitContainer(); err != nil {
+		return nil, err
+	}
+	for _, tag := range []uint8{tagSerialNumber, tagIssuer, tagNotBefore, tagNotAfter, tagSubject, tagPublicKey, tagExtensions, tagSignature} {
+		if !seen[tag] {
+			return nil, fmt.Errorf("missing field %d", tag)
+		}
+	}
+	if len(c.PublicKey) != PublicKeySize {
+		return nil, fmt.Errorf("public key of %d bytes", len(c.PublicKey))
+	}
+	if len(c.Signature) != SignatureSize {
+		return nil, fmt.Errorf("signature of %d bytes", len(c.Signature))
+	}
+	return c, nil
+}
+
+func decodeExtensions(r *tlv.Reader) (Extensions, error) {
+	var e Extensions
+	if r.Element().Type() != tlv.ETList {
+		return e, errors.New("extensions are not a list")
+	}
+	if err := r.EnterContainer(); err != nil {
+		return e, err
+	}
+	e.order = []uint8{}
+	for r.Next() {
+		num, _ := tlv.ContextTagNumber(r.Tag())
+		el := r.Element()
+		var err error
+		switch num {
+		case tagBasicConstraints:
+			e.BasicConstraints, err = decodeBasicConstraints(r)
+		case tagKeyUsage:
+			v, ok := el.Unsigned()
+			if !ok || v == 0 || v > 0xFFFF {
+				err = errors.New("invalid key usage")
+			}
+			e.KeyUsage = uint16(v)
+		case tagExtKeyUsage:
+			e.ExtKeyUsage, err = decodeExtKeyUsage(r)
+		case tagSubjectKeyID:
+			e.SubjectKeyID, err = elementBytes(el)
+		case tagAuthorityKeyID:
+			e.AuthorityKeyID, err = elementBytes(el)
+		default:
+			err = fmt.Errorf("unsupported extension %s", r.Tag())
+		}
+		if err != nil {
+			return e, err
+		}
+		e.order = append(e.order, num)
+	}
+	return e, r.ExitContainer()
+}
+
+func decodeBasicConstraints(r *tlv.Reader) (*BasicConstraints, error) {
+	if r.Element().Type() != tlv.ETStructure {
+		return nil, errors.New("basic constraints are not a structure")
+	}
+	if err := r.EnterContainer(); err != nil {
+		return nil, err
+	}
+	bc := &BasicConstraints{}
+	for r.Next() {
+		num, _ := tlv.ContextTagNumber(r.Tag())
+		switch num {
+		case tagIsCA:
+			v, ok := r.Element().Bool()
+			if !ok {
+				return nil, errors.New("invalid is-ca")
+			}
+			bc.IsCA = v
+		case tagPathLen:
+			v, ok := r.Element().Unsigned()
+			if !ok || v > 0xFF {
+				return nil, errors.New("invalid path length")
+			}
+			pathLen := uint8(v)
+			bc.PathLen = &pathLen
+		}
+	}
+	return bc, r.ExitContainer()
+}
+
+func decodeExtKeyUsage(r *tlv.Reader) ([]uint8, error) {
+	if r.Element().Type() != tlv.ETArray {
+		return nil, errors.New("extended key usage is not an array")
+	}
+	if err := r.EnterContainer(); err != nil {
+		return nil, err
+	}
+	purposes := []uint8{}
+	for r.Next() {
+		v, ok := r.Element().Unsigned()
+		if !ok || v == 0 || v > uint64(len(extKeyUsageOIDs)) {
+			return nil, errors.New("invalid extended key usage")
+		}
+		purposes = append(purposes, uint8(v))
+	}
+	return purposes, r.ExitContainer()
+}
+
+func elementBytes(el tlv.Element) ([]byte, error) {
+	b, ok := el.Bytes()
+	if !ok {
+		return nil, fmt.Errorf("%s is not an octet string", el.Tag())
+	}
+	return b, nil
+}
+
+func elementTime(el tlv.Element) (time.Time, error) {
+	v, ok := el.Unsigned()
+	if !ok || v > 0xFFFFFFFF {
+		return time.Time{}, fmt.Errorf("%s is not a validity time", el.Tag())
+	}
+	if v == 0 {
+		return time.Time{}, nil
+	}
+	return matterEpoch.Add(time.Duration(v) * time.Second), nil
+}
+
+func expectUnsigned(el tlv.Element, want uint64, name string) error {
+	if v, ok := el.Unsigned(); !ok || v != want {
+		return fmt.Errorf("unsupported %s", name)
+	}
+	return nil
+}
+
+// matterTime returns t in seconds since the Matter epoch; the zero time and
+// times before the epoch encode as zero.
+func matterTime(t time.Time) uint64 {
+	if t.IsZero() || t.Before(matterEpoch) {
+		return 0
+	}
+	return uint64(t.Sub(matterEpoch) / time.Second)
+}
+
+// keyID returns the key identifier of an uncompressed public key.
+// 6.5.11.4. Subject Key Identifier Extension.
+func keyID(pub []byte) []byte {
+	sum := sha1.Sum(pub)
+	return sum[:keyIDSize]
+}
+
+// digest returns the SHA-256 digest signed by ECDSA.
+func digest(msg []byte) []byte {
+	sum := sha256.Sum256(msg)
+	return sum[:]
+}
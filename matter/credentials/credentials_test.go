@@ -0,0 +1,258 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+)
+
+const (
+	testFabricID uint64 = 0xFAB000000000001D
+	testNodeID   uint64 = 0x000000000001B669
+)
+
+func newTestFabric(t *testing.T) *Fabric {
+	t.Helper()
+	f, err := NewFabric(rand.Reader, testFabricID, testNodeID, 0xFFF1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func encode(t *testing.T, c *Certificate) []byte {
+	t.Helper()
+	b, err := c.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func parseX509(t *testing.T, c *Certificate) *x509.Certificate {
+	t.Helper()
+	der, err := c.X509()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestNewFabricX509(t *testing.T) {
+	f := newTestFabric(t)
+	root := parseX509(t, f.RCAC)
+	noc := parseX509(t, f.NOC)
+
+	// The signatures cover the TBSCertificate crypto/x509 sees.
+	if err := root.CheckSignatureFrom(root); err != nil {
+		t.Errorf("RCAC: %v", err)
+	}
+	if err := noc.CheckSignatureFrom(root); err != nil {
+		t.Errorf("NOC: %v", err)
+	}
+	if !root.IsCA || root.KeyUsage != x509.KeyUsageCertSign|x509.KeyUsageCRLSign {
+		t.Errorf("RCAC: CA %t, key usage %b", root.IsCA, root.KeyUsage)
+	}
+	if noc.IsCA || noc.KeyUsage != x509.KeyUsageDigitalSignature ||
+		!slices.Equal(noc.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth}) {
+		t.Errorf("NOC: CA %t, key usage %b, extended %v", noc.IsCA, noc.KeyUsage, noc.ExtKeyUsage)
+	}
+	if !bytes.Equal(noc.AuthorityKeyId, root.SubjectKeyId) {
+		t.Errorf("NOC authority key ID %X, want %X", noc.AuthorityKeyId, root.SubjectKeyId)
+	}
+	if !noc.NotAfter.Equal(noExpiry) {
+		t.Errorf("NOC not after %s, want %s", noc.NotAfter, noExpiry)
+	}
+}
+
+func TestCertificateRoundTrip(t *testing.T) {
+	f := newTestFabric(t)
+	for name, cert := range map[string]*Certificate{"RCAC": f.RCAC, "NOC": f.NOC} {
+		b := encode(t, cert)
+		decoded, err := DecodeCertificate(b)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := encode(t, decoded); !bytes.Equal(got, b) {
+			t.Errorf("%s: TLV round trip differs", name)
+		}
+		der, err := decoded.X509()
+		if err != nil {
+			t.Fatal(err)
+		}
+		converted, err := ParseX509(der)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := encode(t, converted); !bytes.Equal(got, b) {
+			t.Errorf("%s: X.509 round trip differs", name)
+		}
+	}
+	if id, ok := f.NOC.Subject.Value(DNNodeID); !ok || id != testNodeID {
+		t.Errorf("NOC node ID %016X, want %016X", id, testNodeID)
+	}
+}
+
+func TestParseX509Rejects(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A certificate outside the Matter profile, with an RSA-style subject
+	// and default extensions, cannot be converted.
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"example"}, Country: []string{"JP"}},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		DNSNames:              []string{"example.com"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseX509(der); !errors.Is(err, ErrInvalidCertificate) {
+		t.Errorf("ParseX509() = %v, want %v", err, ErrInvalidCertificate)
+	}
+}
+
+func TestVerifyNOC(t *testing.T) {
+	f := newTestFabric(t)
+	other := newTestFabric(t)
+	nodeKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noc, err := f.IssueNOC(rand.Reader, 0x1234, &nodeKey.PublicKey, 0x00010001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := VerifyNOC(f.RCAC, encode(t, noc), nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cats := cert.Subject.CATs(); !slices.Equal(cats, []uint32{0x00010001}) {
+		t.Errorf("CATs = %X", cats)
+	}
+
+	expired := *noc
+	expired.NotAfter = time.Now().Add(-time.Hour)
+	expired.NotBefore = expired.NotAfter.Add(-time.Hour)
+	if err := expired.SignBy(rand.Reader, f.RootKey); err != nil {
+		t.Fatal(err)
+	}
+	tampered := *noc
+	tampered.Subject = Name{{Tag: DNNodeID, Value: 0x5678}, {Tag: DNFabricID, Value: testFabricID}}
+
+	tests := []struct {
+		name string
+		rcac *Certificate
+		noc  []byte
+		icac []byte
+	}{
+		{"other root", other.RCAC, encode(t, noc), nil},
+		{"tampered subject", f.RCAC, encode(t, &tampered), nil},
+		{"root as NOC", f.RCAC, encode(t, f.RCAC), nil},
+		{"NOC as ICAC", f.RCAC, encode(t, noc), encode(t, f.NOC)},
+		{"expired", f.RCAC, encode(t, &expired), nil},
+		{"malformed", f.RCAC, []byte{0x15, 0x18}, nil},
+	}
+	for _, tt := range tests {
+		if _, err := VerifyNOC(tt.rcac, tt.noc, tt.icac, time.Now()); err == nil {
+			t.Errorf("%s: VerifyNOC() succeeded", tt.name)
+		}
+	}
+	if _, err := VerifyNOC(f.RCAC, encode(t, &expired), nil, time.Time{}); err != nil {
+		t.Errorf("expired without time source: %v", err)
+	}
+}
+
+func TestIssueNOCWithoutRootKey(t *testing.T) {
+	f := newTestFabric(t)
+	f.RootKey = nil
+	if _, err := f.IssueNOC(rand.Reader, 1, &f.Key.PublicKey); !errors.Is(err, ErrNoRootKey) {
+		t.Errorf("IssueNOC() = %v, want %v", err, ErrNoRootKey)
+	}
+}
+
+func TestNOCSRElements(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := bytes.Repeat([]byte{0xA5}, 32)
+	elements, err := NewNOCSRElements(rand.Reader, key, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := ParseNOCSRElements(elements, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Equal(&key.PublicKey) {
+		t.Error("CSR public key differs")
+	}
+	if _, err := ParseNOCSRElements(elements, bytes.Repeat([]byte{0x5A}, 32)); !errors.Is(err, ErrInvalidCSR) {
+		t.Errorf("nonce mismatch: %v, want %v", err, ErrInvalidCSR)
+	}
+
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	dac, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	challenge := []byte("attestation challenge")
+	sig, err := Sign(rand.Reader, key, append(bytes.Clone(elements), challenge...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyNOCSRSignature(dac, elements, challenge, sig); err != nil {
+		t.Error(err)
+	}
+	if err := VerifyNOCSRSignature(dac, elements, []byte("other"), sig); !errors.Is(err, ErrSignature) {
+		t.Errorf("challenge mismatch: %v, want %v", err, ErrSignature)
+	}
+}
+
+func TestOperationalIPK(t *testing.T) {
+	f := newTestFabric(t)
+	ipk, err := f.OperationalIPK()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ipk) != EpochKeySize || bytes.Equal(ipk, f.EpochKey) {
+		t.Errorf("operational IPK %X from epoch key %X", ipk, f.EpochKey)
+	}
+	if _, err := OperationalIPK(f.EpochKey[:8], 1); err == nil {
+		t.Error("short epoch key accepted")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// ErrInvalidCSR is returned when the NOCSR elements of a commissionee are
+// malformed or do not answer the CSR request.
+var ErrInvalidCSR = errors.New("credentials: invalid NOCSR elements")
+
+// nocsrElements is the nocsr-elements structure of the CSRResponse.
+// 11.18.5.6. NOCSR Elements.
+type nocsrElements struct {
+	CSR   []byte `tlv:"1"`
+	Nonce []byte `tlv:"2"`
+}
+
+// NewNOCSRElements returns the NOCSR elements a commissionee answers the
+// CSR request with nonce with, for the operational key.
+func NewNOCSRElements(rand io.Reader, key *ecdsa.PrivateKey, nonce []byte) ([]byte, error) {
+	csr, err := x509.CreateCertificateRequest(rand, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "CSR"},
+	}, key)
+	if err != nil {
+		return nil, err
+	}
+	return tlv.Marshal(nocsrElements{CSR: csr, Nonce: nonce})
+}
+
+// ParseNOCSRElements checks that the NOCSR elements answer the CSR request
+// with nonce and returns the operational public key of the signed CSR.
+func ParseNOCSRElements(elements, nonce []byte) (*ecdsa.PublicKey, error) {
+	var e nocsrElements
+	if err := tlv.Unmarshal(elements, &e); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCSR, err)
+	}
+	if !bytes.Equal(e.Nonce, nonce) {
+		return nil, fmt.Errorf("%w: CSR nonce mismatch", ErrInvalidCSR)
+	}
+	csr, err := x509.ParseCertificateRequest(e.CSR)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCSR, err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCSR, err)
+	}
+	pub, ok := csr.PublicKey.(*ecdsa.PublicKey)
+	if !ok || pub.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w: CSR key is not P-256", ErrInvalidCSR)
+	}
+	return pub, nil
+}
+
+// VerifyNOCSRSignature checks the attestation signature of the NOCSR
+// elements, made with the key of the DAC over the elements and the
+// attestation challenge.
+// 11.18.4.9. CSRResponse Command.
+func VerifyNOCSRSignature(dac, elements, challenge, sig []byte) error {
+	cert, err := x509.ParseCertificate(dac)
+	if err != nil {
+		return fmt.Errorf("DAC: %w", err)
+	}
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("DAC key is not ECDSA")
+	}
+	pubBytes, err := pub.Bytes()
+	if err != nil {
+		return err
+	}
+	msg := append(bytes.Clone(elements), challenge...)
+	if err := Verify(pubBytes, msg, sig); err != nil {
+		return fmt.Errorf("NOCSR attestation signature: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/types"
+)
+
+// ErrNoRootKey is returned when a fabric without the root CA key is asked to
+// issue a certificate.
+var ErrNoRootKey = errors.New("credentials: fabric has no root CA key")
+
+const (
+	// EpochKeySize is the length of the IPK epoch key.
+	EpochKeySize = 16
+	// groupKeyInfo is the HKDF info of the operational group key.
+	// 4.17.2. Operational Group Key Derivation.
+	groupKeyInfo = "GroupKey v1.0"
+	// serialNumberSize is the length of the random certificate serial numbers.
+	serialNumberSize = 8
+)
+
+// Fabric holds the credentials a controller administers a fabric with: the
+// root CA, the controller's own node operational certificate and key, and
+// the IPK epoch key shared with the nodes.
+type Fabric struct {
+	FabricID uint64
+	// VendorID is the admin vendor ID given to commissioned nodes.
+	VendorID uint16
+	// RootKey signs the NOCs of commissioned nodes. It is nil for fabrics
+	// whose root CA is administered elsewhere.
+	RootKey *ecdsa.PrivateKey
+	RCAC    *Certificate
+	// ICAC is the intermediate CA that issued NOC, if any.
+	ICAC     *Certificate
+	NodeID   uint64
+	NOC      *Certificate
+	Key      *ecdsa.PrivateKey
+	EpochKey []byte
+}
+
+// NewFabric creates the root CA of fabricID, and the NOC of the controller
+// nodeID on it, with fresh keys and epoch key.
+func NewFabric(rand io.Reader, fabricID, nodeID uint64, vendorID uint16) (*Fabric, error) {
+	if fabricID == 0 || nodeID == 0 {
+		return nil, errors.New("credentials: fabric and node IDs must be non-zero")
+	}
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand)
+	if err != nil {
+		return nil, err
+	}
+	rootPub, err := rootKey.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	serial, err := serialNumber(rand)
+	if err != nil {
+		return nil, err
+	}
+	rcacID := binary.BigEndian.Uint64(serial)
+	subject := Name{{Tag: DNRCACID, Value: rcacID}, {Tag: DNFabricID, Value: fabricID}}
+	rcac := &Certificate{
+		SerialNumber: serial,
+		Issuer:       subject,
+		NotBefore:    time.Now().UTC().Truncate(time.Second),
+		Subject:      subject,
+		PublicKey:    rootPub,
+		Extensions: Extensions{
+			BasicConstraints: &BasicConstraints{IsCA: true},
+			KeyUsage:         KeyUsageKeyCertSign | KeyUsageCRLSign,
+			SubjectKeyID:     keyID(rootPub),
+		},
+	}
+	if err := rcac.SignBy(rand, rootKey); err != nil {
+		return nil, err
+	}
+	f := &Fabric{FabricID: fabricID, VendorID: vendorID, RootKey: rootKey, RCAC: rcac, NodeID: nodeID}
+	if f.Key, err = ecdsa.GenerateKey(elliptic.P256(), rand); err != nil {
+		return nil, err
+	}
+	if f.NOC, err = f.IssueNOC(rand, nodeID, &f.Key.PublicKey); err != nil {
+		return nil, err
+	}
+	f.EpochKey = make([]byte, EpochKeySize)
+	if _, err := io.ReadFull(rand, f.EpochKey); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// IssueNOC signs a node operational certificate for nodeID with the public
+// key pub, carrying the given CASE Authenticated Tags.
+// 6.5.5. Node Operational Credentials Certificate.
+func (f *Fabric) IssueNOC(rand io.Reader, nodeID uint64, pub *ecdsa.PublicKey, cats ...uint32) (*Certificate, error) {
+	if f.RootKey == nil {
+		return nil, ErrNoRootKey
+	}
+	pubBytes, err := pub.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	serial, err := serialNumber(rand)
+	if err != nil {
+		return nil, err
+	}
+	subject := Name{{Tag: DNNodeID, Value: nodeID}, {Tag: DNFabricID, Value: f.FabricID}}
+	for _, cat := range cats {
+		subject = append(subject, Attribute{Tag: DNNOCCAT, Value: uint64(cat)})
+	}
+	noc := &Certificate{
+		SerialNumber: serial,
+		Issuer:       f.RCAC.Subject,
+		NotBefore:    time.Now().UTC().Truncate(time.Second),
+		Subject:      subject,
+		PublicKey:    pubBytes,
+		Extensions: Extensions{
+			BasicConstraints: &BasicConstraints{},
+			KeyUsage:         KeyUsageDigitalSignature,
+			ExtKeyUsage:      []uint8{ExtKeyUsageClientAuth, ExtKeyUsageServerAuth},
+			SubjectKeyID:     keyID(pubBytes),
+		},
+	}
+	if err := noc.SignBy(rand, f.RootKey); err != nil {
+		return nil, err
+	}
+	return noc, nil
+}
+
+// CompressedFabricID returns the compressed fabric identifier that names the
+// operational instances of the fabric.
+func (f *Fabric) CompressedFabricID() (uint64, error) {
+	cfid, err := types.NewCompressedFabricID(f.RCAC.PublicKey, f.FabricID)
+	return uint64(cfid), err
+}
+
+// OperationalIPK returns the operational group key derived from the IPK
+// epoch key, which keys the CASE destination identifier and session keys.
+// 4.17.2. Operational Group Key Derivation.
+func (f *Fabric) OperationalIPK() ([]byte, error) {
+	cfid, err := f.CompressedFabricID()
+	if err != nil {
+		return nil, err
+	}
+	return OperationalIPK(f.EpochKey, cfid)
+}
+
+// OperationalIPK derives the operational group key of the fabric with the
+// compressed fabric identifier cfid from an epoch key.
+func OperationalIPK(epochKey []byte, cfid uint64) ([]byte, error) {
+	if len(epochKey) != EpochKeySize {
+		return nil, fmt.Errorf("credentials: epoch key of %d bytes, want %d", len(epochKey), EpochKeySize)
+	}
+	salt := binary.BigEndian.AppendUint64(nil, cfid)
+	return hkdf.Key(sha256.New, epochKey, salt, groupKeyInfo, EpochKeySize)
+}
+
+// VerifyNOC checks that the NOC, issued by the optional ICAC, chains to
+// rcac and is valid at now, and returns the decoded NOC. A zero now skips
+// the validity period checks.
+// 6.5.8. Certificate Chain Validation.
+func VerifyNOC(rcac *Certificate, noc, icac []byte, now time.Time) (*Certificate, error) {
+	issuer := rcac
+	if err := rcac.CheckSignatureFrom(rcac); err != nil {
+		return nil, fmt.Errorf("root certificate: %w", err)
+	}
+	fabricID, hasFabricID := rcac.Subject.Value(DNFabricID)
+	if len(icac) != 0 {
+		cert, err := DecodeCertificate(icac)
+		if err != nil {
+			return nil, fmt.Errorf("ICAC: %w", err)
+		}
+		if err := checkIssued(cert, issuer, now, true); err != nil {
+			return nil, fmt.Errorf("ICAC: %w", err)
+		}
+		if id, ok := cert.Subject.Value(DNFabricID); ok {
+			if hasFabricID && id != fabricID {
+				return nil, fmt.Errorf("%w: ICAC fabric ID %016X differs from %016X", ErrInvalidCertificate, id, fabricID)
+			}
+			fabricID, hasFabricID = id, true
+		}
+		issuer = cert
+	}
+	cert, err := DecodeCertificate(noc)
+	if err != nil {
+		return nil, fmt.Errorf("NOC: %w", err)
+	}
+	if err := checkIssued(cert, issuer, now, false); err != nil {
+		return nil, fmt.Errorf("NOC: %w", err)
+	}
+	if _, ok := cert.Subject.Value(DNNodeID); !ok {
+		return nil, fmt.Errorf("%w: NOC without node ID", ErrInvalidCertificate)
+	}
+	id, ok := cert.Subject.Value(DNFabricID)
+	if !ok || (hasFabricID && id != fabricID) {
+		return nil, fmt.Errorf("%w: NOC fabric ID does not match its issuer", ErrInvalidCertificate)
+	}
+	if cert.Extensions.KeyUsage&KeyUsageDigitalSignature == 0 {
+		return nil, fmt.Errorf("%w: NOC key usage does not allow signing", ErrInvalidCertificate)
+	}
+	return cert, nil
+}
+
+func checkIssued(cert, issuer *Certificate, now time.Time, ca bool) error {
+	if cert.IsCA() != ca {
+		return fmt.Errorf("%w: unexpected basic constraints", ErrInvalidCertificate)
+	}
+	if err := cert.CheckValidity(now); err != nil {
+		return err
+	}
+	return cert.CheckSignatureFrom(issuer)
+}
+
+// serialNumber returns a random positive serial number in its minimal DER
+// INTEGER form.
+func serialNumber(rand io.Reader) ([]byte, error) {
+	b := make([]byte, serialNumberSize)
+	if _, err := io.ReadFull(rand, b); err != nil {
+		return nil, err
+	}
+	b[0] = b[0]&0x3F | 0x40
+	return b, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// Distinguished name attribute tags. Standard attributes use tags 1 to 16
+// for UTF8String values; setting printableString on tags 1 to 15 selects
+// PrintableString instead.
+// 6.5.6.1. Distinguished Name (DN) attributes.
+const (
+	DNCommonName        uint8 = 1
+	DNNodeID            uint8 = 17
+	DNFirmwareSigningID uint8 = 18
+	DNICACID            uint8 = 19
+	DNRCACID            uint8 = 20
+	DNFabricID          uint8 = 21
+	DNNOCCAT            uint8 = 22
+
+	printableString uint8 = 0x80
+)
+
+// dnOIDs maps the attribute tags to their X.509 attribute types.
+var dnOIDs = map[uint8]asn1.ObjectIdentifier{
+	1:                   {2, 5, 4, 3},
+	2:                   {2, 5, 4, 4},
+	3:                   {2, 5, 4, 5},
+	4:                   {2, 5, 4, 6},
+	5:                   {2, 5, 4, 7},
+	6:                   {2, 5, 4, 8},
+	7:                   {2, 5, 4, 10},
+	8:                   {2, 5, 4, 11},
+	9:                   {2, 5, 4, 12},
+	10:                  {2, 5, 4, 41},
+	11:                  {2, 5, 4, 42},
+	12:                  {2, 5, 4, 43},
+	13:                  {2, 5, 4, 44},
+	14:                  {2, 5, 4, 46},
+	15:                  {2, 5, 4, 65},
+	16:                  {0, 9, 2342, 19200300, 100, 1, 25},
+	DNNodeID:            {1, 3, 6, 1, 4, 1, 37244, 1, 1},
+	DNFirmwareSigningID: {1, 3, 6, 1, 4, 1, 37244, 1, 2},
+	DNICACID:            {1, 3, 6, 1, 4, 1, 37244, 1, 3},
+	DNRCACID:            {1, 3, 6, 1, 4, 1, 37244, 1, 4},
+	DNFabricID:          {1, 3, 6, 1, 4, 1, 37244, 1, 5},
+	DNNOCCAT:            {1, 3, 6, 1, 4, 1, 37244, 1, 6},
+}
+
+// domainComponent is the tag of the IA5String domain component.
+const domainComponent uint8 = 16
+
+// Attribute is a distinguished name attribute. Matter-specific attributes
+// (tags 17 to 22) hold Value; standard attributes hold Text.
+type Attribute struct {
+	Tag   uint8
+	Value uint64
+	Text  string
+}
+
+// isMatter reports whether the attribute is a Matter-specific identifier.
+func (a Attribute) isMatter() bool {
+	return a.Tag >= DNNodeID && a.Tag <= DNNOCCAT
+}
+
+// Name is a distinguished name: the issuer or subject of a certificate.
+type Name []Attribute
+
+// Value returns the first Matter-specific attribute with tag.
+func (n Name) Value(tag uint8) (uint64, bool) {
+	for _, a := range n {
+		if a.Tag == tag {
+			return a.Value, true
+		}
+	}
+	return 0, false
+}
+
+// CATs returns the CASE Authenticated Tags of the name.
+func (n Name) CATs() []uint32 {
+	var cats []uint32
+	for _, a := range n {
+		if a.Tag == DNNOCCAT {
+			cats = append(cats, uint32(a.Value))
+		}
+	}
+	return cats
+}
+
+// String returns the name in the order of its attributes, for logging.
+func (n Name) String() string {
+	s := ""
+	for i, a := range n {
+		if i > 0 {
+			s += ","
+		}
+		if a.isMatter() {
+			s += fmt.Sprintf("%d=%016X", a.Tag, a.Value)
+		} else {
+			s += fmt.Sprintf("%d=%s", a.Tag&^printableString, strconv.Quote(a.Text))
+		}
+	}
+	return s
+}
+
+func (n Name) encode(enc tlv.Encoder, tag uint8) error {
+	enc.StartList(tlv.ContextTag(tag))
+	var errs []error
+	for _, a := range n {
+		if a.isMatter() {
+			errs = append(errs, enc.PutUnsigned(tlv.ContextTag(a.Tag), a.Value))
+		} else {
+			errs = append(errs, enc.PutUTF8(tlv.ContextTag(a.Tag), a.Text))
+		}
+	}
+	errs = append(errs, enc.EndContainer())
+	return errors.Join(errs...)
+}
+
+func decodeName(r *tlv.Reader) (Name, error) {
+	if r.Element().Type() != tlv.ETList {
+		return nil, errors.New("distinguished name is not a list")
+	}
+	if err := r.EnterContainer(); err != nil {
+		return nil, err
+	}
+	name := Name{}
+	for r.Next() {
+		tag, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok || dnOIDs[tag&^printableString] == nil {
+			return nil, fmt.Errorf("unsupported distinguished name attribute %s", r.Tag())
+		}
+		a := Attribute{Tag: tag}
+		if a.isMatter() {
+			v, ok := r.Element().Unsigned()
+			if !ok || (tag == DNNOCCAT && v > 0xFFFFFFFF) {
+				return nil, fmt.Errorf("invalid distinguished name attribute %d", tag)
+			}
+			a.Value = v
+		} else {
+			v, ok := r.Element().UTF8()
+			if !ok {
+				return nil, fmt.Errorf("invalid distinguished name attribute %d", tag)
+			}
+			a.Text = v
+		}
+		name = append(name, a)
+	}
+	return name, r.ExitContainer()
+}
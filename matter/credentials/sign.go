@@ -0,0 +1,92 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+)
+
+// Sign returns the raw ECDSA signature (r || s) of msg with SHA-256.
+func Sign(rand io.Reader, key *ecdsa.PrivateKey, msg []byte) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand, key, digest(msg))
+	if err != nil {
+		return nil, err
+	}
+	sig := make([]byte, SignatureSize)
+	r.FillBytes(sig[:SignatureSize/2])
+	s.FillBytes(sig[SignatureSize/2:])
+	return sig, nil
+}
+
+// Verify checks the raw ECDSA signature of msg by the uncompressed P-256
+// public key pub.
+func Verify(pub, msg, sig []byte) error {
+	key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), pub)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSignature, err)
+	}
+	if len(sig) != SignatureSize {
+		return fmt.Errorf("%w: signature of %d bytes", ErrSignature, len(sig))
+	}
+	r := new(big.Int).SetBytes(sig[:SignatureSize/2])
+	s := new(big.Int).SetBytes(sig[SignatureSize/2:])
+	if !ecdsa.Verify(key, digest(msg), r, s) {
+		return ErrSignature
+	}
+	return nil
+}
+
+// SignBy sets the authority key identifier and signature of c as issued
+// with the key of issuer.
+func (c *Certificate) SignBy(rand io.Reader, issuer *ecdsa.PrivateKey) error {
+	pub, err := issuer.PublicKey.Bytes()
+	if err != nil {
+		return err
+	}
+	c.Extensions.AuthorityKeyID = keyID(pub)
+	c.Extensions.order = nil
+	tbs, err := c.TBS()
+	if err != nil {
+		return err
+	}
+	c.Signature, err = Sign(rand, issuer, tbs)
+	return err
+}
+
+// CheckSignatureFrom checks that issuer signed c.
+func (c *Certificate) CheckSignatureFrom(issuer *Certificate) error {
+	tbs, err := c.TBS()
+	if err != nil {
+		return err
+	}
+	return Verify(issuer.PublicKey, tbs, c.Signature)
+}
+
+// CheckValidity checks that now falls within the validity period of c. A
+// zero now skips the check, for nodes without a trusted time source.
+func (c *Certificate) CheckValidity(now time.Time) error {
+	if now.IsZero() {
+		return nil
+	}
+	if now.Before(c.NotBefore) || (!c.NotAfter.IsZero() && now.After(c.NotAfter)) {
+		return fmt.Errorf("%w: not valid at %s", ErrInvalidCertificate, now.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
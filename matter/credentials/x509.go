@@ -0,0 +1,435 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	encoding_asn1 "encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// X.509 object identifiers of the Matter certificate profile.
+var (
+	oidECDSAWithSHA256  = encoding_asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidECPublicKey      = encoding_asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidPrime256v1       = encoding_asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidBasicConstraints = encoding_asn1.ObjectIdentifier{2, 5, 29, 19}
+	oidKeyUsage         = encoding_asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtKeyUsage      = encoding_asn1.ObjectIdentifier{2, 5, 29, 37}
+	oidSubjectKeyID     = encoding_asn1.ObjectIdentifier{2, 5, 29, 14}
+	oidAuthorityKeyID   = encoding_asn1.ObjectIdentifier{2, 5, 29, 35}
+	extKeyUsageOIDs     = []encoding_asn1.ObjectIdentifier{
+		{1, 3, 6, 1, 5, 5, 7, 3, 1},
+		{1, 3, 6, 1, 5, 5, 7, 3, 2},
+		{1, 3, 6, 1, 5, 5, 7, 3, 3},
+		{1, 3, 6, 1, 5, 5, 7, 3, 4},
+		{1, 3, 6, 1, 5, 5, 7, 3, 8},
+		{1, 3, 6, 1, 5, 5, 7, 3, 9},
+	}
+	extensionOIDs = map[uint8]encoding_asn1.ObjectIdentifier{
+		tagBasicConstraints: oidBasicConstraints,
+		tagKeyUsage:         oidKeyUsage,
+		tagExtKeyUsage:      oidExtKeyUsage,
+		tagSubjectKeyID:     oidSubjectKeyID,
+		tagAuthorityKeyID:   oidAuthorityKeyID,
+	}
+)
+
+// noExpiry is the X.509 notAfter of certificates without a well-defined
+// expiration date.
+var noExpiry = time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+// TBS returns the DER encoding of the X.509 TBSCertificate that the
+// certificate signature covers.
+// 6.5.1. Encoding of Matter-specific RDNs and 6.6 Certificate Conversion.
+func (c *Certificate) TBS() ([]byte, error) {
+	b := cryptobyte.NewBuilder(nil)
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+			b.AddASN1Int64(2)
+		})
+		b.AddASN1(asn1.INTEGER, func(b *cryptobyte.Builder) {
+			b.AddBytes(c.SerialNumber)
+		})
+		b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1ObjectIdentifier(oidECDSAWithSHA256)
+		})
+		addName(b, c.Issuer)
+		b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			addTime(b, c.NotBefore)
+			notAfter := c.NotAfter
+			if notAfter.IsZero() {
+				notAfter = noExpiry
+			}
+			addTime(b, notAfter)
+		})
+		addName(b, c.Subject)
+		b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+				b.AddASN1ObjectIdentifier(oidECPublicKey)
+				b.AddASN1ObjectIdentifier(oidPrime256v1)
+			})
+			b.AddASN1BitString(c.PublicKey)
+		})
+		b.AddASN1(asn1.Tag(3).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {
+			b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+				for _, tag := range c.Extensions.tags() {
+					addExtension(b, tag, &c.Extensions)
+				}
+			})
+		})
+	})
+	der, err := b.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+	return der, nil
+}
+
+// X509 returns the DER encoding of the certificate in its X.509 form.
+func (c *Certificate) X509() ([]byte, error) {
+	tbs, err := c.TBS()
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Signature) != SignatureSize {
+		return nil, fmt.Errorf("%w: signature of %d bytes", ErrInvalidCertificate, len(c.Signature))
+	}
+	b := cryptobyte.NewBuilder(nil)
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddBytes(tbs)
+		b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1ObjectIdentifier(oidECDSAWithSHA256)
+		})
+		b.AddASN1(asn1.BIT_STRING, func(b *cryptobyte.Builder) {
+			b.AddUint8(0)
+			b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+				b.AddASN1BigInt(new(big.Int).SetBytes(c.Signature[:SignatureSize/2]))
+				b.AddASN1BigInt(new(big.Int).SetBytes(c.Signature[SignatureSize/2:]))
+			})
+		})
+	})
+	return b.Bytes()
+}
+
+func addName(b *cryptobyte.Builder, name Name) {
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		for _, a := range name {
+			b.AddASN1(asn1.SET, func(b *cryptobyte.Builder) {
+				b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					b.AddASN1ObjectIdentifier(dnOIDs[a.Tag&^printableString])
+					switch {
+					case a.Tag == DNNOCCAT:
+						addString(b, asn1.UTF8String, fmt.Sprintf("%08X", a.Value))
+					case a.isMatter():
+						addString(b, asn1.UTF8String, fmt.Sprintf("%016X", a.Value))
+					case a.Tag == domainComponent:
+						addString(b, asn1.IA5String, a.Text)
+					case a.Tag&printableString != 0:
+						addString(b, asn1.PrintableString, a.Text)
+					default:
+						addString(b, asn1.UTF8String, a.Text)
+					}
+				})
+			})
+		}
+	})
+}
+
+func addString(b *cryptobyte.Builder, tag asn1.Tag, s string) {
+	b.AddASN1(tag, func(b *cryptobyte.Builder) {
+		b.AddBytes([]byte(s))
+	})
+}
+
+// addTime adds t as UTCTime through 2049 and GeneralizedTime after.
+func addTime(b *cryptobyte.Builder, t time.Time) {
+	t = t.UTC()
+	if t.Year() < 2050 {
+		b.AddASN1UTCTime(t)
+	} else {
+		b.AddASN1GeneralizedTime(t)
+	}
+}
+
+func addExtension(b *cryptobyte.Builder, tag uint8, e *Extensions) {
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1ObjectIdentifier(extensionOIDs[tag])
+		// Only the subject and authority key identifiers are not critical.
+		if tag != tagSubjectKeyID && tag != tagAuthorityKeyID {
+			b.AddASN1Boolean(true)
+		}
+		b.AddASN1(asn1.OCTET_STRING, func(b *cryptobyte.Builder) {
+			switch tag {
+			case tagBasicConstraints:
+				b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					if e.BasicConstraints.IsCA {
+						b.AddASN1Boolean(true)
+					}
+					if e.BasicConstraints.PathLen != nil {
+						b.AddASN1Int64(int64(*e.BasicConstraints.PathLen))
+					}
+				})
+			case tagKeyUsage:
+				addKeyUsage(b, e.KeyUsage)
+			case tagExtKeyUsage:
+				b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					for _, purpose := range e.ExtKeyUsage {
+						if purpose == 0 || int(purpose) > len(extKeyUsageOIDs) {
+							b.SetError(fmt.Errorf("unknown extended key usage %d", purpose))
+							return
+						}
+						b.AddASN1ObjectIdentifier(extKeyUsageOIDs[purpose-1])
+					}
+				})
+			case tagSubjectKeyID:
+				b.AddASN1OctetString(e.SubjectKeyID)
+			case tagAuthorityKeyID:
+				b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					b.AddASN1(asn1.Tag(0).ContextSpecific(), func(b *cryptobyte.Builder) {
+						b.AddBytes(e.AuthorityKeyID)
+					})
+				})
+			}
+		})
+	})
+}
+
+// addKeyUsage adds the key usage flags as a DER BIT STRING, where flag bit
+// n is bit n of the string and trailing zero bits are dropped.
+func addKeyUsage(b *cryptobyte.Builder, usage uint16) {
+	last := 15
+	for last > 0 && usage&(1<<last) == 0 {
+		last--
+	}
+	bits := make([]byte, last/8+1)
+	for i := 0; i <= last; i++ {
+		if usage&(1<<i) != 0 {
+			bits[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	b.AddASN1(asn1.BIT_STRING, func(b *cryptobyte.Builder) {
+		b.AddUint8(uint8(7 - last%8))
+		b.AddBytes(bits)
+	})
+}
+
+// ParseX509 converts a DER X.509 certificate of the Matter certificate
+// profile to its TLV form. Certificates that cannot be converted without
+// changing the signed TBSCertificate are rejected.
+func ParseX509(der []byte) (*Certificate, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+	c, err := fromX509(cert)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+	tbs, err := c.TBS()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tbs, cert.RawTBSCertificate) {
+		return nil, fmt.Errorf("%w: not encoded as the Matter certificate profile requires", ErrInvalidCertificate)
+	}
+	return c, nil
+}
+
+func fromX509(cert *x509.Certificate) (*Certificate, error) {
+	if cert.SignatureAlgorithm != x509.ECDSAWithSHA256 {
+		return nil, fmt.Errorf("unsupported signature algorithm %s", cert.SignatureAlgorithm)
+	}
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not ECDSA")
+	}
+	pubBytes, err := pub.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	c := &Certificate{
+		SerialNumber: serialBytes(cert.SerialNumber),
+		NotBefore:    cert.NotBefore,
+		PublicKey:    pubBytes,
+	}
+	if !cert.NotAfter.Equal(noExpiry) {
+		c.NotAfter = cert.NotAfter
+	}
+	if c.Issuer, err = parseName(cert.RawIssuer); err != nil {
+		return nil, fmt.Errorf("issuer: %w", err)
+	}
+	if c.Subject, err = parseName(cert.RawSubject); err != nil {
+		return nil, fmt.Errorf("subject: %w", err)
+	}
+	c.Extensions.order = []uint8{}
+	for _, ext := range cert.Extensions {
+		tag, err := parseExtension(&c.Extensions, ext.Id, ext.Value)
+		if err != nil {
+			return nil, err
+		}
+		c.Extensions.order = append(c.Extensions.order, tag)
+	}
+	var sig struct{ R, S *big.Int }
+	if rest, err := encoding_asn1.Unmarshal(cert.Signature, &sig); err != nil || len(rest) != 0 {
+		return nil, errors.New("malformed signature")
+	}
+	c.Signature = make([]byte, SignatureSize)
+	sig.R.FillBytes(c.Signature[:SignatureSize/2])
+	sig.S.FillBytes(c.Signature[SignatureSize/2:])
+	return c, nil
+}
+
+// serialBytes returns the content octets of the DER INTEGER n.
+func serialBytes(n *big.Int) []byte {
+	b := n.Bytes()
+	if len(b) == 0 || b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+func parseName(raw []byte) (Name, error) {
+	in := cryptobyte.String(raw)
+	var rdns cryptobyte.String
+	if !in.ReadASN1(&rdns, asn1.SEQUENCE) {
+		return nil, errors.New("malformed name")
+	}
+	name := Name{}
+	for !rdns.Empty() {
+		var set, atv cryptobyte.String
+		var oid encoding_asn1.ObjectIdentifier
+		var valueTag asn1.Tag
+		var value cryptobyte.String
+		if !rdns.ReadASN1(&set, asn1.SET) || !set.ReadASN1(&atv, asn1.SEQUENCE) || !set.Empty() ||
+			!atv.ReadASN1ObjectIdentifier(&oid) || !atv.ReadAnyASN1(&value, &valueTag) {
+			return nil, errors.New("malformed or multi-valued name attribute")
+		}
+		a, err := parseAttribute(oid, valueTag, string(value))
+		if err != nil {
+			return nil, err
+		}
+		name = append(name, a)
+	}
+	return name, nil
+}
+
+func parseAttribute(oid encoding_asn1.ObjectIdentifier, valueTag asn1.Tag, value string) (Attribute, error) {
+	for tag, known := range dnOIDs {
+		if !oid.Equal(known) {
+			continue
+		}
+		a := Attribute{Tag: tag}
+		switch {
+		case a.isMatter():
+			digits := 16
+			if tag == DNNOCCAT {
+				digits = 8
+			}
+			v, err := strconv.ParseUint(value, 16, 64)
+			if valueTag != asn1.UTF8String || len(value) != digits || err != nil {
+				return a, fmt.Errorf("invalid Matter attribute %s=%q", oid, value)
+			}
+			a.Value = v
+		case valueTag == asn1.PrintableString && tag != domainComponent:
+			a.Tag |= printableString
+			a.Text = value
+		case valueTag == asn1.UTF8String && tag != domainComponent, valueTag == asn1.IA5String && tag == domainComponent:
+			a.Text = value
+		default:
+			return a, fmt.Errorf("unsupported string type of attribute %s", oid)
+		}
+		return a, nil
+	}
+	return Attribute{}, fmt.Errorf("unsupported attribute %s", oid)
+}
+
+func parseExtension(e *Extensions, oid encoding_asn1.ObjectIdentifier, value []byte) (uint8, error) {
+	in := cryptobyte.String(value)
+	switch {
+	case oid.Equal(oidBasicConstraints):
+		var seq cryptobyte.String
+		bc := &BasicConstraints{}
+		if !in.ReadASN1(&seq, asn1.SEQUENCE) ||
+			(seq.PeekASN1Tag(asn1.BOOLEAN) && !seq.ReadASN1Boolean(&bc.IsCA)) {
+			return 0, errors.New("malformed basic constraints")
+		}
+		if !seq.Empty() {
+			var pathLen int64
+			if !seq.ReadASN1Int64WithTag(&pathLen, asn1.INTEGER) || pathLen < 0 || pathLen > 0xFF {
+				return 0, errors.New("malformed basic constraints")
+			}
+			v := uint8(pathLen)
+			bc.PathLen = &v
+		}
+		e.BasicConstraints = bc
+		return tagBasicConstraints, nil
+	case oid.Equal(oidKeyUsage):
+		var bits encoding_asn1.BitString
+		if !in.ReadASN1BitString(&bits) || bits.BitLength > 16 {
+			return 0, errors.New("malformed key usage")
+		}
+		for i := 0; i < bits.BitLength; i++ {
+			if bits.At(i) != 0 {
+				e.KeyUsage |= 1 << i
+			}
+		}
+		return tagKeyUsage, nil
+	case oid.Equal(oidExtKeyUsage):
+		var seq cryptobyte.String
+		if !in.ReadASN1(&seq, asn1.SEQUENCE) {
+			return 0, errors.New("malformed extended key usage")
+		}
+		e.ExtKeyUsage = []uint8{}
+		for !seq.Empty() {
+			var purpose encoding_asn1.ObjectIdentifier
+			if !seq.ReadASN1ObjectIdentifier(&purpose) {
+				return 0, errors.New("malformed extended key usage")
+			}
+			known := false
+			for i, oid := range extKeyUsageOIDs {
+				if purpose.Equal(oid) {
+					e.ExtKeyUsage = append(e.ExtKeyUsage, uint8(i+1))
+					known = true
+				}
+			}
+			if !known {
+				return 0, fmt.Errorf("unsupported extended key usage %s", purpose)
+			}
+		}
+		return tagExtKeyUsage, nil
+	case oid.Equal(oidSubjectKeyID):
+		if !in.ReadASN1Bytes(&e.SubjectKeyID, asn1.OCTET_STRING) {
+			return 0, errors.New("malformed subject key identifier")
+		}
+		return tagSubjectKeyID, nil
+	case oid.Equal(oidAuthorityKeyID):
+		var seq cryptobyte.String
+		if !in.ReadASN1(&seq, asn1.SEQUENCE) || !seq.ReadASN1Bytes(&e.AuthorityKeyID, asn1.Tag(0).ContextSpecific()) {
+			return 0, errors.New("malformed authority key identifier")
+		}
+		return tagAuthorityKeyID, nil
+	}
+	return 0, fmt.Errorf("unsupported extension %s", oid)
+}
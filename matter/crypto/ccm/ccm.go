@@ -0,0 +1,183 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ccm implements the AES-CCM authenticated encryption mode used by
+// Matter message security.
+// Reference: Matter Core Spec 1.5, Section 3.6 (Data Confidentiality and Integrity), NIST SP 800-38C
+package ccm
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrOpen is returned when a ciphertext fails authentication.
+var ErrOpen = errors.New("ccm: message authentication failed")
+
+type ccm struct {
+	block     cipher.Block
+	tagSize   int
+	nonceSize int
+}
+
+// New returns a CCM cipher.AEAD for block, which must have a 16-byte block
+// size. tagSize must be an even number in [4, 16] and nonceSize in [7, 13].
+// Matter uses 16-byte tags and 13-byte nonces.
+func New(block cipher.Block, tagSize, nonceSize int) (cipher.AEAD, error) {
+	if block.BlockSize() != 16 {
+		return nil, errors.New("ccm: block size must be 16 bytes")
+	}
+	if tagSize < 4 || tagSize > 16 || tagSize%2 != 0 {
+		return nil, fmt.Errorf("ccm: invalid tag size %d", tagSize)
+	}
+	if nonceSize < 7 || nonceSize > 13 {
+		return nil, fmt.Errorf("ccm: invalid nonce size %d", nonceSize)
+	}
+	return &ccm{block: block, tagSize: tagSize, nonceSize: nonceSize}, nil
+}
+
+// NonceSize implements cipher.AEAD.
+func (c *ccm) NonceSize() int { return c.nonceSize }
+
+// Overhead implements cipher.AEAD.
+func (c *ccm) Overhead() int { return c.tagSize }
+
+// maxLength returns the largest message length the length field can encode.
+func (c *ccm) maxLength() uint64 {
+	l := 15 - c.nonceSize
+	if l >= 8 {
+		return math.MaxInt
+	}
+	return 1<<(8*l) - 1
+}
+
+// Seal implements cipher.AEAD.
+func (c *ccm) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != c.nonceSize {
+		panic("ccm: incorrect nonce length")
+	}
+	if uint64(len(plaintext)) > c.maxLength() {
+		panic("ccm: message too large")
+	}
+	tag := c.mac(nonce, plaintext, additionalData)
+	ret, out := sliceForAppend(dst, len(plaintext)+c.tagSize)
+	c.ctr(nonce, out[:len(plaintext)], plaintext)
+	s0 := c.counterBlock(nonce, 0)
+	subtle.XORBytes(out[len(plaintext):], tag, s0[:c.tagSize])
+	return ret
+}
+
+// Open implements cipher.AEAD.
+func (c *ccm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != c.nonceSize {
+		panic("ccm: incorrect nonce length")
+	}
+	if len(ciphertext) < c.tagSize || uint64(len(ciphertext)-c.tagSize) > c.maxLength() {
+		return nil, ErrOpen
+	}
+	n := len(ciphertext) - c.tagSize
+	ret, out := sliceForAppend(dst, n)
+	c.ctr(nonce, out, ciphertext[:n])
+	expected := c.mac(nonce, out, additionalData)
+	s0 := c.counterBlock(nonce, 0)
+	subtle.XORBytes(expected, expected, s0[:c.tagSize])
+	if subtle.ConstantTimeCompare(expected, ciphertext[n:]) != 1 {
+		clear(out)
+		return nil, ErrOpen
+	}
+	return ret, nil
+}
+
+// mac computes the CBC-MAC T over B0, the encoded additional data and the
+// plaintext, truncated to the tag size.
+func (c *ccm) mac(nonce, plaintext, additionalData []byte) []byte {
+	l := 15 - c.nonceSize
+	var b0 [16]byte
+	b0[0] = byte((c.tagSize-2)/2)<<3 | byte(l-1)
+	if len(additionalData) > 0 {
+		b0[0] |= 0x40
+	}
+	copy(b0[1:], nonce)
+	putLength(b0[1+c.nonceSize:], uint64(len(plaintext)))
+
+	var x [16]byte
+	c.block.Encrypt(x[:], b0[:])
+	if len(additionalData) > 0 {
+		var header []byte
+		if n := uint64(len(additionalData)); n < 0xFF00 {
+			header = binary.BigEndian.AppendUint16(nil, uint16(n))
+		} else if n <= math.MaxUint32 {
+			header = binary.BigEndian.AppendUint32([]byte{0xFF, 0xFE}, uint32(n))
+		} else {
+			header = binary.BigEndian.AppendUint64([]byte{0xFF, 0xFF}, n)
+		}
+		c.cbc(&x, append(header, additionalData...))
+	}
+	c.cbc(&x, plaintext)
+	return x[:c.tagSize]
+}
+
+// cbc folds data, zero padded to the block size, into the CBC-MAC state x.
+func (c *ccm) cbc(x *[16]byte, data []byte) {
+	for len(data) > 0 {
+		n := subtle.XORBytes(x[:], x[:], data)
+		data = data[n:]
+		c.block.Encrypt(x[:], x[:])
+	}
+}
+
+// ctr encrypts src into dst with the counter blocks A1, A2, ...
+func (c *ccm) ctr(nonce, dst, src []byte) {
+	for i := uint64(1); len(src) > 0; i++ {
+		s := c.counterBlock(nonce, i)
+		n := subtle.XORBytes(dst, src, s[:])
+		dst, src = dst[n:], src[n:]
+	}
+}
+
+// counterBlock returns S_i = E(K, A_i).
+func (c *ccm) counterBlock(nonce []byte, i uint64) [16]byte {
+	var a [16]byte
+	a[0] = byte(15 - c.nonceSize - 1)
+	copy(a[1:], nonce)
+	putLength(a[1+c.nonceSize:], i)
+	c.block.Encrypt(a[:], a[:])
+	return a
+}
+
+// putLength writes v big-endian into all of b.
+func putLength(b []byte, v uint64) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+}
+
+// sliceForAppend extends in by n bytes, returning the whole slice and the
+// extension.
+func sliceForAppend(in []byte, n int) ([]byte, []byte) {
+	total := len(in) + n
+	var head []byte
+	if cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	return head, head[len(in):]
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ccm
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	for _, tt := range []struct {
+		name                            string
+		key, nonce, ad, plaintext, want string
+		tagSize                         int
+	}{
+		{
+			// NIST SP 800-38C, Example 1.
+			name:      "SP800-38C-1",
+			key:       "404142434445464748494a4b4c4d4e4f",
+			nonce:     "10111213141516",
+			ad:        "0001020304050607",
+			plaintext: "20212223",
+			want:      "7162015b4dac255d",
+			tagSize:   4,
+		},
+		{
+			// NIST SP 800-38C, Example 2.
+			name:      "SP800-38C-2",
+			key:       "404142434445464748494a4b4c4d4e4f",
+			nonce:     "1011121314151617",
+			ad:        "000102030405060708090a0b0c0d0e0f",
+			plaintext: "202122232425262728292a2b2c2d2e2f",
+			want:      "d2a1f0e051ea5f62081a7792073d593d1fc64fbfaccd",
+			tagSize:   6,
+		},
+		{
+			// RFC 3610, Packet Vector #1.
+			name:      "RFC3610-1",
+			key:       "c0c1c2c3c4c5c6c7c8c9cacbcccdcecf",
+			nonce:     "00000003020100a0a1a2a3a4a5",
+			ad:        "0001020304050607",
+			plaintext: "08090a0b0c0d0e0f101112131415161718191a1b1c1d1e",
+			want:      "588c979a61c663d2f066d0c2c0f989806d5f6b61dac38417e8d12cfdf926e0",
+			tagSize:   8,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			block, err := aes.NewCipher(unhex(t, tt.key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			nonce := unhex(t, tt.nonce)
+			aead, err := New(block, tt.tagSize, len(nonce))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ad, plaintext, want := unhex(t, tt.ad), unhex(t, tt.plaintext), unhex(t, tt.want)
+			got := aead.Seal(nil, nonce, plaintext, ad)
+			if !bytes.Equal(got, want) {
+				t.Fatalf("Seal = %x, want %x", got, want)
+			}
+			opened, err := aead.Open(nil, nonce, got, ad)
+			if err != nil || !bytes.Equal(opened, plaintext) {
+				t.Fatalf("Open = %x, %v", opened, err)
+			}
+			got[0] ^= 1
+			if _, err := aead.Open(nil, nonce, got, ad); !errors.Is(err, ErrOpen) {
+				t.Errorf("Open(tampered) = %v", err)
+			}
+		})
+	}
+}
+
+func TestMatterParameters(t *testing.T) {
+	block, _ := aes.NewCipher(make([]byte, 16))
+	aead, err := New(block, 16, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, 13)
+	for _, n := range []int{0, 1, 15, 16, 17, 1280} {
+		plaintext := bytes.Repeat([]byte{0xA5}, n)
+		sealed := aead.Seal([]byte("prefix"), nonce, plaintext, []byte("header"))
+		if len(sealed) != len("prefix")+n+16 {
+			t.Fatalf("len(Seal(%d)) = %d", n, len(sealed))
+		}
+		opened, err := aead.Open(nil, nonce, sealed[len("prefix"):], []byte("header"))
+		if err != nil || !bytes.Equal(opened, plaintext) {
+			t.Fatalf("Open(%d) = %v", n, err)
+		}
+		if _, err := aead.Open(nil, nonce, sealed[len("prefix"):], []byte("other")); !errors.Is(err, ErrOpen) {
+			t.Errorf("Open(%d) with different additional data = %v", n, err)
+		}
+	}
+	if _, err := New(block, 5, 13); err == nil {
+		t.Errorf("New accepted an odd tag size")
+	}
+}
@@ -14,42 +14,32 @@
 
 package spake2p
 
-// PointM is the M constant used in SPAKE2+ for the Prover role.
-// This is a placeholder and must be replaced with the actual value from
-// Matter 1.5 Core specification.
-// Reference: Matter Core Spec 1.5, Section 3.9 (SPAKE2+), Table 3.11 (SPAKE2+ Parameters)
-// The point should be in SEC1 uncompressed form (0x04 || x || y) on the P-256 curve.
-// TODO: Replace with the actual M point from Matter Core Spec 1.5 Section 3.9, Table 3.11.
-// WARNING: These are INVALID placeholder values (all 0xFF) to prevent accidental use.
+// PointM is the M constant used in SPAKE2+ for the Prover role, in SEC1
+// uncompressed form (0x04 || x || y) on the P-256 curve.
+// Reference: Matter Core Spec 1.5, Section 3.10 (SPAKE2+), RFC 9383 Section 4
 var PointM = []byte{
 	0x04, // SEC1 uncompressed point indicator
-	// INVALID PLACEHOLDER: Replace with actual x and y coordinates from Matter 1.5 Core spec
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, // x coordinate (32 bytes)
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, // y coordinate (32 bytes)
+	0x88, 0x6E, 0x2F, 0x97, 0xAC, 0xE4, 0x6E, 0x55,
+	0xBA, 0x9D, 0xD7, 0x24, 0x25, 0x79, 0xF2, 0x99,
+	0x3B, 0x64, 0xE1, 0x6E, 0xF3, 0xDC, 0xAB, 0x95,
+	0xAF, 0xD4, 0x97, 0x33, 0x3D, 0x8F, 0xA1, 0x2F, // x coordinate (32 bytes)
+	0x5F, 0xF3, 0x55, 0x16, 0x3E, 0x43, 0xCE, 0x22,
+	0x4E, 0x0B, 0x0E, 0x65, 0xFF, 0x02, 0xAC, 0x8E,
+	0x5C, 0x7B, 0xE0, 0x94, 0x19, 0xC7, 0x85, 0xE0,
+	0xCA, 0x54, 0x7D, 0x55, 0xA1, 0x2E, 0x2D, 0x20, // y coordinate (32 bytes)
 }
 
-// PointN is the N constant used in SPAKE2+ for the Verifier role.
-// This is a placeholder and must be replaced with the actual value from
-// Matter 1.5 Core specification.
-// Reference: Matter Core Spec 1.5, Section 3.9 (SPAKE2+), Table 3.11 (SPAKE2+ Parameters)
-// The point should be in SEC1 uncompressed form (0x04 || x || y) on the P-256 curve.
-// TODO: Replace with the actual N point from Matter Core Spec 1.5 Section 3.9, Table 3.11.
-// WARNING: These are INVALID placeholder values (all 0xFF) to prevent accidental use.
+// PointN is the N constant used in SPAKE2+ for the Verifier role, in SEC1
+// uncompressed form (0x04 || x || y) on the P-256 curve.
+// Reference: Matter Core Spec 1.5, Section 3.10 (SPAKE2+), RFC 9383 Section 4
 var PointN = []byte{
 	0x04, // SEC1 uncompressed point indicator
-	// INVALID PLACEHOLDER: Replace with actual x and y coordinates from Matter 1.5 Core spec
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, // x coordinate (32 bytes)
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, // y coordinate (32 bytes)
+	0xD8, 0xBB, 0xD6, 0xC6, 0x39, 0xC6, 0x29, 0x37,
+	0xB0, 0x4D, 0x99, 0x7F, 0x38, 0xC3, 0x77, 0x07,
+	0x19, 0xC6, 0x29, 0xD7, 0x01, 0x4D, 0x49, 0xA2,
+	0x4B, 0x4F, 0x98, 0xBA, 0xA1, 0x29, 0x2B, 0x49, // x coordinate (32 bytes)
+	0x07, 0xD6, 0x0A, 0xA6, 0xBF, 0xAD, 0xE4, 0x50,
+	0x08, 0xA6, 0x36, 0x33, 0x7F, 0x51, 0x68, 0xC6,
+	0x4D, 0x9B, 0xD3, 0x60, 0x34, 0x80, 0x8C, 0xD5,
+	0x64, 0x49, 0x0B, 0x1E, 0x65, 0x6E, 0xDB, 0xE7, // y coordinate (32 bytes)
 }
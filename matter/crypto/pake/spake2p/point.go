@@ -0,0 +1,78 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spake2p
+
+import (
+	"crypto/elliptic"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// P-256 sizes in bytes.
+const (
+	scalarSize     = 32
+	sessionKeySize = 16
+)
+
+// The crypto/ecdh package does not expose point addition, which SPAKE2+
+// needs, so the group operations use the crypto/elliptic curve methods.
+var curve = elliptic.P256()
+
+// reduce interprets b as a big-endian integer modulo the group order.
+func reduce(b []byte) *big.Int {
+	n := new(big.Int).SetBytes(b)
+	return n.Mod(n, curve.Params().N)
+}
+
+// randomScalar returns a uniformly random scalar in [1, n-1].
+func randomScalar(r io.Reader) (*big.Int, error) {
+	max := new(big.Int).Sub(curve.Params().N, big.NewInt(1))
+	for {
+		b := make([]byte, scalarSize)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, fmt.Errorf("spake2p: random scalar: %w", err)
+		}
+		k := new(big.Int).SetBytes(b)
+		if k.Sign() > 0 && k.Cmp(max) <= 0 {
+			return k, nil
+		}
+	}
+}
+
+func unmarshalPoint(b []byte) (*big.Int, *big.Int) {
+	return elliptic.Unmarshal(curve, b) //nolint:staticcheck
+}
+
+func marshalPoint(x, y *big.Int) []byte {
+	return elliptic.Marshal(curve, x, y) //nolint:staticcheck
+}
+
+func baseMult(k *big.Int) (*big.Int, *big.Int) {
+	return curve.ScalarBaseMult(k.FillBytes(make([]byte, scalarSize))) //nolint:staticcheck
+}
+
+func scalarMult(x, y, k *big.Int) (*big.Int, *big.Int) {
+	return curve.ScalarMult(x, y, k.FillBytes(make([]byte, scalarSize))) //nolint:staticcheck
+}
+
+func add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	return curve.Add(x1, y1, x2, y2) //nolint:staticcheck
+}
+
+func negate(y *big.Int) *big.Int {
+	p := curve.Params().P
+	return new(big.Int).Mod(new(big.Int).Sub(p, y), p)
+}
@@ -15,9 +15,26 @@
 package spake2p
 
 import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"hash"
+	"io"
+	"math/big"
+)
+
+var (
+	// ErrState is returned when the protocol steps are called out of order.
+	ErrState = errors.New("spake2p: operation out of order")
+	// ErrInvalidPoint is returned when the peer public value is not a valid
+	// P-256 point.
+	ErrInvalidPoint = errors.New("spake2p: invalid peer point")
+	// ErrConfirmation is returned when the peer confirmation MAC does not match.
+	ErrConfirmation = errors.New("spake2p: confirmation MAC mismatch")
 )
 
 // Role represents the role in the SPAKE2+ protocol.
@@ -32,23 +49,58 @@ const (
 
 // Params holds the parameters for SPAKE2+ protocol.
 type Params struct {
-	// W0 and W1 are the password-derived values.
+	// W0 and W1 are the password-derived values, big-endian. Both are reduced
+	// modulo the group order, so the 40-byte PBKDF outputs can be passed as is.
 	// W0 is used for the key exchange, W1 is used for verification.
 	W0 []byte
 	W1 []byte
+	// L is the verifier registration record w1*P in SEC1 uncompressed form.
+	// A verifier computes it from W1 when L is nil.
+	L []byte
+	// Context is the protocol context hashed into the transcript.
+	Context []byte
+	// ProverID and VerifierID are the party identities hashed into the
+	// transcript. Matter PASE leaves both empty.
+	ProverID   []byte
+	VerifierID []byte
 	// Hash function to use (defaults to SHA-256).
 	Hash func() hash.Hash
+	// Rand is the source of the ephemeral scalar; nil uses crypto/rand.
+	Rand io.Reader
+}
+
+// SessionKeys are the keys derived from the shared secret Ke.
+// Reference: Matter Core Spec 1.5, Section 4.14.1.4 (Session Encryption Keys)
+type SessionKeys struct {
+	// I2RKey encrypts messages from the initiator (prover) to the responder.
+	I2RKey []byte
+	// R2IKey encrypts messages from the responder (verifier) to the initiator.
+	R2IKey []byte
+	// AttestationChallenge is used by device attestation and CSR requests.
+	AttestationChallenge []byte
 }
 
 // Suite represents a SPAKE2+ protocol suite instance.
 type Suite struct {
 	role   Role
 	params Params
-	// TODO: Add state for the protocol execution per Matter Core Spec 1.5 Section 3.9.1:
-	// - private scalar x or y
-	// - peer's public point X or Y
-	// - shared secret K
-	// - transcript hash TT
+	w0     *big.Int
+	scalar *big.Int
+	// public is the local public value and peer the peer public value,
+	// both in SEC1 uncompressed form.
+	public []byte
+	peer   []byte
+	ke     []byte
+	kcA    []byte
+	kcB    []byte
+}
+
+// Verifier returns the registration record w0 || L for the PBKDF outputs
+// w0s and w1s: w0 as a 32 byte big-endian scalar followed by L = w1*P in
+// SEC1 uncompressed form. A verifier holding it needs no passcode.
+func Verifier(w0s, w1s []byte) []byte {
+	w0 := reduce(w0s).FillBytes(make([]byte, scalarSize))
+	return append(w0, marshalPoint(baseMult(reduce(w1s)))...)
 }
 
 // New creates a new SPAKE2+ suite with the given role and parameters.
@@ -56,63 +108,171 @@ func New(role Role, params Params) *Suite {
 	if params.Hash == nil {
 		params.Hash = sha256.New
 	}
+	if params.Rand == nil {
+		params.Rand = rand.Reader
+	}
 	return &Suite{
 		role:   role,
 		params: params,
+		w0:     reduce(params.W0),
 	}
 }
 
 // Start initiates the SPAKE2+ protocol and returns the public value to send to the peer.
-// TODO: Implement SPAKE2+ Start according to Matter 1.5 Core specification:
-// Reference: Matter Core Spec 1.5, Section 3.9 (SPAKE2+), Section 3.9.1 (Protocol Flow)
-// - Generate random scalar x (Prover) or y (Verifier)
-// - Compute X = x*P + w0*M (Prover) or Y = y*P + w0*N (Verifier)
-// - Return the point in SEC1 uncompressed form
-// - Update transcript TT with context and public values.
+// Reference: Matter Core Spec 1.5, Section 3.10 (SPAKE2+)
+// - Prover: X = x*P + w0*M
+// - Verifier: Y = y*P + w0*N
+// The point is returned in SEC1 uncompressed form.
 func (s *Suite) Start() ([]byte, error) {
-	return nil, errors.New("spake2p.Start: not implemented - TODO: implement SPAKE2+ Start per Matter Core Spec 1.5 Section 3.9.1")
+	if s.public != nil {
+		return nil, ErrState
+	}
+	scalar, err := randomScalar(s.params.Rand)
+	if err != nil {
+		return nil, err
+	}
+	blind := PointM
+	if s.role == RoleVerifier {
+		blind = PointN
+	}
+	bx, by := unmarshalPoint(blind)
+	px, py := baseMult(scalar)
+	mx, my := scalarMult(bx, by, s.w0)
+	s.scalar = scalar
+	s.public = marshalPoint(add(px, py, mx, my))
+	return s.public, nil
 }
 
 // ProcessPeer processes the peer's public value and computes the shared secret.
-// TODO: Implement SPAKE2+ ProcessPeer according to Matter 1.5 Core specification:
-// Reference: Matter Core Spec 1.5, Section 3.9.1 (Protocol Flow), Section 3.9.2 (Shared Secret Computation)
-// - Parse peer point from SEC1 uncompressed form
-// - Compute shared point:
-//   - Prover: K = x*(Y - w0*N)
-//   - Verifier: K = y*(X - w0*M)
+// Reference: Matter Core Spec 1.5, Section 3.10 (SPAKE2+)
+// - Prover: Z = x*(Y - w0*N), V = w1*(Y - w0*N)
+// - Verifier: Z = y*(X - w0*M), V = y*L
 //
-// - Update transcript TT with peer public value
-// - Return error if point validation fails or computation fails.
+// The transcript TT is then hashed into Ka || Ke, and the confirmation keys
+// KcA || KcB are derived from Ka.
 func (s *Suite) ProcessPeer(peerPublic []byte) error {
-	return errors.New("spake2p.ProcessPeer: not implemented - TODO: implement SPAKE2+ ProcessPeer per Matter Core Spec 1.5 Section 3.9.2")
+	if s.public == nil || s.peer != nil {
+		return ErrState
+	}
+	qx, qy := unmarshalPoint(peerPublic)
+	if qx == nil {
+		return ErrInvalidPoint
+	}
+	blind := PointN
+	if s.role == RoleVerifier {
+		blind = PointM
+	}
+	bx, by := unmarshalPoint(blind)
+	bx, by = scalarMult(bx, by, s.w0)
+	tx, ty := add(qx, qy, bx, negate(by))
+	if tx.Sign() == 0 && ty.Sign() == 0 {
+		return ErrInvalidPoint
+	}
+	zx, zy := scalarMult(tx, ty, s.scalar)
+	var vx, vy *big.Int
+	if s.role == RoleProver {
+		vx, vy = scalarMult(tx, ty, reduce(s.params.W1))
+	} else {
+		l := s.params.L
+		if l == nil {
+			l = marshalPoint(baseMult(reduce(s.params.W1)))
+		}
+		lx, ly := unmarshalPoint(l)
+		if lx == nil {
+			return fmt.Errorf("spake2p: invalid registration record L")
+		}
+		vx, vy = scalarMult(lx, ly, s.scalar)
+	}
+	s.peer = peerPublic
+
+	x, y := s.public, s.peer
+	if s.role == RoleVerifier {
+		x, y = y, x
+	}
+	h := s.params.Hash()
+	for _, b := range [][]byte{
+		s.params.Context,
+		s.params.ProverID,
+		s.params.VerifierID,
+		PointM,
+		PointN,
+		x,
+		y,
+		marshalPoint(zx, zy),
+		marshalPoint(vx, vy),
+		s.w0.FillBytes(make([]byte, scalarSize)),
+	} {
+		var n [8]byte
+		binary.LittleEndian.PutUint64(n[:], uint64(len(b)))
+		h.Write(n[:])
+		h.Write(b)
+	}
+	kaKe := h.Sum(nil)
+	ka, ke := kaKe[:len(kaKe)/2], kaKe[len(kaKe)/2:]
+	kc, err := hkdf.Key(s.params.Hash, ka, nil, "ConfirmationKeys", len(kaKe))
+	if err != nil {
+		return err
+	}
+	s.ke = ke
+	s.kcA, s.kcB = kc[:len(kc)/2], kc[len(kc)/2:]
+	return nil
 }
 
-// VerifyConfirmation verifies the peer's confirmation MAC.
-// TODO: Implement confirmation verification according to Matter 1.5 Core specification:
-// Reference: Matter Core Spec 1.5, Section 3.9.3 (Key Confirmation), Section 4.14.1.3 (PASE Protocol)
-// - Compute expected MAC using HKDF and transcript TT
-// - Compare with received MAC using constant-time comparison
-// - Return error if verification fails.
+// VerifyConfirmation verifies the peer's confirmation MAC using a
+// constant-time comparison.
+// Reference: Matter Core Spec 1.5, Section 3.10 (SPAKE2+)
+// - Prover verifies cB = HMAC(KcB, X)
+// - Verifier verifies cA = HMAC(KcA, Y)
 func (s *Suite) VerifyConfirmation(peerMAC []byte) error {
-	return errors.New("spake2p.VerifyConfirmation: not implemented - TODO: implement confirmation MAC verification per Matter Core Spec 1.5 Section 3.9.3")
+	if s.ke == nil {
+		return ErrState
+	}
+	var expected []byte
+	if s.role == RoleProver {
+		expected = s.mac(s.kcB, s.public)
+	} else {
+		expected = s.mac(s.kcA, s.public)
+	}
+	if !hmac.Equal(expected, peerMAC) {
+		return ErrConfirmation
+	}
+	return nil
 }
 
-// ExportKeys derives session keys from the shared secret using HKDF.
-// TODO: Implement key export according to Matter 1.5 Core specification:
-// Reference: Matter Core Spec 1.5, Section 3.9.4 (Key Derivation), Section 4.14.1.4 (Session Key Generation)
-// - Use HKDF-Expand with the shared secret K and transcript TT
-// - Derive I2R (Initiator to Responder) and R2I (Responder to Initiator) keys
-// - Use proper labels as defined in Matter Core Spec 1.5 Section 3.9.4
-// - Return (I2R key, R2I key, error).
-func (s *Suite) ExportKeys() ([]byte, []byte, error) {
-	return nil, nil, errors.New("spake2p.ExportKeys: not implemented - TODO: implement HKDF key derivation per Matter Core Spec 1.5 Section 3.9.4")
+// ExportKeys derives the session keys from the shared secret Ke using HKDF
+// with the "SessionKeys" info label.
+// Reference: Matter Core Spec 1.5, Section 4.14.1.4 (Session Encryption Keys)
+func (s *Suite) ExportKeys() (*SessionKeys, error) {
+	if s.ke == nil {
+		return nil, ErrState
+	}
+	keys, err := hkdf.Key(s.params.Hash, s.ke, nil, "SessionKeys", 3*sessionKeySize)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionKeys{
+		I2RKey:               keys[:sessionKeySize],
+		R2IKey:               keys[sessionKeySize : 2*sessionKeySize],
+		AttestationChallenge: keys[2*sessionKeySize:],
+	}, nil
 }
 
 // GetConfirmation computes the local confirmation MAC to send to the peer.
-// TODO: Implement confirmation MAC computation according to Matter 1.5 Core specification:
-// Reference: Matter Core Spec 1.5, Section 3.9.3 (Key Confirmation), Section 4.14.1.3 (PASE Protocol)
-// - Compute MAC using HKDF and transcript TT
-// - Return MAC value.
+// Reference: Matter Core Spec 1.5, Section 3.10 (SPAKE2+)
+// - Prover: cA = HMAC(KcA, Y)
+// - Verifier: cB = HMAC(KcB, X)
 func (s *Suite) GetConfirmation() ([]byte, error) {
-	return nil, errors.New("spake2p.GetConfirmation: not implemented - TODO: implement confirmation MAC generation per Matter Core Spec 1.5 Section 3.9.3")
+	if s.ke == nil {
+		return nil, ErrState
+	}
+	if s.role == RoleProver {
+		return s.mac(s.kcA, s.peer), nil
+	}
+	return s.mac(s.kcB, s.peer), nil
+}
+
+func (s *Suite) mac(key, data []byte) []byte {
+	m := hmac.New(s.params.Hash, key)
+	m.Write(data)
+	return m.Sum(nil)
 }
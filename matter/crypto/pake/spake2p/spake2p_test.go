@@ -0,0 +1,219 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spake2p
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func handshake(t *testing.T, prover, verifier *Suite) error {
+	t.Helper()
+	x, err := prover.Start()
+	if err != nil {
+		t.Fatalf("prover Start: %v", err)
+	}
+	y, err := verifier.Start()
+	if err != nil {
+		t.Fatalf("verifier Start: %v", err)
+	}
+	if err := verifier.ProcessPeer(x); err != nil {
+		t.Fatalf("verifier ProcessPeer: %v", err)
+	}
+	cB, err := verifier.GetConfirmation()
+	if err != nil {
+		t.Fatalf("verifier GetConfirmation: %v", err)
+	}
+	if err := prover.ProcessPeer(y); err != nil {
+		t.Fatalf("prover ProcessPeer: %v", err)
+	}
+	if err := prover.VerifyConfirmation(cB); err != nil {
+		return err
+	}
+	cA, err := prover.GetConfirmation()
+	if err != nil {
+		t.Fatalf("prover GetConfirmation: %v", err)
+	}
+	return verifier.VerifyConfirmation(cA)
+}
+
+func TestConstants(t *testing.T) {
+	for name, p := range map[string][]byte{"M": PointM, "N": PointN} {
+		if x, _ := unmarshalPoint(p); x == nil {
+			t.Errorf("%s is not a P-256 point", name)
+		}
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	w0 := bytes.Repeat([]byte{0x11}, 40)
+	w1 := bytes.Repeat([]byte{0x22}, 40)
+	context := []byte("context")
+	l := marshalPoint(baseMult(reduce(w1)))
+
+	for name, verifierParams := range map[string]Params{
+		"w1": {W0: w0, W1: w1, Context: context},
+		"L":  {W0: w0, L: l, Context: context},
+	} {
+		t.Run(name, func(t *testing.T) {
+			prover := New(RoleProver, Params{W0: w0, W1: w1, Context: context})
+			verifier := New(RoleVerifier, verifierParams)
+			if err := handshake(t, prover, verifier); err != nil {
+				t.Fatalf("handshake: %v", err)
+			}
+			pk, err := prover.ExportKeys()
+			if err != nil {
+				t.Fatalf("prover ExportKeys: %v", err)
+			}
+			vk, err := verifier.ExportKeys()
+			if err != nil {
+				t.Fatalf("verifier ExportKeys: %v", err)
+			}
+			if !bytes.Equal(pk.I2RKey, vk.I2RKey) || !bytes.Equal(pk.R2IKey, vk.R2IKey) ||
+				!bytes.Equal(pk.AttestationChallenge, vk.AttestationChallenge) {
+				t.Fatalf("keys differ: %x %x", pk, vk)
+			}
+			if len(pk.I2RKey) != 16 || len(pk.R2IKey) != 16 || len(pk.AttestationChallenge) != 16 {
+				t.Errorf("key sizes = %d %d %d", len(pk.I2RKey), len(pk.R2IKey), len(pk.AttestationChallenge))
+			}
+			if bytes.Equal(pk.I2RKey, pk.R2IKey) {
+				t.Errorf("I2R and R2I keys are equal")
+			}
+		})
+	}
+}
+
+// TestKnownAnswer checks the first SPAKE2+ P256-SHA256-HKDF draft-01 test
+// vector used by the CHIP SDK (src/crypto/tests/SPAKE2P_RFC_test_vectors.h),
+// which follows the Ka || Ke transcript split that Matter PASE uses.
+func TestKnownAnswer(t *testing.T) {
+	unhex := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	var (
+		context = []byte("SPAKE2+-P256-SHA256-HKDF draft-01")
+		w0      = unhex("e6887cf9bdfb7579c69bf47928a84514b5e355ac034863f7ffaf4390e67d798c")
+		w1      = unhex("24b5ae4abda868ec9336ffc3b78ee31c5755bef1759227ef5372ca139b94e512")
+		l       = unhex("0495645cfb74df6e58f9748bb83a86620bab7c82e107f57d6870da8cbcb2ff9f70" +
+			"63a14b6402c62f99afcb9706a4d1a143273259fe76f1c605a3639745a92154b9")
+		x     = unhex("8b0f3f383905cf3a3bb955ef8fb62e24849dd349a05ca79aafb18041d30cbdb6")
+		wantX = unhex("04af09987a593d3bac8694b123839422c3cc87e37d6b41c1d630f000dd64980e53" +
+			"7ae704bcede04ea3bec9b7475b32fa2ca3b684be14d11645e38ea6609eb39e7e")
+		y     = unhex("2e0895b0e763d6d5a9564433e64ac3cac74ff897f6c3445247ba1bab40082a91")
+		wantY = unhex("04417592620aebf9fd203616bbb9f121b730c258b286f890c5f19fea833a9c900c" +
+			"be9057bc549a3e19975be9927f0e7614f08d1f0a108eede5fd7eb5624584a4f4")
+		wantKe = unhex("801db297654816eb4f02868129b9dc89")
+		wantCA = unhex("d4376f2da9c72226dd151b77c2919071155fc22a2068d90b5faa6c78c11e77dd")
+		wantCB = unhex("0660a680663e8c5695956fb22dff298b1d07a526cf3cc591adfecd1f6ef6e02e")
+	)
+	if got := Verifier(w0, w1); !bytes.Equal(got, append(bytes.Clone(w0), l...)) {
+		t.Errorf("Verifier = %x", got)
+	}
+
+	prover := New(RoleProver, Params{
+		W0: w0, W1: w1, Context: context,
+		ProverID: []byte("client"), VerifierID: []byte("server"),
+		Rand: bytes.NewReader(x),
+	})
+	verifier := New(RoleVerifier, Params{
+		W0: w0, L: l, Context: context,
+		ProverID: []byte("client"), VerifierID: []byte("server"),
+		Rand: bytes.NewReader(y),
+	})
+	gotX, err := prover.Start()
+	if err != nil {
+		t.Fatalf("prover Start: %v", err)
+	}
+	if !bytes.Equal(gotX, wantX) {
+		t.Errorf("X = %x, want %x", gotX, wantX)
+	}
+	gotY, err := verifier.Start()
+	if err != nil {
+		t.Fatalf("verifier Start: %v", err)
+	}
+	if !bytes.Equal(gotY, wantY) {
+		t.Errorf("Y = %x, want %x", gotY, wantY)
+	}
+	if err := prover.ProcessPeer(gotY); err != nil {
+		t.Fatalf("prover ProcessPeer: %v", err)
+	}
+	if err := verifier.ProcessPeer(gotX); err != nil {
+		t.Fatalf("verifier ProcessPeer: %v", err)
+	}
+	for name, s := range map[string]*Suite{"prover": prover, "verifier": verifier} {
+		if !bytes.Equal(s.ke, wantKe) {
+			t.Errorf("%s Ke = %x, want %x", name, s.ke, wantKe)
+		}
+	}
+	cA, err := prover.GetConfirmation()
+	if err != nil {
+		t.Fatalf("prover GetConfirmation: %v", err)
+	}
+	if !bytes.Equal(cA, wantCA) {
+		t.Errorf("cA = %x, want %x", cA, wantCA)
+	}
+	cB, err := verifier.GetConfirmation()
+	if err != nil {
+		t.Fatalf("verifier GetConfirmation: %v", err)
+	}
+	if !bytes.Equal(cB, wantCB) {
+		t.Errorf("cB = %x, want %x", cB, wantCB)
+	}
+	if err := prover.VerifyConfirmation(wantCB); err != nil {
+		t.Errorf("prover VerifyConfirmation: %v", err)
+	}
+	if err := verifier.VerifyConfirmation(wantCA); err != nil {
+		t.Errorf("verifier VerifyConfirmation: %v", err)
+	}
+}
+
+func TestHandshakeMismatch(t *testing.T) {
+	w1 := bytes.Repeat([]byte{0x22}, 40)
+	prover := New(RoleProver, Params{W0: bytes.Repeat([]byte{0x11}, 40), W1: w1})
+	verifier := New(RoleVerifier, Params{W0: bytes.Repeat([]byte{0x12}, 40), W1: w1})
+	if err := handshake(t, prover, verifier); !errors.Is(err, ErrConfirmation) {
+		t.Fatalf("handshake = %v, want ErrConfirmation", err)
+	}
+
+	prover = New(RoleProver, Params{W0: bytes.Repeat([]byte{0x11}, 40), W1: w1})
+	verifier = New(RoleVerifier, Params{W0: bytes.Repeat([]byte{0x11}, 40), W1: w1, Context: []byte("other")})
+	if err := handshake(t, prover, verifier); !errors.Is(err, ErrConfirmation) {
+		t.Fatalf("handshake with different contexts = %v, want ErrConfirmation", err)
+	}
+}
+
+func TestInvalidUse(t *testing.T) {
+	s := New(RoleProver, Params{W0: []byte{1}, W1: []byte{2}})
+	if err := s.ProcessPeer(PointN); !errors.Is(err, ErrState) {
+		t.Errorf("ProcessPeer before Start = %v", err)
+	}
+	if _, err := s.ExportKeys(); !errors.Is(err, ErrState) {
+		t.Errorf("ExportKeys before ProcessPeer = %v", err)
+	}
+	if _, err := s.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	bad := bytes.Clone(PointN)
+	bad[64] ^= 1
+	if err := s.ProcessPeer(bad); !errors.Is(err, ErrInvalidPoint) {
+		t.Errorf("ProcessPeer(off-curve) = %v", err)
+	}
+}
@@ -17,6 +17,7 @@ package matter
 import (
 	"context"
 
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
 	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
 	"github.com/YashubuStudio/go-matter-pack/matter/types"
 )
@@ -44,11 +45,11 @@ type Device interface {
 	String() string
 }
 
-// Commission commissions the node with the given commissioning options.}
 // CommissionableDevice represents a commissionable device interface.
 // 5.4.3. Discovery by Commissioner.
 type CommissionableDevice interface {
 	Device
-	// Commission commissions the node with the given commissioning options.
-	Commission(ctx context.Context, payload OnboardingPayload) error
+	// EstablishPASE opens a PASE session with the setup passcode of the payload.
+	// 4.14.1. Passcode-Authenticated Session Establishment (PASE).
+	EstablishPASE(ctx context.Context, payload OnboardingPayload) (commissioning.Session, error)
 }
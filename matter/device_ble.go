@@ -20,6 +20,10 @@ import (
 
 	"github.com/cybergarage/go-logger/log"
 	"github.com/YashubuStudio/go-matter-pack/matter/ble"
+	"github.com/YashubuStudio/go-matter-pack/matter/ble/btp"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/pase"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
 )
 
 type bleDevice struct {
@@ -36,35 +40,63 @@ func newBLEDevice(dev ble.Device, srv ble.Service) CommissionableDevice {
 	}
 }
 
-// Commission commissions the node with the given commissioning options.
-func (dev *bleDevice) Commission(ctx context.Context, payload OnboardingPayload) error {
+// EstablishPASE connects to the device, opens a BTP session and runs PASE
+// over it. BTP delivers messages reliably, so the session runs without MRP.
+// Closing the session disconnects the device.
+// 4.19. Bluetooth Transport Protocol (BTP).
+func (dev *bleDevice) EstablishPASE(ctx context.Context, payload OnboardingPayload) (commissioning.Session, error) {
 	if err := dev.Connect(ctx); err != nil {
-		return fmt.Errorf("failed to connect: %w", err)
+		return nil, fmt.Errorf("failed to connect: %s: %w", dev.String(), err)
 	}
-	defer func() {
+	session, err := dev.establishPASE(ctx, payload.Passcode())
+	if err != nil {
 		if err := dev.Disconnect(); err != nil {
 			log.Errorf("Failed to disconnect: %v", err)
 		}
-	}()
-
-	log.Infof("Connected to device: %s", dev.String())
-
-	log.Infof("Device service: %s", dev.Service.String())
+		return nil, fmt.Errorf("%s: %w", dev.String(), err)
+	}
+	log.Infof("PASE session established with %s", dev.Address().String())
+	return session, nil
+}
 
-	transport, err := dev.Service.Open()
+func (dev *bleDevice) establishPASE(ctx context.Context, passcode pase.Passcode) (*transport.Session, error) {
+	t, err := dev.Service.Open()
 	if err != nil {
-		return fmt.Errorf("failed to open device transport: %s: %w", dev.String(), err)
+		return nil, fmt.Errorf("failed to open device transport: %w", err)
 	}
-	defer transport.Close()
-
-	res, err := transport.Handshake(ctx)
+	btpConn, err := t.Handshake(ctx, btp.Addr(dev.Address().String()))
 	if err != nil {
-		return fmt.Errorf("failed to perform handshake: %s: %w", dev.String(), err)
+		t.Close()
+		return nil, fmt.Errorf("failed to perform handshake: %w", err)
 	}
+	log.Infof("BTP session opened with %d byte segments", btpConn.SegmentSize())
+	conn := transport.NewConn(&bleConn{Conn: btpConn, device: dev.Device}, btpConn.RemoteAddr())
+	nodeID, err := ephemeralNodeID()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	session, err := pase.Establish(ctx, transport.NewUnsecuredSession(conn, nodeID, transport.WithoutMRP()), passcode)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return session, nil
+}
 
-	log.Infof("Handshake response: %s", res.String())
+// bleConn is a BTP session that disconnects the device when closed.
+type bleConn struct {
+	*btp.Conn
+	device ble.Device
+}
 
-	return nil
+// Close ends the BTP session and disconnects the device.
+func (c *bleConn) Close() error {
+	err := c.Conn.Close()
+	if derr := c.device.Disconnect(); err == nil {
+		err = derr
+	}
+	return err
 }
 
 // String returns the string representation of the BLE device.
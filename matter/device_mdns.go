@@ -17,6 +17,7 @@ package matter
 import (
 	"context"
 
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
 	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
 )
 
@@ -62,9 +63,11 @@ func (d *mDNSDevice) Discriminator() Discriminator {
 	return Discriminator(discriminator)
 }
 
-// Commission commissions the node with the given commissioning options.
-func (d *mDNSDevice) Commission(ctx context.Context, payload OnboardingPayload) error {
-	return nil
+// EstablishPASE opens a PASE session over UDP.
+func (d *mDNSDevice) EstablishPASE(ctx context.Context, payload OnboardingPayload) (commissioning.Session, error) {
+	addrs, _ := d.CommissionableNode.Addresses()
+	port, _ := d.CommissionableNode.Port()
+	return establishUDPPASE(ctx, addrs, port, payload)
 }
 
 // String returns the string representation of the mDNS device.
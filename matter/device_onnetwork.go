@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
 )
 
 type onNetworkDevice struct {
@@ -54,9 +56,9 @@ func (dev *onNetworkDevice) Discriminator() Discriminator {
 	return Discriminator(dev.payload.Discriminator())
 }
 
-// Commission commissions the node with the given commissioning options.
-func (dev *onNetworkDevice) Commission(_ context.Context, _ OnboardingPayload) error {
-	return nil
+// EstablishPASE opens a PASE session over UDP.
+func (dev *onNetworkDevice) EstablishPASE(ctx context.Context, payload OnboardingPayload) (commissioning.Session, error) {
+	return establishUDPPASE(ctx, []net.IP{dev.address}, dev.port, payload)
 }
 
 // String returns the string representation of the on-network device.
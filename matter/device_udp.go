@@ -0,0 +1,84 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
+	"github.com/YashubuStudio/go-matter-pack/matter/pase"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+// establishUDPPASE runs PASE with the device at the first of addrs that
+// answers, using the default Matter port when port is zero.
+func establishUDPPASE(ctx context.Context, addrs []net.IP, port int, payload OnboardingPayload) (commissioning.Session, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("%w: no address to open PASE over UDP", ErrNotFound)
+	}
+	if port == 0 {
+		port = mdns.Port
+	}
+	var errs []error
+	for _, addr := range addrs {
+		udpAddr := &net.UDPAddr{IP: addr, Port: port}
+		session, err := establishUDPPASEWith(ctx, udpAddr, payload.Passcode())
+		if err == nil {
+			log.Infof("PASE session established with %s", udpAddr.String())
+			return session, nil
+		}
+		log.Warnf("Failed to establish PASE with %s: %v", udpAddr.String(), err)
+		errs = append(errs, fmt.Errorf("%s: %w", udpAddr.String(), err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+func establishUDPPASEWith(ctx context.Context, addr *net.UDPAddr, passcode pase.Passcode) (*transport.Session, error) {
+	conn, err := transport.Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	nodeID, err := ephemeralNodeID()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	session, err := pase.Establish(ctx, transport.NewUnsecuredSession(conn, nodeID), passcode)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return session, nil
+}
+
+// ephemeralNodeID returns a random node ID identifying the commissioner
+// on unsecured sessions.
+func ephemeralNodeID() (uint64, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b[:]), nil
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+)
+
+// DefaultDiscoveryExpiry is how long a device whose advertisement carries no
+// TTL, such as a BLE advertisement, stays discovered after it was last seen.
+const DefaultDiscoveryExpiry = time.Duration(30 * time.Second)
+
+// DiscoveryEventType represents the kind of a discovery event.
+type DiscoveryEventType int
+
+const (
+	// DiscoveryEventAdded is emitted when a device is discovered.
+	DiscoveryEventAdded DiscoveryEventType = iota
+	// DiscoveryEventChanged is emitted when the advertisement of a known device changes.
+	DiscoveryEventChanged
+	// DiscoveryEventExpired is emitted when a device has not been seen within its TTL.
+	DiscoveryEventExpired
+)
+
+// String returns the string representation of the event type.
+func (t DiscoveryEventType) String() string {
+	switch t {
+	case DiscoveryEventAdded:
+		return "added"
+	case DiscoveryEventChanged:
+		return "changed"
+	case DiscoveryEventExpired:
+		return "expired"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(t))
+	}
+}
+
+// DiscoveryEvent represents a change of the set of discovered devices.
+type DiscoveryEvent struct {
+	// Type is the kind of the event.
+	Type DiscoveryEventType
+	// Key identifies the device across events, e.g. its BLE address or mDNS instance name.
+	Key string
+	// Device is the device as last advertised.
+	Device CommissionableDevice
+	// Time is when the event was detected.
+	Time time.Time
+}
+
+// discoveryEntry is a device known to a discoveryTracker.
+type discoveryEntry struct {
+	dev         CommissionableDevice
+	fingerprint string
+	expiresAt   time.Time
+}
+
+// discoveryTracker turns discovery snapshots into added, changed and expired events.
+type discoveryTracker struct {
+	entries map[string]*discoveryEntry
+}
+
+func newDiscoveryTracker() *discoveryTracker {
+	return &discoveryTracker{
+		entries: map[string]*discoveryEntry{},
+	}
+}
+
+// update records the devices of one discovery round at now and returns the resulting events.
+func (t *discoveryTracker) update(devs []CommissionableDevice, now time.Time) []DiscoveryEvent {
+	events := []DiscoveryEvent{}
+	seen := map[string]bool{}
+	for _, dev := range devs {
+		expiresAt := discoveryExpiresAt(dev, now)
+		if !now.Before(expiresAt) {
+			continue
+		}
+		key, ok := discoveryKey(dev)
+		if !ok {
+			continue
+		}
+		seen[key] = true
+		fingerprint := discoveryFingerprint(dev)
+		entry, ok := t.entries[key]
+		switch {
+		case !ok:
+			events = append(events, DiscoveryEvent{Type: DiscoveryEventAdded, Key: key, Device: dev, Time: now})
+		case entry.fingerprint != fingerprint:
+			events = append(events, DiscoveryEvent{Type: DiscoveryEventChanged, Key: key, Device: dev, Time: now})
+		}
+		t.entries[key] = &discoveryEntry{
+			dev:         dev,
+			fingerprint: fingerprint,
+			expiresAt:   expiresAt,
+		}
+	}
+
+	expired := []string{}
+	for key, entry := range t.entries {
+		if seen[key] || now.Before(entry.expiresAt) {
+			continue
+		}
+		expired = append(expired, key)
+	}
+	slices.Sort(expired)
+	for _, key := range expired {
+		events = append(events, DiscoveryEvent{Type: DiscoveryEventExpired, Key: key, Device: t.entries[key].dev, Time: now})
+		delete(t.entries, key)
+	}
+	return events
+}
+
+// discoveryExpiresAt returns when dev expires unless it is seen again. mDNS
+// devices live for the TTL of their records and BLE devices for
+// DefaultDiscoveryExpiry after their last advertisement.
+func discoveryExpiresAt(dev CommissionableDevice, now time.Time) time.Time {
+	seenAt := now
+	ttl := DefaultDiscoveryExpiry
+	switch d := dev.(type) {
+	case *mDNSDevice:
+		ttl = d.CommissionableNode.TTL()
+	case *bleDevice:
+		if lastSeenAt := d.Device.LastSeenAt(); !lastSeenAt.IsZero() {
+			seenAt = lastSeenAt
+		}
+	}
+	return seenAt.Add(ttl)
+}
+
+// discoveryKey returns the identity of dev across discovery rounds: the
+// instance name of an mDNS device, which stays the same while its host name
+// and addresses change, or the address of a BLE device. A device without
+// either cannot be told apart from others and is not tracked.
+func discoveryKey(dev CommissionableDevice) (string, bool) {
+	switch d := dev.(type) {
+	case *mDNSDevice:
+		if name, ok := d.CommissionableNode.InstanceName(); ok {
+			return "mdns:" + name, true
+		}
+	case *bleDevice:
+		if addr := d.Device.Address(); len(addr) != 0 {
+			return "ble:" + addr.String(), true
+		}
+	}
+	return "", false
+}
+
+// discoveryFingerprint returns the advertised state of dev whose change emits DiscoveryEventChanged.
+func discoveryFingerprint(dev CommissionableDevice) string {
+	fingerprint := dev.String()
+	if d, ok := dev.(*mDNSDevice); ok {
+		addrs, _ := d.CommissionableNode.Addresses()
+		port, _ := d.CommissionableNode.Port()
+		cm, _ := d.CommissionableNode.CommissioningMode()
+		fingerprint += fmt.Sprintf(", CommissioningMode: %s, Addresses: %v, Port: %d", cm, addrs, port)
+	}
+	return fingerprint
+}
+
+// DiscoverStream discovers commissionable devices continuously, one
+// DefaultDiscoveryTimeout round after another, until ctx is done. It emits
+// an event when a device appears, when its advertisement changes and when it
+// expires. The returned channel is closed when ctx is done.
+func (cmr *commissioner) DiscoverStream(ctx context.Context, query Query) (<-chan DiscoveryEvent, error) {
+	if !cmr.enableBLE && !cmr.enableMDNS {
+		return nil, fmt.Errorf("%w: discovery is disabled (enable BLE or mDNS)", ErrDisabled)
+	}
+
+	events := make(chan DiscoveryEvent)
+	go func() {
+		defer close(events)
+		tracker := newDiscoveryTracker()
+		for ctx.Err() == nil {
+			roundCtx, cancel := context.WithTimeout(ctx, DefaultDiscoveryTimeout)
+			devs, err := cmr.Discover(roundCtx, query)
+			if err != nil && ctx.Err() == nil {
+				log.Warnf("Discovery round failed: %v", err)
+			}
+			for _, event := range tracker.update(devs, time.Now()) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					cancel()
+					return
+				}
+			}
+			// Rounds that return early, such as on-network queries, still
+			// wait for the round timeout so the loop does not spin.
+			<-roundCtx.Done()
+			cancel()
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package matter
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/ble"
+	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
+)
+
+// fakeCommissionableNode is an mDNS commissionable node advertised by name
+// at addrs for ttl.
+type fakeCommissionableNode struct {
+	mdns.CommissionableNode
+	name  string
+	addrs []net.IP
+	ttl   time.Duration
+}
+
+func (n *fakeCommissionableNode) InstanceName() (string, bool)      { return n.name, n.name != "" }
+func (n *fakeCommissionableNode) Addresses() ([]net.IP, bool)       { return n.addrs, len(n.addrs) != 0 }
+func (n *fakeCommissionableNode) Port() (int, bool)                 { return 5540, true }
+func (n *fakeCommissionableNode) TTL() time.Duration                { return n.ttl }
+func (n *fakeCommissionableNode) VendorID() (mdns.VendorID, bool)   { return 0xFFF1, true }
+func (n *fakeCommissionableNode) ProductID() (mdns.ProductID, bool) { return 0x8000, true }
+func (n *fakeCommissionableNode) Discriminator() (mdns.Discriminator, bool) {
+	return 3840, true
+}
+func (n *fakeCommissionableNode) CommissioningMode() (mdns.CommissioningMode, bool) {
+	return 0, false
+}
+
+// fakeBLEDevice is a BLE device with addr last seen at lastSeenAt.
+type fakeBLEDevice struct {
+	ble.Device
+	addr       ble.Address
+	lastSeenAt time.Time
+}
+
+func (d *fakeBLEDevice) Address() ble.Address  { return d.addr }
+func (d *fakeBLEDevice) LastSeenAt() time.Time { return d.lastSeenAt }
+
+type fakeBLEService struct {
+	ble.Service
+}
+
+func (s *fakeBLEService) VendorID() ble.VendorID           { return 0xFFF1 }
+func (s *fakeBLEService) ProductID() ble.ProductID         { return 0x8000 }
+func (s *fakeBLEService) Discriminator() ble.Discriminator { return 3840 }
+
+func mdnsTestDevice(name string, addr string, ttl time.Duration) CommissionableDevice {
+	return newMDNSDevice(&fakeCommissionableNode{name: name, addrs: []net.IP{net.ParseIP(addr)}, ttl: ttl})
+}
+
+func bleTestDevice(addr ble.Address, lastSeenAt time.Time) CommissionableDevice {
+	return newBLEDevice(&fakeBLEDevice{addr: addr, lastSeenAt: lastSeenAt}, &fakeBLEService{})
+}
+
+func TestDiscoveryKey(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		dev    CommissionableDevice
+		want   string
+		wantOK bool
+	}{
+		{name: "mdns", dev: mdnsTestDevice("E7C8DB2A0FC30AF9", "192.0.2.1", time.Minute), want: "mdns:E7C8DB2A0FC30AF9", wantOK: true},
+		{name: "mdns without instance name", dev: mdnsTestDevice("", "192.0.2.1", time.Minute)},
+		{name: "ble", dev: bleTestDevice(ble.Address{0xC0, 0x01, 0x02, 0x03, 0x04, 0x05}, now), want: "ble:" + ble.Address{0xC0, 0x01, 0x02, 0x03, 0x04, 0x05}.String(), wantOK: true},
+		{name: "ble without address", dev: bleTestDevice(nil, now)},
+	}
+	for _, tt := range tests {
+		got, ok := discoveryKey(tt.dev)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: discoveryKey = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestDiscoveryTracker(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	bleAddr := ble.Address{0xC0, 0x01, 0x02, 0x03, 0x04, 0x05}
+	bleKey := "ble:" + bleAddr.String()
+
+	type event struct {
+		typ DiscoveryEventType
+		key string
+	}
+	rounds := []struct {
+		name string
+		at   time.Duration
+		devs []CommissionableDevice
+		want []event
+	}{
+		{
+			name: "first round",
+			devs: []CommissionableDevice{
+				mdnsTestDevice("AAAA000000000001", "192.0.2.1", 2*time.Minute),
+				mdnsTestDevice("AAAA000000000002", "192.0.2.2", 30*time.Second),
+				bleTestDevice(bleAddr, start),
+				mdnsTestDevice("", "192.0.2.9", 2*time.Minute),
+			},
+			want: []event{
+				{DiscoveryEventAdded, "mdns:AAAA000000000001"},
+				{DiscoveryEventAdded, "mdns:AAAA000000000002"},
+				{DiscoveryEventAdded, bleKey},
+			},
+		},
+		{
+			name: "unchanged within the ttl",
+			at:   10 * time.Second,
+			devs: []CommissionableDevice{
+				mdnsTestDevice("AAAA000000000001", "192.0.2.1", 2*time.Minute),
+			},
+			want: []event{},
+		},
+		{
+			name: "address change keeps the instance",
+			at:   20 * time.Second,
+			devs: []CommissionableDevice{
+				mdnsTestDevice("AAAA000000000001", "192.0.2.10", 2*time.Minute),
+			},
+			want: []event{
+				{DiscoveryEventChanged, "mdns:AAAA000000000001"},
+			},
+		},
+		{
+			name: "ttl and ble expiry",
+			at:   DefaultDiscoveryExpiry,
+			devs: []CommissionableDevice{},
+			want: []event{
+				{DiscoveryEventExpired, bleKey},
+				{DiscoveryEventExpired, "mdns:AAAA000000000002"},
+			},
+		},
+		{
+			name: "stale ble advertisement is ignored",
+			at:   DefaultDiscoveryExpiry + time.Second,
+			devs: []CommissionableDevice{
+				bleTestDevice(bleAddr, start),
+			},
+			want: []event{},
+		},
+		{
+			name: "expired after its ttl",
+			at:   2*time.Minute + 20*time.Second,
+			devs: []CommissionableDevice{},
+			want: []event{
+				{DiscoveryEventExpired, "mdns:AAAA000000000001"},
+			},
+		},
+		{
+			name: "seen again",
+			at:   3 * time.Minute,
+			devs: []CommissionableDevice{
+				mdnsTestDevice("AAAA000000000001", "192.0.2.10", 2*time.Minute),
+			},
+			want: []event{
+				{DiscoveryEventAdded, "mdns:AAAA000000000001"},
+			},
+		},
+	}
+
+	tracker := newDiscoveryTracker()
+	for _, round := range rounds {
+		now := start.Add(round.at)
+		events := tracker.update(round.devs, now)
+		got := []event{}
+		for _, e := range events {
+			got = append(got, event{e.Type, e.Key})
+			if !e.Time.Equal(now) {
+				t.Errorf("%s: %s %s at %v, want %v", round.name, e.Type, e.Key, e.Time, now)
+			}
+		}
+		if len(got) != len(round.want) {
+			t.Errorf("%s: events = %v, want %v", round.name, got, round.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != round.want[i] {
+				t.Errorf("%s: events = %v, want %v", round.name, got, round.want)
+				break
+			}
+		}
+	}
+}
@@ -15,7 +15,7 @@
 package encoding
 
 import (
-	"errors"
+	"fmt"
 )
 
 const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-."
@@ -95,7 +95,7 @@ func DecodeBase38(s string) ([]byte, error) {
 				val = rev[c]
 			}
 			if val < 0 {
-				return nil, errors.New("invalid base38 character")
+				return nil, fmt.Errorf("%w base38 character %q at %d", ErrInvalid, s[i+j], i+j)
 			}
 			u += uint32(val) * m
 			m *= 38
@@ -117,7 +117,7 @@ func DecodeBase38(s string) ([]byte, error) {
 				val = rev[c]
 			}
 			if val < 0 {
-				return nil, errors.New("invalid base38 character")
+				return nil, fmt.Errorf("%w base38 character %q at %d", ErrInvalid, s[i+j], i+j)
 			}
 			u += uint32(val) * m
 			m *= 38
@@ -133,14 +133,14 @@ func DecodeBase38(s string) ([]byte, error) {
 				val = rev[c]
 			}
 			if val < 0 {
-				return nil, errors.New("invalid base38 character")
+				return nil, fmt.Errorf("%w base38 character %q at %d", ErrInvalid, s[i+j], i+j)
 			}
 			u += uint32(val) * m
 			m *= 38
 		}
 		out = append(out, byte(u&0xFF))
 	default:
-		return nil, errors.New("invalid base38 length")
+		return nil, fmt.Errorf("%w base38 length: %d", ErrInvalid, len(s))
 	}
 	return out, nil
 }
@@ -33,6 +33,9 @@ type CommissioningFlow = types.CommissioningFlow
 // Passcode represents a passcode.
 type Passcode = types.Passcode
 
+// DiscoveryCapabilities represents the discovery capabilities bitmap.
+type DiscoveryCapabilities = types.DiscoveryCapabilities
+
 // OnboardingPayload defines the common onboarding payload fields.
 type OnboardingPayload interface {
 	// Version returns the version.
@@ -15,7 +15,6 @@
 package encoding
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 	"unicode"
@@ -45,6 +44,36 @@ func NewPairingCodeFromString(code string) (PairingCode, error) {
 	return decodeManualPairingCode(code)
 }
 
+// NewPairingCode returns the manual pairing code for the given fields. The
+// code only carries the upper four bits of the discriminator; the vendor and
+// product IDs are included, as a 21-digit code, for non-standard flows.
+func NewPairingCode(flow CommissioningFlow, vendorID VendorID, productID ProductID, discriminator Discriminator, passcode Passcode) (PairingCode, error) {
+	if err := validatePayloadFields(discriminator, passcode); err != nil {
+		return nil, err
+	}
+	pc := &pairingCode{
+		commFlow:  uint8(flow),
+		upperDesc: uint16(discriminator) & 0xF00,
+		passcode:  passcode,
+	}
+	if !flow.IsStandard() {
+		if vendorID == 0 && productID == 0 {
+			return nil, fmt.Errorf("%w pairing code: %s flow needs a vendor or product ID", ErrInvalid, flow)
+		}
+		pc.vendorID = uint16(vendorID)
+		pc.productID = uint16(productID)
+	}
+	return pc, nil
+}
+
+// validatePayloadFields checks the discriminator range and the passcode.
+func validatePayloadFields(discriminator Discriminator, passcode Passcode) error {
+	if discriminator > MaxDiscriminator {
+		return fmt.Errorf("%w discriminator: %d", ErrInvalid, discriminator)
+	}
+	return ValidatePasscode(passcode)
+}
+
 // Version returns the version.
 func (pc *pairingCode) Version() uint8 {
 	return pc.version
@@ -134,14 +163,14 @@ func decodeManualPairingCode(paraingCodeStr string) (*pairingCode, error) {
 		}
 	}
 
-	// Verify the Verhoeff checksum of the entire code.
-	if !validateVerhoeffCheck(code) {
-		return nil, errors.New("manual pairing code failed checksum validation")
-	}
-
 	// Check length: must be 11 or 21 digits.
 	if len(code) != 11 && len(code) != 21 {
-		return nil, errors.New("manual pairing code must be 11 or 21 digits long")
+		return nil, fmt.Errorf("%w manual pairing code: %d digits, must be 11 or 21", ErrInvalid, len(code))
+	}
+
+	// Verify the Verhoeff checksum of the entire code.
+	if !validateVerhoeffCheck(code) {
+		return nil, fmt.Errorf("%w manual pairing code: check digit %c, expected %c", ErrInvalid, code[len(code)-1], generateVerhoeffCheck(code[:len(code)-1]))
 	}
 
 	// Determine if VendorID/ProductID are included based on length.
@@ -15,6 +15,8 @@
 package encoding
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -98,3 +100,50 @@ func TestPairingCode(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPairingCode(t *testing.T) {
+	code, err := NewPairingCode(CommissioningFlow(0), 5010, 259, 3136, 13045239)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code.String() != "3035-750-7966" {
+		t.Errorf("String: got=%q, want=%q", code.String(), "3035-750-7966")
+	}
+
+	code, err = NewPairingCode(CommissioningFlow(2), 0xFFF1, 0x8000, 3840, 20202021)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := NewPairingCodeFromString(code.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.VendorID() != 0xFFF1 || decoded.ProductID() != 0x8000 || decoded.Passcode() != 20202021 || decoded.Discriminator() != 3840 {
+		t.Errorf("round trip of %q: got VID=%d PID=%d passcode=%d discriminator=%d", code.String(),
+			decoded.VendorID(), decoded.ProductID(), decoded.Passcode(), decoded.Discriminator())
+	}
+
+	if _, err := NewPairingCode(CommissioningFlow(2), 0, 0, 3840, 20202021); err == nil {
+		t.Error("expected an error for a custom flow without vendor and product IDs")
+	}
+}
+
+func TestPairingCodeInvalid(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"3497-011-2333", "check digit 3, expected 2"},
+		{"3497-011-233", "10 digits"},
+		{"", "0 digits"},
+	}
+	for _, tt := range tests {
+		_, err := NewPairingCodeFromString(tt.code)
+		if !errors.Is(err, ErrInvalid) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewPairingCodeFromString(%q): got %v, want %q", tt.code, err, tt.want)
+		}
+	}
+	if _, err := DecodeBase38("Y.K9042C00KA0648G0a"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("DecodeBase38: got %v, want ErrInvalid", err)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"slices"
+)
+
+const (
+	// MinPasscode is the smallest valid setup passcode.
+	MinPasscode Passcode = 1
+	// MaxPasscode is the largest valid setup passcode.
+	MaxPasscode Passcode = 99999998
+	// MaxDiscriminator is the largest discriminator.
+	MaxDiscriminator Discriminator = 0xFFF
+)
+
+// forbiddenPasscodes are the trivial passcodes the specification does not
+// allow a device to use.
+var forbiddenPasscodes = []Passcode{
+	0, 11111111, 22222222, 33333333, 44444444,
+	55555555, 66666666, 77777777, 88888888, 99999999,
+	12345678, 87654321,
+}
+
+// ValidatePasscode checks that passcode is in range and is not one of the
+// trivial passcodes forbidden by the specification.
+func ValidatePasscode(passcode Passcode) error {
+	if passcode < MinPasscode || MaxPasscode < passcode {
+		return fmt.Errorf("%w passcode: %d is out of range", ErrInvalid, passcode)
+	}
+	if slices.Contains(forbiddenPasscodes, passcode) {
+		return fmt.Errorf("%w passcode: %08d is not allowed", ErrInvalid, passcode)
+	}
+	return nil
+}
+
+// GeneratePasscode returns a uniformly random valid setup passcode read from
+// r, or from crypto/rand if r is nil.
+func GeneratePasscode(r io.Reader) (Passcode, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	var b [4]byte
+	for {
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, err
+		}
+		// Rejection sampling over 27 bits keeps the distribution uniform.
+		passcode := Passcode(binary.BigEndian.Uint32(b[:]) & 0x7FFFFFF)
+		if ValidatePasscode(passcode) == nil {
+			return passcode, nil
+		}
+	}
+}
+
+// GenerateDiscriminator returns a uniformly random 12-bit discriminator read
+// from r, or from crypto/rand if r is nil.
+func GenerateDiscriminator(r io.Reader) (Discriminator, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	var b [2]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return Discriminator(binary.BigEndian.Uint16(b[:])) & MaxDiscriminator, nil
+}
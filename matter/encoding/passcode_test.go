@@ -0,0 +1,78 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestValidatePasscode(t *testing.T) {
+	for _, passcode := range []Passcode{1, 20202021, 99999998} {
+		if err := ValidatePasscode(passcode); err != nil {
+			t.Errorf("ValidatePasscode(%d) = %v", passcode, err)
+		}
+	}
+	for _, passcode := range []Passcode{0, 11111111, 12345678, 87654321, 99999999, 0x7FFFFFF} {
+		if err := ValidatePasscode(passcode); !errors.Is(err, ErrInvalid) {
+			t.Errorf("ValidatePasscode(%d): got %v, want ErrInvalid", passcode, err)
+		}
+	}
+	if _, err := NewPairingCode(CommissioningFlow(0), 0, 0, 3840, 12345678); !errors.Is(err, ErrInvalid) {
+		t.Errorf("NewPairingCode: got %v, want ErrInvalid", err)
+	}
+	if _, err := NewQRPayload(CommissioningFlow(0), 0xFFF1, 0x8000, 0, 3840, 11111111); !errors.Is(err, ErrInvalid) {
+		t.Errorf("NewQRPayload: got %v, want ErrInvalid", err)
+	}
+}
+
+func TestGeneratePasscode(t *testing.T) {
+	// 0x0000000 and 0x7FFFFFF are rejected before 12345678 (0x00BC614E),
+	// which is forbidden, and 20202021 (0x01344225) is accepted.
+	r := bytes.NewReader([]byte{
+		0x00, 0x00, 0x00, 0x00,
+		0x07, 0xFF, 0xFF, 0xFF,
+		0x00, 0xBC, 0x61, 0x4E,
+		0x01, 0x34, 0x42, 0x25,
+	})
+	passcode, err := GeneratePasscode(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passcode != 20202021 {
+		t.Errorf("GeneratePasscode: got=%d, want=%d", passcode, 20202021)
+	}
+	if _, err := GeneratePasscode(bytes.NewReader(nil)); err == nil {
+		t.Error("GeneratePasscode: expected an error for an exhausted reader")
+	}
+
+	for range 100 {
+		passcode, err := GeneratePasscode(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ValidatePasscode(passcode); err != nil {
+			t.Errorf("GeneratePasscode: %v", err)
+		}
+		discriminator, err := GenerateDiscriminator(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if MaxDiscriminator < discriminator {
+			t.Errorf("GenerateDiscriminator: got=%d", discriminator)
+		}
+	}
+}
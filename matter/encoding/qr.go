@@ -16,23 +16,99 @@ package encoding
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/types"
 )
 
 const (
 	QRPayloadPrefix = "MT:"
+	// QRPayloadSeparator separates concatenated payloads of a multi-device QR code.
+	QRPayloadSeparator = "*"
+)
+
+// Tags of the Matter-defined QR code extension data. Tags from
+// QRTagVendorMin are vendor specific.
+const (
+	QRTagSerialNumber         uint8 = 0x00
+	QRTagPBKDFIterations      uint8 = 0x01
+	QRTagBPKFSalt             uint8 = 0x02
+	QRTagNumberOfDevices      uint8 = 0x03
+	QRTagCommissioningTimeout uint8 = 0x04
+	QRTagVendorMin            uint8 = 0x80
 )
 
+// qrBaseLength is the length of the fixed QR code payload fields in bytes.
+const qrBaseLength = 11
+
 // QRPayload represents the Matter QR code payload interface.
 type QRPayload interface {
 	// OnboardingPayload defines the common onboarding payload fields.
 	OnboardingPayload
+	// DiscoveryCapabilities returns the discovery capabilities bitmap.
+	DiscoveryCapabilities() DiscoveryCapabilities
+	// Extensions returns the optional extension data, ordered by tag.
+	Extensions() []QRExtension
+	// Extension returns the extension data element with the tag.
+	Extension(tag uint8) (QRExtension, bool)
+	// SerialNumber returns the serial number extension.
+	SerialNumber() (string, bool)
+	// CommissioningTimeout returns the commissioning timeout extension.
+	CommissioningTimeout() (time.Duration, bool)
 	// Bytes returns the QR code byte representation.
 	Bytes() []byte
 	// String encodes the payload into the Matter QR code string (with "MT:" prefix and Base38 encoding).
 	String() string
 }
 
+// QRExtension is an optional data element of a QR code payload, carried as
+// TLV after the fixed fields. Value is a string, []byte, uint64 or int64.
+type QRExtension struct {
+	Tag   uint8
+	Value any
+}
+
+// IsVendor returns whether the extension has a vendor specific tag.
+func (ext QRExtension) IsVendor() bool {
+	return QRTagVendorMin <= ext.Tag
+}
+
+// Name returns the name of a Matter-defined extension tag, "vendor" for a
+// vendor specific tag, or "unknown".
+func (ext QRExtension) Name() string {
+	switch {
+	case ext.IsVendor():
+		return "vendor"
+	case ext.Tag == QRTagSerialNumber:
+		return "serial-number"
+	case ext.Tag == QRTagPBKDFIterations:
+		return "pbkdf-iterations"
+	case ext.Tag == QRTagBPKFSalt:
+		return "bpkf-salt"
+	case ext.Tag == QRTagNumberOfDevices:
+		return "number-of-devices"
+	case ext.Tag == QRTagCommissioningTimeout:
+		return "commissioning-timeout"
+	}
+	return "unknown"
+}
+
+// String returns the extension value as a string.
+func (ext QRExtension) String() string {
+	switch v := ext.Value.(type) {
+	case string:
+		return v
+	case []byte:
+		return fmt.Sprintf("%X", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 // qrPayload represents the Matter QR code payload fields.
 type qrPayload struct {
 	version               uint8  // 3-bit version
@@ -42,18 +118,91 @@ type qrPayload struct {
 	discoveryCapabilities uint8  // 8-bit discovery flags (bitmap for BLE, soft-AP, on-network, etc.)
 	discriminator         uint16 // 12-bit discriminator (0–4095)
 	passcode              uint32 // 27-bit setup PIN code (usually 8 decimal digits)
+	extensions            []QRExtension
+}
+
+// NewQRPayload returns the QR code payload for the given fields and optional
+// extension data.
+func NewQRPayload(flow CommissioningFlow, vendorID VendorID, productID ProductID, capabilities DiscoveryCapabilities, discriminator Discriminator, passcode Passcode, extensions ...QRExtension) (QRPayload, error) {
+	if err := validatePayloadFields(discriminator, passcode); err != nil {
+		return nil, err
+	}
+	if flow > types.CommissioningFlowCustom {
+		return nil, fmt.Errorf("%w commissioning flow: %d", ErrInvalid, flow)
+	}
+	exts, err := normalizeQRExtensions(extensions)
+	if err != nil {
+		return nil, err
+	}
+	return &qrPayload{
+		vendorID:              uint16(vendorID),
+		productID:             uint16(productID),
+		commFlow:              uint8(flow),
+		discoveryCapabilities: uint8(capabilities),
+		discriminator:         uint16(discriminator),
+		passcode:              passcode,
+		extensions:            exts,
+	}, nil
 }
 
-// NewQRPayloadFromString parses the QR code string and returns a QRPayload instance.
+// NewQRPayloadFromString parses the QR code string and returns a QRPayload
+// instance. A QR code of concatenated payloads is rejected; use
+// NewQRPayloadsFromString for it.
 func NewQRPayloadFromString(str string) (QRPayload, error) {
 	return newQRPayloadFromString(str)
 }
 
+// NewQRPayloadsFromString parses a QR code string of one or more payloads
+// concatenated with QRPayloadSeparator, as printed on multi-device packages.
+func NewQRPayloadsFromString(str string) ([]QRPayload, error) {
+	chunks, err := splitQRPayloadString(str)
+	if err != nil {
+		return nil, err
+	}
+	payloads := make([]QRPayload, 0, len(chunks))
+	for n, chunk := range chunks {
+		qr, err := newQRPayloadFromBase38(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("payload %d: %w", n+1, err)
+		}
+		payloads = append(payloads, qr)
+	}
+	return payloads, nil
+}
+
+// EncodeQRPayloads encodes payloads into one QR code string, concatenating
+// them with QRPayloadSeparator.
+func EncodeQRPayloads(payloads ...QRPayload) string {
+	chunks := make([]string, 0, len(payloads))
+	for _, payload := range payloads {
+		chunks = append(chunks, EncodeBase38(payload.Bytes()))
+	}
+	return QRPayloadPrefix + strings.Join(chunks, QRPayloadSeparator)
+}
+
 func newQRPayloadFromString(str string) (*qrPayload, error) {
+	chunks, err := splitQRPayloadString(str)
+	if err != nil {
+		return nil, err
+	}
+	if 1 < len(chunks) {
+		return nil, fmt.Errorf("%w QR payload: %d concatenated payloads", ErrInvalid, len(chunks))
+	}
+	return newQRPayloadFromBase38(chunks[0])
+}
+
+func splitQRPayloadString(str string) ([]string, error) {
 	if !strings.HasPrefix(str, QRPayloadPrefix) {
 		return nil, fmt.Errorf("%w QR payload: %s", ErrInvalid, str)
 	}
-	encoded := strings.TrimPrefix(str, QRPayloadPrefix)
+	chunks := strings.Split(strings.TrimPrefix(str, QRPayloadPrefix), QRPayloadSeparator)
+	if slices.Contains(chunks, "") {
+		return nil, fmt.Errorf("%w QR payload: %s", ErrInvalid, str)
+	}
+	return chunks, nil
+}
+
+func newQRPayloadFromBase38(encoded string) (*qrPayload, error) {
 	payloadBytes, err := DecodeBase38(encoded)
 	if err != nil {
 		return nil, err
@@ -62,7 +211,7 @@ func newQRPayloadFromString(str string) (*qrPayload, error) {
 }
 
 func newQRPayloadFromBytes(data []byte) (*qrPayload, error) {
-	if len(data) != 11 {
+	if len(data) < qrBaseLength {
 		return nil, fmt.Errorf("%w QR payload length: %d", ErrInvalid, len(data))
 	}
 	bitPos := uint(0)
@@ -94,9 +243,81 @@ func newQRPayloadFromBytes(data []byte) (*qrPayload, error) {
 		return nil, fmt.Errorf("%w QR payload passcode out of range: %d", ErrInvalid, qr.passcode)
 	}
 
+	if qrBaseLength < len(data) {
+		exts, err := decodeQRExtensions(data[qrBaseLength:])
+		if err != nil {
+			return nil, err
+		}
+		qr.extensions = exts
+	}
+
 	return qr, nil
 }
 
+// decodeQRExtensions decodes the TLV section of a QR code payload, an
+// anonymous structure of context tagged elements.
+func decodeQRExtensions(data []byte) ([]QRExtension, error) {
+	r := tlv.NewReader(data)
+	if !r.Next() {
+		if err := r.Err(); err != nil {
+			return nil, fmt.Errorf("%w QR payload extension data: %w", ErrInvalid, err)
+		}
+		return nil, nil
+	}
+	if r.Element().Type() != tlv.ETStructure {
+		return nil, fmt.Errorf("%w QR payload extension data: not a structure", ErrInvalid)
+	}
+	if err := r.EnterContainer(); err != nil {
+		return nil, fmt.Errorf("%w QR payload extension data: %w", ErrInvalid, err)
+	}
+	exts := []QRExtension{}
+	for r.Next() {
+		el := r.Element()
+		if el.Tag().Control() != tlv.TagCtlContext {
+			return nil, fmt.Errorf("%w QR payload extension tag: %s", ErrInvalid, el.Tag())
+		}
+		ext := QRExtension{Tag: el.Tag().SerializeTag()[0]}
+		if v, ok := el.UTF8(); ok {
+			ext.Value = v
+		} else if v, ok := el.Unsigned(); ok {
+			ext.Value = v
+		} else if v, ok := el.Signed(); ok {
+			ext.Value = v
+		} else if v, ok := el.Bytes(); ok {
+			ext.Value = v
+		} else {
+			return nil, fmt.Errorf("%w QR payload extension %d: unsupported type", ErrInvalid, ext.Tag)
+		}
+		exts = append(exts, ext)
+	}
+	if err := r.Err(); err != nil {
+		return nil, fmt.Errorf("%w QR payload extension data: %w", ErrInvalid, err)
+	}
+	return normalizeQRExtensions(exts)
+}
+
+// normalizeQRExtensions validates the extensions and orders them by tag.
+func normalizeQRExtensions(extensions []QRExtension) ([]QRExtension, error) {
+	if len(extensions) == 0 {
+		return nil, nil
+	}
+	exts := slices.Clone(extensions)
+	slices.SortStableFunc(exts, func(a, b QRExtension) int {
+		return int(a.Tag) - int(b.Tag)
+	})
+	for i, ext := range exts {
+		if 0 < i && exts[i-1].Tag == ext.Tag {
+			return nil, fmt.Errorf("%w QR payload extension %d: duplicated", ErrInvalid, ext.Tag)
+		}
+		switch ext.Value.(type) {
+		case string, []byte, uint64, int64:
+		default:
+			return nil, fmt.Errorf("%w QR payload extension %d: unsupported type %T", ErrInvalid, ext.Tag, ext.Value)
+		}
+	}
+	return exts, nil
+}
+
 // Version returns the QR code version.
 func (qr *qrPayload) Version() uint8 {
 	return qr.version
@@ -117,6 +338,11 @@ func (qr *qrPayload) CommissioningFlow() CommissioningFlow {
 	return CommissioningFlow(qr.commFlow)
 }
 
+// DiscoveryCapabilities returns the discovery capabilities bitmap.
+func (qr *qrPayload) DiscoveryCapabilities() DiscoveryCapabilities {
+	return DiscoveryCapabilities(qr.discoveryCapabilities)
+}
+
 // Discriminator returns the Discriminator.
 func (qr *qrPayload) Discriminator() Discriminator {
 	return Discriminator(qr.discriminator)
@@ -127,12 +353,55 @@ func (qr *qrPayload) Passcode() Passcode {
 	return Passcode(qr.passcode)
 }
 
-// Bytes packs the payload fields into a little-endian []byte per Matter spec.
+// Extensions returns the optional extension data, ordered by tag.
+func (qr *qrPayload) Extensions() []QRExtension {
+	return slices.Clone(qr.extensions)
+}
+
+// Extension returns the extension data element with the tag.
+func (qr *qrPayload) Extension(tag uint8) (QRExtension, bool) {
+	for _, ext := range qr.extensions {
+		if ext.Tag == tag {
+			return ext, true
+		}
+	}
+	return QRExtension{}, false
+}
+
+// SerialNumber returns the serial number extension, which is encoded either
+// as a string or as an unsigned integer.
+func (qr *qrPayload) SerialNumber() (string, bool) {
+	ext, ok := qr.Extension(QRTagSerialNumber)
+	if !ok {
+		return "", false
+	}
+	switch v := ext.Value.(type) {
+	case string:
+		return v, true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	}
+	return "", false
+}
+
+// CommissioningTimeout returns the commissioning timeout extension.
+func (qr *qrPayload) CommissioningTimeout() (time.Duration, bool) {
+	ext, ok := qr.Extension(QRTagCommissioningTimeout)
+	if !ok {
+		return 0, false
+	}
+	v, ok := ext.Value.(uint64)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(v) * time.Second, true
+}
+
+// Bytes packs the payload fields into a little-endian []byte per Matter spec,
+// followed by the TLV encoded extension data if any.
 func (qr *qrPayload) Bytes() []byte {
 	// Total bits = 3+16+16+2+8+12+27 + 4 padding = 88 bits (11 bytes):contentReference[oaicite:15]{index=15}
-	totalBits := 88
-	totalBytes := totalBits / 8 // 11 bytes
-	buf := make([]byte, totalBytes)
+	buf := make([]byte, qrBaseLength)
 
 	// Use a 64-bit or larger container (88 bits doesn't fit in 64-bit, so use bytes).
 	// We'll pack bits LSB-first into a little-endian byte buffer.
@@ -164,7 +433,32 @@ func (qr *qrPayload) Bytes() []byte {
 	setBits(0, 4)                                // Padding (4 bits)
 	// The remaining high-order bits (up to 88) serve as padding (implicitly 0).
 
-	return buf
+	if len(qr.extensions) == 0 {
+		return buf
+	}
+	return append(buf, qr.extensionBytes()...)
+}
+
+// extensionBytes encodes the extension data as an anonymous TLV structure.
+// The values are validated on construction, so encoding cannot fail.
+func (qr *qrPayload) extensionBytes() []byte {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	for _, ext := range qr.extensions {
+		tag := tlv.ContextTag(ext.Tag)
+		switch v := ext.Value.(type) {
+		case string:
+			_ = enc.PutUTF8(tag, v)
+		case []byte:
+			_ = enc.PutBytes(tag, v)
+		case uint64:
+			_ = enc.PutUnsigned(tag, v)
+		case int64:
+			_ = enc.PutSigned(tag, v)
+		}
+	}
+	enc.MustEndAll()
+	return enc.Bytes()
 }
 
 // String encodes the payload into the Matter QR code string (with "MT:" prefix and Base38 encoding).
@@ -15,7 +15,9 @@
 package encoding
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestQRPayload(t *testing.T) {
@@ -94,3 +96,128 @@ func TestQRPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestNewQRPayload(t *testing.T) {
+	for _, s := range []string{"MT:Y.ET0EDB00SWDX0IA00", "MT:MFAA0CIW17MA.X1IN00"} {
+		want, err := newQRPayloadFromString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload, err := NewQRPayload(want.CommissioningFlow(), want.VendorID(), want.ProductID(), want.DiscoveryCapabilities(), want.Discriminator(), want.Passcode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if payload.String() != s {
+			t.Errorf("String: got=%q, want=%q", payload.String(), s)
+		}
+	}
+
+	invalid := []struct {
+		discriminator Discriminator
+		passcode      Passcode
+	}{
+		{0x1000, 20202021},
+		{3840, 0},
+		{3840, 99999999},
+	}
+	for _, tt := range invalid {
+		if _, err := NewQRPayload(CommissioningFlow(0), 0xFFF1, 0x8000, 0x04, tt.discriminator, tt.passcode); err == nil {
+			t.Errorf("NewQRPayload(%d, %d): expected an error", tt.discriminator, tt.passcode)
+		}
+	}
+}
+
+func TestQRPayloadExtensions(t *testing.T) {
+	payload, err := NewQRPayload(CommissioningFlow(0), 0xFFF1, 0x8000, DiscoveryCapabilities(0x06), 3840, 20202021,
+		QRExtension{Tag: 0x81, Value: "vendor"},
+		QRExtension{Tag: QRTagCommissioningTimeout, Value: uint64(900)},
+		QRExtension{Tag: QRTagSerialNumber, Value: "SN-0001"},
+		QRExtension{Tag: 0x82, Value: int64(-3)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(payload.Bytes()); n <= qrBaseLength {
+		t.Fatalf("Bytes: got %d bytes, want extension data", n)
+	}
+
+	decoded, err := NewQRPayloadFromString(payload.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() != payload.String() {
+		t.Errorf("String: got=%q, want=%q", decoded.String(), payload.String())
+	}
+	if decoded.Passcode() != 20202021 || decoded.Discriminator() != 3840 {
+		t.Errorf("fields: got passcode=%d discriminator=%d", decoded.Passcode(), decoded.Discriminator())
+	}
+	caps := decoded.DiscoveryCapabilities()
+	if !caps.HasBLE() || !caps.HasOnNetwork() || caps.HasSoftAP() || caps.String() != "ble,on-network" {
+		t.Errorf("DiscoveryCapabilities: got=%s", caps)
+	}
+	if serial, ok := decoded.SerialNumber(); !ok || serial != "SN-0001" {
+		t.Errorf("SerialNumber: got=%q, %t", serial, ok)
+	}
+	if timeout, ok := decoded.CommissioningTimeout(); !ok || timeout != 15*time.Minute {
+		t.Errorf("CommissioningTimeout: got=%s, %t", timeout, ok)
+	}
+	exts := decoded.Extensions()
+	tags := []uint8{}
+	for _, ext := range exts {
+		tags = append(tags, ext.Tag)
+	}
+	if len(tags) != 4 || tags[0] != QRTagSerialNumber || tags[1] != QRTagCommissioningTimeout || tags[2] != 0x81 || tags[3] != 0x82 {
+		t.Errorf("Extensions: got tags %v", tags)
+	}
+	if ext, ok := decoded.Extension(0x82); !ok || !ext.IsVendor() || ext.Value != int64(-3) {
+		t.Errorf("Extension(0x82): got=%v, %t", ext, ok)
+	}
+
+	invalid := [][]QRExtension{
+		{{Tag: 1, Value: uint64(1)}, {Tag: 1, Value: uint64(2)}},
+		{{Tag: 0x80, Value: 1.5}},
+	}
+	for _, exts := range invalid {
+		if _, err := NewQRPayload(CommissioningFlow(0), 0xFFF1, 0x8000, 0, 3840, 20202021, exts...); !errors.Is(err, ErrInvalid) {
+			t.Errorf("NewQRPayload(%v): got %v, want ErrInvalid", exts, err)
+		}
+	}
+}
+
+func TestQRPayloadsConcatenated(t *testing.T) {
+	codes := []string{"MT:Y.ET0EDB00SWDX0IA00", "MT:Y.ET08O614CCY06A810", "MT:MFAA0CIW17MA.X1IN00"}
+	want := []QRPayload{}
+	for _, code := range codes {
+		payload, err := NewQRPayloadFromString(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, payload)
+	}
+
+	str := EncodeQRPayloads(want...)
+	if str != "MT:Y.ET0EDB00SWDX0IA00*Y.ET08O614CCY06A810*MFAA0CIW17MA.X1IN00" {
+		t.Errorf("EncodeQRPayloads: got=%q", str)
+	}
+	payloads, err := NewQRPayloadsFromString(str)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payloads) != len(codes) {
+		t.Fatalf("NewQRPayloadsFromString: got %d payloads, want %d", len(payloads), len(codes))
+	}
+	for i, payload := range payloads {
+		if payload.String() != codes[i] {
+			t.Errorf("payload %d: got=%q, want=%q", i, payload.String(), codes[i])
+		}
+	}
+
+	if _, err := NewQRPayloadFromString(str); !errors.Is(err, ErrInvalid) {
+		t.Errorf("NewQRPayloadFromString: got %v, want ErrInvalid", err)
+	}
+	for _, s := range []string{"MT:Y.ET0EDB00SWDX0IA00*", "MT:*Y.ET0EDB00SWDX0IA00", "Y.ET0EDB00SWDX0IA00"} {
+		if _, err := NewQRPayloadsFromString(s); !errors.Is(err, ErrInvalid) {
+			t.Errorf("NewQRPayloadsFromString(%q): got %v, want ErrInvalid", s, err)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// errFormat is returned when the format or version information of a symbol
+// cannot be read.
+var errFormat = errors.New("qrcode: unreadable format information")
+
+// decodeModules decodes a sampled symbol of size x size modules, dark
+// modules being true, and returns its text.
+func decodeModules(modules []bool, size int) (string, error) {
+	if size < 21 || 177 < size || size%4 != 1 || len(modules) != size*size {
+		return "", fmt.Errorf("%w: symbol size %d", ErrNotFound, size)
+	}
+	c := &Code{version: (size - 17) / 4, size: size, modules: modules}
+
+	level, mask, err := c.readFormat()
+	if err != nil {
+		return "", err
+	}
+	c.level, c.mask = level, mask
+	if 7 <= c.version {
+		version, err := c.readVersion()
+		if err != nil {
+			return "", err
+		}
+		if version != c.version {
+			return "", fmt.Errorf("%w: version %d in a symbol of version %d", errFormat, version, c.version)
+		}
+	}
+
+	data, err := correctCodewords(c.readCodewords(), c.version, c.level)
+	if err != nil {
+		return "", err
+	}
+	return decodeSegments(data, c.version)
+}
+
+// readFormat reads the error correction level and mask from either copy of
+// the format information, correcting up to three bit errors.
+func (c *Code) readFormat() (Level, int, error) {
+	first, second := 0, 0
+	for i := range 6 {
+		first |= c.bit(8, i) << i
+	}
+	first |= c.bit(8, 7)<<6 | c.bit(8, 8)<<7 | c.bit(7, 8)<<8
+	for i := 9; i < 15; i++ {
+		first |= c.bit(14-i, 8) << i
+	}
+	for i := range 8 {
+		second |= c.bit(c.size-1-i, 8) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= c.bit(8, c.size-15+i) << i
+	}
+
+	bestLevel, bestMask, bestDistance := LevelL, 0, 4
+	for level := LevelL; level <= LevelH; level++ {
+		for mask := range 8 {
+			want := formatBits(level, mask)
+			for _, got := range []int{first, second} {
+				if d := bits.OnesCount(uint(want ^ got)); d < bestDistance {
+					bestLevel, bestMask, bestDistance = level, mask, d
+				}
+			}
+		}
+	}
+	if 3 < bestDistance {
+		return 0, 0, errFormat
+	}
+	return bestLevel, bestMask, nil
+}
+
+// readVersion reads the version from either copy of the version
+// information, correcting up to three bit errors.
+func (c *Code) readVersion() (int, error) {
+	first, second := 0, 0
+	for i := range 18 {
+		a, b := c.size-11+i%3, i/3
+		first |= c.bit(a, b) << i
+		second |= c.bit(b, a) << i
+	}
+	best, bestDistance := 0, 4
+	for version := 7; version <= MaxVersion; version++ {
+		want := versionBits(version)
+		for _, got := range []int{first, second} {
+			if d := bits.OnesCount(uint(want ^ got)); d < bestDistance {
+				best, bestDistance = version, d
+			}
+		}
+	}
+	if 3 < bestDistance {
+		return 0, errFormat
+	}
+	return best, nil
+}
+
+func (c *Code) bit(x, y int) int {
+	if c.Dark(x, y) {
+		return 1
+	}
+	return 0
+}
+
+// readCodewords unmasks the data modules and reads them in placement order.
+func (c *Code) readCodewords() []byte {
+	ref := newCode(c.version, c.level)
+	ref.drawFunctionPatterns()
+	var bb bitBuffer
+	for right := c.size - 1; 1 <= right; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.size {
+			for j := range 2 {
+				x, y := right-j, vert
+				if upward {
+					y = c.size - 1 - vert
+				}
+				if ref.function[y*c.size+x] {
+					continue
+				}
+				bb.bits = append(bb.bits, c.Dark(x, y) != maskBit(c.mask, x, y))
+			}
+		}
+	}
+	return bb.bytes()[:numRawDataModules(c.version)/8]
+}
+
+// correctCodewords de-interleaves the codewords into blocks, corrects the
+// errors of each block and returns the data codewords.
+func correctCodewords(codewords []byte, version int, level Level) ([]byte, error) {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	blocks := make([][]byte, numBlocks)
+	for j := range blocks {
+		blocks[j] = make([]byte, shortBlockLen+1)
+	}
+	k := 0
+	for i := range shortBlockLen + 1 {
+		for j := range blocks {
+			if i != shortBlockLen-eccLen || numShortBlocks <= j {
+				blocks[j][i] = codewords[k]
+				k++
+			}
+		}
+	}
+
+	data := make([]byte, 0, numDataCodewords(version, level))
+	for j, block := range blocks {
+		if j < numShortBlocks {
+			// Drop the placeholder of the short blocks.
+			block = append(block[:shortBlockLen-eccLen], block[shortBlockLen-eccLen+1:]...)
+		}
+		if _, err := rsCorrect(block, eccLen); err != nil {
+			return nil, err
+		}
+		data = append(data, block[:len(block)-eccLen]...)
+	}
+	return data, nil
+}
+
+// decodeSegments decodes the segments of the data codewords.
+func decodeSegments(data []byte, version int) (string, error) {
+	r := bitReader{data: data}
+	var text strings.Builder
+	for 4 <= r.remaining() {
+		mode := r.read(4)
+		switch mode {
+		case 0x0:
+			return text.String(), nil
+		case 0x1:
+			count := r.read([...]int{10, 12, 14}[countIndex(version)])
+			for ; 3 <= count; count -= 3 {
+				fmt.Fprintf(&text, "%03d", r.read(10))
+			}
+			switch count {
+			case 2:
+				fmt.Fprintf(&text, "%02d", r.read(7))
+			case 1:
+				fmt.Fprintf(&text, "%d", r.read(4))
+			}
+		case modeAlphanumeric:
+			count := r.read(segment{mode: modeAlphanumeric}.countBits(version))
+			for ; 2 <= count; count -= 2 {
+				v := r.read(11)
+				if 45*45 <= v {
+					return "", fmt.Errorf("%w: alphanumeric value %d", ErrUncorrectable, v)
+				}
+				text.WriteByte(alphanumericCharset[v/45])
+				text.WriteByte(alphanumericCharset[v%45])
+			}
+			if count == 1 {
+				v := r.read(6)
+				if 45 <= v {
+					return "", fmt.Errorf("%w: alphanumeric value %d", ErrUncorrectable, v)
+				}
+				text.WriteByte(alphanumericCharset[v])
+			}
+		case modeByte:
+			count := r.read(segment{mode: modeByte}.countBits(version))
+			for range count {
+				text.WriteByte(byte(r.read(8)))
+			}
+		case 0x3:
+			// Structured append: symbol sequence and parity.
+			r.read(16)
+		case 0x5, 0x9:
+			// FNC1 markers carry no text.
+			if mode == 0x9 {
+				r.read(8)
+			}
+		case 0x7:
+			// The ECI designator is one to three bytes long.
+			switch {
+			case r.read(1) == 0:
+				r.read(7)
+			case r.read(1) == 0:
+				r.read(14)
+			default:
+				r.read(22)
+			}
+		default:
+			return "", fmt.Errorf("qrcode: unsupported mode %d", mode)
+		}
+		if r.err {
+			return "", fmt.Errorf("%w: truncated segment", ErrUncorrectable)
+		}
+	}
+	return text.String(), nil
+}
+
+// countIndex returns the index of the character count width of a version.
+func countIndex(version int) int {
+	switch {
+	case 27 <= version:
+		return 2
+	case 10 <= version:
+		return 1
+	}
+	return 0
+}
+
+// bitReader reads big endian bit fields from a byte slice.
+type bitReader struct {
+	data []byte
+	pos  int
+	err  bool
+}
+
+func (r *bitReader) remaining() int {
+	return len(r.data)*8 - r.pos
+}
+
+func (r *bitReader) read(n int) int {
+	if r.remaining() < n {
+		r.err = true
+		r.pos = len(r.data) * 8
+		return 0
+	}
+	v := 0
+	for range n {
+		v = v<<1 | int(r.data[r.pos>>3]>>(7-(r.pos&7)))&1
+		r.pos++
+	}
+	return v
+}
@@ -0,0 +1,243 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestRSCorrect(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, nsym := range []int{7, 10, 18, 30} {
+		data := make([]byte, 40)
+		for i := range data {
+			data[i] = byte(r.IntN(256))
+		}
+		block := append(append([]byte{}, data...), rsRemainder(data, rsDivisor(nsym))...)
+		want := append([]byte{}, block...)
+		for errs := 0; errs <= nsym/2; errs++ {
+			damaged := append([]byte{}, want...)
+			for _, pos := range r.Perm(len(damaged))[:errs] {
+				damaged[pos] ^= byte(1 + r.IntN(255))
+			}
+			n, err := rsCorrect(damaged, nsym)
+			if err != nil {
+				t.Fatalf("nsym=%d errors=%d: %v", nsym, errs, err)
+			}
+			if n != errs || !bytes.Equal(damaged, want) {
+				t.Errorf("nsym=%d errors=%d: corrected %d, got=%X", nsym, errs, n, damaged)
+			}
+		}
+	}
+	block := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if _, err := rsCorrect(block, 4); !errors.Is(err, ErrUncorrectable) {
+		t.Errorf("rsCorrect: got %v, want ErrUncorrectable", err)
+	}
+}
+
+func TestDecodeSegments(t *testing.T) {
+	// Numeric "01234567" as in the specification, followed by a byte segment.
+	var bb bitBuffer
+	bb.appendBits(0x1, 4)
+	bb.appendBits(8, 10)
+	bb.appendBits(12, 10)
+	bb.appendBits(345, 10)
+	bb.appendBits(67, 7)
+	newSegment("ab").appendTo(&bb, 1)
+	bb.appendBits(0, 4)
+	text, err := decodeSegments(bb.bytes(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "01234567ab" {
+		t.Errorf("decodeSegments: got=%q", text)
+	}
+}
+
+// transformImage renders src into a width x height white image, mapping each
+// destination pixel to its source pixel with f.
+func transformImage(src image.Image, width, height int, f func(x, y float64) (float64, float64)) image.Image {
+	dst := image.NewGray(image.Rect(0, 0, width, height))
+	b := src.Bounds()
+	for y := range height {
+		for x := range width {
+			sx, sy := f(float64(x)+0.5, float64(y)+0.5)
+			c := color.Gray{Y: 255}
+			if p := (image.Point{int(math.Floor(sx)), int(math.Floor(sy))}); p.In(b) {
+				c = color.GrayModel.Convert(src.At(p.X, p.Y)).(color.Gray)
+			}
+			dst.SetGray(x, y, c)
+		}
+	}
+	return dst
+}
+
+func TestDecode(t *testing.T) {
+	const payload = "MT:Y.K9042C00KA0648G00"
+	rotate := func(angle float64) func(image.Image) image.Image {
+		return func(img image.Image) image.Image {
+			w := img.Bounds().Dx()
+			size := int(float64(w) * 1.5)
+			sin, cos := math.Sincos(angle)
+			return transformImage(img, size, size, func(x, y float64) (float64, float64) {
+				dx, dy := x-float64(size)/2, y-float64(size)/2
+				return cos*dx + sin*dy + float64(w)/2, -sin*dx + cos*dy + float64(w)/2
+			})
+		}
+	}
+	tests := []struct {
+		name      string
+		text      string
+		level     Level
+		scale     int
+		transform func(image.Image) image.Image
+	}{
+		{name: "plain", text: payload, level: LevelM, scale: 4},
+		{name: "small", text: payload, level: LevelL, scale: 2},
+		{name: "level H", text: payload, level: LevelH, scale: 3},
+		{name: "concatenated", text: "MT:Y.ET0EDB00SWDX0IA00*Y.ET08O614CCY06A810*MFAA0CIW17MA.X1IN00", level: LevelQ, scale: 5},
+		{name: "version 10", text: "https://example.com/" + strings.Repeat("matter", 30), level: LevelM, scale: 3},
+		{name: "rotated 90", text: payload, level: LevelM, scale: 4, transform: rotate(math.Pi / 2)},
+		{name: "rotated 180", text: payload, level: LevelM, scale: 4, transform: rotate(math.Pi)},
+		{name: "rotated 17", text: payload, level: LevelM, scale: 6, transform: rotate(17 * math.Pi / 180)},
+		{
+			name: "perspective", text: payload, level: LevelM, scale: 8,
+			transform: func(img image.Image) image.Image {
+				w := float64(img.Bounds().Dx())
+				// The top of the sticker is further from the camera.
+				t := quadToQuad(
+					[4]point{{0.2 * w, 0.1 * w}, {0.85 * w, 0.15 * w}, {w, 0.95 * w}, {0.05 * w, w}},
+					[4]point{{0, 0}, {w, 0}, {w, w}, {0, w}},
+				)
+				return transformImage(img, int(w), int(w), func(x, y float64) (float64, float64) {
+					p := t.apply(point{x, y})
+					return p.x, p.y
+				})
+			},
+		},
+		{
+			name: "jpeg", text: payload, level: LevelM, scale: 5,
+			transform: func(img image.Image) image.Image {
+				var buf bytes.Buffer
+				if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 40}); err != nil {
+					t.Fatal(err)
+				}
+				decoded, err := jpeg.Decode(&buf)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return decoded
+			},
+		},
+		{
+			name: "uneven lighting", text: payload, level: LevelM, scale: 6,
+			transform: func(img image.Image) image.Image {
+				b := img.Bounds()
+				dst := image.NewGray(b)
+				for y := b.Min.Y; y < b.Max.Y; y++ {
+					for x := b.Min.X; x < b.Max.X; x++ {
+						v := float64(color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y)
+						shade := 0.35 + 0.65*float64(x)/float64(b.Dx())
+						dst.SetGray(x, y, color.Gray{Y: uint8(30 + v*shade*0.8)})
+					}
+				}
+				return dst
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Encode(tt.text, tt.level)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var img image.Image = code.Image(tt.scale)
+			if tt.transform != nil {
+				img = tt.transform(img)
+			}
+			text, err := Decode(img)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if text != tt.text {
+				t.Errorf("Decode: got=%q, want=%q", text, tt.text)
+			}
+		})
+	}
+}
+
+func TestDecodeDamaged(t *testing.T) {
+	const payload = "MT:Y.K9042C00KA0648G00"
+	code, err := Encode(payload, LevelH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A smudge over a 4x4 block of data modules.
+	for y := 12; y < 16; y++ {
+		for x := 12; x < 16; x++ {
+			code.modules[y*code.size+x] = !code.modules[y*code.size+x]
+		}
+	}
+	text, err := Decode(code.Image(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != payload {
+		t.Errorf("Decode: got=%q, want=%q", text, payload)
+	}
+}
+
+func TestDecodeImage(t *testing.T) {
+	const payload = "MT:Y.K9042C00KA0648G00"
+	code, err := Encode(payload, LevelM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := code.WritePNG(&buf, 4); err != nil {
+		t.Fatal(err)
+	}
+	text, err := DecodeImage(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != payload {
+		t.Errorf("DecodeImage: got=%q, want=%q", text, payload)
+	}
+
+	blank := image.NewGray(image.Rect(0, 0, 200, 200))
+	for i := range blank.Pix {
+		blank.Pix[i] = 0xFF
+	}
+	buf.Reset()
+	if err := png.Encode(&buf, blank); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeImage(&buf); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DecodeImage: got %v, want ErrNotFound", err)
+	}
+	if _, err := DecodeImage(strings.NewReader("not an image")); err == nil {
+		t.Error("DecodeImage: expected an error")
+	}
+}
@@ -0,0 +1,609 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"sort"
+
+	// Register the image formats accepted by DecodeImage.
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// DecodeImage decodes the QR code in a PNG or JPEG image and returns its
+// text.
+func DecodeImage(r io.Reader) (string, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return "", err
+	}
+	return Decode(img)
+}
+
+// Decode locates the QR code in img and returns its text. The symbol may be
+// rotated or seen in perspective, as in a photograph of a sticker.
+func Decode(img image.Image) (string, error) {
+	gray := newLuminance(img)
+	var lastErr error = ErrNotFound
+	for _, binarize := range []func(*luminance) *bitmap{binarizeHybrid, binarizeGlobal} {
+		text, err := decodeBitmap(binarize(gray))
+		if err == nil {
+			return text, nil
+		}
+		if !errors.Is(err, ErrNotFound) || errors.Is(lastErr, ErrNotFound) {
+			lastErr = err
+		}
+	}
+	return "", lastErr
+}
+
+// decodeBitmap tries the most plausible finder pattern triples of bm.
+func decodeBitmap(bm *bitmap) (string, error) {
+	var lastErr error = ErrNotFound
+	for _, triple := range findFinderTriples(bm) {
+		tl, tr, bl := triple[0], triple[1], triple[2]
+		moduleSize := (tl.moduleSize + tr.moduleSize + bl.moduleSize) / 3
+		estimate := (math.Round(distance(tl, tr)/moduleSize)+math.Round(distance(tl, bl)/moduleSize))/2 + 7
+		size := int(estimate)
+		switch size % 4 {
+		case 0:
+			size++
+		case 2:
+			size--
+		case 3:
+			size -= 2
+		}
+		for _, s := range []int{size, size + 4, size - 4} {
+			text, err := decodeGrid(bm, tl, tr, bl, moduleSize, s)
+			if err == nil {
+				return text, nil
+			}
+			lastErr = err
+		}
+	}
+	return "", lastErr
+}
+
+// decodeGrid samples a symbol of size modules located by its finder
+// patterns and decodes it.
+func decodeGrid(bm *bitmap, tl, tr, bl finderPattern, moduleSize float64, size int) (string, error) {
+	if size < 21 || 177 < size {
+		return "", ErrNotFound
+	}
+	dim := float64(size)
+	br := point{tr.x - tl.x + bl.x, tr.y - tl.y + bl.y}
+	brModule := point{dim - 3.5, dim - 3.5}
+	if 21 < size {
+		// The bottom right alignment pattern corrects for perspective.
+		correction := 1 - 3/(dim-7)
+		estimate := point{tl.x + correction*(br.x-tl.x), tl.y + correction*(br.y-tl.y)}
+		if p, ok := findAlignmentPattern(bm, estimate, moduleSize); ok {
+			br = p
+			brModule = point{dim - 6.5, dim - 6.5}
+		}
+	}
+	transform := quadToQuad(
+		[4]point{{3.5, 3.5}, {dim - 3.5, 3.5}, brModule, {3.5, dim - 3.5}},
+		[4]point{{tl.x, tl.y}, {tr.x, tr.y}, br, {bl.x, bl.y}},
+	)
+	modules := make([]bool, size*size)
+	for y := range size {
+		for x := range size {
+			p := transform.apply(point{float64(x) + 0.5, float64(y) + 0.5})
+			modules[y*size+x] = bm.dark(int(math.Floor(p.x)), int(math.Floor(p.y)))
+		}
+	}
+	return decodeModules(modules, size)
+}
+
+// luminance is a grayscale copy of an image.
+type luminance struct {
+	width, height int
+	pix           []uint8
+}
+
+func newLuminance(img image.Image) *luminance {
+	b := img.Bounds()
+	l := &luminance{width: b.Dx(), height: b.Dy(), pix: make([]uint8, b.Dx()*b.Dy())}
+	for y := range l.height {
+		for x := range l.width {
+			l.pix[y*l.width+x] = color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray).Y
+		}
+	}
+	return l
+}
+
+// bitmap is a binarized image, dark pixels being true.
+type bitmap struct {
+	width, height int
+	pix           []bool
+}
+
+// dark returns whether the pixel at x, y is dark; pixels outside the image
+// are light.
+func (bm *bitmap) dark(x, y int) bool {
+	if x < 0 || y < 0 || bm.width <= x || bm.height <= y {
+		return false
+	}
+	return bm.pix[y*bm.width+x]
+}
+
+// binarizeGlobal thresholds the image at the level that best separates the
+// luminance histogram (Otsu's method).
+func binarizeGlobal(l *luminance) *bitmap {
+	var histogram [256]int
+	for _, v := range l.pix {
+		histogram[v]++
+	}
+	total := len(l.pix)
+	sum := 0
+	for i, n := range histogram {
+		sum += i * n
+	}
+	threshold, best := 0, -1.0
+	sumDark, weightDark := 0, 0
+	for i, n := range histogram {
+		weightDark += n
+		if weightDark == 0 {
+			continue
+		}
+		weightLight := total - weightDark
+		if weightLight == 0 {
+			break
+		}
+		sumDark += i * n
+		meanDark := float64(sumDark) / float64(weightDark)
+		meanLight := float64(sum-sumDark) / float64(weightLight)
+		if v := float64(weightDark) * float64(weightLight) * (meanDark - meanLight) * (meanDark - meanLight); best < v {
+			threshold, best = i, v
+		}
+	}
+	bm := &bitmap{width: l.width, height: l.height, pix: make([]bool, len(l.pix))}
+	for i, v := range l.pix {
+		bm.pix[i] = int(v) <= threshold
+	}
+	return bm
+}
+
+// binarizeHybrid thresholds each 8x8 block of the image at the average
+// black point of the surrounding 5x5 blocks, which copes with the uneven
+// lighting of photographs. Small images are thresholded globally.
+func binarizeHybrid(l *luminance) *bitmap {
+	const (
+		blockSize    = 8
+		minDynRange  = 24
+		minImageSize = blockSize * 5
+	)
+	if l.width < minImageSize || l.height < minImageSize {
+		return binarizeGlobal(l)
+	}
+	subWidth := (l.width + blockSize - 1) / blockSize
+	subHeight := (l.height + blockSize - 1) / blockSize
+	blackPoints := make([]int, subWidth*subHeight)
+	for by := range subHeight {
+		for bx := range subWidth {
+			sum, lo, hi, n := 0, 255, 0, 0
+			for y := by * blockSize; y < min((by+1)*blockSize, l.height); y++ {
+				for x := bx * blockSize; x < min((bx+1)*blockSize, l.width); x++ {
+					v := int(l.pix[y*l.width+x])
+					sum += v
+					lo = min(lo, v)
+					hi = max(hi, v)
+					n++
+				}
+			}
+			average := sum / n
+			if hi-lo <= minDynRange {
+				// A flat block is assumed light unless its neighbours say
+				// otherwise.
+				average = lo / 2
+				if 0 < by && 0 < bx {
+					neighbours := (blackPoints[(by-1)*subWidth+bx] + 2*blackPoints[by*subWidth+bx-1] + blackPoints[(by-1)*subWidth+bx-1]) / 4
+					if lo < neighbours {
+						average = neighbours
+					}
+				}
+			}
+			blackPoints[by*subWidth+bx] = average
+		}
+	}
+
+	bm := &bitmap{width: l.width, height: l.height, pix: make([]bool, len(l.pix))}
+	for by := range subHeight {
+		for bx := range subWidth {
+			cx := min(max(bx, 2), subWidth-3)
+			cy := min(max(by, 2), subHeight-3)
+			sum := 0
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					sum += blackPoints[(cy+dy)*subWidth+cx+dx]
+				}
+			}
+			threshold := sum / 25
+			for y := by * blockSize; y < min((by+1)*blockSize, l.height); y++ {
+				for x := bx * blockSize; x < min((bx+1)*blockSize, l.width); x++ {
+					bm.pix[y*l.width+x] = int(l.pix[y*l.width+x]) <= threshold
+				}
+			}
+		}
+	}
+	return bm
+}
+
+type point struct {
+	x, y float64
+}
+
+// finderPattern is a candidate finder pattern centre.
+type finderPattern struct {
+	x, y       float64
+	moduleSize float64
+	// count is the number of scans that confirmed the pattern.
+	count int
+}
+
+func distance(a, b finderPattern) float64 {
+	return math.Hypot(a.x-b.x, a.y-b.y)
+}
+
+// foundPatternCross returns whether the run lengths of state follow the
+// 1:1:3:1:1 ratio of a finder pattern.
+func foundPatternCross(state [5]int) bool {
+	total := 0
+	for _, n := range state {
+		if n == 0 {
+			return false
+		}
+		total += n
+	}
+	if total < 7 {
+		return false
+	}
+	moduleSize := float64(total) / 7
+	maxVariance := moduleSize / 2
+	return math.Abs(moduleSize-float64(state[0])) < maxVariance &&
+		math.Abs(moduleSize-float64(state[1])) < maxVariance &&
+		math.Abs(3*moduleSize-float64(state[2])) < 3*maxVariance &&
+		math.Abs(moduleSize-float64(state[3])) < maxVariance &&
+		math.Abs(moduleSize-float64(state[4])) < maxVariance
+}
+
+// finderScanner collects the finder patterns of a bitmap.
+type finderScanner struct {
+	bm       *bitmap
+	patterns []finderPattern
+}
+
+// findFinderTriples returns the triples of finder patterns (top left, top
+// right, bottom left) that most resemble the corners of a symbol, best
+// first.
+func findFinderTriples(bm *bitmap) [][3]finderPattern {
+	s := &finderScanner{bm: bm}
+	s.scan()
+
+	candidates := s.patterns
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].count > candidates[j].count })
+	if confirmed := countConfirmed(candidates); 3 <= confirmed {
+		candidates = candidates[:confirmed]
+	}
+	candidates = candidates[:min(len(candidates), 12)]
+
+	type scored struct {
+		triple [3]finderPattern
+		score  float64
+	}
+	var triples []scored
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			for k := j + 1; k < len(candidates); k++ {
+				triple, score, ok := orderFinderPatterns(candidates[i], candidates[j], candidates[k])
+				if ok {
+					triples = append(triples, scored{triple, score})
+				}
+			}
+		}
+	}
+	sort.SliceStable(triples, func(i, j int) bool { return triples[i].score < triples[j].score })
+	result := make([][3]finderPattern, 0, min(len(triples), 4))
+	for _, t := range triples[:min(len(triples), 4)] {
+		result = append(result, t.triple)
+	}
+	return result
+}
+
+func countConfirmed(patterns []finderPattern) int {
+	n := 0
+	for _, p := range patterns {
+		if 2 <= p.count {
+			n++
+		}
+	}
+	return n
+}
+
+// orderFinderPatterns orders three finder patterns as top left, top right
+// and bottom left, and scores how far they are from the right isosceles
+// triangle of a symbol with equal module sizes.
+func orderFinderPatterns(a, b, c finderPattern) ([3]finderPattern, float64, bool) {
+	ab, bc, ac := distance(a, b), distance(b, c), distance(a, c)
+	var tl, p, q finderPattern
+	var legA, legB, hyp float64
+	switch {
+	case ab <= bc && ac <= bc:
+		tl, p, q, legA, legB, hyp = a, b, c, ab, ac, bc
+	case ab <= ac && bc <= ac:
+		tl, p, q, legA, legB, hyp = b, a, c, ab, bc, ac
+	default:
+		tl, p, q, legA, legB, hyp = c, a, b, ac, bc, ab
+	}
+	sizes := []float64{a.moduleSize, b.moduleSize, c.moduleSize}
+	sort.Float64s(sizes)
+	if sizes[0]*2 < sizes[2] || legA < 7*sizes[0] || legB < 7*sizes[0] {
+		return [3]finderPattern{}, 0, false
+	}
+	// In image coordinates the top right pattern is clockwise from the
+	// bottom left one around the top left.
+	if (p.x-tl.x)*(q.y-tl.y)-(p.y-tl.y)*(q.x-tl.x) < 0 {
+		p, q = q, p
+	}
+	score := math.Abs(legA-legB)/math.Max(legA, legB) +
+		math.Abs(hyp*hyp-legA*legA-legB*legB)/(hyp*hyp) +
+		(sizes[2]-sizes[0])/sizes[2]
+	return [3]finderPattern{tl, p, q}, score, true
+}
+
+// scan looks for the 1:1:3:1:1 runs of finder patterns along the rows and
+// confirms each candidate across its column.
+func (s *finderScanner) scan() {
+	bm := s.bm
+	skip := max(1, 3*bm.height/(4*177))
+	for y := skip - 1; y < bm.height; y += skip {
+		var state [5]int
+		current := 0
+		for x := range bm.width {
+			if bm.dark(x, y) {
+				if current&1 == 1 {
+					current++
+				}
+				state[current]++
+				continue
+			}
+			if current&1 == 1 {
+				state[current]++
+				continue
+			}
+			if current != 4 {
+				current++
+				state[current]++
+				continue
+			}
+			if foundPatternCross(state) && s.handlePossibleCenter(state, y, x) {
+				state = [5]int{}
+				current = 0
+				continue
+			}
+			state = [5]int{state[2], state[3], state[4], 1, 0}
+			current = 3
+		}
+		if foundPatternCross(state) {
+			s.handlePossibleCenter(state, y, bm.width)
+		}
+	}
+}
+
+// centerFromEnd returns the centre of a finder pattern run ending at end.
+func centerFromEnd(state [5]int, end int) float64 {
+	return float64(end-state[4]-state[3]) - float64(state[2])/2
+}
+
+func (s *finderScanner) handlePossibleCenter(state [5]int, y, end int) bool {
+	total := state[0] + state[1] + state[2] + state[3] + state[4]
+	centerX := centerFromEnd(state, end)
+	centerY, ok := s.crossCheck(int(centerX), y, state[2], total, true)
+	if !ok {
+		return false
+	}
+	centerX, ok = s.crossCheck(int(centerX), int(centerY), state[2], total, false)
+	if !ok {
+		return false
+	}
+	moduleSize := float64(total) / 7
+	for i, p := range s.patterns {
+		if math.Abs(centerY-p.y) <= moduleSize && math.Abs(centerX-p.x) <= moduleSize {
+			if diff := math.Abs(moduleSize - p.moduleSize); diff <= 1 || diff <= p.moduleSize {
+				n := float64(p.count)
+				s.patterns[i] = finderPattern{
+					x:          (n*p.x + centerX) / (n + 1),
+					y:          (n*p.y + centerY) / (n + 1),
+					moduleSize: (n*p.moduleSize + moduleSize) / (n + 1),
+					count:      p.count + 1,
+				}
+				return true
+			}
+		}
+	}
+	s.patterns = append(s.patterns, finderPattern{x: centerX, y: centerY, moduleSize: moduleSize, count: 1})
+	return true
+}
+
+// crossCheck measures the finder pattern through x, y along the column
+// (vertical) or the row and returns its centre on that axis.
+func (s *finderScanner) crossCheck(x, y, maxCount, originalTotal int, vertical bool) (float64, bool) {
+	at := func(i int) bool {
+		if vertical {
+			return s.bm.dark(x, i)
+		}
+		return s.bm.dark(i, y)
+	}
+	start, limit := x, s.bm.width
+	if vertical {
+		start, limit = y, s.bm.height
+	}
+
+	var state [5]int
+	i := start
+	for ; 0 <= i && at(i); i-- {
+		state[2]++
+	}
+	if i < 0 {
+		return 0, false
+	}
+	for ; 0 <= i && !at(i) && state[1] <= maxCount; i-- {
+		state[1]++
+	}
+	if i < 0 || maxCount < state[1] {
+		return 0, false
+	}
+	for ; 0 <= i && at(i) && state[0] <= maxCount; i-- {
+		state[0]++
+	}
+	if maxCount < state[0] {
+		return 0, false
+	}
+
+	i = start + 1
+	for ; i < limit && at(i); i++ {
+		state[2]++
+	}
+	if i == limit {
+		return 0, false
+	}
+	for ; i < limit && !at(i) && state[3] < maxCount; i++ {
+		state[3]++
+	}
+	if i == limit || maxCount <= state[3] {
+		return 0, false
+	}
+	for ; i < limit && at(i) && state[4] < maxCount; i++ {
+		state[4]++
+	}
+	if maxCount <= state[4] {
+		return 0, false
+	}
+
+	total := state[0] + state[1] + state[2] + state[3] + state[4]
+	if 2*originalTotal <= 5*abs(total-originalTotal) {
+		return 0, false
+	}
+	if !foundPatternCross(state) {
+		return 0, false
+	}
+	return centerFromEnd(state, i), true
+}
+
+// findAlignmentPattern looks for the alignment pattern near estimate by
+// matching its 5x5 module template, and returns the centre of the best
+// matching positions.
+func findAlignmentPattern(bm *bitmap, estimate point, moduleSize float64) (point, bool) {
+	const samples = 25
+	match := func(cx, cy float64) int {
+		n := 0
+		for dy := -2; dy <= 2; dy++ {
+			for dx := -2; dx <= 2; dx++ {
+				want := max(abs(dx), abs(dy)) != 1
+				if bm.dark(int(math.Floor(cx+float64(dx)*moduleSize)), int(math.Floor(cy+float64(dy)*moduleSize))) == want {
+					n++
+				}
+			}
+		}
+		return n
+	}
+	for _, allowance := range []float64{4, 8, 16} {
+		radius := allowance * moduleSize
+		step := math.Max(1, moduleSize/4)
+		bestScore, sum, n := 0, point{}, 0
+		for y := estimate.y - radius; y <= estimate.y+radius; y += step {
+			for x := estimate.x - radius; x <= estimate.x+radius; x += step {
+				score := match(x, y)
+				if score < bestScore {
+					continue
+				}
+				if bestScore < score {
+					bestScore, sum, n = score, point{}, 0
+				}
+				sum.x += x
+				sum.y += y
+				n++
+			}
+		}
+		if samples-1 <= bestScore {
+			return point{sum.x / float64(n), sum.y / float64(n)}, true
+		}
+	}
+	return point{}, false
+}
+
+// perspective is a projective transform of the plane.
+type perspective [9]float64
+
+func (t perspective) apply(p point) point {
+	w := t[6]*p.x + t[7]*p.y + t[8]
+	return point{(t[0]*p.x + t[1]*p.y + t[2]) / w, (t[3]*p.x + t[4]*p.y + t[5]) / w}
+}
+
+// squareToQuad maps the unit square corners (0,0), (1,0), (1,1), (0,1) to q.
+func squareToQuad(q [4]point) perspective {
+	sx := q[0].x - q[1].x + q[2].x - q[3].x
+	sy := q[0].y - q[1].y + q[2].y - q[3].y
+	if sx == 0 && sy == 0 {
+		return perspective{
+			q[1].x - q[0].x, q[3].x - q[0].x, q[0].x,
+			q[1].y - q[0].y, q[3].y - q[0].y, q[0].y,
+			0, 0, 1,
+		}
+	}
+	dx1, dx2 := q[1].x-q[2].x, q[3].x-q[2].x
+	dy1, dy2 := q[1].y-q[2].y, q[3].y-q[2].y
+	den := dx1*dy2 - dx2*dy1
+	g := (sx*dy2 - dx2*sy) / den
+	h := (dx1*sy - sx*dy1) / den
+	return perspective{
+		q[1].x - q[0].x + g*q[1].x, q[3].x - q[0].x + h*q[3].x, q[0].x,
+		q[1].y - q[0].y + g*q[1].y, q[3].y - q[0].y + h*q[3].y, q[0].y,
+		g, h, 1,
+	}
+}
+
+// adjugate returns a transform inverse to t up to scale.
+func (t perspective) adjugate() perspective {
+	return perspective{
+		t[4]*t[8] - t[5]*t[7], t[2]*t[7] - t[1]*t[8], t[1]*t[5] - t[2]*t[4],
+		t[5]*t[6] - t[3]*t[8], t[0]*t[8] - t[2]*t[6], t[2]*t[3] - t[0]*t[5],
+		t[3]*t[7] - t[4]*t[6], t[1]*t[6] - t[0]*t[7], t[0]*t[4] - t[1]*t[3],
+	}
+}
+
+// times returns the transform applying u, then t.
+func (t perspective) times(u perspective) perspective {
+	var r perspective
+	for i := range 3 {
+		for j := range 3 {
+			for k := range 3 {
+				r[i*3+j] += t[i*3+k] * u[k*3+j]
+			}
+		}
+	}
+	return r
+}
+
+// quadToQuad maps the corners of from to the corners of to.
+func quadToQuad(from, to [4]point) perspective {
+	return squareToQuad(to).times(squareToQuad(from).adjugate())
+}
@@ -0,0 +1,484 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package qrcode encodes QR codes (ISO/IEC 18004) for onboarding payloads
+// and renders them as terminal blocks, PNG and SVG.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Level represents a QR code error correction level.
+type Level int
+
+const (
+	// LevelL recovers about 7% of the codewords.
+	LevelL Level = iota
+	// LevelM recovers about 15% of the codewords.
+	LevelM
+	// LevelQ recovers about 25% of the codewords.
+	LevelQ
+	// LevelH recovers about 30% of the codewords.
+	LevelH
+)
+
+const (
+	// MinVersion is the smallest QR code version (21x21 modules).
+	MinVersion = 1
+	// MaxVersion is the largest QR code version (177x177 modules).
+	MaxVersion = 40
+)
+
+var (
+	// ErrTooLong is returned when the text does not fit in a QR code.
+	ErrTooLong = errors.New("qrcode: text too long")
+	// ErrNotFound is returned when no QR code is found in an image.
+	ErrNotFound = errors.New("qrcode: no QR code found")
+	// ErrUncorrectable is returned when a QR code has more errors than its
+	// error correction level can recover.
+	ErrUncorrectable = errors.New("qrcode: too many errors")
+)
+
+// NewLevelFromString returns the error correction level named s (L, M, Q or H).
+func NewLevelFromString(s string) (Level, error) {
+	for _, l := range []Level{LevelL, LevelM, LevelQ, LevelH} {
+		if strings.EqualFold(strings.TrimSpace(s), l.String()) {
+			return l, nil
+		}
+	}
+	return 0, fmt.Errorf("qrcode: unknown error correction level: %s", s)
+}
+
+func (l Level) String() string {
+	switch l {
+	case LevelL:
+		return "L"
+	case LevelM:
+		return "M"
+	case LevelQ:
+		return "Q"
+	case LevelH:
+		return "H"
+	default:
+		return "unknown"
+	}
+}
+
+// formatBits returns the two bit level indicator of the format information.
+func (l Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+// Code is an encoded QR code symbol.
+type Code struct {
+	version int
+	level   Level
+	mask    int
+	size    int
+	modules []bool
+	// function marks the modules of function patterns while encoding.
+	function []bool
+}
+
+// Encode returns the smallest QR code holding text at the error correction
+// level. Text made only of the QR alphanumeric characters, such as an "MT:"
+// onboarding payload, is encoded in alphanumeric mode and anything else in
+// byte mode.
+func Encode(text string, level Level) (*Code, error) {
+	if level < LevelL || LevelH < level {
+		return nil, fmt.Errorf("qrcode: invalid error correction level: %d", level)
+	}
+	seg := newSegment(text)
+	version := MinVersion
+	for ; version <= MaxVersion; version++ {
+		if seg.bitLength(version) <= numDataCodewords(version, level)*8 {
+			break
+		}
+	}
+	if MaxVersion < version {
+		return nil, fmt.Errorf("%w: %d characters", ErrTooLong, len(text))
+	}
+
+	c := newCode(version, level)
+	c.drawFunctionPatterns()
+	c.drawCodewords(addErrorCorrection(dataCodewords(seg, version, level), version, level))
+	c.applyBestMask()
+	c.function = nil
+	return c, nil
+}
+
+func newCode(version int, level Level) *Code {
+	size := version*4 + 17
+	return &Code{
+		version:  version,
+		level:    level,
+		size:     size,
+		modules:  make([]bool, size*size),
+		function: make([]bool, size*size),
+	}
+}
+
+// Version returns the QR code version (1-40).
+func (c *Code) Version() int {
+	return c.version
+}
+
+// Level returns the error correction level.
+func (c *Code) Level() Level {
+	return c.level
+}
+
+// Mask returns the data mask pattern (0-7).
+func (c *Code) Mask() int {
+	return c.mask
+}
+
+// Size returns the width and height of the symbol in modules, excluding the
+// quiet zone.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark returns whether the module at column x and row y is dark. Modules
+// outside the symbol are light.
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || c.size <= x || c.size <= y {
+		return false
+	}
+	return c.modules[y*c.size+x]
+}
+
+func (c *Code) set(x, y int, dark bool) {
+	c.modules[y*c.size+x] = dark
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.set(x, y, dark)
+	c.function[y*c.size+x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := range c.size {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+	c.drawFinderPattern(3, 3)
+	c.drawFinderPattern(c.size-4, 3)
+	c.drawFinderPattern(3, c.size-4)
+
+	positions := alignmentPatternPositions(c.version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignmentPattern(x, y)
+		}
+	}
+
+	// Reserve the format information; drawFormatBits overwrites it once
+	// the mask is chosen.
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+// drawFinderPattern draws a finder pattern and its separator centred at x, y.
+func (c *Code) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || c.size <= xx || c.size <= yy {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// formatBits returns the 15-bit BCH protected format information.
+func formatBits(level Level, mask int) int {
+	data := level.formatBits()<<3 | mask
+	rem := data
+	for range 10 {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(c.level, mask)
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+
+	// First copy, around the top left finder pattern.
+	for i := range 6 {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	// Second copy, split between the other two finder patterns.
+	for i := range 8 {
+		c.setFunction(c.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.size-15+i, bit(i))
+	}
+	c.setFunction(8, c.size-8, true)
+}
+
+// versionBits returns the 18-bit BCH protected version information.
+func versionBits(version int) int {
+	rem := version
+	for range 12 {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+func (c *Code) drawVersion() {
+	if c.version < 7 {
+		return
+	}
+	bits := versionBits(c.version)
+	for i := range 18 {
+		dark := (bits>>i)&1 != 0
+		a := c.size - 11 + i%3
+		b := i / 3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// dataCodewords returns the segment followed by the terminator and padding
+// filling the data capacity of the version.
+func dataCodewords(seg segment, version int, level Level) []byte {
+	capacity := numDataCodewords(version, level) * 8
+	var bb bitBuffer
+	seg.appendTo(&bb, version)
+	bb.appendBits(0, min(4, capacity-bb.len()))
+	bb.appendBits(0, (8-bb.len()%8)%8)
+	for pad := 0xEC; bb.len() < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.appendBits(pad, 8)
+	}
+	return bb.bytes()
+}
+
+// addErrorCorrection splits data into blocks, appends the error correction
+// codewords of each block and interleaves the blocks.
+func addErrorCorrection(data []byte, version int, level Level) []byte {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := rsDivisor(eccLen)
+	blocks := make([][]byte, 0, numBlocks)
+	k := 0
+	for i := range numBlocks {
+		n := shortBlockLen - eccLen
+		if numShortBlocks <= i {
+			n++
+		}
+		block := append([]byte{}, data[k:k+n]...)
+		k += n
+		ecc := rsRemainder(block, divisor)
+		if i < numShortBlocks {
+			// Placeholder so that every block has the same length.
+			block = append(block, 0)
+		}
+		blocks = append(blocks, append(block, ecc...))
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLen-eccLen || numShortBlocks <= j {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// drawCodewords places the codewords in the zigzag order of the symbol,
+// skipping function modules.
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.size - 1; 1 <= right; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.size {
+			for j := range 2 {
+				x := right - j
+				y := vert
+				if upward {
+					y = c.size - 1 - vert
+				}
+				if c.function[y*c.size+x] || len(data)*8 <= i {
+					continue
+				}
+				c.set(x, y, (data[i>>3]>>(7-(i&7)))&1 != 0)
+				i++
+			}
+		}
+	}
+}
+
+// maskBit returns whether the data mask pattern inverts the module at x, y.
+func maskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := range c.size {
+		for x := range c.size {
+			if !c.function[y*c.size+x] && maskBit(mask, x, y) {
+				c.modules[y*c.size+x] = !c.modules[y*c.size+x]
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask with the lowest penalty score.
+func (c *Code) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := range 8 {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask)
+	}
+	c.mask = best
+	c.applyMask(best)
+	c.drawFormatBits(best)
+}
+
+// penalty scores the symbol with the four rules of the specification.
+func (c *Code) penalty() int {
+	const (
+		penaltyN1 = 3
+		penaltyN2 = 3
+		penaltyN3 = 40
+		penaltyN4 = 10
+	)
+	finderLike := [2][11]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+
+	result := 0
+	for _, vertical := range []bool{false, true} {
+		at := func(i, j int) bool {
+			if vertical {
+				return c.Dark(i, j)
+			}
+			return c.Dark(j, i)
+		}
+		for i := range c.size {
+			run := 1
+			for j := 1; j < c.size; j++ {
+				if at(i, j) == at(i, j-1) {
+					run++
+					continue
+				}
+				if 5 <= run {
+					result += penaltyN1 + run - 5
+				}
+				run = 1
+			}
+			if 5 <= run {
+				result += penaltyN1 + run - 5
+			}
+			for j := 0; j+11 <= c.size; j++ {
+				for _, pattern := range finderLike {
+					matched := true
+					for k, dark := range pattern {
+						if at(i, j+k) != dark {
+							matched = false
+							break
+						}
+					}
+					if matched {
+						result += penaltyN3
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := range c.size {
+		for x := range c.size {
+			if c.Dark(x, y) {
+				dark++
+			}
+			if x+1 < c.size && y+1 < c.size {
+				d := c.Dark(x, y)
+				if d == c.Dark(x+1, y) && d == c.Dark(x, y+1) && d == c.Dark(x+1, y+1) {
+					result += penaltyN2
+				}
+			}
+		}
+	}
+	total := c.size * c.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	result += k * penaltyN4
+	return result
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestDataCodewords(t *testing.T) {
+	// The "HELLO WORLD" example of version 1-M.
+	data := dataCodewords(newSegment("HELLO WORLD"), 1, LevelM)
+	want := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	if !bytes.Equal(data, want) {
+		t.Errorf("data codewords: got=%v, want=%v", data, want)
+	}
+	ecc := rsRemainder(data, rsDivisor(eccCodewordsPerBlock[LevelM][1]))
+	wantECC := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if !bytes.Equal(ecc, wantECC) {
+		t.Errorf("error correction codewords: got=%v, want=%v", ecc, wantECC)
+	}
+}
+
+func TestTables(t *testing.T) {
+	for level := LevelL; level <= LevelH; level++ {
+		for version := MinVersion; version <= MaxVersion; version++ {
+			raw := numRawDataModules(version) / 8
+			blocks := numErrorCorrectionBlocks[level][version]
+			// Every block holds at least one data codeword and the short
+			// and long blocks differ by one codeword.
+			if numDataCodewords(version, level) < blocks {
+				t.Errorf("%d-%s: %d data codewords for %d blocks", version, level, numDataCodewords(version, level), blocks)
+			}
+			if raw/blocks-eccCodewordsPerBlock[level][version] < 1 {
+				t.Errorf("%d-%s: empty blocks", version, level)
+			}
+		}
+	}
+	if positions := alignmentPatternPositions(32); len(positions) != 6 || positions[1] != 34 || positions[5] != 138 {
+		t.Errorf("alignment pattern positions of version 32: %v", positions)
+	}
+	if bits := versionBits(7); bits != 0x07C94 {
+		t.Errorf("version bits of version 7: got=0x%05X, want=0x07C94", bits)
+	}
+	if bits := formatBits(LevelM, 5); bits != 0x40CE {
+		t.Errorf("format bits of M-5: got=0x%04X, want=0x40CE", bits)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		text    string
+		level   Level
+		version int
+	}{
+		{"MT:Y.K9042C00KA0648G00", LevelL, 1},
+		{"MT:Y.K9042C00KA0648G00", LevelM, 2},
+		{"MT:Y.K9042C00KA0648G00", LevelH, 3},
+		{"MT:Y.ET0EDB00SWDX0IA00*Y.ET08O614CCY06A810*MFAA0CIW17MA.X1IN00", LevelQ, 4},
+		{"https://example.com/" + strings.Repeat("matter", 30), LevelM, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String()+"/"+tt.text, func(t *testing.T) {
+			code, err := Encode(tt.text, tt.level)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if code.Version() != tt.version {
+				t.Errorf("Version: got=%d, want=%d", code.Version(), tt.version)
+			}
+			if code.Size() != tt.version*4+17 {
+				t.Errorf("Size: got=%d", code.Size())
+			}
+			for _, corner := range [][2]int{{0, 0}, {code.Size() - 7, 0}, {0, code.Size() - 7}} {
+				for i := range 7 {
+					if !code.Dark(corner[0]+i, corner[1]) || !code.Dark(corner[0], corner[1]+i) {
+						t.Fatalf("finder pattern at %v is broken", corner)
+					}
+				}
+			}
+
+			// The format information copy around the top left finder
+			// pattern reads back the level and mask.
+			bits := 0
+			for i, pos := range [][2]int{{8, 0}, {8, 1}, {8, 2}, {8, 3}, {8, 4}, {8, 5}, {8, 7}, {8, 8}, {7, 8}, {5, 8}, {4, 8}, {3, 8}, {2, 8}, {1, 8}, {0, 8}} {
+				if code.Dark(pos[0], pos[1]) {
+					bits |= 1 << i
+				}
+			}
+			if bits != formatBits(tt.level, code.Mask()) {
+				t.Errorf("format bits: got=0x%04X, want=0x%04X", bits, formatBits(tt.level, code.Mask()))
+			}
+
+			// Unmasking the data modules in placement order reads back the
+			// interleaved codewords.
+			seg := newSegment(tt.text)
+			want := addErrorCorrection(dataCodewords(seg, code.Version(), tt.level), code.Version(), tt.level)
+			if got := code.readCodewords(); !bytes.Equal(got, want) {
+				t.Errorf("codewords: got=%X, want=%X", got, want)
+			}
+		})
+	}
+
+	if _, err := Encode(strings.Repeat("A", 4297), LevelL); err == nil {
+		t.Error("Encode: expected ErrTooLong")
+	}
+	if code, err := Encode(strings.Repeat("A", 4296), LevelL); err != nil || code.Version() != MaxVersion {
+		t.Errorf("Encode: got %v, want version 40", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	code, err := Encode("MT:Y.K9042C00KA0648G00", LevelM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	width := code.Size() + 2*QuietZone
+
+	var buf bytes.Buffer
+	if err := code.WritePNG(&buf, 4); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != width*4 || b.Dy() != width*4 {
+		t.Errorf("PNG bounds: got=%v", b)
+	}
+	if r, _, _, _ := img.At(QuietZone*4, QuietZone*4).RGBA(); r != 0 {
+		t.Error("PNG: top left finder pattern is not dark")
+	}
+
+	buf.Reset()
+	if err := code.WriteSVG(&buf, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+		t.Errorf("SVG: %v", err)
+	}
+
+	buf.Reset()
+	if err := code.WriteTerminal(&buf, false); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != (width+1)/2 {
+		t.Errorf("terminal lines: got=%d, want=%d", len(lines), (width+1)/2)
+	}
+	if n := len([]rune(lines[0])); n != width {
+		t.Errorf("terminal columns: got=%d, want=%d", n, width)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+// GF(2^8) arithmetic modulo the QR code polynomial x^8 + x^4 + x^3 + x^2 + 1.
+var (
+	// gfExp holds the powers of the generator 2, repeated so that the sum
+	// of two logarithms can index it without reduction.
+	gfExp, gfLog = func() ([510]byte, [256]int) {
+		var exp [510]byte
+		var log [256]int
+		x := 1
+		for i := range 255 {
+			exp[i] = byte(x)
+			exp[i+255] = byte(x)
+			log[x] = i
+			x <<= 1
+			if x&0x100 != 0 {
+				x ^= 0x11D
+			}
+		}
+		return exp, log
+	}()
+)
+
+func gfMul(x, y byte) byte {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return gfExp[gfLog[x]+gfLog[y]]
+}
+
+func gfDiv(x, y byte) byte {
+	if x == 0 {
+		return 0
+	}
+	return gfExp[gfLog[x]+255-gfLog[y]]
+}
+
+// gfAlpha returns the generator raised to the power e.
+func gfAlpha(e int) byte {
+	return gfExp[(e%255+255)%255]
+}
+
+// gfEval evaluates the polynomial p, lowest power first, at x.
+func gfEval(p []byte, x byte) byte {
+	var y byte
+	for i := len(p) - 1; 0 <= i; i-- {
+		y = gfMul(y, x) ^ p[i]
+	}
+	return y
+}
+
+// rsDivisor returns the coefficients of the Reed-Solomon generator
+// polynomial of the degree, highest power first, excluding the leading 1.
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder returns the error correction codewords of data.
+func rsRemainder(data []byte, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMul(d, factor)
+		}
+	}
+	return result
+}
+
+// rsCorrect corrects the errors of a block of data and nsym error
+// correction codewords in place and returns the number of corrected
+// codewords.
+func rsCorrect(block []byte, nsym int) (int, error) {
+	n := len(block)
+	syndromes, clean := rsSyndromes(block, nsym)
+	if clean {
+		return 0, nil
+	}
+
+	// Berlekamp-Massey finds the error locator polynomial.
+	locator := []byte{1}
+	prev := []byte{1}
+	degree, shift, prevDiscrepancy := 0, 1, byte(1)
+	for k := range nsym {
+		d := syndromes[k]
+		for i := 1; i <= degree && i < len(locator); i++ {
+			d ^= gfMul(locator[i], syndromes[k-i])
+		}
+		if d == 0 {
+			shift++
+			continue
+		}
+		saved := append([]byte{}, locator...)
+		coef := gfDiv(d, prevDiscrepancy)
+		for len(locator) < len(prev)+shift {
+			locator = append(locator, 0)
+		}
+		for i, p := range prev {
+			locator[i+shift] ^= gfMul(coef, p)
+		}
+		if 2*degree <= k {
+			degree = k + 1 - degree
+			prev = saved
+			prevDiscrepancy = d
+			shift = 1
+		} else {
+			shift++
+		}
+	}
+	locator = locator[:degree+1]
+	if nsym < 2*degree {
+		return 0, ErrUncorrectable
+	}
+
+	// Chien search finds the error positions; Forney's algorithm their
+	// magnitudes.
+	evaluator := make([]byte, nsym)
+	for k := range evaluator {
+		for i := 0; i <= k && i < len(locator); i++ {
+			evaluator[k] ^= gfMul(syndromes[k-i], locator[i])
+		}
+	}
+	derivative := make([]byte, len(locator))
+	for i := 1; i < len(locator); i += 2 {
+		derivative[i-1] = locator[i]
+	}
+	corrected := 0
+	for pos := range n {
+		e := n - 1 - pos
+		xInv := gfAlpha(-e)
+		if gfEval(locator, xInv) != 0 {
+			continue
+		}
+		den := gfEval(derivative, xInv)
+		if den == 0 {
+			return 0, ErrUncorrectable
+		}
+		block[pos] ^= gfMul(gfAlpha(e), gfDiv(gfEval(evaluator, xInv), den))
+		corrected++
+	}
+	if corrected != degree {
+		return 0, ErrUncorrectable
+	}
+	if _, clean := rsSyndromes(block, nsym); !clean {
+		return 0, ErrUncorrectable
+	}
+	return corrected, nil
+}
+
+// rsSyndromes evaluates block at the nsym roots of the generator polynomial
+// and reports whether all of them are zero.
+func rsSyndromes(block []byte, nsym int) ([]byte, bool) {
+	syndromes := make([]byte, nsym)
+	clean := true
+	for i := range syndromes {
+		var s byte
+		for _, c := range block {
+			s = gfMul(s, gfAlpha(i)) ^ c
+		}
+		syndromes[i] = s
+		if s != 0 {
+			clean = false
+		}
+	}
+	return syndromes, clean
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// QuietZone is the width of the light margin around a symbol in modules.
+const QuietZone = 4
+
+// Image returns the symbol, with its quiet zone, as a black on white image
+// of scale pixels per module.
+func (c *Code) Image(scale int) *image.Paletted {
+	scale = max(scale, 1)
+	width := (c.size + 2*QuietZone) * scale
+	palette := color.Palette{color.White, color.Black}
+	img := image.NewPaletted(image.Rect(0, 0, width, width), palette)
+	for y := range width {
+		for x := range width {
+			if c.Dark(x/scale-QuietZone, y/scale-QuietZone) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG writes the symbol as a PNG image of scale pixels per module.
+func (c *Code) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, c.Image(scale))
+}
+
+// WriteSVG writes the symbol as an SVG image of scale pixels per module.
+func (c *Code) WriteSVG(w io.Writer, scale int) error {
+	scale = max(scale, 1)
+	width := c.size + 2*QuietZone
+	var path strings.Builder
+	for y := range c.size {
+		for x := range c.size {
+			if c.Dark(x, y) {
+				fmt.Fprintf(&path, "M%d,%dh1v1h-1z", x+QuietZone, y+QuietZone)
+			}
+		}
+	}
+	_, err := fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">
+<rect width="100%%" height="100%%" fill="#FFFFFF"/>
+<path d="%s" fill="#000000"/>
+</svg>
+`, width*scale, width*scale, width, width, path.String())
+	return err
+}
+
+// WriteTerminal writes the symbol with Unicode half blocks, two module rows
+// per line. Blocks are drawn for the light modules so that the code scans on
+// a dark terminal; invert draws the dark modules for light terminals.
+func (c *Code) WriteTerminal(w io.Writer, invert bool) error {
+	blocks := [4]string{" ", "▄", "▀", "█"}
+	width := c.size + 2*QuietZone
+	bw := bufio.NewWriter(w)
+	for y := 0; y < width; y += 2 {
+		for x := range width {
+			i := 0
+			if c.Dark(x-QuietZone, y-QuietZone) == invert {
+				i |= 2
+			}
+			if y+1 < width && c.Dark(x-QuietZone, y+1-QuietZone) == invert {
+				i |= 1
+			}
+			bw.WriteString(blocks[i])
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"strings"
+)
+
+// alphanumericCharset is the character set of the alphanumeric mode.
+const alphanumericCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+const (
+	modeAlphanumeric = 0x2
+	modeByte         = 0x4
+)
+
+// segment is the data of a QR code in one encoding mode.
+type segment struct {
+	mode int
+	text string
+}
+
+func newSegment(text string) segment {
+	for _, r := range text {
+		if !strings.ContainsRune(alphanumericCharset, r) {
+			return segment{mode: modeByte, text: text}
+		}
+	}
+	return segment{mode: modeAlphanumeric, text: text}
+}
+
+// countBits returns the width of the character count indicator.
+func (s segment) countBits(version int) int {
+	i := countIndex(version)
+	if s.mode == modeAlphanumeric {
+		return [...]int{9, 11, 13}[i]
+	}
+	return [...]int{8, 16, 16}[i]
+}
+
+// bitLength returns the encoded length of the segment in bits.
+func (s segment) bitLength(version int) int {
+	n := len(s.text)
+	if s.mode == modeAlphanumeric {
+		return 4 + s.countBits(version) + n/2*11 + n%2*6
+	}
+	return 4 + s.countBits(version) + n*8
+}
+
+func (s segment) appendTo(bb *bitBuffer, version int) {
+	bb.appendBits(s.mode, 4)
+	bb.appendBits(len(s.text), s.countBits(version))
+	if s.mode == modeByte {
+		for i := range len(s.text) {
+			bb.appendBits(int(s.text[i]), 8)
+		}
+		return
+	}
+	i := 0
+	for ; i+2 <= len(s.text); i += 2 {
+		v := strings.IndexByte(alphanumericCharset, s.text[i])*45 + strings.IndexByte(alphanumericCharset, s.text[i+1])
+		bb.appendBits(v, 11)
+	}
+	if i < len(s.text) {
+		bb.appendBits(strings.IndexByte(alphanumericCharset, s.text[i]), 6)
+	}
+}
+
+// bitBuffer is a sequence of bits, most significant bit first.
+type bitBuffer struct {
+	bits []bool
+}
+
+func (bb *bitBuffer) len() int {
+	return len(bb.bits)
+}
+
+func (bb *bitBuffer) appendBits(v int, n int) {
+	for i := n - 1; 0 <= i; i-- {
+		bb.bits = append(bb.bits, (v>>i)&1 != 0)
+	}
+}
+
+func (bb *bitBuffer) bytes() []byte {
+	result := make([]byte, (len(bb.bits)+7)/8)
+	for i, bit := range bb.bits {
+		if bit {
+			result[i>>3] |= 0x80 >> (i & 7)
+		}
+	}
+	return result
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package thread encodes and decodes Thread operational datasets, the MeshCoP
+// TLV blobs handed to devices by Network Commissioning
+// AddOrUpdateThreadNetwork.
+// Reference: Thread 1.3.0 Specification, Section 8.10 (MeshCoP TLVs)
+package thread
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrMalformed is returned when a dataset cannot be decoded.
+var ErrMalformed = errors.New("thread: malformed dataset")
+
+// MeshCoP TLV types used in operational datasets.
+const (
+	TypeChannel         uint8 = 0
+	TypePANID           uint8 = 1
+	TypeExtendedPANID   uint8 = 2
+	TypeNetworkName     uint8 = 3
+	TypePSKc            uint8 = 4
+	TypeNetworkKey      uint8 = 5
+	TypeMeshLocalPrefix uint8 = 7
+	TypeSecurityPolicy  uint8 = 12
+	TypeActiveTimestamp uint8 = 14
+	TypeChannelMask     uint8 = 53
+)
+
+// Field lengths.
+const (
+	ExtendedPANIDLength   = 8
+	NetworkKeyLength      = 16
+	MeshLocalPrefixLength = 8
+	PSKcLength            = 16
+	maxNetworkNameLength  = 16
+	// MaxDatasetLength is the largest dataset a device accepts.
+	MaxDatasetLength = 254
+)
+
+// Dataset is a Thread operational dataset. Nil or empty fields are absent.
+// TLVs without a typed field are kept in Extra so that a parsed dataset
+// encodes back to the same TLVs.
+type Dataset struct {
+	ActiveTimestamp *uint64
+	// ChannelPage is encoded together with Channel; page 0 is 2.4 GHz.
+	ChannelPage     uint8
+	Channel         *uint16
+	PANID           *uint16
+	ExtendedPANID   []byte
+	NetworkName     string
+	NetworkKey      []byte
+	MeshLocalPrefix []byte
+	PSKc            []byte
+	SecurityPolicy  []byte
+	ChannelMask     []byte
+	Extra           map[uint8][]byte
+}
+
+// Parse decodes a dataset from its TLV encoding.
+func Parse(b []byte) (*Dataset, error) {
+	if len(b) > MaxDatasetLength {
+		return nil, fmt.Errorf("%w: %d bytes exceeds %d", ErrMalformed, len(b), MaxDatasetLength)
+	}
+	d := &Dataset{}
+	seen := map[uint8]bool{}
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return nil, fmt.Errorf("%w: truncated TLV header", ErrMalformed)
+		}
+		typ, n := b[0], int(b[1])
+		if len(b) < 2+n {
+			return nil, fmt.Errorf("%w: TLV %d: truncated value", ErrMalformed, typ)
+		}
+		if seen[typ] {
+			return nil, fmt.Errorf("%w: TLV %d: duplicate", ErrMalformed, typ)
+		}
+		seen[typ] = true
+		if err := d.set(typ, b[2:2+n]); err != nil {
+			return nil, err
+		}
+		b = b[2+n:]
+	}
+	return d, nil
+}
+
+// ParseHex decodes a hex encoded dataset, as printed by
+// "ot-ctl dataset active -x".
+func ParseHex(s string) (*Dataset, error) {
+	b, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	return Parse(b)
+}
+
+func (d *Dataset) set(typ uint8, v []byte) error {
+	fixed := func(n int) error {
+		if len(v) != n {
+			return fmt.Errorf("%w: TLV %d: length %d, want %d", ErrMalformed, typ, len(v), n)
+		}
+		return nil
+	}
+	value := append([]byte(nil), v...)
+	switch typ {
+	case TypeChannel:
+		if err := fixed(3); err != nil {
+			return err
+		}
+		ch := binary.BigEndian.Uint16(v[1:])
+		d.ChannelPage, d.Channel = v[0], &ch
+	case TypePANID:
+		if err := fixed(2); err != nil {
+			return err
+		}
+		pan := binary.BigEndian.Uint16(v)
+		d.PANID = &pan
+	case TypeExtendedPANID:
+		if err := fixed(ExtendedPANIDLength); err != nil {
+			return err
+		}
+		d.ExtendedPANID = value
+	case TypeNetworkName:
+		if len(v) > maxNetworkNameLength {
+			return fmt.Errorf("%w: TLV %d: network name longer than %d bytes", ErrMalformed, typ, maxNetworkNameLength)
+		}
+		d.NetworkName = string(v)
+	case TypePSKc:
+		if err := fixed(PSKcLength); err != nil {
+			return err
+		}
+		d.PSKc = value
+	case TypeNetworkKey:
+		if err := fixed(NetworkKeyLength); err != nil {
+			return err
+		}
+		d.NetworkKey = value
+	case TypeMeshLocalPrefix:
+		if err := fixed(MeshLocalPrefixLength); err != nil {
+			return err
+		}
+		d.MeshLocalPrefix = value
+	case TypeSecurityPolicy:
+		d.SecurityPolicy = value
+	case TypeActiveTimestamp:
+		if err := fixed(8); err != nil {
+			return err
+		}
+		ts := binary.BigEndian.Uint64(v)
+		d.ActiveTimestamp = &ts
+	case TypeChannelMask:
+		d.ChannelMask = value
+	default:
+		if d.Extra == nil {
+			d.Extra = map[uint8][]byte{}
+		}
+		d.Extra[typ] = value
+	}
+	return nil
+}
+
+// Marshal returns the TLV encoding with TLVs in ascending type order.
+func (d *Dataset) Marshal() ([]byte, error) {
+	tlvs := map[uint8][]byte{}
+	for typ, v := range d.Extra {
+		tlvs[typ] = v
+	}
+	if d.ActiveTimestamp != nil {
+		tlvs[TypeActiveTimestamp] = binary.BigEndian.AppendUint64(nil, *d.ActiveTimestamp)
+	}
+	if d.Channel != nil {
+		tlvs[TypeChannel] = binary.BigEndian.AppendUint16([]byte{d.ChannelPage}, *d.Channel)
+	}
+	if d.PANID != nil {
+		tlvs[TypePANID] = binary.BigEndian.AppendUint16(nil, *d.PANID)
+	}
+	for typ, v := range map[uint8][]byte{
+		TypeExtendedPANID:   d.ExtendedPANID,
+		TypeNetworkName:     []byte(d.NetworkName),
+		TypePSKc:            d.PSKc,
+		TypeNetworkKey:      d.NetworkKey,
+		TypeMeshLocalPrefix: d.MeshLocalPrefix,
+		TypeSecurityPolicy:  d.SecurityPolicy,
+		TypeChannelMask:     d.ChannelMask,
+	} {
+		if len(v) > 0 {
+			tlvs[typ] = v
+		}
+	}
+
+	types := make([]int, 0, len(tlvs))
+	for typ := range tlvs {
+		types = append(types, int(typ))
+	}
+	sort.Ints(types)
+	var b []byte
+	for _, typ := range types {
+		v := tlvs[uint8(typ)]
+		if len(v) > 0xFF {
+			return nil, fmt.Errorf("thread: TLV %d: value too long", typ)
+		}
+		b = append(b, uint8(typ), uint8(len(v)))
+		b = append(b, v...)
+	}
+	if len(b) > MaxDatasetLength {
+		return nil, fmt.Errorf("thread: dataset is %d bytes, exceeds %d", len(b), MaxDatasetLength)
+	}
+	// Round trip through Parse to reject fields with invalid lengths.
+	if _, err := Parse(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Validate reports whether the dataset holds what a device needs to join the
+// network: channel, PAN ID, extended PAN ID, network key and mesh-local
+// prefix.
+func (d *Dataset) Validate() error {
+	var missing []string
+	if d.Channel == nil {
+		missing = append(missing, "channel")
+	}
+	if d.PANID == nil {
+		missing = append(missing, "PAN ID")
+	}
+	if len(d.ExtendedPANID) == 0 {
+		missing = append(missing, "extended PAN ID")
+	}
+	if len(d.NetworkKey) == 0 {
+		missing = append(missing, "network key")
+	}
+	if len(d.MeshLocalPrefix) == 0 {
+		missing = append(missing, "mesh-local prefix")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("thread: dataset is missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// String describes the network without the network key or PSKc.
+func (d *Dataset) String() string {
+	var parts []string
+	if d.NetworkName != "" {
+		parts = append(parts, fmt.Sprintf("name=%q", d.NetworkName))
+	}
+	if d.Channel != nil {
+		parts = append(parts, fmt.Sprintf("channel=%d", *d.Channel))
+	}
+	if d.PANID != nil {
+		parts = append(parts, fmt.Sprintf("panid=0x%04x", *d.PANID))
+	}
+	if len(d.ExtendedPANID) > 0 {
+		parts = append(parts, fmt.Sprintf("xpanid=%x", d.ExtendedPANID))
+	}
+	if len(d.MeshLocalPrefix) == MeshLocalPrefixLength {
+		p := d.MeshLocalPrefix
+		parts = append(parts, fmt.Sprintf("mesh-local=%x:%x:%x:%x::/64",
+			binary.BigEndian.Uint16(p[0:]), binary.BigEndian.Uint16(p[2:]), binary.BigEndian.Uint16(p[4:]), binary.BigEndian.Uint16(p[6:])))
+	}
+	return "Thread " + strings.Join(parts, " ")
+}
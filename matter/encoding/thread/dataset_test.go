@@ -0,0 +1,132 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thread
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// otDataset is in the order "ot-ctl dataset active -x" prints, with an
+// unknown TLV 0x4a appended.
+const otDataset = "0e080000000000010000" +
+	"000300000f" +
+	"35060004001fffe0" +
+	"02081111111122222222" +
+	"0708fd11111111222222" +
+	"051000112233445566778899aabbccddeeff" +
+	"030a4f70656e546872656164" +
+	"01021234" +
+	"0410c0ffeec0ffeec0ffeec0ffeec0ffee00" +
+	"0c0402a0f7f8" +
+	"4a02abcd"
+
+func TestParseHex(t *testing.T) {
+	d, err := ParseHex(otDataset)
+	if err != nil {
+		t.Fatalf("ParseHex: %v", err)
+	}
+	if d.Channel == nil || *d.Channel != 15 || d.ChannelPage != 0 {
+		t.Errorf("Channel = %v", d.Channel)
+	}
+	if d.PANID == nil || *d.PANID != 0x1234 {
+		t.Errorf("PANID = %v", d.PANID)
+	}
+	if d.ActiveTimestamp == nil || *d.ActiveTimestamp != 0x10000 {
+		t.Errorf("ActiveTimestamp = %v", d.ActiveTimestamp)
+	}
+	if hex.EncodeToString(d.ExtendedPANID) != "1111111122222222" ||
+		hex.EncodeToString(d.NetworkKey) != "00112233445566778899aabbccddeeff" ||
+		hex.EncodeToString(d.MeshLocalPrefix) != "fd11111111222222" ||
+		len(d.PSKc) != PSKcLength || d.NetworkName != "OpenThread" {
+		t.Errorf("dataset = %+v", *d)
+	}
+	if !bytes.Equal(d.Extra[0x4a], []byte{0xab, 0xcd}) {
+		t.Errorf("Extra = %v", d.Extra)
+	}
+	if err := d.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+
+	s := d.String()
+	if strings.Contains(s, "00112233") || !strings.Contains(s, "fd11:1111:1122:2222::/64") {
+		t.Errorf("String() = %s", s)
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	d, err := ParseHex(otDataset)
+	if err != nil {
+		t.Fatalf("ParseHex: %v", err)
+	}
+	b, err := d.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if len(b) != len(otDataset)/2 || b[0] != TypeChannel {
+		t.Errorf("Marshal = %x", b)
+	}
+	again, err := Parse(b)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	b2, _ := again.Marshal()
+	if !bytes.Equal(b, b2) {
+		t.Errorf("round trip changed dataset:\n%x\n%x", b, b2)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	ch, pan := uint16(25), uint16(0xabcd)
+	d := &Dataset{
+		Channel:         &ch,
+		PANID:           &pan,
+		ExtendedPANID:   make([]byte, ExtendedPANIDLength),
+		NetworkKey:      make([]byte, NetworkKeyLength),
+		MeshLocalPrefix: []byte{0xfd, 0, 0, 0, 0, 0, 0, 0},
+	}
+	b, err := d.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got := hex.EncodeToString(b[:9]); got != "0003000019"+"0102abcd" {
+		t.Errorf("Marshal = %x", b)
+	}
+
+	d.NetworkKey = []byte{1, 2, 3}
+	if _, err := d.Marshal(); !errors.Is(err, ErrMalformed) {
+		t.Errorf("short network key: %v", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		"zz",
+		"00",
+		"0003000f",
+		"01021234" + "01021234",
+		"0504aabbccdd",
+	} {
+		if _, err := ParseHex(in); !errors.Is(err, ErrMalformed) {
+			t.Errorf("ParseHex(%q) = %v", in, err)
+		}
+	}
+	if err := (&Dataset{}).Validate(); err == nil || !strings.Contains(err.Error(), "network key") {
+		t.Errorf("Validate(empty) = %v", err)
+	}
+}
@@ -26,6 +26,10 @@ type Decoder interface {
 	// Element returns the most recently decoded element. It is valid
 	// only if the preceding Next() returned true.
 	Element() Element
+	// Depth returns the container nesting depth of the most recently
+	// decoded element. Top-level elements have depth 0; a container start
+	// reports the depth it was opened at, and its members report one more.
+	Depth() int
 	// Err returns the first error encountered (if any).
 	Err() error
 }
@@ -37,6 +41,7 @@ type decoderImpl struct {
 	err  error
 
 	next       Element
+	depth      int
 	containerS []ElementType
 }
 
@@ -49,6 +54,7 @@ func NewDecoder(b []byte) Decoder {
 		pos:        0,
 		err:        nil,
 		next:       nil,
+		depth:      0,
 		containerS: []ElementType{},
 	}
 }
@@ -72,6 +78,7 @@ func (d *decoderImpl) Next() bool {
 		d.err = err
 		return false
 	}
+	depth := len(d.containerS)
 	if containerElement(el.Type()) {
 		switch el.Type() {
 		case ETStructure, ETArray, ETList:
@@ -87,12 +94,16 @@ func (d *decoderImpl) Next() bool {
 		}
 	}
 	d.next = el
+	d.depth = depth
 	return true
 }
 
 // Element implements Decoder.Element.
 func (d *decoderImpl) Element() Element { return d.next }
 
+// Depth implements Decoder.Depth.
+func (d *decoderImpl) Depth() int { return d.depth }
+
 // read reads n bytes from the buffer advancing the position.
 func (d *decoderImpl) read(n int) ([]byte, error) {
 	if d.pos+n > len(d.data) {
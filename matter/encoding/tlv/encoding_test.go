@@ -132,3 +132,34 @@ func TestBooleanVariants(t *testing.T) {
 		t.Fatalf("unexpected bool sequence: %#v", vals)
 	}
 }
+
+func TestDecoderDepth(t *testing.T) {
+	enc := NewEncoder()
+	enc.StartStructure(AnonymousTag())
+	_ = enc.PutUnsigned(ContextTag(0), 1)
+	enc.StartList(ContextTag(1))
+	enc.StartStructure(AnonymousTag())
+	_ = enc.EndContainer()
+	_ = enc.PutUnsigned(ContextTag(2), 2)
+	_ = enc.EndContainer()
+	_ = enc.PutUnsigned(ContextTag(3), 3)
+	_ = enc.EndContainer()
+
+	dec := NewDecoder(enc.Bytes())
+	var depths []int
+	for dec.Next() {
+		depths = append(depths, dec.Depth())
+	}
+	if dec.Err() != nil {
+		t.Fatalf("decode err: %v", dec.Err())
+	}
+	want := []int{0, 1, 1, 2, 2, 1}
+	if len(depths) != len(want) {
+		t.Fatalf("depths = %v, want %v", depths, want)
+	}
+	for i := range want {
+		if depths[i] != want[i] {
+			t.Fatalf("depths = %v, want %v", depths, want)
+		}
+	}
+}
@@ -38,4 +38,18 @@ var (
 	ErrTagUnsupportedForm = errors.New("tlv: unsupported tag control form")
 	// ErrDecodeTagLength indicates insufficient bytes while decoding a tag field.
 	ErrDecodeTagLength = errors.New("tlv: insufficient bytes for tag")
+	// ErrTrailingData indicates more than one top-level element where a single value was expected.
+	ErrTrailingData = errors.New("tlv: trailing data after top-level element")
+	// ErrUnsupportedType indicates a Go type that Marshal/Unmarshal cannot handle.
+	ErrUnsupportedType = errors.New("tlv: unsupported Go type")
+	// ErrInvalidStructTag indicates a malformed `tlv` struct tag.
+	ErrInvalidStructTag = errors.New("tlv: invalid struct tag")
+	// ErrTypeMismatch indicates an element type that does not fit the destination.
+	ErrTypeMismatch = errors.New("tlv: element type does not match destination")
+	// ErrValueOverflow indicates an integer that does not fit the destination.
+	ErrValueOverflow = errors.New("tlv: value overflows destination")
+	// ErrNotContainer indicates EnterContainer was called on a non-container element.
+	ErrNotContainer = errors.New("tlv: element is not a container")
+	// ErrJSONMapping indicates TLV that has no TLV-JSON form, or malformed TLV-JSON.
+	ErrJSONMapping = errors.New("tlv: invalid TLV-JSON mapping")
 )
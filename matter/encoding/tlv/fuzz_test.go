@@ -15,6 +15,7 @@
 package tlv
 
 import (
+	"bytes"
 	"testing"
 )
 
@@ -34,3 +35,41 @@ func FuzzDecodeEncode(f *testing.F) {
 		}
 	})
 }
+
+func FuzzMarshalRoundTrip(f *testing.F) {
+	cluster := uint32(0x0006)
+	seed, err := Marshal(marshalEntry{
+		Privilege:   3,
+		Subjects:    []uint64{1, 2},
+		Targets:     []marshalTarget{{Cluster: &cluster, Endpoint: 1}},
+		Label:       "kitchen",
+		Key:         []byte{1, 2, 3},
+		FabricIndex: 1,
+	})
+	if err != nil {
+		f.Fatalf("Marshal: %v", err)
+	}
+	f.Add(seed)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var first marshalEntry
+		if err := Unmarshal(data, &first); err != nil {
+			return
+		}
+		b1, err := Marshal(&first)
+		if err != nil {
+			t.Fatalf("Marshal after Unmarshal: %v", err)
+		}
+		var second marshalEntry
+		if err := Unmarshal(b1, &second); err != nil {
+			t.Fatalf("Unmarshal of re-encoded value: %v", err)
+		}
+		b2, err := Marshal(&second)
+		if err != nil {
+			t.Fatalf("second Marshal: %v", err)
+		}
+		if !bytes.Equal(b1, b2) {
+			t.Fatalf("unstable round trip:\n%x\n%x", b1, b2)
+		}
+	})
+}
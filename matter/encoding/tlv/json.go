@@ -0,0 +1,405 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TLV-JSON type names.
+// Reference: Matter Core Spec 1.5, Appendix (Matter JSON representation of TLV)
+// Each structure member is keyed "<context tag>:<TYPE>", optionally prefixed
+// with a field name ("name:<tag>:<TYPE>"). Arrays carry their element type
+// ("ARRAY-UINT", "ARRAY-STRUCT", "ARRAY-?" when empty). Lists, which the
+// convention does not cover, use the same form with a "LIST-" prefix.
+const (
+	JSONTypeInt    = "INT"
+	JSONTypeUint   = "UINT"
+	JSONTypeBool   = "BOOL"
+	JSONTypeFloat  = "FLOAT"
+	JSONTypeDouble = "DOUBLE"
+	JSONTypeString = "STRING"
+	JSONTypeBytes  = "BYTES"
+	JSONTypeNull   = "NULL"
+	JSONTypeStruct = "STRUCT"
+	JSONTypeArray  = "ARRAY"
+	JSONTypeList   = "LIST"
+	jsonTypeEmpty  = "?"
+)
+
+// jsonTypeAliases maps accepted short names to canonical type names.
+var jsonTypeAliases = map[string]string{
+	"I": JSONTypeInt,
+	"U": JSONTypeUint,
+	"B": JSONTypeBool,
+	"F": JSONTypeFloat,
+	"D": JSONTypeDouble,
+	"S": JSONTypeString,
+}
+
+// jsonMaxSafeInteger is the largest integer JSON consumers can represent
+// exactly; larger 64-bit values are written as strings.
+const jsonMaxSafeInteger = 1<<53 - 1
+
+// ToJSON converts a TLV encoded anonymous structure into its TLV-JSON form.
+func ToJSON(data []byte) ([]byte, error) {
+	root, err := parseValueTree(data)
+	if err != nil {
+		return nil, err
+	}
+	if root.el.Type() != ETStructure || root.el.Tag().Control() != TagCtlAnonymous {
+		return nil, fmt.Errorf("%w: top-level element must be an anonymous structure", ErrJSONMapping)
+	}
+	var buf bytes.Buffer
+	if err := writeJSONValue(&buf, root); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonType returns the TLV-JSON type name of a decoded element.
+func jsonType(n *valueNode) (string, error) {
+	switch et := n.el.Type(); et {
+	case ETSignedInt1, ETSignedInt2, ETSignedInt4, ETSignedInt8:
+		return JSONTypeInt, nil
+	case ETUnsignedInt1, ETUnsignedInt2, ETUnsignedInt4, ETUnsignedInt8:
+		return JSONTypeUint, nil
+	case ETBoolFalse, ETBoolTrue:
+		return JSONTypeBool, nil
+	case ETFloat32:
+		return JSONTypeFloat, nil
+	case ETFloat64:
+		return JSONTypeDouble, nil
+	case ETUtf8String1, ETUtf8String2, ETUtf8String4, ETUtf8String8:
+		return JSONTypeString, nil
+	case ETByteString1, ETByteString2, ETByteString4, ETByteString8:
+		return JSONTypeBytes, nil
+	case ETNull:
+		return JSONTypeNull, nil
+	case ETStructure:
+		return JSONTypeStruct, nil
+	case ETArray, ETList:
+		prefix := JSONTypeArray
+		if et == ETList {
+			prefix = JSONTypeList
+		}
+		if len(n.children) == 0 {
+			return prefix + "-" + jsonTypeEmpty, nil
+		}
+		elem, err := jsonType(n.children[0])
+		if err != nil {
+			return "", err
+		}
+		for _, c := range n.children[1:] {
+			t, err := jsonType(c)
+			if err != nil {
+				return "", err
+			}
+			if t != elem {
+				return "", fmt.Errorf("%w: array mixes %s and %s elements", ErrJSONMapping, elem, t)
+			}
+		}
+		return prefix + "-" + elem, nil
+	default:
+		return "", fmt.Errorf("%w: element type 0x%02X", ErrJSONMapping, uint8(et))
+	}
+}
+
+func writeJSONValue(buf *bytes.Buffer, n *valueNode) error {
+	el := n.el
+	switch el.Type() {
+	case ETStructure:
+		buf.WriteByte('{')
+		for i, c := range n.children {
+			t := c.el.Tag()
+			if t.Control() != TagCtlContext {
+				return fmt.Errorf("%w: structure member with %s tag", ErrJSONMapping, t)
+			}
+			typ, err := jsonType(c)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(fmt.Sprintf("%d:%s", t.SerializeTag()[0], typ))
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSONValue(buf, c); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case ETArray, ETList:
+		buf.WriteByte('[')
+		for i, c := range n.children {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONValue(buf, c); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case ETNull:
+		buf.WriteString("null")
+		return nil
+	}
+	if v, ok := el.Signed(); ok {
+		if v > jsonMaxSafeInteger || v < -jsonMaxSafeInteger {
+			buf.WriteString(strconv.Quote(strconv.FormatInt(v, 10)))
+		} else {
+			buf.WriteString(strconv.FormatInt(v, 10))
+		}
+		return nil
+	}
+	if v, ok := el.Unsigned(); ok {
+		if v > jsonMaxSafeInteger {
+			buf.WriteString(strconv.Quote(strconv.FormatUint(v, 10)))
+		} else {
+			buf.WriteString(strconv.FormatUint(v, 10))
+		}
+		return nil
+	}
+	if v, ok := el.Bool(); ok {
+		buf.WriteString(strconv.FormatBool(v))
+		return nil
+	}
+	if v, ok := el.Float(); ok {
+		switch {
+		case math.IsInf(v, 1):
+			buf.WriteString(`"Infinity"`)
+		case math.IsInf(v, -1):
+			buf.WriteString(`"-Infinity"`)
+		case math.IsNaN(v):
+			buf.WriteString(`"NaN"`)
+		default:
+			bits := 64
+			if el.Type() == ETFloat32 {
+				bits = 32
+			}
+			buf.WriteString(strconv.FormatFloat(v, 'g', -1, bits))
+		}
+		return nil
+	}
+	if v, ok := el.UTF8(); ok {
+		s, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(s)
+		return nil
+	}
+	if v, ok := el.Bytes(); ok {
+		buf.WriteString(strconv.Quote(base64.StdEncoding.EncodeToString(v)))
+		return nil
+	}
+	return fmt.Errorf("%w: element type 0x%02X", ErrJSONMapping, uint8(el.Type()))
+}
+
+// FromJSON converts a TLV-JSON object into a TLV encoded anonymous structure.
+// Structure members are written in ascending tag order.
+func FromJSON(data []byte) ([]byte, error) {
+	enc := NewEncoder()
+	if err := encodeJSONValue(enc, AnonymousTag(), JSONTypeStruct, json.RawMessage(data)); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// jsonMember is a parsed structure member key.
+type jsonMember struct {
+	tag   uint8
+	typ   string
+	key   string
+	value json.RawMessage
+}
+
+// parseJSONKey splits "[name:]tag:TYPE" into the tag number and type.
+func parseJSONKey(key string) (uint8, string, error) {
+	parts := strings.Split(key, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, "", fmt.Errorf("%w: key %q is not \"tag:TYPE\"", ErrJSONMapping, key)
+	}
+	tagStr, typ := parts[len(parts)-2], parts[len(parts)-1]
+	tag, err := strconv.ParseUint(tagStr, 10, 8)
+	if err != nil {
+		return 0, "", fmt.Errorf("%w: key %q has invalid tag", ErrJSONMapping, key)
+	}
+	return uint8(tag), canonicalJSONType(typ), nil
+}
+
+// canonicalJSONType upper-cases typ and resolves short aliases, including
+// the element type of ARRAY-/LIST- forms.
+func canonicalJSONType(typ string) string {
+	typ = strings.ToUpper(strings.TrimSpace(typ))
+	for _, prefix := range []string{JSONTypeArray + "-", JSONTypeList + "-"} {
+		if rest, ok := strings.CutPrefix(typ, prefix); ok {
+			return prefix + canonicalJSONType(rest)
+		}
+	}
+	if alias, ok := jsonTypeAliases[typ]; ok {
+		return alias
+	}
+	return typ
+}
+
+func encodeJSONValue(enc Encoder, tag Tag, typ string, raw json.RawMessage) error {
+	raw = bytes.TrimSpace(raw)
+	if bytes.Equal(raw, []byte("null")) {
+		enc.PutNull(tag)
+		return nil
+	}
+	for _, prefix := range []string{JSONTypeArray, JSONTypeList} {
+		rest, ok := strings.CutPrefix(typ, prefix+"-")
+		if !ok {
+			continue
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return fmt.Errorf("%w: %s value: %w", ErrJSONMapping, typ, err)
+		}
+		if rest == jsonTypeEmpty && len(items) != 0 {
+			return fmt.Errorf("%w: %s must be empty", ErrJSONMapping, typ)
+		}
+		if prefix == JSONTypeList {
+			enc.StartList(tag)
+		} else {
+			enc.StartArray(tag)
+		}
+		for i, item := range items {
+			if err := encodeJSONValue(enc, AnonymousTag(), rest, item); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return enc.EndContainer()
+	}
+
+	switch typ {
+	case JSONTypeStruct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil || obj == nil {
+			return fmt.Errorf("%w: STRUCT value must be an object", ErrJSONMapping)
+		}
+		members := make([]jsonMember, 0, len(obj))
+		seen := make(map[uint8]string, len(obj))
+		for key, value := range obj {
+			memberTag, memberType, err := parseJSONKey(key)
+			if err != nil {
+				return err
+			}
+			if prev, dup := seen[memberTag]; dup {
+				return fmt.Errorf("%w: keys %q and %q share tag %d", ErrJSONMapping, prev, key, memberTag)
+			}
+			seen[memberTag] = key
+			members = append(members, jsonMember{tag: memberTag, typ: memberType, key: key, value: value})
+		}
+		sort.Slice(members, func(i, j int) bool { return members[i].tag < members[j].tag })
+		enc.StartStructure(tag)
+		for _, m := range members {
+			if err := encodeJSONValue(enc, ContextTag(m.tag), m.typ, m.value); err != nil {
+				return fmt.Errorf("%s: %w", m.key, err)
+			}
+		}
+		return enc.EndContainer()
+	case JSONTypeNull:
+		return fmt.Errorf("%w: NULL value must be null", ErrJSONMapping)
+	case JSONTypeBool:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return fmt.Errorf("%w: BOOL value: %w", ErrJSONMapping, err)
+		}
+		enc.PutBool(tag, b)
+		return nil
+	case JSONTypeString:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return fmt.Errorf("%w: STRING value: %w", ErrJSONMapping, err)
+		}
+		return enc.PutUTF8(tag, s)
+	case JSONTypeBytes:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return fmt.Errorf("%w: BYTES value: %w", ErrJSONMapping, err)
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("%w: BYTES value is not base64: %w", ErrJSONMapping, err)
+		}
+		return enc.PutBytes(tag, b)
+	case JSONTypeUint:
+		s := jsonNumberString(raw)
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: UINT value %s", ErrJSONMapping, raw)
+		}
+		return enc.PutUnsigned(tag, v)
+	case JSONTypeInt:
+		s := jsonNumberString(raw)
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: INT value %s", ErrJSONMapping, raw)
+		}
+		return enc.PutSigned(tag, v)
+	case JSONTypeFloat, JSONTypeDouble:
+		s := jsonNumberString(raw)
+		var f float64
+		switch s {
+		case "Infinity":
+			f = math.Inf(1)
+		case "-Infinity":
+			f = math.Inf(-1)
+		case "NaN":
+			f = math.NaN()
+		default:
+			bits := 64
+			if typ == JSONTypeFloat {
+				bits = 32
+			}
+			v, err := strconv.ParseFloat(s, bits)
+			if err != nil {
+				return fmt.Errorf("%w: %s value %s", ErrJSONMapping, typ, raw)
+			}
+			f = v
+		}
+		if typ == JSONTypeFloat {
+			enc.PutFloat32(tag, float32(f))
+		} else {
+			enc.PutFloat64(tag, f)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrJSONMapping, typ)
+	}
+}
+
+// jsonNumberString returns the text of a JSON number, or the contents of a
+// JSON string (used for large integers and non-finite floats).
+func jsonNumberString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	enc := NewEncoder()
+	enc.StartStructure(AnonymousTag())
+	_ = enc.PutUnsigned(ContextTag(0), 5)
+	_ = enc.PutSigned(ContextTag(1), -7)
+	enc.PutBool(ContextTag(2), true)
+	_ = enc.PutUTF8(ContextTag(3), "front \"door\"")
+	_ = enc.PutBytes(ContextTag(4), []byte{0x01, 0x02, 0xFF})
+	enc.PutNull(ContextTag(5))
+	enc.PutFloat32(ContextTag(6), 1.5)
+	enc.PutFloat64(ContextTag(7), -0.25)
+	enc.StartStructure(ContextTag(8))
+	_ = enc.PutUnsigned(ContextTag(1), 1<<60)
+	_ = enc.EndContainer()
+	enc.StartArray(ContextTag(9))
+	_ = enc.PutUnsigned(AnonymousTag(), 1)
+	_ = enc.PutUnsigned(AnonymousTag(), 2)
+	_ = enc.EndContainer()
+	enc.StartArray(ContextTag(10))
+	_ = enc.EndContainer()
+	enc.StartList(ContextTag(11))
+	enc.StartStructure(AnonymousTag())
+	_ = enc.EndContainer()
+	_ = enc.EndContainer()
+	_ = enc.EndContainer()
+
+	js, err := ToJSON(enc.Bytes())
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	want := `{"0:UINT":5,"1:INT":-7,"2:BOOL":true,"3:STRING":"front \"door\"","4:BYTES":"AQL/","5:NULL":null,` +
+		`"6:FLOAT":1.5,"7:DOUBLE":-0.25,"8:STRUCT":{"1:UINT":"1152921504606846976"},"9:ARRAY-UINT":[1,2],` +
+		`"10:ARRAY-?":[],"11:LIST-STRUCT":[{}]}`
+	if string(js) != want {
+		t.Fatalf("ToJSON =\n%s\nwant\n%s", js, want)
+	}
+
+	back, err := FromJSON(js)
+	if err != nil {
+		t.Fatalf("FromJSON: %v", err)
+	}
+	if !bytes.Equal(back, enc.Bytes()) {
+		t.Fatalf("FromJSON = %x, want %x", back, enc.Bytes())
+	}
+}
+
+func TestFromJSONAliasesAndOrder(t *testing.T) {
+	got, err := FromJSON([]byte(`{"2:STRUCT": {"name:0:U": 3}, "1:U": 5, "3:ARRAY-I": [-1]}`))
+	if err != nil {
+		t.Fatalf("FromJSON: %v", err)
+	}
+	enc := NewEncoder()
+	enc.StartStructure(AnonymousTag())
+	_ = enc.PutUnsigned(ContextTag(1), 5)
+	enc.StartStructure(ContextTag(2))
+	_ = enc.PutUnsigned(ContextTag(0), 3)
+	_ = enc.EndContainer()
+	enc.StartArray(ContextTag(3))
+	_ = enc.PutSigned(AnonymousTag(), -1)
+	_ = enc.EndContainer()
+	_ = enc.EndContainer()
+	if !bytes.Equal(got, enc.Bytes()) {
+		t.Fatalf("FromJSON = %x, want %x", got, enc.Bytes())
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	for _, in := range []string{
+		`[]`,
+		`{"x:UINT": 1}`,
+		`{"1:UINT": -1}`,
+		`{"1:BYTES": "***"}`,
+		`{"1:ARRAY-?": [1]}`,
+		`{"1:WHAT": 1}`,
+		`{"1:UINT": 1, "a:1:INT": 2}`,
+	} {
+		if _, err := FromJSON([]byte(in)); !errors.Is(err, ErrJSONMapping) {
+			t.Errorf("FromJSON(%s) err = %v", in, err)
+		}
+	}
+
+	enc := NewEncoder()
+	_ = enc.PutUnsigned(AnonymousTag(), 1)
+	if _, err := ToJSON(enc.Bytes()); !errors.Is(err, ErrJSONMapping) {
+		t.Errorf("ToJSON scalar err = %v", err)
+	}
+}
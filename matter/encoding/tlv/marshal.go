@@ -0,0 +1,192 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FabricIndexTag is the context tag reserved for the FabricIndex field of
+// fabric-scoped structures.
+// Reference: Matter Core Spec 1.5, Section 7.13.6 (Fabric-Scoped Struct)
+const FabricIndexTag = 0xFE
+
+// Marshal returns the TLV encoding of v.
+//
+// Struct fields are encoded as context-tagged members of a structure, using
+// the number given in the field's `tlv` struct tag:
+//
+//	type Target struct {
+//		Cluster     *uint32 `tlv:"0,nullable"`
+//		Endpoint    uint16  `tlv:"1"`
+//		Label       string  `tlv:"2,omitempty"`
+//		Targets     []Entry `tlv:"3,list"`
+//		FabricIndex uint8   `tlv:"254"`
+//	}
+//
+// Options:
+//   - omitempty: skip the field if it holds its zero value (nil pointer,
+//     empty slice or string, 0, false).
+//   - list: encode a slice as a List instead of an Array.
+//   - nullable: encode a nil pointer as Null (the default for pointers
+//     without omitempty; the option documents intent).
+//
+// Fields without a `tlv` tag, or tagged "-", are ignored. []byte is encoded
+// as an octet string, string as a UTF-8 string, other slices and arrays as
+// Arrays of anonymous elements. The top-level value uses an anonymous tag.
+func Marshal(v any) ([]byte, error) {
+	enc := NewEncoder()
+	if err := marshalValue(enc, AnonymousTag(), reflect.ValueOf(v), fieldOptions{}); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// fieldOptions are the parsed options of a `tlv` struct tag.
+type fieldOptions struct {
+	omitEmpty bool
+	list      bool
+	nullable  bool
+}
+
+// structField describes a tagged struct field.
+type structField struct {
+	index int
+	name  string
+	tag   uint8
+	opts  fieldOptions
+}
+
+var structFieldsCache sync.Map // map[reflect.Type][]structField
+
+// cachedStructFields returns the tagged fields of t in declaration order.
+func cachedStructFields(t reflect.Type) ([]structField, error) {
+	if f, ok := structFieldsCache.Load(t); ok {
+		return f.([]structField), nil
+	}
+	var fields []structField
+	seen := make(map[uint8]string)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		raw, ok := sf.Tag.Lookup("tlv")
+		if !ok || raw == "-" {
+			continue
+		}
+		if !sf.IsExported() {
+			return nil, fmt.Errorf("%w: %s.%s is unexported", ErrInvalidStructTag, t, sf.Name)
+		}
+		parts := strings.Split(raw, ",")
+		num, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s.%s: %q", ErrInvalidStructTag, t, sf.Name, raw)
+		}
+		field := structField{index: i, name: sf.Name, tag: uint8(num)}
+		for _, opt := range parts[1:] {
+			switch strings.TrimSpace(opt) {
+			case "omitempty":
+				field.opts.omitEmpty = true
+			case "list":
+				field.opts.list = true
+			case "nullable":
+				field.opts.nullable = true
+			case "":
+			default:
+				return nil, fmt.Errorf("%w: %s.%s: unknown option %q", ErrInvalidStructTag, t, sf.Name, opt)
+			}
+		}
+		if prev, dup := seen[field.tag]; dup {
+			return nil, fmt.Errorf("%w: %s.%s reuses tag %d of %s", ErrInvalidStructTag, t, sf.Name, field.tag, prev)
+		}
+		seen[field.tag] = sf.Name
+		fields = append(fields, field)
+	}
+	structFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+func marshalValue(enc Encoder, tag Tag, v reflect.Value, opts fieldOptions) error {
+	if !v.IsValid() {
+		enc.PutNull(tag)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			enc.PutNull(tag)
+			return nil
+		}
+		return marshalValue(enc, tag, v.Elem(), opts)
+	case reflect.Bool:
+		enc.PutBool(tag, v.Bool())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return enc.PutSigned(tag, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return enc.PutUnsigned(tag, v.Uint())
+	case reflect.Float32:
+		enc.PutFloat32(tag, float32(v.Float()))
+		return nil
+	case reflect.Float64:
+		enc.PutFloat64(tag, v.Float())
+		return nil
+	case reflect.String:
+		return enc.PutUTF8(tag, v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.Kind() == reflect.Slice {
+				return enc.PutBytes(tag, v.Bytes())
+			}
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return enc.PutBytes(tag, b)
+		}
+		if opts.list {
+			enc.StartList(tag)
+		} else {
+			enc.StartArray(tag)
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := marshalValue(enc, AnonymousTag(), v.Index(i), fieldOptions{}); err != nil {
+				return err
+			}
+		}
+		return enc.EndContainer()
+	case reflect.Struct:
+		fields, err := cachedStructFields(v.Type())
+		if err != nil {
+			return err
+		}
+		enc.StartStructure(tag)
+		for _, f := range fields {
+			fv := v.Field(f.index)
+			if f.opts.omitEmpty && fv.IsZero() {
+				continue
+			}
+			if f.opts.omitEmpty && (fv.Kind() == reflect.Slice || fv.Kind() == reflect.String) && fv.Len() == 0 {
+				continue
+			}
+			if err := marshalValue(enc, ContextTag(f.tag), fv, f.opts); err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+		}
+		return enc.EndContainer()
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+	}
+}
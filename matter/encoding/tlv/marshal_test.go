@@ -0,0 +1,151 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type marshalTarget struct {
+	Cluster    *uint32 `tlv:"0,nullable"`
+	Endpoint   uint16  `tlv:"1"`
+	DeviceType *uint32 `tlv:"2,omitempty"`
+}
+
+type marshalEntry struct {
+	Privilege   uint8           `tlv:"1"`
+	AuthMode    uint8           `tlv:"2"`
+	Subjects    []uint64        `tlv:"3,nullable"`
+	Targets     []marshalTarget `tlv:"4,list"`
+	Label       string          `tlv:"5,omitempty"`
+	Key         []byte          `tlv:"6"`
+	Offset      int16           `tlv:"7"`
+	Ratio       float32         `tlv:"8"`
+	Enabled     bool            `tlv:"9"`
+	Digest      [4]byte         `tlv:"10"`
+	Ignored     string
+	FabricIndex uint8 `tlv:"254"`
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	cluster := uint32(0x0101)
+	in := marshalEntry{
+		Privilege: 5,
+		AuthMode:  2,
+		Subjects:  []uint64{0x1122334455667788},
+		Targets: []marshalTarget{
+			{Cluster: &cluster, Endpoint: 1},
+			{Cluster: nil, Endpoint: 2},
+		},
+		Key:         []byte{0xDE, 0xAD},
+		Offset:      -300,
+		Ratio:       0.5,
+		Enabled:     true,
+		Digest:      [4]byte{1, 2, 3, 4},
+		Ignored:     "not encoded",
+		FabricIndex: 3,
+	}
+	b, err := Marshal(&in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out marshalEntry
+	if err := Unmarshal(b, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	in.Ignored = ""
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("round trip mismatch:\n in=%+v\nout=%+v", in, out)
+	}
+
+	// The fabric index member uses context tag 0xFE and the omitted label is absent.
+	dec := NewDecoder(b)
+	var tags []uint8
+	for dec.Next() {
+		if dec.Depth() == 1 {
+			tags = append(tags, dec.Element().Tag().SerializeTag()[0])
+		}
+	}
+	want := []uint8{1, 2, 3, 4, 6, 7, 8, 9, 10, FabricIndexTag}
+	if !bytes.Equal(tags, want) {
+		t.Fatalf("member tags = %v, want %v", tags, want)
+	}
+}
+
+func TestMarshalNullable(t *testing.T) {
+	b, err := Marshal(marshalTarget{Endpoint: 7})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	enc := NewEncoder()
+	enc.StartStructure(AnonymousTag())
+	enc.PutNull(ContextTag(0))
+	_ = enc.PutUnsigned(ContextTag(1), 7)
+	_ = enc.EndContainer()
+	if !bytes.Equal(b, enc.Bytes()) {
+		t.Fatalf("Marshal = %x, want %x", b, enc.Bytes())
+	}
+
+	cluster := uint32(9)
+	out := marshalTarget{Cluster: &cluster}
+	if err := Unmarshal(b, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out.Cluster != nil || out.Endpoint != 7 {
+		t.Fatalf("out = %+v", out)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	enc := NewEncoder()
+	enc.StartStructure(AnonymousTag())
+	_ = enc.PutUnsigned(ContextTag(1), 300)
+	_ = enc.EndContainer()
+	var entry marshalEntry
+	if err := Unmarshal(enc.Bytes(), &entry); !errors.Is(err, ErrValueOverflow) {
+		t.Fatalf("overflow err = %v", err)
+	}
+
+	enc = NewEncoder()
+	enc.StartStructure(AnonymousTag())
+	_ = enc.PutUTF8(ContextTag(2), "two")
+	_ = enc.EndContainer()
+	if err := Unmarshal(enc.Bytes(), &entry); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("mismatch err = %v", err)
+	}
+
+	enc = NewEncoder()
+	enc.StartStructure(AnonymousTag())
+	enc.PutNull(ContextTag(1))
+	_ = enc.EndContainer()
+	if err := Unmarshal(enc.Bytes(), &entry); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("null err = %v", err)
+	}
+
+	if err := Unmarshal(enc.Bytes(), entry); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("non-pointer err = %v", err)
+	}
+
+	type badTag struct {
+		A uint8 `tlv:"x"`
+	}
+	if _, err := Marshal(badTag{}); !errors.Is(err, ErrInvalidStructTag) {
+		t.Fatalf("bad tag err = %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PrettyPrint writes a human-readable, indented rendering of a TLV element
+// stream to w:
+//
+//	{
+//	  1 = 42 (uint)
+//	  2 = [
+//	    "kitchen" (string)
+//	  ]
+//	}
+//
+// Structures use braces, arrays brackets and lists double brackets. Context
+// tags are shown as numbers, anonymous tags are omitted.
+func PrettyPrint(w io.Writer, data []byte) error {
+	r := NewReader(data)
+	if err := prettyLevel(w, r, 0); err != nil {
+		return err
+	}
+	return r.Err()
+}
+
+// PrettyString returns the PrettyPrint rendering of data.
+func PrettyString(data []byte) (string, error) {
+	var sb strings.Builder
+	err := PrettyPrint(&sb, data)
+	return sb.String(), err
+}
+
+func prettyLevel(w io.Writer, r *Reader, indent int) error {
+	pad := strings.Repeat("  ", indent)
+	for r.Next() {
+		el := r.Element()
+		prefix := pad
+		switch el.Tag().Control() {
+		case TagCtlAnonymous:
+		case TagCtlContext:
+			prefix += fmt.Sprintf("%d = ", el.Tag().SerializeTag()[0])
+		default:
+			prefix += el.Tag().String() + " = "
+		}
+
+		open, closing := "", ""
+		switch el.Type() {
+		case ETStructure:
+			open, closing = "{", "}"
+		case ETArray:
+			open, closing = "[", "]"
+		case ETList:
+			open, closing = "[[", "]]"
+		}
+		if open == "" {
+			if _, err := fmt.Fprintf(w, "%s%s\n", prefix, prettyValue(el)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "%s%s\n", prefix, open); err != nil {
+			return err
+		}
+		if err := r.EnterContainer(); err != nil {
+			return err
+		}
+		if err := prettyLevel(w, r, indent+1); err != nil {
+			return err
+		}
+		if err := r.ExitContainer(); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s%s\n", pad, closing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func prettyValue(el Element) string {
+	if v, ok := el.Signed(); ok {
+		return fmt.Sprintf("%d (int)", v)
+	}
+	if v, ok := el.Unsigned(); ok {
+		return fmt.Sprintf("%d (uint)", v)
+	}
+	if v, ok := el.Bool(); ok {
+		return fmt.Sprintf("%t (bool)", v)
+	}
+	if v, ok := el.Float(); ok {
+		if el.Type() == ETFloat32 {
+			return fmt.Sprintf("%v (float)", float32(v))
+		}
+		return fmt.Sprintf("%v (double)", v)
+	}
+	if v, ok := el.UTF8(); ok {
+		return fmt.Sprintf("%q (string)", v)
+	}
+	if v, ok := el.Bytes(); ok {
+		return fmt.Sprintf("hex:%s (%d bytes)", hex.EncodeToString(v), len(v))
+	}
+	if el.Type() == ETNull {
+		return "null"
+	}
+	return el.DebugString()
+}
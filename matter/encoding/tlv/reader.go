@@ -0,0 +1,316 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Reader is a cursor over a TLV element stream that navigates one container
+// level at a time:
+//
+//	r := NewReader(b)
+//	for r.Next() {                  // top-level elements
+//		if r.Element().Type() == ETStructure {
+//			_ = r.EnterContainer()
+//			if r.FindTag(ContextTag(2)) {
+//				v, _ := r.Element().Unsigned()
+//				...
+//			}
+//			_ = r.ExitContainer()   // skips any remaining members
+//		}
+//	}
+//	err := r.Err()
+//
+// Next only visits elements of the current level; members of containers that
+// were not entered are skipped automatically.
+type Reader struct {
+	dec     Decoder
+	pending *readerEntry
+	cur     *readerEntry
+	// level is the number of entered containers.
+	level int
+	// tags holds the tags of entered containers.
+	tags []Tag
+	// index holds the position of the current element within each level.
+	index []int
+	err   error
+}
+
+// readerEntry is an element together with its container depth.
+type readerEntry struct {
+	el    Element
+	depth int
+}
+
+// NewReader returns a Reader positioned before the first top-level element.
+func NewReader(b []byte) *Reader {
+	return &Reader{
+		dec:   NewDecoder(b),
+		index: []int{-1},
+	}
+}
+
+// read returns the next raw element, honouring a pushed back element.
+func (r *Reader) read() (*readerEntry, bool) {
+	if r.pending != nil {
+		e := r.pending
+		r.pending = nil
+		return e, true
+	}
+	if r.err != nil {
+		return nil, false
+	}
+	if !r.dec.Next() {
+		r.err = r.dec.Err()
+		return nil, false
+	}
+	return &readerEntry{el: r.dec.Element(), depth: r.dec.Depth()}, true
+}
+
+// Next advances to the next element of the current container level. It
+// returns false at the end of the container, at the end of input, or on
+// error (see Err).
+func (r *Reader) Next() bool {
+	r.cur = nil
+	for {
+		e, ok := r.read()
+		if !ok {
+			return false
+		}
+		if e.depth > r.level {
+			continue
+		}
+		if e.depth < r.level {
+			r.pending = e
+			return false
+		}
+		r.cur = e
+		r.index[r.level]++
+		return true
+	}
+}
+
+// Element returns the current element, or nil if there is none.
+func (r *Reader) Element() Element {
+	if r.cur == nil {
+		return nil
+	}
+	return r.cur.el
+}
+
+// Tag returns the tag of the current element, or nil if there is none.
+func (r *Reader) Tag() Tag {
+	if r.cur == nil {
+		return nil
+	}
+	return r.cur.el.Tag()
+}
+
+// Depth returns the number of containers entered.
+func (r *Reader) Depth() int { return r.level }
+
+// Err returns the first decoding error encountered (if any).
+func (r *Reader) Err() error { return r.err }
+
+// EnterContainer descends into the current element, which must be a
+// structure, array or list. Subsequent calls to Next visit its members.
+func (r *Reader) EnterContainer() error {
+	if r.cur == nil {
+		return fmt.Errorf("%w: no current element", ErrNotContainer)
+	}
+	if !isContainerStart(r.cur.el.Type()) {
+		return fmt.Errorf("%w: %s", ErrNotContainer, r.cur.el.DebugString())
+	}
+	r.tags = append(r.tags, r.cur.el.Tag())
+	r.index = append(r.index, -1)
+	r.level++
+	r.cur = nil
+	return nil
+}
+
+// ExitContainer skips the remaining members of the entered container and
+// returns to its parent level; the next call to Next visits the sibling that
+// follows the container.
+func (r *Reader) ExitContainer() error {
+	if r.level == 0 {
+		return ErrContainerStackEmpty
+	}
+	r.cur = nil
+	for {
+		e, ok := r.read()
+		if !ok {
+			break
+		}
+		if e.depth < r.level {
+			r.pending = e
+			break
+		}
+	}
+	r.level--
+	r.tags = r.tags[:len(r.tags)-1]
+	r.index = r.index[:len(r.index)-1]
+	return r.err
+}
+
+// Skip discards the current element, including all members if it is a
+// container, so that decoding errors inside it surface immediately.
+func (r *Reader) Skip() error {
+	if r.cur == nil {
+		return r.err
+	}
+	if isContainerStart(r.cur.el.Type()) {
+		if err := r.EnterContainer(); err != nil {
+			return err
+		}
+		return r.ExitContainer()
+	}
+	r.cur = nil
+	return r.err
+}
+
+// FindTag advances through the current level until an element with the given
+// tag is found. It returns false if the level ends first.
+func (r *Reader) FindTag(tag Tag) bool {
+	for r.Next() {
+		if TagsEqual(r.cur.el.Tag(), tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Path returns the tags of the entered containers followed by the tag of the
+// current element (if any).
+func (r *Reader) Path() []Tag {
+	path := append([]Tag{}, r.tags...)
+	if r.cur != nil {
+		path = append(path, r.cur.el.Tag())
+	}
+	return path
+}
+
+// PathString renders Path with context tags as numbers and anonymous
+// members as their index, e.g. "/2/[0]/1".
+func (r *Reader) PathString() string {
+	path := r.Path()
+	var sb strings.Builder
+	for i, tag := range path {
+		sb.WriteByte('/')
+		switch tag.Control() {
+		case TagCtlAnonymous:
+			fmt.Fprintf(&sb, "[%d]", r.index[i])
+		case TagCtlContext:
+			fmt.Fprintf(&sb, "%d", tag.SerializeTag()[0])
+		default:
+			sb.WriteString(tag.String())
+		}
+	}
+	if sb.Len() == 0 {
+		return "/"
+	}
+	return sb.String()
+}
+
+// Copy re-encodes the current element, including all members, into enc
+// under tag and consumes it.
+func (r *Reader) Copy(enc Encoder, tag Tag) error {
+	if r.cur == nil {
+		return fmt.Errorf("tlv: no current element to copy")
+	}
+	el := r.cur.el
+	switch el.Type() {
+	case ETStructure, ETArray, ETList:
+		switch el.Type() {
+		case ETStructure:
+			enc.StartStructure(tag)
+		case ETArray:
+			enc.StartArray(tag)
+		default:
+			enc.StartList(tag)
+		}
+		if err := r.EnterContainer(); err != nil {
+			return err
+		}
+		for r.Next() {
+			if err := r.Copy(enc, r.cur.el.Tag()); err != nil {
+				return err
+			}
+		}
+		if err := r.ExitContainer(); err != nil {
+			return err
+		}
+		return enc.EndContainer()
+	}
+	r.cur = nil
+	return putElement(enc, tag, el)
+}
+
+// putElement encodes a scalar element under tag.
+func putElement(enc Encoder, tag Tag, el Element) error {
+	switch el.Type() {
+	case ETNull:
+		enc.PutNull(tag)
+		return nil
+	case ETFloat32:
+		f, _ := el.Float()
+		enc.PutFloat32(tag, float32(f))
+		return nil
+	case ETFloat64:
+		f, _ := el.Float()
+		enc.PutFloat64(tag, f)
+		return nil
+	}
+	if v, ok := el.Signed(); ok {
+		return enc.PutSigned(tag, v)
+	}
+	if v, ok := el.Unsigned(); ok {
+		return enc.PutUnsigned(tag, v)
+	}
+	if v, ok := el.Bool(); ok {
+		enc.PutBool(tag, v)
+		return nil
+	}
+	if v, ok := el.UTF8(); ok {
+		return enc.PutUTF8(tag, v)
+	}
+	if v, ok := el.Bytes(); ok {
+		return enc.PutBytes(tag, v)
+	}
+	return fmt.Errorf("%w: 0x%02X", ErrUnknownElementType, uint8(el.Type()))
+}
+
+// TagsEqual reports whether two tags have the same form and value.
+func TagsEqual(a, b Tag) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Control() == b.Control() && bytes.Equal(a.SerializeTag(), b.SerializeTag())
+}
+
+// ContextTagNumber returns the number of a context tag.
+func ContextTagNumber(tag Tag) (uint8, bool) {
+	if tag == nil || tag.Control() != TagCtlContext {
+		return 0, false
+	}
+	return tag.SerializeTag()[0], true
+}
+
+// isContainerStart reports whether et opens a container.
+func isContainerStart(et ElementType) bool {
+	return et == ETStructure || et == ETArray || et == ETList
+}
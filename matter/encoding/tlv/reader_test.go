@@ -0,0 +1,184 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// readerSample encodes:
+//
+//	{ 1 = 42, 2 = [ {0 = "a"}, {0 = "b"} ], 3 = [[ ]], 4 = true }
+func readerSample() []byte {
+	enc := NewEncoder()
+	enc.StartStructure(AnonymousTag())
+	_ = enc.PutUnsigned(ContextTag(1), 42)
+	enc.StartArray(ContextTag(2))
+	for _, s := range []string{"a", "b"} {
+		enc.StartStructure(AnonymousTag())
+		_ = enc.PutUTF8(ContextTag(0), s)
+		_ = enc.EndContainer()
+	}
+	_ = enc.EndContainer()
+	enc.StartList(ContextTag(3))
+	_ = enc.EndContainer()
+	enc.PutBool(ContextTag(4), true)
+	_ = enc.EndContainer()
+	return enc.Bytes()
+}
+
+func TestReaderNavigation(t *testing.T) {
+	r := NewReader(readerSample())
+	if !r.Next() {
+		t.Fatalf("no top-level element: %v", r.Err())
+	}
+	if err := r.EnterContainer(); err != nil {
+		t.Fatalf("EnterContainer: %v", err)
+	}
+	if !r.FindTag(ContextTag(2)) {
+		t.Fatalf("tag 2 not found")
+	}
+	if got := r.PathString(); got != "/[0]/2" {
+		t.Errorf("PathString = %s", got)
+	}
+	if err := r.EnterContainer(); err != nil {
+		t.Fatalf("EnterContainer(2): %v", err)
+	}
+	var names []string
+	for r.Next() {
+		if err := r.EnterContainer(); err != nil {
+			t.Fatalf("EnterContainer(elem): %v", err)
+		}
+		if !r.FindTag(ContextTag(0)) {
+			t.Fatalf("tag 0 not found at %s", r.PathString())
+		}
+		if len(names) == 1 {
+			if got := r.PathString(); got != "/[0]/2/[1]/0" {
+				t.Errorf("PathString = %s", got)
+			}
+		}
+		s, _ := r.Element().UTF8()
+		names = append(names, s)
+		if err := r.ExitContainer(); err != nil {
+			t.Fatalf("ExitContainer(elem): %v", err)
+		}
+	}
+	if len(names) != 2 || names[0] != "a" || names[1] != "b" {
+		t.Fatalf("names = %v", names)
+	}
+	if err := r.ExitContainer(); err != nil {
+		t.Fatalf("ExitContainer(2): %v", err)
+	}
+
+	// The unentered list is skipped by Next.
+	if !r.Next() || !TagsEqual(r.Tag(), ContextTag(3)) {
+		t.Fatalf("expected tag 3, got %v", r.Tag())
+	}
+	if !r.Next() || !TagsEqual(r.Tag(), ContextTag(4)) {
+		t.Fatalf("expected tag 4, got %v", r.Tag())
+	}
+	if r.Next() {
+		t.Fatalf("unexpected element %s", r.Element().DebugString())
+	}
+	if err := r.ExitContainer(); err != nil {
+		t.Fatalf("ExitContainer(root): %v", err)
+	}
+	if r.Next() || r.Err() != nil {
+		t.Fatalf("expected clean EOF, err=%v", r.Err())
+	}
+}
+
+func TestReaderSkipAndCopy(t *testing.T) {
+	data := readerSample()
+	r := NewReader(data)
+	r.Next()
+	_ = r.EnterContainer()
+	r.Next()
+	r.Next()
+	if err := r.Skip(); err != nil {
+		t.Fatalf("Skip: %v", err)
+	}
+	if !r.Next() || !TagsEqual(r.Tag(), ContextTag(3)) {
+		t.Fatalf("expected tag 3 after Skip, got %v", r.Tag())
+	}
+	if err := r.EnterContainer(); err == nil {
+		_ = r.ExitContainer()
+	}
+	if r.FindTag(ContextTag(9)) {
+		t.Fatalf("found missing tag")
+	}
+
+	r = NewReader(data)
+	r.Next()
+	enc := NewEncoder()
+	if err := r.Copy(enc, AnonymousTag()); err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	if !bytes.Equal(enc.Bytes(), data) {
+		t.Fatalf("Copy = %x, want %x", enc.Bytes(), data)
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	r := NewReader(readerSample())
+	if err := r.EnterContainer(); !errors.Is(err, ErrNotContainer) {
+		t.Fatalf("EnterContainer without element: %v", err)
+	}
+	if err := r.ExitContainer(); !errors.Is(err, ErrContainerStackEmpty) {
+		t.Fatalf("ExitContainer at top: %v", err)
+	}
+	r.Next()
+	_ = r.EnterContainer()
+	r.Next()
+	if err := r.EnterContainer(); !errors.Is(err, ErrNotContainer) {
+		t.Fatalf("EnterContainer on scalar: %v", err)
+	}
+
+	truncated := readerSample()
+	truncated = truncated[:len(truncated)-4]
+	r = NewReader(truncated)
+	r.Next()
+	_ = r.EnterContainer()
+	if err := r.ExitContainer(); !errors.Is(err, ErrUnexpectedEOF) {
+		t.Fatalf("truncated ExitContainer: %v", err)
+	}
+}
+
+func TestPrettyString(t *testing.T) {
+	got, err := PrettyString(readerSample())
+	if err != nil {
+		t.Fatalf("PrettyString: %v", err)
+	}
+	want := `{
+  1 = 42 (uint)
+  2 = [
+    {
+      0 = "a" (string)
+    }
+    {
+      0 = "b" (string)
+    }
+  ]
+  3 = [[
+  ]]
+  4 = true (bool)
+}
+`
+	if got != want {
+		t.Fatalf("PrettyString =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+// TestSpecExamples checks the encodings of Matter Core Spec 1.5, Appendix
+// A.12 (TLV Encoding Examples) and that each decodes to the expected element
+// type and tag.
+func TestSpecExamples(t *testing.T) {
+	tests := []struct {
+		name string
+		put  func(Encoder)
+		want string
+		et   ElementType
+		tag  Tag
+	}{
+		{"bool false", func(e Encoder) { e.PutBool(AnonymousTag(), false) }, "08", ETBoolFalse, AnonymousTag()},
+		{"bool true", func(e Encoder) { e.PutBool(AnonymousTag(), true) }, "09", ETBoolTrue, AnonymousTag()},
+		{"signed 1-octet 42", func(e Encoder) { _ = e.PutSigned(AnonymousTag(), 42) }, "002a", ETSignedInt1, AnonymousTag()},
+		{"signed 1-octet -17", func(e Encoder) { _ = e.PutSigned(AnonymousTag(), -17) }, "00ef", ETSignedInt1, AnonymousTag()},
+		{"unsigned 1-octet 42", func(e Encoder) { _ = e.PutUnsigned(AnonymousTag(), 42) }, "042a", ETUnsignedInt1, AnonymousTag()},
+		{"signed 2-octet 422", func(e Encoder) { _ = e.PutSigned(AnonymousTag(), 422) }, "01a601", ETSignedInt2, AnonymousTag()},
+		{"signed 4-octet -170000", func(e Encoder) { _ = e.PutSigned(AnonymousTag(), -170000) }, "02f067fdff", ETSignedInt4, AnonymousTag()},
+		{"signed 8-octet 40000000000", func(e Encoder) { _ = e.PutSigned(AnonymousTag(), 40000000000) }, "0300902f5009000000", ETSignedInt8, AnonymousTag()},
+		{"utf8 Hello!", func(e Encoder) { _ = e.PutUTF8(AnonymousTag(), "Hello!") }, "0c0648656c6c6f21", ETUtf8String1, AnonymousTag()},
+		{"utf8 Tschüs", func(e Encoder) { _ = e.PutUTF8(AnonymousTag(), "Tschüs") }, "0c0754736368c3bc73", ETUtf8String1, AnonymousTag()},
+		{"octet string", func(e Encoder) { _ = e.PutBytes(AnonymousTag(), []byte{0, 1, 2, 3, 4}) }, "10050001020304", ETByteString1, AnonymousTag()},
+		{"null", func(e Encoder) { e.PutNull(AnonymousTag()) }, "14", ETNull, AnonymousTag()},
+		{"float32 0.0", func(e Encoder) { e.PutFloat32(AnonymousTag(), 0) }, "0a00000000", ETFloat32, AnonymousTag()},
+		{"float32 1/3", func(e Encoder) { e.PutFloat32(AnonymousTag(), 1.0/3) }, "0aabaaaa3e", ETFloat32, AnonymousTag()},
+		{"float32 17.9", func(e Encoder) { e.PutFloat32(AnonymousTag(), 17.9) }, "0a33338f41", ETFloat32, AnonymousTag()},
+		{"float32 +inf", func(e Encoder) { e.PutFloat32(AnonymousTag(), float32(math.Inf(1))) }, "0a0000807f", ETFloat32, AnonymousTag()},
+		{"float32 -inf", func(e Encoder) { e.PutFloat32(AnonymousTag(), float32(math.Inf(-1))) }, "0a000080ff", ETFloat32, AnonymousTag()},
+		{"float64 0.0", func(e Encoder) { e.PutFloat64(AnonymousTag(), 0) }, "0b0000000000000000", ETFloat64, AnonymousTag()},
+		{"float64 1/3", func(e Encoder) { e.PutFloat64(AnonymousTag(), 1.0/3) }, "0b555555555555d53f", ETFloat64, AnonymousTag()},
+		{"float64 17.9", func(e Encoder) { e.PutFloat64(AnonymousTag(), 17.9) }, "0b6666666666e63140", ETFloat64, AnonymousTag()},
+		{"empty structure", func(e Encoder) { e.StartStructure(AnonymousTag()); _ = e.EndContainer() }, "1518", ETStructure, AnonymousTag()},
+		{"empty array", func(e Encoder) { e.StartArray(AnonymousTag()); _ = e.EndContainer() }, "1618", ETArray, AnonymousTag()},
+		{"empty list", func(e Encoder) { e.StartList(AnonymousTag()); _ = e.EndContainer() }, "1718", ETList, AnonymousTag()},
+		{"structure with context tags", func(e Encoder) {
+			e.StartStructure(AnonymousTag())
+			_ = e.PutSigned(ContextTag(0), 42)
+			_ = e.PutSigned(ContextTag(1), -17)
+			_ = e.EndContainer()
+		}, "1520002a2001ef18", ETStructure, AnonymousTag()},
+		{"array of signed integers", func(e Encoder) {
+			e.StartArray(AnonymousTag())
+			for i := int64(0); i < 5; i++ {
+				_ = e.PutSigned(AnonymousTag(), i)
+			}
+			_ = e.EndContainer()
+		}, "160000000100020003000418", ETArray, AnonymousTag()},
+		{"array of mixed types", func(e Encoder) {
+			e.StartArray(AnonymousTag())
+			_ = e.PutSigned(AnonymousTag(), 42)
+			_ = e.PutSigned(AnonymousTag(), -170000)
+			e.StartStructure(AnonymousTag())
+			_ = e.EndContainer()
+			e.PutFloat32(AnonymousTag(), 17.9)
+			_ = e.PutUTF8(AnonymousTag(), "Hello!")
+			_ = e.EndContainer()
+		}, "16002a02f067fdff15180a33338f410c0648656c6c6f2118", ETArray, AnonymousTag()},
+		{"context tag 1", func(e Encoder) { _ = e.PutUnsigned(ContextTag(1), 42) }, "24012a", ETUnsignedInt1, ContextTag(1)},
+		{"common profile 2-octet tag", func(e Encoder) { _ = e.PutUnsigned(Common2Tag(1), 42) }, "4401002a", ETUnsignedInt1, Common2Tag(1)},
+		{"common profile 4-octet tag", func(e Encoder) { _ = e.PutUnsigned(Common4Tag(100000), 42) }, "64a08601002a", ETUnsignedInt1, Common4Tag(100000)},
+		{"fully qualified 6-octet tag", func(e Encoder) { _ = e.PutUnsigned(FullyQualified6(0xFFF1, 0xDEED, 1), 42) }, "c4f1ffedde01002a", ETUnsignedInt1, FullyQualified6(0xFFF1, 0xDEED, 1)},
+		{"fully qualified 8-octet tag", func(e Encoder) { _ = e.PutUnsigned(FullyQualified8(0xFFF1, 0xDEED, 0xAA55FEED), 42) }, "e4f1ffeddeedfe55aa2a", ETUnsignedInt1, FullyQualified8(0xFFF1, 0xDEED, 0xAA55FEED)},
+	}
+	for _, tt := range tests {
+		enc := NewEncoder()
+		tt.put(enc)
+		if got := hex.EncodeToString(enc.Bytes()); got != tt.want {
+			t.Errorf("%s: encoded %s, want %s", tt.name, got, tt.want)
+		}
+		b, _ := hex.DecodeString(tt.want)
+		dec := NewDecoder(b)
+		if !dec.Next() {
+			t.Errorf("%s: decode: %v", tt.name, dec.Err())
+			continue
+		}
+		el := dec.Element()
+		if el.Type() != tt.et {
+			t.Errorf("%s: decoded type 0x%02X, want 0x%02X", tt.name, uint8(el.Type()), uint8(tt.et))
+		}
+		if el.Tag().Control() != tt.tag.Control() || !bytes.Equal(el.Tag().SerializeTag(), tt.tag.SerializeTag()) {
+			t.Errorf("%s: decoded tag %s, want %s", tt.name, el.Tag(), tt.tag)
+		}
+		for dec.Next() {
+		}
+		if err := dec.Err(); err != nil {
+			t.Errorf("%s: decode: %v", tt.name, err)
+		}
+	}
+}
@@ -22,8 +22,7 @@ package tlv
 
 // TagControl represents the 3-bit tag control field in the control octet.
 // Each value determines how many tag bytes follow the control octet.
-// Some values are illustrative (e.g. FullyQualified6 vs FullyQualified8) and
-// may need adjustment to match a specific spec revision exactly.
+// Reference: Matter Core Spec 1.5, Appendix A.7.2 (Tag Control Field)
 type TagControl uint8
 
 const (
@@ -35,18 +34,18 @@ const (
 	TagCtlCommon2
 	// TagCtlCommon4 indicates a 4-byte common profile tag / extended form.
 	TagCtlCommon4
+	// TagCtlImplicit2 indicates a 2-byte implicit profile tag (not decoded).
+	TagCtlImplicit2
+	// TagCtlImplicit4 indicates a 4-byte implicit profile tag (not decoded).
+	TagCtlImplicit4
 	// TagCtlFullyQualified6 indicates a 6-byte fully-qualified tag (2+2+2).
 	TagCtlFullyQualified6
 	// TagCtlFullyQualified8 indicates an 8-byte fully-qualified tag (2+2+4).
 	TagCtlFullyQualified8
-	// TagCtlReserved6 is reserved.
-	TagCtlReserved6
-	// TagCtlReserved7 is reserved.
-	TagCtlReserved7
 )
 
 // ElementType (5 bits). Each size variant is a distinct constant.
-// Only a subset of Matter element types/variants is modeled here for illustration.
+// Reference: Matter Core Spec 1.5, Appendix A.7.1 (Element Type Field)
 type ElementType uint8
 
 const (
@@ -63,26 +62,26 @@ const (
 	// Boolean values (no payload).
 	ETBoolFalse ElementType = 0x08
 	ETBoolTrue  ElementType = 0x09
+	// Floating point.
+	ETFloat32 ElementType = 0x0A
+	ETFloat64 ElementType = 0x0B
 	// UTF-8 string (length-of-length = 1/2/4/8 bytes).
-	ETUtf8String1 ElementType = 0x0A
-	ETUtf8String2 ElementType = 0x0B
-	ETUtf8String4 ElementType = 0x0C
-	ETUtf8String8 ElementType = 0x0D
+	ETUtf8String1 ElementType = 0x0C
+	ETUtf8String2 ElementType = 0x0D
+	ETUtf8String4 ElementType = 0x0E
+	ETUtf8String8 ElementType = 0x0F
 	// Byte string (length-of-length = 1/2/4/8 bytes).
-	ETByteString1 ElementType = 0x0E
-	ETByteString2 ElementType = 0x0F
-	ETByteString4 ElementType = 0x10
-	ETByteString8 ElementType = 0x11
+	ETByteString1 ElementType = 0x10
+	ETByteString2 ElementType = 0x11
+	ETByteString4 ElementType = 0x12
+	ETByteString8 ElementType = 0x13
 	// Null (no payload).
-	ETNull ElementType = 0x12
+	ETNull ElementType = 0x14
 	// Containers & end marker.
-	ETStructure      ElementType = 0x13
-	ETArray          ElementType = 0x14
-	ETList           ElementType = 0x15
-	ETEndOfContainer ElementType = 0x16
-	// Floating point.
-	ETFloat32 ElementType = 0x17
-	ETFloat64 ElementType = 0x18
+	ETStructure      ElementType = 0x15
+	ETArray          ElementType = 0x16
+	ETList           ElementType = 0x17
+	ETEndOfContainer ElementType = 0x18
 	// 0x19..0x1F reserved.
 )
 
@@ -0,0 +1,280 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlv
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Unmarshal decodes a single TLV element from data into the value pointed
+// to by v, using the same `tlv` struct tags as Marshal.
+//
+// Structure members whose context tag has no matching field are ignored so
+// newer peers can add fields. Null decodes to a nil pointer, slice or
+// interface; decoding Null into any other type is an error. Integers are
+// range-checked against the destination type.
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w: Unmarshal requires a non-nil pointer, got %T", ErrUnsupportedType, v)
+	}
+	root, err := parseValueTree(data)
+	if err != nil {
+		return err
+	}
+	return unmarshalValue(root, rv.Elem())
+}
+
+// valueNode is a decoded element with its container members.
+type valueNode struct {
+	el       Element
+	children []*valueNode
+}
+
+// parseValueTree decodes data, which must hold exactly one top-level element.
+func parseValueTree(data []byte) (*valueNode, error) {
+	dec := NewDecoder(data)
+	var root *valueNode
+	var stack []*valueNode
+	for dec.Next() {
+		n := &valueNode{el: dec.Element()}
+		depth := dec.Depth()
+		stack = stack[:depth]
+		if depth == 0 {
+			if root != nil {
+				return nil, ErrTrailingData
+			}
+			root = n
+		} else {
+			stack[depth-1].children = append(stack[depth-1].children, n)
+		}
+		if _, ok := n.el.ContainerKind(); ok {
+			stack = append(stack, n)
+		}
+	}
+	if err := dec.Err(); err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, ErrUnexpectedEOF
+	}
+	return root, nil
+}
+
+func unmarshalValue(n *valueNode, v reflect.Value) error {
+	et := n.el.Type()
+	if et == ETNull {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Interface, reflect.Map:
+			v.SetZero()
+			return nil
+		default:
+			return fmt.Errorf("%w: null into %s", ErrTypeMismatch, v.Type())
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return unmarshalValue(n, v.Elem())
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+		}
+		val, err := genericValue(n)
+		if err != nil {
+			return err
+		}
+		if val == nil {
+			v.SetZero()
+		} else {
+			v.Set(reflect.ValueOf(val))
+		}
+		return nil
+	case reflect.Bool:
+		b, ok := n.el.Bool()
+		if !ok {
+			return mismatch(n, v)
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if s, ok := n.el.Signed(); ok {
+			i = s
+		} else if u, ok := n.el.Unsigned(); ok {
+			if u > math.MaxInt64 {
+				return fmt.Errorf("%w: %d into %s", ErrValueOverflow, u, v.Type())
+			}
+			i = int64(u)
+		} else {
+			return mismatch(n, v)
+		}
+		if v.OverflowInt(i) {
+			return fmt.Errorf("%w: %d into %s", ErrValueOverflow, i, v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		if x, ok := n.el.Unsigned(); ok {
+			u = x
+		} else if s, ok := n.el.Signed(); ok {
+			if s < 0 {
+				return fmt.Errorf("%w: %d into %s", ErrValueOverflow, s, v.Type())
+			}
+			u = uint64(s)
+		} else {
+			return mismatch(n, v)
+		}
+		if v.OverflowUint(u) {
+			return fmt.Errorf("%w: %d into %s", ErrValueOverflow, u, v.Type())
+		}
+		v.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, ok := n.el.Float()
+		if !ok {
+			return mismatch(n, v)
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.String:
+		s, ok := n.el.UTF8()
+		if !ok {
+			return mismatch(n, v)
+		}
+		v.SetString(s)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, ok := n.el.Bytes()
+			if !ok {
+				return mismatch(n, v)
+			}
+			v.SetBytes(append([]byte{}, b...))
+			return nil
+		}
+		if et != ETArray && et != ETList {
+			return mismatch(n, v)
+		}
+		out := reflect.MakeSlice(v.Type(), len(n.children), len(n.children))
+		for i, c := range n.children {
+			if err := unmarshalValue(c, out.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		v.Set(out)
+		return nil
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, ok := n.el.Bytes()
+			if !ok {
+				return mismatch(n, v)
+			}
+			if len(b) != v.Len() {
+				return fmt.Errorf("%w: %d bytes into %s", ErrTypeMismatch, len(b), v.Type())
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		if et != ETArray && et != ETList {
+			return mismatch(n, v)
+		}
+		if len(n.children) != v.Len() {
+			return fmt.Errorf("%w: %d elements into %s", ErrTypeMismatch, len(n.children), v.Type())
+		}
+		for i, c := range n.children {
+			if err := unmarshalValue(c, v.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return nil
+	case reflect.Struct:
+		if et != ETStructure && et != ETList {
+			return mismatch(n, v)
+		}
+		fields, err := cachedStructFields(v.Type())
+		if err != nil {
+			return err
+		}
+		for _, c := range n.children {
+			t := c.el.Tag()
+			if t.Control() != TagCtlContext {
+				continue
+			}
+			num := t.SerializeTag()[0]
+			for _, f := range fields {
+				if f.tag != num {
+					continue
+				}
+				if err := unmarshalValue(c, v.Field(f.index)); err != nil {
+					return fmt.Errorf("%s: %w", f.name, err)
+				}
+				break
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+	}
+}
+
+// genericValue converts n into a plain Go value for interface destinations:
+// integers become int64/uint64, containers become []any.
+func genericValue(n *valueNode) (any, error) {
+	el := n.el
+	if v, ok := el.Signed(); ok {
+		return v, nil
+	}
+	if v, ok := el.Unsigned(); ok {
+		return v, nil
+	}
+	if v, ok := el.Bool(); ok {
+		return v, nil
+	}
+	if v, ok := el.Float(); ok {
+		return v, nil
+	}
+	if v, ok := el.UTF8(); ok {
+		return v, nil
+	}
+	if v, ok := el.Bytes(); ok {
+		return append([]byte{}, v...), nil
+	}
+	if _, ok := el.ContainerKind(); ok {
+		out := make([]any, len(n.children))
+		for i, c := range n.children {
+			v, err := genericValue(c)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = v
+		}
+		return out, nil
+	}
+	if el.Type() == ETNull {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: element type 0x%02X", ErrUnsupportedType, uint8(el.Type()))
+}
+
+func mismatch(n *valueNode, v reflect.Value) error {
+	return fmt.Errorf("%w: %s into %s", ErrTypeMismatch, n.el.DebugString(), v.Type())
+}
@@ -25,4 +25,6 @@ var (
 	ErrFailed = errors.ErrFailed
 	// ErrDisabled is returned when a feature is disabled.
 	ErrDisabled = errors.ErrDisabled
+	// ErrNotImplemented is returned when a protocol step is not implemented yet.
+	ErrNotImplemented = errors.ErrNotImplemented
 )
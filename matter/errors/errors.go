@@ -27,6 +27,8 @@ var (
 	ErrFailed = errors.New("failed")
 	// ErrDisabled indicates a feature is disabled.
 	ErrDisabled = errors.New("disabled")
+	// ErrNotImplemented indicates a protocol step that is not implemented yet.
+	ErrNotImplemented = errors.New("not implemented")
 )
 
 // Is reports whether any error in err's chain matches target.
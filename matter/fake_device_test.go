@@ -0,0 +1,313 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/casesession"
+	"github.com/YashubuStudio/go-matter-pack/matter/clusters"
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+	"github.com/YashubuStudio/go-matter-pack/matter/pase"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+// commandKey identifies a command across clusters.
+type commandKey struct{ cluster, command uint32 }
+
+func keyOf(cmd im.Command) commandKey { return commandKey{cmd.ClusterID(), cmd.CommandID()} }
+
+// fakeDevice is a commissionee on loopback UDP: it accepts PASE sessions
+// with pase.Responder on its commissioning port, answers the commissioning
+// commands over them, and once it has a NOC accepts CASE sessions with
+// casesession.Responder on its operational port.
+type fakeDevice struct {
+	t         *testing.T
+	ctx       context.Context
+	responder *pase.Responder
+	dacKey    *ecdsa.PrivateKey
+	dac       []byte
+	paseConn  net.PacketConn
+	opConn    net.PacketConn
+
+	mu sync.Mutex
+	// paseSessions counts the PASE sessions established.
+	paseSessions int
+	// armed is set while the fail-safe timer runs; the credentials added
+	// meanwhile are discarded when it is disarmed.
+	armed        bool
+	opKey        *ecdsa.PrivateKey
+	rcac         *credentials.Certificate
+	fabric       *credentials.Fabric
+	commissioned bool
+	serving      bool
+	commissions  chan uint64
+}
+
+func newFakeDevice(t *testing.T, ctx context.Context, passcode pase.Passcode) *fakeDevice {
+	t.Helper()
+	dacKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	dac, err := x509.CreateCertificate(rand.Reader, template, template, &dacKey.PublicKey, dacKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &fakeDevice{
+		t:           t,
+		ctx:         ctx,
+		responder:   &pase.Responder{Passcode: passcode, Salt: []byte("SPAKE2P Key Salt"), Iterations: 1000},
+		dacKey:      dacKey,
+		dac:         dac,
+		commissions: make(chan uint64, 1),
+	}
+	for _, pc := range []*net.PacketConn{&d.paseConn, &d.opConn} {
+		if *pc, err = net.ListenPacket("udp", "127.0.0.1:0"); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { (*pc).Close() })
+	}
+	go d.servePASE()
+	return d
+}
+
+// port returns the commissioning port.
+func (d *fakeDevice) port() int {
+	return d.paseConn.LocalAddr().(*net.UDPAddr).Port
+}
+
+// ResolveOperational returns the operational port of the device.
+func (d *fakeDevice) ResolveOperational(_ context.Context, peer commissioning.OperationalPeer) ([]*net.UDPAddr, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.fabric == nil || d.fabric.NodeID != peer.NodeID || d.fabric.FabricID != peer.FabricID {
+		return nil, nil
+	}
+	return []*net.UDPAddr{d.opConn.LocalAddr().(*net.UDPAddr)}, nil
+}
+
+// servePASE accepts one PASE session after the other, each from a new
+// peer, and serves it until the commissioner closes it.
+func (d *fakeDevice) servePASE() {
+	for d.ctx.Err() == nil {
+		s, err := d.responder.Accept(d.ctx, transport.NewUnsecuredSession(transport.NewConn(d.paseConn, nil), 0))
+		if err != nil {
+			continue
+		}
+		d.mu.Lock()
+		d.paseSessions++
+		d.mu.Unlock()
+		d.serve(s, false)
+	}
+}
+
+// serveOperational accepts CASE sessions with the NOC of fabric.
+func (d *fakeDevice) serveOperational(fabric *credentials.Fabric) {
+	responder := &casesession.Responder{Fabric: fabric}
+	for d.ctx.Err() == nil {
+		s, err := responder.Accept(d.ctx, transport.NewUnsecuredSession(transport.NewConn(d.opConn, nil), 0))
+		if err != nil {
+			continue
+		}
+		d.serve(s, true)
+	}
+}
+
+// serve answers the invoke requests on s until the peer closes it.
+func (d *fakeDevice) serve(s *transport.Session, operational bool) {
+	for {
+		ex, msg, err := s.Accept(d.ctx)
+		if err != nil {
+			return
+		}
+		if !msg.Is(protocol.InteractionModelProtocol, protocol.InvokeRequestMessage) {
+			ex.Close()
+			if msg.Is(protocol.SecureChannelProtocol, protocol.StatusReportMessage) {
+				return
+			}
+			continue
+		}
+		resp, err := d.invoke(msg.Payload, s.AttestationChallenge(), operational)
+		if err != nil {
+			d.t.Errorf("fake device: %v", err)
+			ex.Close()
+			return
+		}
+		payload, err := resp.Encode()
+		if err != nil {
+			d.t.Errorf("fake device: %v", err)
+			return
+		}
+		if err := ex.Send(d.ctx, protocol.InteractionModelProtocol, protocol.InvokeResponseMessage, payload); err != nil {
+			return
+		}
+	}
+}
+
+// invoke runs an invoke request and returns the response.
+func (d *fakeDevice) invoke(payload, challenge []byte, operational bool) (*im.InvokeResponse, error) {
+	req, err := im.DecodeInvokeRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	path := req.Commands[0].Path
+	fields, err := im.NewValue(req.Commands[0].Fields)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var resp im.Command
+	switch (commandKey{path.Cluster, path.Command}) {
+	case keyOf(clusters.GeneralCommissioningArmFailSafeRequest{}):
+		var cmd clusters.GeneralCommissioningArmFailSafeRequest
+		if err := fields.Unmarshal(&cmd); err != nil {
+			return nil, err
+		}
+		d.armed = cmd.ExpiryLengthSeconds != 0
+		if !d.armed && !d.commissioned {
+			d.opKey, d.rcac, d.fabric = nil, nil, nil
+		}
+		resp = clusters.GeneralCommissioningArmFailSafeResponse{}
+	case keyOf(clusters.GeneralCommissioningSetRegulatoryConfigRequest{}):
+		resp = clusters.GeneralCommissioningSetRegulatoryConfigResponse{}
+	case keyOf(clusters.OperationalCredentialsCertificateChainRequestRequest{}):
+		var cmd clusters.OperationalCredentialsCertificateChainRequestRequest
+		if err := fields.Unmarshal(&cmd); err != nil {
+			return nil, err
+		}
+		cert := d.dac
+		if cmd.CertificateType == clusters.OperationalCredentialsCertificateChainTypeEnumPAICertificate {
+			cert = []byte("pai")
+		}
+		resp = clusters.OperationalCredentialsCertificateChainResponse{Certificate: cert}
+	case keyOf(clusters.OperationalCredentialsAttestationRequestRequest{}):
+		var cmd clusters.OperationalCredentialsAttestationRequestRequest
+		if err := fields.Unmarshal(&cmd); err != nil {
+			return nil, err
+		}
+		sig, err := credentials.Sign(rand.Reader, d.dacKey, append(bytes.Clone(cmd.AttestationNonce), challenge...))
+		if err != nil {
+			return nil, err
+		}
+		resp = clusters.OperationalCredentialsAttestationResponse{AttestationElements: cmd.AttestationNonce, AttestationSignature: sig}
+	case keyOf(clusters.OperationalCredentialsCSRRequestRequest{}):
+		var cmd clusters.OperationalCredentialsCSRRequestRequest
+		if err := fields.Unmarshal(&cmd); err != nil {
+			return nil, err
+		}
+		if d.opKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, err
+		}
+		elements, err := credentials.NewNOCSRElements(rand.Reader, d.opKey, cmd.CSRNonce)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := credentials.Sign(rand.Reader, d.dacKey, append(bytes.Clone(elements), challenge...))
+		if err != nil {
+			return nil, err
+		}
+		resp = clusters.OperationalCredentialsCSRResponse{NOCSRElements: elements, AttestationSignature: sig}
+	case keyOf(clusters.OperationalCredentialsAddTrustedRootCertificateRequest{}):
+		var cmd clusters.OperationalCredentialsAddTrustedRootCertificateRequest
+		if err := fields.Unmarshal(&cmd); err != nil {
+			return nil, err
+		}
+		if d.rcac, err = credentials.DecodeCertificate(cmd.RootCACertificate); err != nil {
+			return nil, err
+		}
+		return im.NewInvokeStatusResponse(path, im.StatusSuccess), nil
+	case keyOf(clusters.OperationalCredentialsAddNOCRequest{}):
+		var cmd clusters.OperationalCredentialsAddNOCRequest
+		if err := fields.Unmarshal(&cmd); err != nil {
+			return nil, err
+		}
+		if err := d.addNOC(cmd); err != nil {
+			return nil, err
+		}
+		index := uint8(1)
+		resp = clusters.OperationalCredentialsNOCResponse{FabricIndex: &index}
+	case keyOf(clusters.GeneralCommissioningCommissioningCompleteRequest{}):
+		if !operational || !d.armed {
+			return nil, fmt.Errorf("CommissioningComplete over PASE %t, armed %t", !operational, d.armed)
+		}
+		d.armed, d.commissioned = false, true
+		select {
+		case d.commissions <- d.fabric.NodeID:
+		default:
+		}
+		resp = clusters.GeneralCommissioningCommissioningCompleteResponse{}
+	default:
+		return im.NewInvokeStatusResponse(path, im.StatusUnsupportedCluster), nil
+	}
+	return im.NewInvokeResponse(path, resp)
+}
+
+// addNOC installs the NOC and starts accepting CASE sessions.
+func (d *fakeDevice) addNOC(cmd clusters.OperationalCredentialsAddNOCRequest) error {
+	if d.rcac == nil || d.opKey == nil {
+		return errors.New("AddNOC before AddTrustedRootCertificate and CSRRequest")
+	}
+	noc, err := credentials.VerifyNOC(d.rcac, cmd.NOCValue, cmd.ICACValue, time.Now())
+	if err != nil {
+		return err
+	}
+	nodeID, _ := noc.Subject.Value(credentials.DNNodeID)
+	fabricID, _ := noc.Subject.Value(credentials.DNFabricID)
+	d.fabric = &credentials.Fabric{
+		FabricID: fabricID,
+		RCAC:     d.rcac,
+		NodeID:   nodeID,
+		NOC:      noc,
+		Key:      d.opKey,
+		EpochKey: cmd.IPKValue,
+	}
+	if !d.serving {
+		d.serving = true
+		go d.serveOperational(d.fabric)
+	}
+	return nil
+}
+
+// sessions returns the number of PASE sessions established.
+func (d *fakeDevice) sessions() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.paseSessions
+}
+
+// failSafeArmed reports whether the fail-safe timer runs.
+func (d *fakeDevice) failSafeArmed() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.armed
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// openMessage positions a reader inside the top-level anonymous structure of
+// an Interaction Model message.
+func openMessage(b []byte) (*tlv.Reader, error) {
+	r := tlv.NewReader(b)
+	if !r.Next() {
+		if err := r.Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+		return nil, fmt.Errorf("%w: empty message", ErrMalformed)
+	}
+	if r.Element().Type() != tlv.ETStructure {
+		return nil, fmt.Errorf("%w: message is not a structure", ErrMalformed)
+	}
+	if err := r.EnterContainer(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	return r, nil
+}
+
+// closeMessage leaves the top-level structure and rejects trailing data.
+func closeMessage(r *tlv.Reader) error {
+	if err := r.ExitContainer(); err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	if r.Next() {
+		return fmt.Errorf("%w: trailing top-level element", ErrMalformed)
+	}
+	if err := r.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	return nil
+}
+
+// enter descends into the current element, which must be a container.
+func enter(r *tlv.Reader) error {
+	if err := r.EnterContainer(); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrMalformed, r.PathString(), err)
+	}
+	return nil
+}
+
+// exit returns from the current container, surfacing decoding errors.
+func exit(r *tlv.Reader) error {
+	if err := r.ExitContainer(); err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	return nil
+}
+
+// readUint returns the current element as an unsigned integer narrowed to T.
+func readUint[T uint8 | uint16 | uint32 | uint64](r *tlv.Reader) (T, error) {
+	v, ok := r.Element().Unsigned()
+	if !ok {
+		return 0, fmt.Errorf("%w: %s is not an unsigned integer", ErrMalformed, r.PathString())
+	}
+	var limit uint64
+	switch any(T(0)).(type) {
+	case uint8:
+		limit = math.MaxUint8
+	case uint16:
+		limit = math.MaxUint16
+	case uint32:
+		limit = math.MaxUint32
+	default:
+		limit = math.MaxUint64
+	}
+	if v > limit {
+		return 0, fmt.Errorf("%w: %s value %d out of range", ErrMalformed, r.PathString(), v)
+	}
+	return T(v), nil
+}
+
+// readUintPtr is readUint returning a pointer, for optional fields.
+func readUintPtr[T uint8 | uint16 | uint32 | uint64](r *tlv.Reader) (*T, error) {
+	v, err := readUint[T](r)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// readBool returns the current element as a boolean.
+func readBool(r *tlv.Reader) (bool, error) {
+	v, ok := r.Element().Bool()
+	if !ok {
+		return false, fmt.Errorf("%w: %s is not a boolean", ErrMalformed, r.PathString())
+	}
+	return v, nil
+}
+
+// readRaw re-encodes the current element with an anonymous tag, suitable for
+// handing a Data field to cluster-specific decoders.
+func readRaw(r *tlv.Reader) ([]byte, error) {
+	enc := tlv.NewEncoder()
+	if err := r.Copy(enc, tlv.AnonymousTag()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	return enc.Bytes(), nil
+}
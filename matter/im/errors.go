@@ -0,0 +1,94 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// ErrMalformed is returned when an Interaction Model message cannot be decoded.
+var ErrMalformed = errors.New("im: malformed message")
+
+// Status is an Interaction Model status code.
+// Reference: Matter Core Spec 1.5, Section 8.10 (Status Code Table)
+type Status uint8
+
+const (
+	StatusSuccess              Status = 0x00
+	StatusFailure              Status = 0x01
+	StatusInvalidSubscription  Status = 0x7D
+	StatusUnsupportedAccess    Status = 0x7E
+	StatusUnsupportedEndpoint  Status = 0x7F
+	StatusUnsupportedAttribute Status = 0x86
+	StatusResourceExhausted    Status = 0x89
+	StatusBusy                 Status = 0x9C
+	StatusUnsupportedCluster   Status = 0xC3
+	StatusUnsupportedEvent     Status = 0xC7
+)
+
+// StatusError reports a non-success StatusIB returned for a path.
+type StatusError struct {
+	Path          string
+	Status        Status
+	ClusterStatus *uint8
+}
+
+// Error implements error.
+func (e *StatusError) Error() string {
+	if e.ClusterStatus != nil {
+		return fmt.Sprintf("im: status 0x%02X (cluster status 0x%02X) for %s", uint8(e.Status), *e.ClusterStatus, e.Path)
+	}
+	return fmt.Sprintf("im: status 0x%02X for %s", uint8(e.Status), e.Path)
+}
+
+// StatusIB context tags.
+const (
+	statusTagStatus        = 0
+	statusTagClusterStatus = 1
+)
+
+// decodeStatus parses the StatusIB structure at the reader position.
+func decodeStatus(r *tlv.Reader) (*StatusError, error) {
+	if err := enter(r); err != nil {
+		return nil, err
+	}
+	var status, clusterStatus *uint8
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		var err error
+		switch num {
+		case statusTagStatus:
+			status, err = readUintPtr[uint8](r)
+		case statusTagClusterStatus:
+			clusterStatus, err = readUintPtr[uint8](r)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := exit(r); err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, fmt.Errorf("%w: status missing code", ErrMalformed)
+	}
+	return &StatusError{Status: Status(*status), ClusterStatus: clusterStatus}, nil
+}
@@ -0,0 +1,241 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// EventPriority is the priority level an event was recorded with.
+// Reference: Matter Core Spec 1.5, Section 7.14.2.1 (Priority Levels)
+type EventPriority uint8
+
+const (
+	// EventPriorityDebug is used for diagnostic events.
+	EventPriorityDebug EventPriority = 0
+	// EventPriorityInfo is used for normal operational events.
+	EventPriorityInfo EventPriority = 1
+	// EventPriorityCritical is used for events that must not be lost.
+	EventPriorityCritical EventPriority = 2
+)
+
+// String returns the priority name.
+func (p EventPriority) String() string {
+	switch p {
+	case EventPriorityDebug:
+		return "debug"
+	case EventPriorityInfo:
+		return "info"
+	case EventPriorityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("priority(%d)", uint8(p))
+	}
+}
+
+// EventFilter restricts reported events to those with an event number of at
+// least EventMin (EventFilterIB).
+// Reference: Matter Core Spec 1.5, Section 10.6.6 (EventFilterIB)
+type EventFilter struct {
+	// Node optionally scopes the filter to a node; nil applies to all.
+	Node     *uint64
+	EventMin uint64
+}
+
+// EventFilterIB context tags.
+const (
+	eventFilterTagNode     = 0
+	eventFilterTagEventMin = 1
+)
+
+// encode writes the filter as an EventFilterIB structure under tag.
+func (f EventFilter) encode(enc tlv.Encoder, tag tlv.Tag) error {
+	enc.StartStructure(tag)
+	if f.Node != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(eventFilterTagNode), *f.Node); err != nil {
+			return err
+		}
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(eventFilterTagEventMin), f.EventMin); err != nil {
+		return err
+	}
+	return enc.EndContainer()
+}
+
+// EventData is a single event record reported by a node (EventDataIB).
+// Reference: Matter Core Spec 1.5, Section 10.6.9 (EventDataIB)
+// Delta timestamps are resolved against the preceding event in the same
+// report, so EpochTimestamp and SystemTimestamp are always absolute.
+type EventData struct {
+	Path        EventPath
+	EventNumber uint64
+	Priority    EventPriority
+	// EpochTimestamp is milliseconds since the Unix epoch (0 if the node
+	// reported a system timestamp instead).
+	EpochTimestamp uint64
+	// SystemTimestamp is milliseconds since node boot (0 if the node reported
+	// an epoch timestamp instead).
+	SystemTimestamp uint64
+	// Data is the cluster-specific event payload encoded as TLV with an
+	// anonymous tag (nil if the node omitted it).
+	Data []byte
+}
+
+// EventDataIB context tags.
+const (
+	eventDataTagPath                 = 0
+	eventDataTagEventNumber          = 1
+	eventDataTagPriority             = 2
+	eventDataTagEpochTimestamp       = 3
+	eventDataTagSystemTimestamp      = 4
+	eventDataTagDeltaEpochTimestamp  = 5
+	eventDataTagDeltaSystemTimestamp = 6
+	eventDataTagData                 = 7
+)
+
+// Time returns the wall clock time of the event if the node reported an
+// epoch timestamp.
+func (e EventData) Time() (time.Time, bool) {
+	if e.EpochTimestamp == 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(int64(e.EpochTimestamp)).UTC(), true
+}
+
+// eventTimestamps tracks the last absolute timestamps in a report so
+// delta-encoded timestamps can be resolved.
+type eventTimestamps struct {
+	epoch  uint64
+	system uint64
+}
+
+// encode writes the event as an EventDataIB structure under tag, with an
+// epoch timestamp if it has one and a system timestamp otherwise.
+func (e EventData) encode(enc tlv.Encoder, tag tlv.Tag) error {
+	enc.StartStructure(tag)
+	if err := e.Path.encode(enc, tlv.ContextTag(eventDataTagPath)); err != nil {
+		return err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(eventDataTagEventNumber), e.EventNumber); err != nil {
+		return err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(eventDataTagPriority), uint64(e.Priority)); err != nil {
+		return err
+	}
+	timestamp := tlv.ContextTag(eventDataTagEpochTimestamp)
+	ms := e.EpochTimestamp
+	if ms == 0 {
+		timestamp, ms = tlv.ContextTag(eventDataTagSystemTimestamp), e.SystemTimestamp
+	}
+	if err := enc.PutUnsigned(timestamp, ms); err != nil {
+		return err
+	}
+	if e.Data != nil {
+		data := tlv.NewReader(e.Data)
+		if !data.Next() {
+			return fmt.Errorf("im: empty event data for %s: %w", e.Path, data.Err())
+		}
+		if err := data.Copy(enc, tlv.ContextTag(eventDataTagData)); err != nil {
+			return fmt.Errorf("im: event data for %s: %w", e.Path, err)
+		}
+	}
+	return enc.EndContainer()
+}
+
+// decodeEventData parses the EventDataIB structure at the reader position.
+func decodeEventData(r *tlv.Reader, ts *eventTimestamps) (EventData, error) {
+	var ev EventData
+	if r.Element().Type() != tlv.ETStructure {
+		return ev, fmt.Errorf("%w: event data is not a structure", ErrMalformed)
+	}
+	if err := enter(r); err != nil {
+		return ev, err
+	}
+	var path *EventPath
+	var number *uint64
+	var priority *uint8
+	var epoch, system, deltaEpoch, deltaSystem *uint64
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		var err error
+		switch num {
+		case eventDataTagPath:
+			var p EventPath
+			p, err = decodeEventPath(r)
+			path = &p
+		case eventDataTagEventNumber:
+			number, err = readUintPtr[uint64](r)
+		case eventDataTagPriority:
+			priority, err = readUintPtr[uint8](r)
+		case eventDataTagEpochTimestamp:
+			epoch, err = readUintPtr[uint64](r)
+		case eventDataTagSystemTimestamp:
+			system, err = readUintPtr[uint64](r)
+		case eventDataTagDeltaEpochTimestamp:
+			deltaEpoch, err = readUintPtr[uint64](r)
+		case eventDataTagDeltaSystemTimestamp:
+			deltaSystem, err = readUintPtr[uint64](r)
+		case eventDataTagData:
+			ev.Data, err = readRaw(r)
+		}
+		if err != nil {
+			return ev, err
+		}
+	}
+	if err := exit(r); err != nil {
+		return ev, err
+	}
+
+	if path == nil {
+		return ev, fmt.Errorf("%w: event data missing path", ErrMalformed)
+	}
+	if number == nil {
+		return ev, fmt.Errorf("%w: event data missing event number", ErrMalformed)
+	}
+	if priority == nil {
+		return ev, fmt.Errorf("%w: event data missing priority", ErrMalformed)
+	}
+	ev.Path = *path
+	ev.EventNumber = *number
+	ev.Priority = EventPriority(*priority)
+
+	switch {
+	case epoch != nil:
+		ts.epoch, ts.system = *epoch, 0
+		ev.EpochTimestamp = *epoch
+	case system != nil:
+		ts.epoch, ts.system = 0, *system
+		ev.SystemTimestamp = *system
+	case deltaEpoch != nil:
+		if ts.epoch == 0 {
+			return ev, fmt.Errorf("%w: delta epoch timestamp without a base", ErrMalformed)
+		}
+		ts.epoch += *deltaEpoch
+		ev.EpochTimestamp = ts.epoch
+	case deltaSystem != nil:
+		if ts.system == 0 {
+			return ev, fmt.Errorf("%w: delta system timestamp without a base", ErrMalformed)
+		}
+		ts.system += *deltaSystem
+		ev.SystemTimestamp = ts.system
+	}
+	return ev, nil
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+type eventPathView struct {
+	Node     *uint64 `tlv:"0"`
+	Endpoint *uint16 `tlv:"1"`
+	Cluster  *uint32 `tlv:"2"`
+	Event    *uint32 `tlv:"3"`
+}
+
+type eventFilterView struct {
+	EventMin uint64 `tlv:"1"`
+}
+
+type readRequestView struct {
+	Attributes     []eventPathView   `tlv:"0"`
+	Events         []eventPathView   `tlv:"1"`
+	Filters        []eventFilterView `tlv:"2"`
+	FabricFiltered bool              `tlv:"3"`
+	Revision       uint8             `tlv:"255"`
+}
+
+func TestReadRequestEncode(t *testing.T) {
+	req := &ReadRequest{
+		EventRequests:  []EventPath{NewClusterEventPath(1, 0x0101)},
+		EventFilters:   []EventFilter{{EventMin: 42}},
+		FabricFiltered: true,
+	}
+	b, err := req.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	var view readRequestView
+	if err := tlv.Unmarshal(b, &view); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if view.Attributes != nil {
+		t.Errorf("unexpected attribute requests")
+	}
+	if len(view.Events) != 1 {
+		t.Fatalf("event requests = %+v", view.Events)
+	}
+	path := view.Events[0]
+	if path.Node != nil || path.Event != nil || *path.Endpoint != 1 || *path.Cluster != 0x0101 {
+		t.Errorf("path = %+v", path)
+	}
+	if len(view.Filters) != 1 || view.Filters[0].EventMin != 42 {
+		t.Errorf("event filters = %+v", view.Filters)
+	}
+	if !view.FabricFiltered || view.Revision != InteractionModelRevision {
+		t.Errorf("view = %+v", view)
+	}
+}
+
+func TestSubscribeRequestIntervals(t *testing.T) {
+	req := &SubscribeRequest{MinIntervalFloor: 10 * time.Second, MaxIntervalCeil: 5 * time.Second}
+	if _, err := req.Encode(); err == nil {
+		t.Fatalf("expected error for max < min")
+	}
+	req.MaxIntervalCeil = time.Minute
+	b, err := req.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	var view struct {
+		MaxIntervalCeil uint16 `tlv:"2"`
+	}
+	if err := tlv.Unmarshal(b, &view); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if view.MaxIntervalCeil != 60 {
+		t.Errorf("max interval = %d", view.MaxIntervalCeil)
+	}
+}
+
+func encodeEventReport(t *testing.T, enc tlv.Encoder, number uint64, ts map[uint8]uint64, payload func()) {
+	t.Helper()
+	enc.StartStructure(tlv.AnonymousTag())
+	enc.StartStructure(tlv.ContextTag(eventReportTagData))
+	_ = NewEventPath(1, 0x0101, 0x02).encode(enc, tlv.ContextTag(eventDataTagPath))
+	_ = enc.PutUnsigned(tlv.ContextTag(eventDataTagEventNumber), number)
+	_ = enc.PutUnsigned(tlv.ContextTag(eventDataTagPriority), uint64(EventPriorityCritical))
+	for tag, v := range ts {
+		_ = enc.PutUnsigned(tlv.ContextTag(tag), v)
+	}
+	if payload != nil {
+		payload()
+	}
+	_ = enc.EndContainer()
+	_ = enc.EndContainer()
+}
+
+func TestDecodeReportDataEvents(t *testing.T) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	_ = enc.PutUnsigned(tlv.ContextTag(reportDataTagSubscriptionID), 7)
+	enc.StartArray(tlv.ContextTag(reportDataTagEventReports))
+	encodeEventReport(t, enc, 100, map[uint8]uint64{eventDataTagEpochTimestamp: 1700000000000}, func() {
+		enc.StartStructure(tlv.ContextTag(eventDataTagData))
+		_ = enc.PutUnsigned(tlv.ContextTag(0), 1)
+		enc.PutNull(tlv.ContextTag(2))
+		_ = enc.EndContainer()
+	})
+	encodeEventReport(t, enc, 101, map[uint8]uint64{eventDataTagDeltaEpochTimestamp: 1500}, nil)
+	// A status for an unsupported path.
+	enc.StartStructure(tlv.AnonymousTag())
+	enc.StartStructure(tlv.ContextTag(eventReportTagStatus))
+	_ = NewEventPath(2, 0x0101, 0x00).encode(enc, tlv.ContextTag(eventStatusTagPath))
+	enc.StartStructure(tlv.ContextTag(eventStatusTagStatus))
+	_ = enc.PutUnsigned(tlv.ContextTag(statusTagStatus), uint64(StatusUnsupportedEndpoint))
+	_ = enc.EndContainer()
+	_ = enc.EndContainer()
+	_ = enc.EndContainer()
+	_ = enc.EndContainer()
+	enc.PutBool(tlv.ContextTag(reportDataTagMoreChunkedMessages), true)
+	_ = enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision)
+	_ = enc.EndContainer()
+
+	r, err := DecodeReportData(enc.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeReportData: %v", err)
+	}
+	if r.SubscriptionID == nil || *r.SubscriptionID != 7 {
+		t.Errorf("SubscriptionID = %v", r.SubscriptionID)
+	}
+	if !r.MoreChunkedMessages {
+		t.Errorf("MoreChunkedMessages = false")
+	}
+	if len(r.Events) != 2 {
+		t.Fatalf("events = %d", len(r.Events))
+	}
+	first, second := r.Events[0], r.Events[1]
+	if first.EventNumber != 100 || first.Priority != EventPriorityCritical || *first.Path.Event != 0x02 {
+		t.Errorf("first = %+v", first)
+	}
+	if second.EpochTimestamp != 1700000001500 {
+		t.Errorf("delta epoch timestamp resolved to %d", second.EpochTimestamp)
+	}
+	if ts, ok := second.Time(); !ok || !ts.Equal(time.UnixMilli(1700000001500)) {
+		t.Errorf("Time() = %v, %v", ts, ok)
+	}
+
+	want := tlv.NewEncoder()
+	want.StartStructure(tlv.AnonymousTag())
+	_ = want.PutUnsigned(tlv.ContextTag(0), 1)
+	want.PutNull(tlv.ContextTag(2))
+	_ = want.EndContainer()
+	if !bytes.Equal(first.Data, want.Bytes()) {
+		t.Errorf("Data = %x, want %x", first.Data, want.Bytes())
+	}
+	if second.Data != nil {
+		t.Errorf("second Data = %x", second.Data)
+	}
+
+	if len(r.EventStatuses) != 1 || r.EventStatuses[0].Status != StatusUnsupportedEndpoint {
+		t.Fatalf("statuses = %+v", r.EventStatuses)
+	}
+}
+
+func TestDecodeReportDataMalformed(t *testing.T) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	enc.StartArray(tlv.ContextTag(reportDataTagEventReports))
+	encodeEventReport(t, enc, 5, map[uint8]uint64{eventDataTagDeltaSystemTimestamp: 10}, nil)
+	enc.MustEndAll()
+
+	if _, err := DecodeReportData(enc.Bytes()); !errors.Is(err, ErrMalformed) {
+		t.Fatalf("err = %v, want ErrMalformed", err)
+	}
+	if _, err := DecodeReportData([]byte{0x15}); !errors.Is(err, ErrMalformed) {
+		t.Fatalf("truncated err = %v, want ErrMalformed", err)
+	}
+}
+
+func TestDecodeSubscribeResponse(t *testing.T) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	_ = enc.PutUnsigned(tlv.ContextTag(subscribeResponseTagSubscriptionID), 0x1234)
+	_ = enc.PutUnsigned(tlv.ContextTag(subscribeResponseTagMaxInterval), 30)
+	_ = enc.EndContainer()
+
+	r, err := DecodeSubscribeResponse(enc.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeSubscribeResponse: %v", err)
+	}
+	if r.SubscriptionID != 0x1234 || r.MaxInterval != 30*time.Second {
+		t.Errorf("response = %+v", r)
+	}
+}
+
+func TestReportDataEncode(t *testing.T) {
+	id, version := uint32(3), uint32(9)
+	value, _ := tlv.Marshal(true)
+	payload, _ := tlv.Marshal(struct {
+		Operation uint8 `tlv:"0"`
+	}{Operation: 1})
+	report := &ReportData{
+		SubscriptionID: &id,
+		Attributes:     []AttributeData{{DataVersion: &version, Path: NewAttributePath(1, 0x0006, 0x0000), Data: value}},
+		Events: []EventData{
+			{Path: NewEventPath(1, 0x0101, 0x02), EventNumber: 7, Priority: EventPriorityCritical, EpochTimestamp: 1700000000000, Data: payload},
+			{Path: NewEventPath(1, 0x0101, 0x00), EventNumber: 8, Priority: EventPriorityInfo, SystemTimestamp: 5000},
+		},
+		MoreChunkedMessages: true,
+	}
+	b, err := report.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := DecodeReportData(b)
+	if err != nil {
+		t.Fatalf("DecodeReportData: %v", err)
+	}
+	if got.SubscriptionID == nil || *got.SubscriptionID != id || !got.MoreChunkedMessages || got.SuppressResponse {
+		t.Errorf("report = %+v", got)
+	}
+	if len(got.Attributes) != 1 || *got.Attributes[0].DataVersion != version || !bytes.Equal(got.Attributes[0].Data, value) {
+		t.Errorf("attributes = %+v", got.Attributes)
+	}
+	if len(got.Events) != 2 {
+		t.Fatalf("events = %+v", got.Events)
+	}
+	for i, want := range report.Events {
+		ev := got.Events[i]
+		if ev.Path.String() != want.Path.String() || ev.EventNumber != want.EventNumber || ev.Priority != want.Priority ||
+			ev.EpochTimestamp != want.EpochTimestamp || ev.SystemTimestamp != want.SystemTimestamp || !bytes.Equal(ev.Data, want.Data) {
+			t.Errorf("event %d = %+v, want %+v", i, ev, want)
+		}
+	}
+}
+
+func TestSubscribeResponseEncode(t *testing.T) {
+	b, err := (&SubscribeResponse{SubscriptionID: 0x1234, MaxInterval: time.Minute}).Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	r, err := DecodeSubscribeResponse(b)
+	if err != nil || r.SubscriptionID != 0x1234 || r.MaxInterval != time.Minute {
+		t.Errorf("DecodeSubscribeResponse = %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,497 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// Command is a cluster command payload, such as the request types generated
+// in the clusters package.
+type Command interface {
+	ClusterID() uint32
+	CommandID() uint32
+}
+
+// CommandPath identifies a command on an endpoint.
+// Reference: Matter Core Spec 1.5, Section 10.6.11 (CommandPathIB)
+type CommandPath struct {
+	Endpoint uint16
+	Cluster  uint32
+	Command  uint32
+}
+
+// CommandPathIB context tags.
+const (
+	commandPathTagEndpoint = 0
+	commandPathTagCluster  = 1
+	commandPathTagCommand  = 2
+)
+
+// String returns a human-readable representation of the path.
+func (p CommandPath) String() string {
+	return fmt.Sprintf("%d/0x%04X/0x%04X", p.Endpoint, p.Cluster, p.Command)
+}
+
+// encode writes the path as a CommandPathIB list under tag.
+func (p CommandPath) encode(enc tlv.Encoder, tag tlv.Tag) error {
+	enc.StartList(tag)
+	if err := enc.PutUnsigned(tlv.ContextTag(commandPathTagEndpoint), uint64(p.Endpoint)); err != nil {
+		return err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(commandPathTagCluster), uint64(p.Cluster)); err != nil {
+		return err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(commandPathTagCommand), uint64(p.Command)); err != nil {
+		return err
+	}
+	return enc.EndContainer()
+}
+
+// decodeCommandPath parses the CommandPathIB container at the reader position.
+func decodeCommandPath(r *tlv.Reader) (CommandPath, error) {
+	var p CommandPath
+	if err := enter(r); err != nil {
+		return p, err
+	}
+	var endpoint *uint16
+	var cluster, command *uint32
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		var err error
+		switch num {
+		case commandPathTagEndpoint:
+			endpoint, err = readUintPtr[uint16](r)
+		case commandPathTagCluster:
+			cluster, err = readUintPtr[uint32](r)
+		case commandPathTagCommand:
+			command, err = readUintPtr[uint32](r)
+		}
+		if err != nil {
+			return p, err
+		}
+	}
+	if err := exit(r); err != nil {
+		return p, err
+	}
+	if endpoint == nil || cluster == nil || command == nil {
+		return p, fmt.Errorf("%w: incomplete command path", ErrMalformed)
+	}
+	return CommandPath{Endpoint: *endpoint, Cluster: *cluster, Command: *command}, nil
+}
+
+// CommandData is a command invocation. Fields holds the TLV encoding of the
+// command fields with an anonymous tag, as produced by tlv.Marshal; nil
+// sends no fields.
+type CommandData struct {
+	Path   CommandPath
+	Fields []byte
+}
+
+// CommandDataIB context tags.
+const (
+	commandDataTagPath   = 0
+	commandDataTagFields = 1
+)
+
+// InvokeRequest is the InvokeRequestMessage payload.
+// Reference: Matter Core Spec 1.5, Section 10.7.9 (Invoke Request Message)
+type InvokeRequest struct {
+	SuppressResponse bool
+	TimedRequest     bool
+	Commands         []CommandData
+}
+
+// InvokeRequestMessage context tags.
+const (
+	invokeRequestTagSuppressResponse = 0
+	invokeRequestTagTimedRequest     = 1
+	invokeRequestTagInvokeRequests   = 2
+)
+
+// NewInvokeRequest returns a request invoking cmd on endpoint.
+func NewInvokeRequest(endpoint uint16, cmd Command) (*InvokeRequest, error) {
+	fields, err := tlv.Marshal(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("im: encode command fields: %w", err)
+	}
+	return &InvokeRequest{Commands: []CommandData{{
+		Path:   CommandPath{Endpoint: endpoint, Cluster: cmd.ClusterID(), Command: cmd.CommandID()},
+		Fields: fields,
+	}}}, nil
+}
+
+// Encode returns the TLV encoding of the request.
+func (r *InvokeRequest) Encode() ([]byte, error) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	enc.PutBool(tlv.ContextTag(invokeRequestTagSuppressResponse), r.SuppressResponse)
+	enc.PutBool(tlv.ContextTag(invokeRequestTagTimedRequest), r.TimedRequest)
+	enc.StartArray(tlv.ContextTag(invokeRequestTagInvokeRequests))
+	for _, cmd := range r.Commands {
+		enc.StartStructure(tlv.AnonymousTag())
+		if err := cmd.Path.encode(enc, tlv.ContextTag(commandDataTagPath)); err != nil {
+			return nil, err
+		}
+		if cmd.Fields != nil {
+			fields := tlv.NewReader(cmd.Fields)
+			if !fields.Next() {
+				return nil, fmt.Errorf("im: empty command fields for %s: %w", cmd.Path, fields.Err())
+			}
+			if err := fields.Copy(enc, tlv.ContextTag(commandDataTagFields)); err != nil {
+				return nil, fmt.Errorf("im: command fields for %s: %w", cmd.Path, err)
+			}
+		}
+		if err := enc.EndContainer(); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision); err != nil {
+		return nil, err
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// DecodeInvokeRequest parses an InvokeRequestMessage payload, as received
+// by a device.
+func DecodeInvokeRequest(b []byte) (*InvokeRequest, error) {
+	r, err := openMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	req := &InvokeRequest{}
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		switch num {
+		case invokeRequestTagSuppressResponse:
+			req.SuppressResponse, err = readBool(r)
+		case invokeRequestTagTimedRequest:
+			req.TimedRequest, err = readBool(r)
+		case invokeRequestTagInvokeRequests:
+			err = decodeReports(r, func(r *tlv.Reader) error {
+				cmd, err := decodeCommandData(r)
+				if err != nil {
+					return err
+				}
+				req.Commands = append(req.Commands, CommandData{Path: cmd.Path, Fields: cmd.Fields})
+				return nil
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := closeMessage(r); err != nil {
+		return nil, err
+	}
+	if len(req.Commands) == 0 {
+		return nil, fmt.Errorf("%w: invoke request without commands", ErrMalformed)
+	}
+	return req, nil
+}
+
+// InvokeResult is the outcome of one invoked command: either response
+// command fields or a status.
+type InvokeResult struct {
+	Path CommandPath
+	// Fields is the TLV encoding of the response command fields with an
+	// anonymous tag, set when the device answered with a response command.
+	Fields []byte
+	// Status is set when the device answered with a status, which may be
+	// StatusSuccess.
+	Status *StatusError
+}
+
+// Value returns the response command fields. A success status returns the
+// zero Value and a failure status is returned as the error.
+func (res InvokeResult) Value() (Value, error) {
+	if res.Status != nil {
+		if res.Status.Status != StatusSuccess {
+			return Value{}, res.Status
+		}
+		return Value{}, nil
+	}
+	return NewValue(res.Fields)
+}
+
+// InvokeResponse is the InvokeResponseMessage payload.
+// Reference: Matter Core Spec 1.5, Section 10.7.10 (Invoke Response Message)
+type InvokeResponse struct {
+	SuppressResponse    bool
+	Results             []InvokeResult
+	MoreChunkedMessages bool
+}
+
+// InvokeResponseMessage and InvokeResponseIB / CommandStatusIB context tags.
+const (
+	invokeResponseTagSuppressResponse    = 0
+	invokeResponseTagInvokeResponses     = 1
+	invokeResponseTagMoreChunkedMessages = 2
+	invokeResultTagCommand               = 0
+	invokeResultTagStatus                = 1
+	commandStatusTagPath                 = 0
+	commandStatusTagStatus               = 1
+)
+
+// DecodeInvokeResponse parses an InvokeResponseMessage payload.
+func DecodeInvokeResponse(b []byte) (*InvokeResponse, error) {
+	r, err := openMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	resp := &InvokeResponse{}
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		switch num {
+		case invokeResponseTagSuppressResponse:
+			resp.SuppressResponse, err = readBool(r)
+		case invokeResponseTagInvokeResponses:
+			err = decodeReports(r, func(r *tlv.Reader) error {
+				res, err := decodeInvokeResult(r)
+				if err != nil {
+					return err
+				}
+				resp.Results = append(resp.Results, res)
+				return nil
+			})
+		case invokeResponseTagMoreChunkedMessages:
+			resp.MoreChunkedMessages, err = readBool(r)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := closeMessage(r); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// NewInvokeResponse returns a response answering the command invoked at
+// path with the response command cmd.
+func NewInvokeResponse(path CommandPath, cmd Command) (*InvokeResponse, error) {
+	fields, err := tlv.Marshal(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("im: encode response fields: %w", err)
+	}
+	return &InvokeResponse{Results: []InvokeResult{{
+		Path:   CommandPath{Endpoint: path.Endpoint, Cluster: cmd.ClusterID(), Command: cmd.CommandID()},
+		Fields: fields,
+	}}}, nil
+}
+
+// NewInvokeStatusResponse returns a response answering the command invoked
+// at path with status.
+func NewInvokeStatusResponse(path CommandPath, status Status) *InvokeResponse {
+	return &InvokeResponse{Results: []InvokeResult{{Path: path, Status: &StatusError{Status: status}}}}
+}
+
+// Encode returns the TLV encoding of the response.
+func (resp *InvokeResponse) Encode() ([]byte, error) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	enc.PutBool(tlv.ContextTag(invokeResponseTagSuppressResponse), resp.SuppressResponse)
+	enc.StartArray(tlv.ContextTag(invokeResponseTagInvokeResponses))
+	for _, res := range resp.Results {
+		enc.StartStructure(tlv.AnonymousTag())
+		if err := res.encode(enc); err != nil {
+			return nil, err
+		}
+		if err := enc.EndContainer(); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	if resp.MoreChunkedMessages {
+		enc.PutBool(tlv.ContextTag(invokeResponseTagMoreChunkedMessages), true)
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision); err != nil {
+		return nil, err
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// encode writes the CommandDataIB or CommandStatusIB of an InvokeResponseIB.
+func (res InvokeResult) encode(enc tlv.Encoder) error {
+	if res.Status == nil {
+		enc.StartStructure(tlv.ContextTag(invokeResultTagCommand))
+		if err := res.Path.encode(enc, tlv.ContextTag(commandDataTagPath)); err != nil {
+			return err
+		}
+		fields := tlv.NewReader(res.Fields)
+		if !fields.Next() {
+			return fmt.Errorf("im: empty response fields for %s: %w", res.Path, fields.Err())
+		}
+		if err := fields.Copy(enc, tlv.ContextTag(commandDataTagFields)); err != nil {
+			return fmt.Errorf("im: response fields for %s: %w", res.Path, err)
+		}
+		return enc.EndContainer()
+	}
+	enc.StartStructure(tlv.ContextTag(invokeResultTagStatus))
+	if err := res.Path.encode(enc, tlv.ContextTag(commandStatusTagPath)); err != nil {
+		return err
+	}
+	enc.StartStructure(tlv.ContextTag(commandStatusTagStatus))
+	if err := enc.PutUnsigned(tlv.ContextTag(statusTagStatus), uint64(res.Status.Status)); err != nil {
+		return err
+	}
+	if res.Status.ClusterStatus != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(statusTagClusterStatus), uint64(*res.Status.ClusterStatus)); err != nil {
+			return err
+		}
+	}
+	if err := enc.EndContainer(); err != nil {
+		return err
+	}
+	return enc.EndContainer()
+}
+
+// Result returns the result for path.
+func (resp *InvokeResponse) Result(path CommandPath) (InvokeResult, error) {
+	for _, res := range resp.Results {
+		if res.Path.Endpoint == path.Endpoint && res.Path.Cluster == path.Cluster &&
+			(res.Status == nil || res.Path.Command == path.Command) {
+			return res, nil
+		}
+	}
+	return InvokeResult{}, fmt.Errorf("%w: no invoke response for %s", ErrMalformed, path)
+}
+
+// decodeInvokeResult parses the InvokeResponseIB at the reader position.
+func decodeInvokeResult(r *tlv.Reader) (InvokeResult, error) {
+	var res InvokeResult
+	if err := enter(r); err != nil {
+		return res, err
+	}
+	var found bool
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		var err error
+		switch num {
+		case invokeResultTagCommand:
+			res, err = decodeCommandData(r)
+			found = true
+		case invokeResultTagStatus:
+			res.Status, err = decodePathStatus(r, commandStatusTagPath, commandStatusTagStatus, func(r *tlv.Reader) (string, error) {
+				var err error
+				res.Path, err = decodeCommandPath(r)
+				return res.Path.String(), err
+			})
+			found = true
+		}
+		if err != nil {
+			return res, err
+		}
+	}
+	if err := exit(r); err != nil {
+		return res, err
+	}
+	if !found {
+		return res, fmt.Errorf("%w: invoke response has neither command nor status", ErrMalformed)
+	}
+	return res, nil
+}
+
+// decodeCommandData parses the CommandDataIB at the reader position.
+func decodeCommandData(r *tlv.Reader) (InvokeResult, error) {
+	var res InvokeResult
+	if err := enter(r); err != nil {
+		return res, err
+	}
+	var hasPath bool
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		var err error
+		switch num {
+		case commandDataTagPath:
+			res.Path, err = decodeCommandPath(r)
+			hasPath = true
+		case commandDataTagFields:
+			res.Fields, err = readRaw(r)
+		}
+		if err != nil {
+			return res, err
+		}
+	}
+	if err := exit(r); err != nil {
+		return res, err
+	}
+	if !hasPath {
+		return res, fmt.Errorf("%w: command data missing path", ErrMalformed)
+	}
+	if res.Fields == nil {
+		res.Fields = emptyStructure
+	}
+	return res, nil
+}
+
+// emptyStructure is the encoding of a command without fields.
+var emptyStructure = []byte{0x15, 0x18}
+
+// StatusResponseMessage context tags.
+const statusResponseTagStatus = 0
+
+// DecodeStatusResponse parses a StatusResponseMessage payload and returns
+// its status, which may be StatusSuccess.
+// Reference: Matter Core Spec 1.5, Section 10.7.1 (Status Response Message)
+func DecodeStatusResponse(b []byte) (*StatusError, error) {
+	r, err := openMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	var status *uint8
+	for r.Next() {
+		if num, ok := tlv.ContextTagNumber(r.Tag()); ok && num == statusResponseTagStatus {
+			if status, err = readUintPtr[uint8](r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := closeMessage(r); err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, fmt.Errorf("%w: status response missing status", ErrMalformed)
+	}
+	return &StatusError{Status: Status(*status)}, nil
+}
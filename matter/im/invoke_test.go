@@ -0,0 +1,244 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+type testCommand struct {
+	Expiry     uint16 `tlv:"0"`
+	Breadcrumb uint64 `tlv:"1"`
+}
+
+func (testCommand) ClusterID() uint32 { return 0x0030 }
+func (testCommand) CommandID() uint32 { return 0x00 }
+
+type commandPathView struct {
+	Endpoint uint16 `tlv:"0"`
+	Cluster  uint32 `tlv:"1"`
+	Command  uint32 `tlv:"2"`
+}
+
+type invokeRequestView struct {
+	SuppressResponse bool `tlv:"0"`
+	TimedRequest     bool `tlv:"1"`
+	Requests         []struct {
+		Path   commandPathView `tlv:"0,list"`
+		Fields testCommand     `tlv:"1"`
+	} `tlv:"2"`
+	Revision uint8 `tlv:"255"`
+}
+
+func TestInvokeRequestEncode(t *testing.T) {
+	req, err := NewInvokeRequest(0, testCommand{Expiry: 60, Breadcrumb: 2})
+	if err != nil {
+		t.Fatalf("NewInvokeRequest: %v", err)
+	}
+	b, err := req.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	var view invokeRequestView
+	if err := tlv.Unmarshal(b, &view); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(view.Requests) != 1 {
+		t.Fatalf("requests = %+v", view.Requests)
+	}
+	cmd := view.Requests[0]
+	if cmd.Path != (commandPathView{Endpoint: 0, Cluster: 0x0030, Command: 0}) || cmd.Fields != (testCommand{Expiry: 60, Breadcrumb: 2}) {
+		t.Errorf("request = %+v", cmd)
+	}
+	if view.SuppressResponse || view.TimedRequest || view.Revision != InteractionModelRevision {
+		t.Errorf("view = %+v", view)
+	}
+}
+
+// invokeResponse builds an InvokeResponseMessage with one result.
+func invokeResponse(t *testing.T, path CommandPath, fields []byte, status *Status) []byte {
+	t.Helper()
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	enc.PutBool(tlv.ContextTag(invokeResponseTagSuppressResponse), false)
+	enc.StartArray(tlv.ContextTag(invokeResponseTagInvokeResponses))
+	enc.StartStructure(tlv.AnonymousTag())
+	if status == nil {
+		enc.StartStructure(tlv.ContextTag(invokeResultTagCommand))
+		if err := path.encode(enc, tlv.ContextTag(commandDataTagPath)); err != nil {
+			t.Fatal(err)
+		}
+		r := tlv.NewReader(fields)
+		r.Next()
+		if err := r.Copy(enc, tlv.ContextTag(commandDataTagFields)); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		enc.StartStructure(tlv.ContextTag(invokeResultTagStatus))
+		if err := path.encode(enc, tlv.ContextTag(commandStatusTagPath)); err != nil {
+			t.Fatal(err)
+		}
+		enc.StartStructure(tlv.ContextTag(commandStatusTagStatus))
+		if err := enc.PutUnsigned(tlv.ContextTag(statusTagStatus), uint64(*status)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	enc.MustEndAll()
+	return enc.Bytes()
+}
+
+func TestDecodeInvokeResponse(t *testing.T) {
+	request := CommandPath{Endpoint: 0, Cluster: 0x0030, Command: 0x00}
+	fields, _ := tlv.Marshal(struct {
+		ErrorCode uint8  `tlv:"0"`
+		DebugText string `tlv:"1"`
+	}{ErrorCode: 0, DebugText: "ok"})
+
+	resp, err := DecodeInvokeResponse(invokeResponse(t, CommandPath{Endpoint: 0, Cluster: 0x0030, Command: 0x01}, fields, nil))
+	if err != nil {
+		t.Fatalf("DecodeInvokeResponse: %v", err)
+	}
+	res, err := resp.Result(request)
+	if err != nil {
+		t.Fatalf("Result: %v", err)
+	}
+	v, err := res.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	var decoded struct {
+		ErrorCode uint8  `tlv:"0"`
+		DebugText string `tlv:"1"`
+	}
+	if err := v.Unmarshal(&decoded); err != nil || decoded.DebugText != "ok" {
+		t.Errorf("fields = %+v, %v", decoded, err)
+	}
+
+	success := StatusSuccess
+	resp, err = DecodeInvokeResponse(invokeResponse(t, request, nil, &success))
+	if err != nil {
+		t.Fatalf("DecodeInvokeResponse(status): %v", err)
+	}
+	if res, err := resp.Result(request); err != nil {
+		t.Fatalf("Result: %v", err)
+	} else if v, err := res.Value(); err != nil || !v.IsNull() {
+		t.Errorf("success Value = %v, %v", v, err)
+	}
+
+	failure := StatusUnsupportedCluster
+	resp, err = DecodeInvokeResponse(invokeResponse(t, request, nil, &failure))
+	if err != nil {
+		t.Fatalf("DecodeInvokeResponse(failure): %v", err)
+	}
+	res, _ = resp.Result(request)
+	var statusErr *StatusError
+	if _, err := res.Value(); !errors.As(err, &statusErr) || statusErr.Status != StatusUnsupportedCluster || statusErr.Path != request.String() {
+		t.Errorf("failure Value = %v", err)
+	}
+
+	if _, err := resp.Result(CommandPath{Endpoint: 1, Cluster: 0x0030}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Result(other path) = %v", err)
+	}
+}
+
+func TestDecodeStatusResponse(t *testing.T) {
+	b, _ := tlv.Marshal(struct {
+		Status   uint8 `tlv:"0"`
+		Revision uint8 `tlv:"255"`
+	}{Status: uint8(StatusBusy), Revision: InteractionModelRevision})
+	status, err := DecodeStatusResponse(b)
+	if err != nil || status.Status != StatusBusy {
+		t.Fatalf("DecodeStatusResponse = %v, %v", status, err)
+	}
+	if _, err := DecodeStatusResponse([]byte{0x15, 0x18}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("DecodeStatusResponse(empty) = %v", err)
+	}
+}
+
+func TestDecodeInvokeRequest(t *testing.T) {
+	req, err := NewInvokeRequest(1, testCommand{Expiry: 60, Breadcrumb: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.TimedRequest = true
+	b, err := req.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeInvokeRequest(b)
+	if err != nil {
+		t.Fatalf("DecodeInvokeRequest: %v", err)
+	}
+	if !decoded.TimedRequest || len(decoded.Commands) != 1 || decoded.Commands[0].Path != req.Commands[0].Path {
+		t.Fatalf("request = %+v", decoded)
+	}
+	v, err := NewValue(decoded.Commands[0].Fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cmd testCommand
+	if err := v.Unmarshal(&cmd); err != nil || cmd != (testCommand{Expiry: 60, Breadcrumb: 2}) {
+		t.Errorf("fields = %+v, %v", cmd, err)
+	}
+	if _, err := DecodeInvokeRequest([]byte{0x15, 0x18}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("DecodeInvokeRequest(empty) = %v", err)
+	}
+}
+
+func TestInvokeResponseEncode(t *testing.T) {
+	request := CommandPath{Endpoint: 0, Cluster: 0x0030, Command: 0x00}
+	resp, err := NewInvokeResponse(request, testCommand{Expiry: 1, Breadcrumb: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	busy := StatusBusy
+	tests := []struct {
+		name   string
+		resp   *InvokeResponse
+		status *Status
+	}{
+		{name: "command", resp: resp},
+		{name: "status", resp: NewInvokeStatusResponse(request, StatusBusy), status: &busy},
+	}
+	for _, tt := range tests {
+		b, err := tt.resp.Encode()
+		if err != nil {
+			t.Fatalf("%s: Encode: %v", tt.name, err)
+		}
+		decoded, err := DecodeInvokeResponse(b)
+		if err != nil {
+			t.Fatalf("%s: DecodeInvokeResponse: %v", tt.name, err)
+		}
+		res, err := decoded.Result(request)
+		if err != nil {
+			t.Fatalf("%s: Result: %v", tt.name, err)
+		}
+		v, err := res.Value()
+		if tt.status != nil {
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.Status != *tt.status {
+				t.Errorf("%s: Value = %v", tt.name, err)
+			}
+			continue
+		}
+		var cmd testCommand
+		if err != nil || v.Unmarshal(&cmd) != nil || cmd != (testCommand{Expiry: 1, Breadcrumb: 3}) {
+			t.Errorf("%s: fields = %+v, %v", tt.name, cmd, err)
+		}
+	}
+}
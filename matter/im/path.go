@@ -0,0 +1,215 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// EventPath identifies one or more events (EventPathIB).
+// Reference: Matter Core Spec 1.5, Section 10.6.8 (EventPathIB)
+// A nil field is a wildcard.
+type EventPath struct {
+	Node     *uint64
+	Endpoint *uint16
+	Cluster  *uint32
+	Event    *uint32
+	// IsUrgent requests that matching events are reported without waiting
+	// for the minimum reporting interval (subscriptions only).
+	IsUrgent bool
+}
+
+// EventPathIB context tags.
+const (
+	eventPathTagNode     = 0
+	eventPathTagEndpoint = 1
+	eventPathTagCluster  = 2
+	eventPathTagEvent    = 3
+	eventPathTagIsUrgent = 4
+)
+
+// NewEventPath returns a concrete path for a single event on an endpoint.
+func NewEventPath(endpoint uint16, cluster uint32, event uint32) EventPath {
+	return EventPath{Endpoint: &endpoint, Cluster: &cluster, Event: &event}
+}
+
+// NewClusterEventPath returns a path matching every event of a cluster on an endpoint.
+func NewClusterEventPath(endpoint uint16, cluster uint32) EventPath {
+	return EventPath{Endpoint: &endpoint, Cluster: &cluster}
+}
+
+// String returns a human-readable representation using '*' for wildcards.
+func (p EventPath) String() string {
+	return fmt.Sprintf("%s/%s/%s", optString(p.Endpoint), optHexString(p.Cluster), optHexString(p.Event))
+}
+
+// encode writes the path as an EventPathIB list under tag.
+func (p EventPath) encode(enc tlv.Encoder, tag tlv.Tag) error {
+	enc.StartList(tag)
+	if p.Node != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(eventPathTagNode), *p.Node); err != nil {
+			return err
+		}
+	}
+	if p.Endpoint != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(eventPathTagEndpoint), uint64(*p.Endpoint)); err != nil {
+			return err
+		}
+	}
+	if p.Cluster != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(eventPathTagCluster), uint64(*p.Cluster)); err != nil {
+			return err
+		}
+	}
+	if p.Event != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(eventPathTagEvent), uint64(*p.Event)); err != nil {
+			return err
+		}
+	}
+	if p.IsUrgent {
+		enc.PutBool(tlv.ContextTag(eventPathTagIsUrgent), true)
+	}
+	return enc.EndContainer()
+}
+
+// decodeEventPath parses the EventPathIB container at the reader position.
+func decodeEventPath(r *tlv.Reader) (EventPath, error) {
+	var p EventPath
+	if err := enter(r); err != nil {
+		return p, err
+	}
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		var err error
+		switch num {
+		case eventPathTagNode:
+			p.Node, err = readUintPtr[uint64](r)
+		case eventPathTagEndpoint:
+			p.Endpoint, err = readUintPtr[uint16](r)
+		case eventPathTagCluster:
+			p.Cluster, err = readUintPtr[uint32](r)
+		case eventPathTagEvent:
+			p.Event, err = readUintPtr[uint32](r)
+		case eventPathTagIsUrgent:
+			p.IsUrgent, err = readBool(r)
+		}
+		if err != nil {
+			return p, err
+		}
+	}
+	return p, exit(r)
+}
+
+// AttributePath identifies one or more attributes (AttributePathIB).
+// Reference: Matter Core Spec 1.5, Section 10.6.2 (AttributePathIB)
+// A nil field is a wildcard.
+type AttributePath struct {
+	Node      *uint64
+	Endpoint  *uint16
+	Cluster   *uint32
+	Attribute *uint32
+}
+
+// AttributePathIB context tags.
+const (
+	attributePathTagNode      = 1
+	attributePathTagEndpoint  = 2
+	attributePathTagCluster   = 3
+	attributePathTagAttribute = 4
+)
+
+// NewAttributePath returns a concrete path for a single attribute on an endpoint.
+func NewAttributePath(endpoint uint16, cluster uint32, attribute uint32) AttributePath {
+	return AttributePath{Endpoint: &endpoint, Cluster: &cluster, Attribute: &attribute}
+}
+
+// String returns a human-readable representation using '*' for wildcards.
+func (p AttributePath) String() string {
+	return fmt.Sprintf("%s/%s/%s", optString(p.Endpoint), optHexString(p.Cluster), optHexString(p.Attribute))
+}
+
+// encode writes the path as an AttributePathIB list under tag.
+func (p AttributePath) encode(enc tlv.Encoder, tag tlv.Tag) error {
+	enc.StartList(tag)
+	if p.Node != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(attributePathTagNode), *p.Node); err != nil {
+			return err
+		}
+	}
+	if p.Endpoint != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(attributePathTagEndpoint), uint64(*p.Endpoint)); err != nil {
+			return err
+		}
+	}
+	if p.Cluster != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(attributePathTagCluster), uint64(*p.Cluster)); err != nil {
+			return err
+		}
+	}
+	if p.Attribute != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(attributePathTagAttribute), uint64(*p.Attribute)); err != nil {
+			return err
+		}
+	}
+	return enc.EndContainer()
+}
+
+// decodeAttributePath parses the AttributePathIB container at the reader position.
+func decodeAttributePath(r *tlv.Reader) (AttributePath, error) {
+	var p AttributePath
+	if err := enter(r); err != nil {
+		return p, err
+	}
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		var err error
+		switch num {
+		case attributePathTagNode:
+			p.Node, err = readUintPtr[uint64](r)
+		case attributePathTagEndpoint:
+			p.Endpoint, err = readUintPtr[uint16](r)
+		case attributePathTagCluster:
+			p.Cluster, err = readUintPtr[uint32](r)
+		case attributePathTagAttribute:
+			p.Attribute, err = readUintPtr[uint32](r)
+		}
+		if err != nil {
+			return p, err
+		}
+	}
+	return p, exit(r)
+}
+
+func optString[T uint16 | uint32 | uint64](v *T) string {
+	if v == nil {
+		return "*"
+	}
+	return fmt.Sprintf("%d", *v)
+}
+
+func optHexString[T uint16 | uint32 | uint64](v *T) string {
+	if v == nil {
+		return "*"
+	}
+	return fmt.Sprintf("0x%04X", *v)
+}
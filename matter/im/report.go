@@ -0,0 +1,421 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// AttributeData is a single attribute value reported by a node (AttributeDataIB).
+type AttributeData struct {
+	DataVersion *uint32
+	Path        AttributePath
+	// Data is the attribute value encoded as TLV with an anonymous tag.
+	Data []byte
+}
+
+// ReportData is the ReportDataMessage payload.
+// Reference: Matter Core Spec 1.5, Section 10.7.3 (Report Data Message)
+// Per-path failures are collected in AttributeStatuses and EventStatuses
+// rather than failing the whole report.
+type ReportData struct {
+	SubscriptionID      *uint32
+	Attributes          []AttributeData
+	AttributeStatuses   []*StatusError
+	Events              []EventData
+	EventStatuses       []*StatusError
+	MoreChunkedMessages bool
+	SuppressResponse    bool
+}
+
+// ReportDataMessage context tags.
+const (
+	reportDataTagSubscriptionID      = 0
+	reportDataTagAttributeReports    = 1
+	reportDataTagEventReports        = 2
+	reportDataTagMoreChunkedMessages = 3
+	reportDataTagSuppressResponse    = 4
+)
+
+// AttributeReportIB / AttributeDataIB / AttributeStatusIB context tags.
+const (
+	attributeReportTagStatus = 0
+	attributeReportTagData   = 1
+	attributeDataTagVersion  = 0
+	attributeDataTagPath     = 1
+	attributeDataTagData     = 2
+	attributeStatusTagPath   = 0
+	attributeStatusTagStatus = 1
+)
+
+// EventReportIB / EventStatusIB context tags.
+const (
+	eventReportTagStatus = 0
+	eventReportTagData   = 1
+	eventStatusTagPath   = 0
+	eventStatusTagStatus = 1
+)
+
+// DecodeReportData parses a ReportDataMessage payload.
+func DecodeReportData(b []byte) (*ReportData, error) {
+	r, err := openMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	report := &ReportData{}
+	var ts eventTimestamps
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		switch num {
+		case reportDataTagSubscriptionID:
+			report.SubscriptionID, err = readUintPtr[uint32](r)
+		case reportDataTagAttributeReports:
+			err = decodeReports(r, report.decodeAttributeReport)
+		case reportDataTagEventReports:
+			err = decodeReports(r, func(r *tlv.Reader) error {
+				return report.decodeEventReport(r, &ts)
+			})
+		case reportDataTagMoreChunkedMessages:
+			report.MoreChunkedMessages, err = readBool(r)
+		case reportDataTagSuppressResponse:
+			report.SuppressResponse, err = readBool(r)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := closeMessage(r); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Encode returns the TLV encoding of the report, as sent by a node. Only
+// Attributes and Events are encoded: the statuses keep their paths as text.
+// Events are encoded with absolute timestamps.
+func (report *ReportData) Encode() ([]byte, error) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	if report.SubscriptionID != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(reportDataTagSubscriptionID), uint64(*report.SubscriptionID)); err != nil {
+			return nil, err
+		}
+	}
+	if len(report.Attributes) > 0 {
+		enc.StartArray(tlv.ContextTag(reportDataTagAttributeReports))
+		for _, data := range report.Attributes {
+			enc.StartStructure(tlv.AnonymousTag())
+			if err := data.encode(enc, tlv.ContextTag(attributeReportTagData)); err != nil {
+				return nil, err
+			}
+			if err := enc.EndContainer(); err != nil {
+				return nil, err
+			}
+		}
+		if err := enc.EndContainer(); err != nil {
+			return nil, err
+		}
+	}
+	if len(report.Events) > 0 {
+		enc.StartArray(tlv.ContextTag(reportDataTagEventReports))
+		for _, ev := range report.Events {
+			enc.StartStructure(tlv.AnonymousTag())
+			if err := ev.encode(enc, tlv.ContextTag(eventReportTagData)); err != nil {
+				return nil, err
+			}
+			if err := enc.EndContainer(); err != nil {
+				return nil, err
+			}
+		}
+		if err := enc.EndContainer(); err != nil {
+			return nil, err
+		}
+	}
+	if report.MoreChunkedMessages {
+		enc.PutBool(tlv.ContextTag(reportDataTagMoreChunkedMessages), true)
+	}
+	if report.SuppressResponse {
+		enc.PutBool(tlv.ContextTag(reportDataTagSuppressResponse), true)
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision); err != nil {
+		return nil, err
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// decodeReports calls decode for each member of the report array at the
+// reader position.
+func decodeReports(r *tlv.Reader, decode func(*tlv.Reader) error) error {
+	if err := enter(r); err != nil {
+		return err
+	}
+	for r.Next() {
+		if err := decode(r); err != nil {
+			return err
+		}
+	}
+	return exit(r)
+}
+
+func (report *ReportData) decodeAttributeReport(r *tlv.Reader) error {
+	if err := enter(r); err != nil {
+		return err
+	}
+	found := false
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		switch num {
+		case attributeReportTagStatus:
+			status, err := decodePathStatus(r, attributeStatusTagPath, attributeStatusTagStatus, func(r *tlv.Reader) (string, error) {
+				p, err := decodeAttributePath(r)
+				return p.String(), err
+			})
+			if err != nil {
+				return err
+			}
+			report.AttributeStatuses = append(report.AttributeStatuses, status)
+			found = true
+		case attributeReportTagData:
+			data, err := decodeAttributeData(r)
+			if err != nil {
+				return err
+			}
+			report.Attributes = append(report.Attributes, data)
+			found = true
+		}
+	}
+	if err := exit(r); err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: attribute report carries neither status nor data", ErrMalformed)
+	}
+	return nil
+}
+
+func decodeAttributeData(r *tlv.Reader) (AttributeData, error) {
+	var data AttributeData
+	if err := enter(r); err != nil {
+		return data, err
+	}
+	var hasPath bool
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		var err error
+		switch num {
+		case attributeDataTagVersion:
+			data.DataVersion, err = readUintPtr[uint32](r)
+		case attributeDataTagPath:
+			data.Path, err = decodeAttributePath(r)
+			hasPath = true
+		case attributeDataTagData:
+			data.Data, err = readRaw(r)
+		}
+		if err != nil {
+			return data, err
+		}
+	}
+	if err := exit(r); err != nil {
+		return data, err
+	}
+	if !hasPath {
+		return data, fmt.Errorf("%w: attribute data missing path", ErrMalformed)
+	}
+	if data.Data == nil {
+		return data, fmt.Errorf("%w: attribute data missing value", ErrMalformed)
+	}
+	return data, nil
+}
+
+// encode writes the attribute data as an AttributeDataIB structure under tag.
+func (d AttributeData) encode(enc tlv.Encoder, tag tlv.Tag) error {
+	enc.StartStructure(tag)
+	if d.DataVersion != nil {
+		if err := enc.PutUnsigned(tlv.ContextTag(attributeDataTagVersion), uint64(*d.DataVersion)); err != nil {
+			return err
+		}
+	}
+	if err := d.Path.encode(enc, tlv.ContextTag(attributeDataTagPath)); err != nil {
+		return err
+	}
+	data := tlv.NewReader(d.Data)
+	if !data.Next() {
+		return fmt.Errorf("im: empty attribute data for %s: %w", d.Path, data.Err())
+	}
+	if err := data.Copy(enc, tlv.ContextTag(attributeDataTagData)); err != nil {
+		return fmt.Errorf("im: attribute data for %s: %w", d.Path, err)
+	}
+	return enc.EndContainer()
+}
+
+func (report *ReportData) decodeEventReport(r *tlv.Reader, ts *eventTimestamps) error {
+	if err := enter(r); err != nil {
+		return err
+	}
+	found := false
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		switch num {
+		case eventReportTagStatus:
+			status, err := decodePathStatus(r, eventStatusTagPath, eventStatusTagStatus, func(r *tlv.Reader) (string, error) {
+				p, err := decodeEventPath(r)
+				return p.String(), err
+			})
+			if err != nil {
+				return err
+			}
+			report.EventStatuses = append(report.EventStatuses, status)
+			found = true
+		case eventReportTagData:
+			ev, err := decodeEventData(r, ts)
+			if err != nil {
+				return err
+			}
+			report.Events = append(report.Events, ev)
+			found = true
+		}
+	}
+	if err := exit(r); err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: event report carries neither status nor data", ErrMalformed)
+	}
+	return nil
+}
+
+// decodePathStatus parses the AttributeStatusIB or EventStatusIB at the
+// reader position.
+func decodePathStatus(r *tlv.Reader, pathTag, statusTag uint8, decodePath func(*tlv.Reader) (string, error)) (*StatusError, error) {
+	if err := enter(r); err != nil {
+		return nil, err
+	}
+	var path string
+	var status *StatusError
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		var err error
+		switch num {
+		case pathTag:
+			path, err = decodePath(r)
+		case statusTag:
+			status, err = decodeStatus(r)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := exit(r); err != nil {
+		return nil, err
+	}
+	if path == "" || status == nil {
+		return nil, fmt.Errorf("%w: status report missing path or status", ErrMalformed)
+	}
+	status.Path = path
+	return status, nil
+}
+
+// SubscribeResponse is the SubscribeResponseMessage payload.
+// Reference: Matter Core Spec 1.5, Section 10.7.5 (Subscribe Response Message)
+type SubscribeResponse struct {
+	SubscriptionID uint32
+	MaxInterval    time.Duration
+}
+
+// SubscribeResponseMessage context tags.
+const (
+	subscribeResponseTagSubscriptionID = 0
+	subscribeResponseTagMaxInterval    = 2
+)
+
+// DecodeSubscribeResponse parses a SubscribeResponseMessage payload.
+func DecodeSubscribeResponse(b []byte) (*SubscribeResponse, error) {
+	r, err := openMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	var id *uint32
+	var maxInterval *uint16
+	for r.Next() {
+		num, ok := tlv.ContextTagNumber(r.Tag())
+		if !ok {
+			continue
+		}
+		switch num {
+		case subscribeResponseTagSubscriptionID:
+			id, err = readUintPtr[uint32](r)
+		case subscribeResponseTagMaxInterval:
+			maxInterval, err = readUintPtr[uint16](r)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := closeMessage(r); err != nil {
+		return nil, err
+	}
+	if id == nil || maxInterval == nil {
+		return nil, fmt.Errorf("%w: subscribe response missing subscription id or max interval", ErrMalformed)
+	}
+	return &SubscribeResponse{
+		SubscriptionID: *id,
+		MaxInterval:    time.Duration(*maxInterval) * time.Second,
+	}, nil
+}
+
+// Encode returns the TLV encoding of the response, as sent by a node.
+// The maximum interval is sent with one second resolution.
+func (resp *SubscribeResponse) Encode() ([]byte, error) {
+	maxInterval := resp.MaxInterval / time.Second
+	if maxInterval < 0 || maxInterval > 0xFFFF {
+		return nil, fmt.Errorf("im: subscribe max interval %s out of range", resp.MaxInterval)
+	}
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	if err := enc.PutUnsigned(tlv.ContextTag(subscribeResponseTagSubscriptionID), uint64(resp.SubscriptionID)); err != nil {
+		return nil, err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(subscribeResponseTagMaxInterval), uint64(maxInterval)); err != nil {
+		return nil, err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision); err != nil {
+		return nil, err
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
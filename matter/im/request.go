@@ -0,0 +1,208 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// InteractionModelRevision is the revision advertised in every message.
+const InteractionModelRevision = 11
+
+// interactionModelRevisionTag is the context tag shared by all IM messages.
+const interactionModelRevisionTag = 0xFF
+
+// ReadRequest is the ReadRequestMessage payload.
+// Reference: Matter Core Spec 1.5, Section 10.7.2 (Read Request Message)
+type ReadRequest struct {
+	AttributeRequests []AttributePath
+	EventRequests     []EventPath
+	EventFilters      []EventFilter
+	FabricFiltered    bool
+}
+
+// ReadRequestMessage context tags.
+const (
+	readRequestTagAttributeRequests = 0
+	readRequestTagEventRequests     = 1
+	readRequestTagEventFilters      = 2
+	readRequestTagFabricFiltered    = 3
+)
+
+// Encode returns the TLV encoding of the request.
+func (r *ReadRequest) Encode() ([]byte, error) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	if err := encodePaths(enc, readRequestTagAttributeRequests, readRequestTagEventRequests, readRequestTagEventFilters,
+		r.AttributeRequests, r.EventRequests, r.EventFilters); err != nil {
+		return nil, err
+	}
+	enc.PutBool(tlv.ContextTag(readRequestTagFabricFiltered), r.FabricFiltered)
+	if err := enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision); err != nil {
+		return nil, err
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// SubscribeRequest is the SubscribeRequestMessage payload.
+// Reference: Matter Core Spec 1.5, Section 10.7.4 (Subscribe Request Message)
+type SubscribeRequest struct {
+	KeepSubscriptions bool
+	MinIntervalFloor  time.Duration
+	MaxIntervalCeil   time.Duration
+	AttributeRequests []AttributePath
+	EventRequests     []EventPath
+	EventFilters      []EventFilter
+	FabricFiltered    bool
+}
+
+// SubscribeRequestMessage context tags.
+const (
+	subscribeRequestTagKeepSubscriptions = 0
+	subscribeRequestTagMinIntervalFloor  = 1
+	subscribeRequestTagMaxIntervalCeil   = 2
+	subscribeRequestTagAttributeRequests = 3
+	subscribeRequestTagEventRequests     = 4
+	subscribeRequestTagEventFilters      = 5
+	subscribeRequestTagFabricFiltered    = 7
+)
+
+// Encode returns the TLV encoding of the request.
+// Intervals are sent with one second resolution.
+func (r *SubscribeRequest) Encode() ([]byte, error) {
+	minFloor := r.MinIntervalFloor / time.Second
+	maxCeil := r.MaxIntervalCeil / time.Second
+	if minFloor < 0 || maxCeil < 0 || minFloor > 0xFFFF || maxCeil > 0xFFFF {
+		return nil, fmt.Errorf("im: subscribe interval out of range (min %s, max %s)", r.MinIntervalFloor, r.MaxIntervalCeil)
+	}
+	if maxCeil < minFloor {
+		return nil, fmt.Errorf("im: subscribe max interval %s is below min interval %s", r.MaxIntervalCeil, r.MinIntervalFloor)
+	}
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	enc.PutBool(tlv.ContextTag(subscribeRequestTagKeepSubscriptions), r.KeepSubscriptions)
+	if err := enc.PutUnsigned(tlv.ContextTag(subscribeRequestTagMinIntervalFloor), uint64(minFloor)); err != nil {
+		return nil, err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(subscribeRequestTagMaxIntervalCeil), uint64(maxCeil)); err != nil {
+		return nil, err
+	}
+	if err := encodePaths(enc, subscribeRequestTagAttributeRequests, subscribeRequestTagEventRequests, subscribeRequestTagEventFilters,
+		r.AttributeRequests, r.EventRequests, r.EventFilters); err != nil {
+		return nil, err
+	}
+	enc.PutBool(tlv.ContextTag(subscribeRequestTagFabricFiltered), r.FabricFiltered)
+	if err := enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision); err != nil {
+		return nil, err
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// encodePaths writes the attribute path, event path and event filter arrays
+// shared by read and subscribe requests. Empty arrays are omitted.
+func encodePaths(enc tlv.Encoder, attrTag, eventTag, filterTag uint8, attrs []AttributePath, events []EventPath, filters []EventFilter) error {
+	if len(attrs) > 0 {
+		enc.StartArray(tlv.ContextTag(attrTag))
+		for _, p := range attrs {
+			if err := p.encode(enc, tlv.AnonymousTag()); err != nil {
+				return err
+			}
+		}
+		if err := enc.EndContainer(); err != nil {
+			return err
+		}
+	}
+	if len(events) > 0 {
+		enc.StartArray(tlv.ContextTag(eventTag))
+		for _, p := range events {
+			if err := p.encode(enc, tlv.AnonymousTag()); err != nil {
+				return err
+			}
+		}
+		if err := enc.EndContainer(); err != nil {
+			return err
+		}
+	}
+	if len(filters) > 0 {
+		enc.StartArray(tlv.ContextTag(filterTag))
+		for _, f := range filters {
+			if err := f.encode(enc, tlv.AnonymousTag()); err != nil {
+				return err
+			}
+		}
+		if err := enc.EndContainer(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TimedRequest is the TimedRequestMessage payload, which opens the window a
+// timed write or invoke on the same exchange must arrive in.
+// Reference: Matter Core Spec 1.5, Section 10.7.8 (Timed Request Message)
+type TimedRequest struct {
+	Timeout time.Duration
+}
+
+// TimedRequestMessage context tags.
+const timedRequestTagTimeout = 0
+
+// Encode returns the TLV encoding of the request.
+// The timeout is sent with one millisecond resolution.
+func (r *TimedRequest) Encode() ([]byte, error) {
+	timeout := r.Timeout / time.Millisecond
+	if timeout <= 0 || timeout > 0xFFFF {
+		return nil, fmt.Errorf("im: timed request timeout %s out of range", r.Timeout)
+	}
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	if err := enc.PutUnsigned(tlv.ContextTag(timedRequestTagTimeout), uint64(timeout)); err != nil {
+		return nil, err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision); err != nil {
+		return nil, err
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// EncodeStatusResponse returns the StatusResponseMessage payload carrying
+// status.
+// Reference: Matter Core Spec 1.5, Section 10.7.1 (Status Response Message)
+func EncodeStatusResponse(status Status) ([]byte, error) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	if err := enc.PutUnsigned(tlv.ContextTag(statusResponseTagStatus), uint64(status)); err != nil {
+		return nil, err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision); err != nil {
+		return nil, err
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
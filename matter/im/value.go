@@ -0,0 +1,218 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// ErrValueType is returned when a Value is accessed as a type it does not hold.
+var ErrValueType = errors.New("im: unexpected value type")
+
+// Value is a decoded attribute or command field value: a single TLV element
+// with an anonymous tag. The As* accessors convert it to Go types and fail
+// with ErrValueType instead of requiring callers to switch over the
+// possible encodings. The zero Value is null.
+type Value struct {
+	data []byte
+	el   tlv.Element
+}
+
+// NewValue wraps the TLV encoding of a single element.
+func NewValue(data []byte) (Value, error) {
+	r := tlv.NewReader(data)
+	if !r.Next() {
+		if err := r.Err(); err != nil {
+			return Value{}, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+		return Value{}, fmt.Errorf("%w: empty value", ErrMalformed)
+	}
+	el := r.Element()
+	if err := r.Skip(); err != nil {
+		return Value{}, fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	if r.Next() {
+		return Value{}, fmt.Errorf("%w: trailing element after value", ErrMalformed)
+	}
+	if err := r.Err(); err != nil {
+		return Value{}, fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	return Value{data: data, el: el}, nil
+}
+
+// MarshalValue encodes v with tlv.Marshal and wraps the result. It is mainly
+// useful for controllers and fakes that produce values from Go types.
+func MarshalValue(v any) (Value, error) {
+	data, err := tlv.Marshal(v)
+	if err != nil {
+		return Value{}, err
+	}
+	return NewValue(data)
+}
+
+// Value decodes the attribute data.
+func (d AttributeData) Value() (Value, error) {
+	return NewValue(d.Data)
+}
+
+// Bytes returns the TLV encoding of the value, or nil for the zero Value.
+func (v Value) Bytes() []byte {
+	return v.data
+}
+
+// IsNull reports whether the value is null.
+func (v Value) IsNull() bool {
+	return v.el == nil || v.el.Type() == tlv.ETNull
+}
+
+// AsUint returns an unsigned integer, also accepting non-negative signed
+// integers.
+func (v Value) AsUint() (uint64, error) {
+	if v.el != nil {
+		if u, ok := v.el.Unsigned(); ok {
+			return u, nil
+		}
+		if s, ok := v.el.Signed(); ok && s >= 0 {
+			return uint64(s), nil
+		}
+	}
+	return 0, v.mismatch("unsigned integer")
+}
+
+// AsInt returns a signed integer, also accepting unsigned integers that fit
+// in an int64.
+func (v Value) AsInt() (int64, error) {
+	if v.el != nil {
+		if s, ok := v.el.Signed(); ok {
+			return s, nil
+		}
+		if u, ok := v.el.Unsigned(); ok && u <= math.MaxInt64 {
+			return int64(u), nil
+		}
+	}
+	return 0, v.mismatch("signed integer")
+}
+
+// AsBool returns a boolean.
+func (v Value) AsBool() (bool, error) {
+	if v.el != nil {
+		if b, ok := v.el.Bool(); ok {
+			return b, nil
+		}
+	}
+	return false, v.mismatch("boolean")
+}
+
+// AsFloat returns a single or double precision float as float64.
+func (v Value) AsFloat() (float64, error) {
+	if v.el != nil {
+		if f, ok := v.el.Float(); ok {
+			return f, nil
+		}
+	}
+	return 0, v.mismatch("float")
+}
+
+// AsString returns a UTF-8 string.
+func (v Value) AsString() (string, error) {
+	if v.el != nil {
+		if s, ok := v.el.UTF8(); ok {
+			return s, nil
+		}
+	}
+	return "", v.mismatch("UTF-8 string")
+}
+
+// AsBytes returns an octet string.
+func (v Value) AsBytes() ([]byte, error) {
+	if v.el != nil {
+		if b, ok := v.el.Bytes(); ok {
+			return b, nil
+		}
+	}
+	return nil, v.mismatch("octet string")
+}
+
+// AsList returns the members of an Array or List.
+func (v Value) AsList() ([]Value, error) {
+	if v.el == nil || (v.el.Type() != tlv.ETArray && v.el.Type() != tlv.ETList) {
+		return nil, v.mismatch("array or list")
+	}
+	r := tlv.NewReader(v.data)
+	r.Next()
+	if err := enter(r); err != nil {
+		return nil, err
+	}
+	var members []Value
+	for r.Next() {
+		data, err := readRaw(r)
+		if err != nil {
+			return nil, err
+		}
+		member, err := NewValue(data)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if err := exit(r); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// Unmarshal decodes the value into dst using tlv.Unmarshal.
+func (v Value) Unmarshal(dst any) error {
+	if v.el == nil {
+		return v.mismatch("encoded value")
+	}
+	return tlv.Unmarshal(v.data, dst)
+}
+
+// String returns a compact human-readable representation.
+func (v Value) String() string {
+	if v.el == nil {
+		return "null"
+	}
+	s, err := tlv.PrettyString(v.data)
+	if err != nil {
+		return v.el.DebugString()
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func (v Value) mismatch(want string) error {
+	if v.el == nil {
+		return fmt.Errorf("%w: want %s, got null", ErrValueType, want)
+	}
+	return fmt.Errorf("%w: want %s, got %s", ErrValueType, want, v.el.DebugString())
+}
+
+// UintAs narrows v.AsUint to T, failing when the value is out of range.
+func UintAs[T uint8 | uint16 | uint32 | uint64](v Value) (T, error) {
+	u, err := v.AsUint()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(T(u)) != u {
+		return 0, fmt.Errorf("%w: %d out of range", ErrValueType, u)
+	}
+	return T(u), nil
+}
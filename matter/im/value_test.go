@@ -0,0 +1,112 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+func TestValueScalars(t *testing.T) {
+	v, err := MarshalValue(uint8(200))
+	if err != nil {
+		t.Fatalf("MarshalValue: %v", err)
+	}
+	if u, err := v.AsUint(); err != nil || u != 200 {
+		t.Errorf("AsUint = %d, %v", u, err)
+	}
+	if _, err := UintAs[uint8](v); err != nil {
+		t.Errorf("UintAs[uint8]: %v", err)
+	}
+	if _, err := v.AsBool(); !errors.Is(err, ErrValueType) {
+		t.Errorf("AsBool err = %v, want ErrValueType", err)
+	}
+
+	v, _ = MarshalValue(int32(-1))
+	if _, err := v.AsUint(); !errors.Is(err, ErrValueType) {
+		t.Errorf("AsUint(-1) err = %v, want ErrValueType", err)
+	}
+	if s, err := v.AsInt(); err != nil || s != -1 {
+		t.Errorf("AsInt = %d, %v", s, err)
+	}
+
+	v, _ = MarshalValue(uint32(70000))
+	if _, err := UintAs[uint16](v); !errors.Is(err, ErrValueType) {
+		t.Errorf("UintAs[uint16](70000) err = %v, want ErrValueType", err)
+	}
+
+	v, _ = MarshalValue("lamp")
+	if s, err := v.AsString(); err != nil || s != "lamp" {
+		t.Errorf("AsString = %q, %v", s, err)
+	}
+	if v.IsNull() {
+		t.Errorf("string reported as null")
+	}
+}
+
+func TestValueNull(t *testing.T) {
+	enc := tlv.NewEncoder()
+	enc.PutNull(tlv.AnonymousTag())
+	v, err := NewValue(enc.Bytes())
+	if err != nil {
+		t.Fatalf("NewValue: %v", err)
+	}
+	if !v.IsNull() || !(Value{}).IsNull() {
+		t.Fatalf("null not reported")
+	}
+	if _, err := v.AsBool(); !errors.Is(err, ErrValueType) {
+		t.Errorf("AsBool(null) err = %v, want ErrValueType", err)
+	}
+}
+
+func TestValueList(t *testing.T) {
+	v, err := MarshalValue([]uint16{1, 2, 3})
+	if err != nil {
+		t.Fatalf("MarshalValue: %v", err)
+	}
+	members, err := v.AsList()
+	if err != nil {
+		t.Fatalf("AsList: %v", err)
+	}
+	if len(members) != 3 {
+		t.Fatalf("members = %v", members)
+	}
+	for i, m := range members {
+		if u, err := UintAs[uint16](m); err != nil || u != uint16(i+1) {
+			t.Errorf("member %d = %d, %v", i, u, err)
+		}
+	}
+	var got []uint16
+	if err := v.Unmarshal(&got); err != nil || len(got) != 3 {
+		t.Errorf("Unmarshal = %v, %v", got, err)
+	}
+	if s := v.String(); s != "[ 1 (uint) 2 (uint) 3 (uint) ]" {
+		t.Errorf("String = %q", s)
+	}
+}
+
+func TestNewValueMalformed(t *testing.T) {
+	if _, err := NewValue(nil); !errors.Is(err, ErrMalformed) {
+		t.Errorf("empty err = %v", err)
+	}
+	if _, err := NewValue([]byte{0x04, 0x01, 0x04, 0x02}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("trailing err = %v", err)
+	}
+	if _, err := NewValue([]byte{0x16, 0x04, 0x01}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("truncated err = %v", err)
+	}
+}
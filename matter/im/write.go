@@ -0,0 +1,109 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package im
+
+import "github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+
+// WriteRequest is the WriteRequestMessage payload.
+// Reference: Matter Core Spec 1.5, Section 10.7.6 (Write Request Message)
+type WriteRequest struct {
+	SuppressResponse bool
+	TimedRequest     bool
+	Attributes       []AttributeData
+}
+
+// WriteRequestMessage context tags.
+const (
+	writeRequestTagSuppressResponse = 0
+	writeRequestTagTimedRequest     = 1
+	writeRequestTagWriteRequests    = 2
+)
+
+// Encode returns the TLV encoding of the request.
+func (r *WriteRequest) Encode() ([]byte, error) {
+	enc := tlv.NewEncoder()
+	enc.StartStructure(tlv.AnonymousTag())
+	enc.PutBool(tlv.ContextTag(writeRequestTagSuppressResponse), r.SuppressResponse)
+	enc.PutBool(tlv.ContextTag(writeRequestTagTimedRequest), r.TimedRequest)
+	enc.StartArray(tlv.ContextTag(writeRequestTagWriteRequests))
+	for _, data := range r.Attributes {
+		if err := data.encode(enc, tlv.AnonymousTag()); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	if err := enc.PutUnsigned(tlv.ContextTag(interactionModelRevisionTag), InteractionModelRevision); err != nil {
+		return nil, err
+	}
+	if err := enc.EndContainer(); err != nil {
+		return nil, err
+	}
+	return enc.Bytes(), nil
+}
+
+// WriteResponse is the WriteResponseMessage payload. Statuses holds the
+// status of every written path, including successes.
+// Reference: Matter Core Spec 1.5, Section 10.7.7 (Write Response Message)
+type WriteResponse struct {
+	Statuses []*StatusError
+}
+
+// WriteResponseMessage context tags.
+const writeResponseTagWriteResponses = 0
+
+// DecodeWriteResponse parses a WriteResponseMessage payload.
+func DecodeWriteResponse(b []byte) (*WriteResponse, error) {
+	r, err := openMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	resp := &WriteResponse{}
+	for r.Next() {
+		if num, ok := tlv.ContextTagNumber(r.Tag()); !ok || num != writeResponseTagWriteResponses {
+			continue
+		}
+		err := decodeReports(r, func(r *tlv.Reader) error {
+			status, err := decodePathStatus(r, attributeStatusTagPath, attributeStatusTagStatus, func(r *tlv.Reader) (string, error) {
+				p, err := decodeAttributePath(r)
+				return p.String(), err
+			})
+			if err != nil {
+				return err
+			}
+			resp.Statuses = append(resp.Statuses, status)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := closeMessage(r); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Err returns the first failure status of the response, or nil if every
+// path was written.
+func (resp *WriteResponse) Err() error {
+	for _, status := range resp.Statuses {
+		if status.Status != StatusSuccess {
+			return status
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package im
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+type attributePathView struct {
+	Endpoint  *uint16 `tlv:"2"`
+	Cluster   *uint32 `tlv:"3"`
+	Attribute *uint32 `tlv:"4"`
+}
+
+func TestWriteRequestEncode(t *testing.T) {
+	data, _ := tlv.Marshal(uint16(180))
+	req := &WriteRequest{
+		TimedRequest: true,
+		Attributes:   []AttributeData{{Path: NewAttributePath(0, 0x0030, 0x0000), Data: data}},
+	}
+	b, err := req.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	var view struct {
+		SuppressResponse bool `tlv:"0"`
+		TimedRequest     bool `tlv:"1"`
+		Writes           []struct {
+			Path attributePathView `tlv:"1"`
+			Data uint16            `tlv:"2"`
+		} `tlv:"2"`
+		Revision uint8 `tlv:"255"`
+	}
+	if err := tlv.Unmarshal(b, &view); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if view.SuppressResponse || !view.TimedRequest || view.Revision != InteractionModelRevision {
+		t.Errorf("view = %+v", view)
+	}
+	if len(view.Writes) != 1 || *view.Writes[0].Path.Cluster != 0x0030 || *view.Writes[0].Path.Attribute != 0 || view.Writes[0].Data != 180 {
+		t.Errorf("writes = %+v", view.Writes)
+	}
+
+	if _, err := (&WriteRequest{Attributes: []AttributeData{{Path: NewAttributePath(0, 0x0030, 0x0000)}}}).Encode(); err == nil {
+		t.Error("Encode() without data succeeded")
+	}
+}
+
+func TestDecodeWriteResponse(t *testing.T) {
+	type statusView struct {
+		Status uint8 `tlv:"0"`
+	}
+	type attributeStatusView struct {
+		Path   attributePathView `tlv:"0"`
+		Status statusView        `tlv:"1"`
+	}
+	endpoint, cluster := uint16(0), uint32(0x0030)
+	written, denied := uint32(0x0000), uint32(0x0001)
+	b, _ := tlv.Marshal(struct {
+		Responses []attributeStatusView `tlv:"0"`
+		Revision  uint8                 `tlv:"255"`
+	}{
+		Responses: []attributeStatusView{
+			{Path: attributePathView{&endpoint, &cluster, &written}, Status: statusView{uint8(StatusSuccess)}},
+			{Path: attributePathView{&endpoint, &cluster, &denied}, Status: statusView{uint8(StatusUnsupportedAccess)}},
+		},
+		Revision: InteractionModelRevision,
+	})
+	resp, err := DecodeWriteResponse(b)
+	if err != nil {
+		t.Fatalf("DecodeWriteResponse: %v", err)
+	}
+	if len(resp.Statuses) != 2 {
+		t.Fatalf("statuses = %v", resp.Statuses)
+	}
+	var status *StatusError
+	if err := resp.Err(); !errors.As(err, &status) || status.Status != StatusUnsupportedAccess || status.Path != "0/0x0030/0x0001" {
+		t.Errorf("Err() = %v", err)
+	}
+	resp.Statuses = resp.Statuses[:1]
+	if err := resp.Err(); err != nil {
+		t.Errorf("Err() of a successful write = %v", err)
+	}
+}
+
+func TestTimedRequestEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    uint16
+		wantErr bool
+	}{
+		{"seconds", 10 * time.Second, 10000, false},
+		{"zero", 0, 0, true},
+		{"too long", 70 * time.Second, 0, true},
+	}
+	for _, tt := range tests {
+		b, err := (&TimedRequest{Timeout: tt.timeout}).Encode()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: Encode() succeeded", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Encode: %v", tt.name, err)
+			continue
+		}
+		var view struct {
+			Timeout  uint16 `tlv:"0"`
+			Revision uint8  `tlv:"255"`
+		}
+		if err := tlv.Unmarshal(b, &view); err != nil || view.Timeout != tt.want || view.Revision != InteractionModelRevision {
+			t.Errorf("%s: view = %+v, %v", tt.name, view, err)
+		}
+	}
+}
+
+func TestEncodeStatusResponse(t *testing.T) {
+	b, err := EncodeStatusResponse(StatusInvalidSubscription)
+	if err != nil {
+		t.Fatalf("EncodeStatusResponse: %v", err)
+	}
+	status, err := DecodeStatusResponse(b)
+	if err != nil || status.Status != StatusInvalidSubscription {
+		t.Errorf("DecodeStatusResponse = %v, %v", status, err)
+	}
+}
@@ -17,6 +17,7 @@ package mdns
 import (
 	"net"
 	"regexp"
+	"time"
 
 	"github.com/YashubuStudio/go-matter-pack/matter/types"
 )
@@ -35,6 +36,10 @@ type Discriminator = types.Discriminator
 
 // CommissionableNode represents the commissionable node.
 type CommissionableNode interface {
+	// InstanceName returns the DNS-SD instance name, the 64-bit random
+	// identifier the node advertises its commissionable service under.
+	// 4.3.1. Commissionable Node Discovery
+	InstanceName() (string, bool)
 	// Hostname returns the host name.
 	// 4.3.1.1. Host Name Construction
 	Hostname() (string, bool)
@@ -80,6 +85,8 @@ type CommissionableNode interface {
 	// PairingInstructions returns the pairing instructions from the TXT record if available,
 	// 4.3.1.12. TXT key for pairing instructions (PI)
 	PairingInstructions() (string, bool)
+	// TTL returns the shortest time to live of the records describing the node.
+	TTL() time.Duration
 	// String returns the string representation.
 	String() string
 }
@@ -17,6 +17,7 @@ package mdns
 import (
 	"net"
 	"strings"
+	"time"
 
 	"github.com/YashubuStudio/go-matter-pack/matter/types"
 	"github.com/cybergarage/go-mdns/mdns"
@@ -85,6 +86,16 @@ func (node *commissioningNode) appendLookupTxtAttribute(records []string, name s
 	return append(records, v)
 }
 
+// InstanceName returns the DNS-SD instance name.
+// 4.3.1. Commissionable Node Discovery.
+func (node *commissioningNode) InstanceName() (string, bool) {
+	names := dns.SplitName(node.Name())
+	if len(names) < 1 || len(names[0]) == 0 {
+		return "", false
+	}
+	return names[0], true
+}
+
 // Hostname returns the host name.
 // 4.3.1.1. Host Name Construction.
 func (node *commissioningNode) Hostname() (string, bool) {
@@ -313,6 +324,11 @@ func (node *commissioningNode) PairingInstructions() (string, bool) {
 	return node.LookupTxtAttribute(TxtRecordPairingInstruction)
 }
 
+// TTL returns the shortest time to live of the records describing the node.
+func (node *commissioningNode) TTL() time.Duration {
+	return serviceTTL(node.Service)
+}
+
 // String returns the string representation.
 func (node *commissioningNode) String() string {
 	return node.Service.String()
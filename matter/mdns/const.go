@@ -48,3 +48,13 @@ const (
 	CommissioningMode1    = "1"
 	CommissioningMode2    = "2"
 )
+
+// Matter Specification Version 1.2
+// 4.3.4. Common TXT Key/Value Pairs.
+const (
+	TxtRecordSessionIdleInterval    = "SII"
+	TxtRecordSessionActiveInterval  = "SAI"
+	TxtRecordSessionActiveThreshold = "SAT"
+	TxtRecordTCPSupport             = "T"
+	TxtRecordICDOperatingMode       = "ICD"
+)
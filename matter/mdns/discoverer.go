@@ -21,13 +21,23 @@ import (
 
 const (
 	SearchTimeout = time.Duration(5 * time.Second)
+	// DefaultServiceTTL is the lifetime of a discovered node whose records carry no TTL.
+	DefaultServiceTTL = time.Duration(120 * time.Second)
 )
 
-// Discoverer represents a discoverer for commissionable Nodes.
+// Discoverer represents a discoverer for commissionable and operational Nodes.
 type Discoverer interface {
 	// Search searches commissionable Nodes.
 	// 4.3. Discovery
 	Search(ctx context.Context, query Query) ([]CommissionableNode, error)
+	// SearchOperationalNodes searches operational Nodes of the fabric identified by compressedFabricID.
+	// 4.3.2. Operational Discovery
+	SearchOperationalNodes(ctx context.Context, compressedFabricID uint64) ([]OperationalNode, error)
+	// ResolveOperationalNode returns the operational Node of nodeID, from the cache while its TTL lasts.
+	// 4.3.2. Operational Discovery
+	ResolveOperationalNode(ctx context.Context, compressedFabricID uint64, nodeID uint64) (OperationalNode, error)
+	// ForgetOperationalNode drops the cached operational Node of nodeID, e.g. after its address stopped answering.
+	ForgetOperationalNode(compressedFabricID uint64, nodeID uint64)
 	// Start starts this discoverer.
 	Start() error
 	// Stop stops this discoverer.
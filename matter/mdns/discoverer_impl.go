@@ -16,6 +16,8 @@ package mdns
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/cybergarage/go-logger/log"
 	"github.com/cybergarage/go-mdns/mdns"
@@ -25,12 +27,14 @@ import (
 // discoverer represents a discoverer for commisionners.
 type discoverer struct {
 	mdns.Client
+	operationalNodes *OperationalNodeCache
 }
 
 // NewDiscoverer returns a new discoverer.
 func NewDiscoverer() Discoverer {
 	disc := &discoverer{
-		Client: mdns.NewClient(),
+		Client:           mdns.NewClient(),
+		operationalNodes: NewOperationalNodeCache(),
 	}
 	return disc
 }
@@ -57,12 +61,15 @@ func (disc *discoverer) Stop() error {
 	return disc.Client.Stop()
 }
 
-// Search searches commissionable Nodes.
-// 4.3. Discovery.
-func (disc *discoverer) Search(ctx context.Context, query Query) ([]CommissionableNode, error) {
+// query runs a DNS-SD query for the service and subtype of query.
+func (disc *discoverer) query(ctx context.Context, query Query) ([]mdns.Service, error) {
+	service := query.Service()
+	if len(service) == 0 {
+		service = CommissionableNodeService
+	}
 	dnsQuery := mdns.NewQuery(
 		mdns.WithQuerySubtype(query.Subtype()),
-		mdns.WithQueryService(CommissionableNodeService),
+		mdns.WithQueryService(service),
 	)
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -75,8 +82,18 @@ func (disc *discoverer) Search(ctx context.Context, query Query) ([]Commissionab
 	if err != nil {
 		if isIgnorableStartError(err) {
 			log.Warnf("mDNS query skipped: %v", err)
-			return []CommissionableNode{}, nil
+			return []mdns.Service{}, nil
 		}
+		return []mdns.Service{}, err
+	}
+	return services, nil
+}
+
+// Search searches commissionable Nodes.
+// 4.3. Discovery.
+func (disc *discoverer) Search(ctx context.Context, query Query) ([]CommissionableNode, error) {
+	services, err := disc.query(ctx, query)
+	if err != nil {
 		return []CommissionableNode{}, err
 	}
 
@@ -87,3 +104,66 @@ func (disc *discoverer) Search(ctx context.Context, query Query) ([]Commissionab
 	}
 	return nodes, nil
 }
+
+// SearchOperationalNodes searches operational Nodes of a fabric and caches the answers.
+func (disc *discoverer) SearchOperationalNodes(ctx context.Context, compressedFabricID uint64) ([]OperationalNode, error) {
+	services, err := disc.query(ctx, NewQuery(WithQueryCompressedFabricID(compressedFabricID)))
+	if err != nil {
+		return []OperationalNode{}, err
+	}
+
+	nodes := []OperationalNode{}
+	for _, service := range services {
+		node, err := NewOperationalNodeWithService(service)
+		if err != nil {
+			log.Debugf("mDNS operational service skipped: %v", err)
+			continue
+		}
+		if node.CompressedFabricID() != compressedFabricID {
+			continue
+		}
+		disc.operationalNodes.Add(node)
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// ResolveOperationalNode returns the operational Node of nodeID, querying the network when the cache has no live entry.
+func (disc *discoverer) ResolveOperationalNode(ctx context.Context, compressedFabricID uint64, nodeID uint64) (OperationalNode, error) {
+	if node, ok := disc.operationalNodes.Lookup(compressedFabricID, nodeID); ok {
+		return node, nil
+	}
+	nodes, err := disc.SearchOperationalNodes(ctx, compressedFabricID)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		if node.NodeID() == nodeID {
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrOperationalNodeNotFound, OperationalInstanceName(compressedFabricID, nodeID))
+}
+
+// ForgetOperationalNode drops the cached operational Node of nodeID.
+func (disc *discoverer) ForgetOperationalNode(compressedFabricID uint64, nodeID uint64) {
+	disc.operationalNodes.Remove(compressedFabricID, nodeID)
+}
+
+// serviceTTL returns the shortest time to live of the records of service.
+func serviceTTL(service mdns.Service) time.Duration {
+	ttl := time.Duration(0)
+	for _, rr := range service.ResourceRecords() {
+		rrTTL := time.Duration(rr.TTL()) * time.Second
+		if rrTTL <= 0 {
+			continue
+		}
+		if ttl == 0 || rrTTL < ttl {
+			ttl = rrTTL
+		}
+	}
+	if ttl == 0 {
+		return DefaultServiceTTL
+	}
+	return ttl
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrOperationalNodeNotFound is returned when no operational node answers for an instance name.
+var ErrOperationalNodeNotFound = errors.New("operational node not found")
+
+// TCPSupport represents the TCP modes advertised by the T key.
+// 4.3.4.4. TXT key for TCP support (T).
+type TCPSupport uint8
+
+const (
+	TCPSupportClient TCPSupport = 0x02
+	TCPSupportServer TCPSupport = 0x04
+)
+
+// NewTCPSupportFromString returns a new TCP support bitmap from a string.
+func NewTCPSupportFromString(s string) (TCPSupport, error) {
+	v, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, err
+	}
+	return TCPSupport(v), nil
+}
+
+// IsClient returns true if the node can act as a TCP client.
+func (t TCPSupport) IsClient() bool {
+	return t&TCPSupportClient != 0
+}
+
+// IsServer returns true if the node accepts TCP connections.
+func (t TCPSupport) IsServer() bool {
+	return t&TCPSupportServer != 0
+}
+
+// OperationalInstanceName returns the operational instance name of a node.
+// 4.3.2.1. Operational Instance Name.
+func OperationalInstanceName(compressedFabricID uint64, nodeID uint64) string {
+	return fmt.Sprintf("%016X-%016X", compressedFabricID, nodeID)
+}
+
+// ParseOperationalInstanceName returns the compressed fabric identifier and node ID of an operational instance name.
+// 4.3.2.1. Operational Instance Name.
+func ParseOperationalInstanceName(name string) (uint64, uint64, error) {
+	parts := strings.Split(name, "-")
+	if len(parts) != 2 || len(parts[0]) != 16 || len(parts[1]) != 16 {
+		return 0, 0, fmt.Errorf("invalid operational instance name: %q", name)
+	}
+	compressedFabricID, err := strconv.ParseUint(parts[0], 16, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid operational instance name: %q", name)
+	}
+	nodeID, err := strconv.ParseUint(parts[1], 16, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid operational instance name: %q", name)
+	}
+	return compressedFabricID, nodeID, nil
+}
+
+// OperationalNode represents a commissioned node advertising the operational service.
+// 4.3.2. Operational Discovery.
+type OperationalNode interface {
+	// InstanceName returns the operational instance name.
+	// 4.3.2.1. Operational Instance Name
+	InstanceName() string
+	// CompressedFabricID returns the compressed fabric identifier.
+	CompressedFabricID() uint64
+	// NodeID returns the operational node ID.
+	NodeID() uint64
+	// Hostname returns the host name.
+	Hostname() (string, bool)
+	// Addresses returns the IP addresses.
+	Addresses() ([]net.IP, bool)
+	// Port returns the port number.
+	Port() (int, bool)
+	// SessionIdleInterval returns the session idle interval from the TXT record if available.
+	// 4.3.4.1. TXT key for session idle interval (SII)
+	SessionIdleInterval() (time.Duration, bool)
+	// SessionActiveInterval returns the session active interval from the TXT record if available.
+	// 4.3.4.2. TXT key for session active interval (SAI)
+	SessionActiveInterval() (time.Duration, bool)
+	// SessionActiveThreshold returns the session active threshold from the TXT record if available.
+	// 4.3.4.3. TXT key for session active threshold (SAT)
+	SessionActiveThreshold() (time.Duration, bool)
+	// TCPSupport returns the supported TCP modes from the TXT record if available.
+	// 4.3.4.4. TXT key for TCP support (T)
+	TCPSupport() (TCPSupport, bool)
+	// LongIdleTimeICD returns true if the node operates as a Long Idle Time ICD.
+	// 4.3.4.5. TXT key for ICD operating mode (ICD)
+	LongIdleTimeICD() (bool, bool)
+	// TTL returns the shortest time to live of the records describing the node.
+	TTL() time.Duration
+	// String returns the string representation.
+	String() string
+}
+
+// OperationalAddresses returns the UDP addresses node advertises, routable
+// IPv6 addresses first, then IPv4 and link-local ones, on its advertised port
+// or Port when it advertises none.
+func OperationalAddresses(node OperationalNode) []*net.UDPAddr {
+	ips, ok := node.Addresses()
+	if !ok {
+		return nil
+	}
+	port, ok := node.Port()
+	if !ok {
+		port = Port
+	}
+	rank := func(ip net.IP) int {
+		switch {
+		case ip.To4() == nil && !ip.IsLinkLocalUnicast():
+			return 0
+		case ip.To4() != nil:
+			return 1
+		default:
+			return 2
+		}
+	}
+	ips = slices.Clone(ips)
+	slices.SortStableFunc(ips, func(a, b net.IP) int {
+		return rank(a) - rank(b)
+	})
+	addrs := make([]*net.UDPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, &net.UDPAddr{IP: ip, Port: port})
+	}
+	return addrs
+}
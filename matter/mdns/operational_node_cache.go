@@ -0,0 +1,74 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdns
+
+import (
+	"sync"
+	"time"
+)
+
+type operationalNodeCacheEntry struct {
+	node      OperationalNode
+	expiresAt time.Time
+}
+
+// OperationalNodeCache caches resolved operational nodes until their TTLs expire.
+type OperationalNodeCache struct {
+	mutex   sync.Mutex
+	entries map[string]operationalNodeCacheEntry
+	now     func() time.Time
+}
+
+// NewOperationalNodeCache returns a new empty operational node cache.
+func NewOperationalNodeCache() *OperationalNodeCache {
+	return &OperationalNodeCache{
+		mutex:   sync.Mutex{},
+		entries: map[string]operationalNodeCacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// Add stores the node, replacing any previous entry for the same instance name.
+func (cache *OperationalNodeCache) Add(node OperationalNode) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.entries[node.InstanceName()] = operationalNodeCacheEntry{
+		node:      node,
+		expiresAt: cache.now().Add(node.TTL()),
+	}
+}
+
+// Lookup returns the cached node if its TTL has not expired yet.
+func (cache *OperationalNodeCache) Lookup(compressedFabricID uint64, nodeID uint64) (OperationalNode, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	name := OperationalInstanceName(compressedFabricID, nodeID)
+	entry, ok := cache.entries[name]
+	if !ok {
+		return nil, false
+	}
+	if !cache.now().Before(entry.expiresAt) {
+		delete(cache.entries, name)
+		return nil, false
+	}
+	return entry.node, true
+}
+
+// Remove drops the cached node so that the next lookup queries the network again.
+func (cache *OperationalNodeCache) Remove(compressedFabricID uint64, nodeID uint64) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	delete(cache.entries, OperationalInstanceName(compressedFabricID, nodeID))
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdns
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/cybergarage/go-mdns/mdns"
+	"github.com/cybergarage/go-mdns/mdns/dns"
+)
+
+// operationalNode represents an operational node.
+type operationalNode struct {
+	mdns.Service
+	compressedFabricID uint64
+	nodeID             uint64
+}
+
+// NewOperationalNodeWithService returns a new operational node with a mDNS service.
+func NewOperationalNodeWithService(service mdns.Service) (OperationalNode, error) {
+	names := dns.SplitName(service.Name())
+	if len(names) < 1 {
+		return nil, ErrOperationalNodeNotFound
+	}
+	compressedFabricID, nodeID, err := ParseOperationalInstanceName(names[0])
+	if err != nil {
+		return nil, err
+	}
+	node := &operationalNode{
+		Service:            service,
+		compressedFabricID: compressedFabricID,
+		nodeID:             nodeID,
+	}
+	return node, nil
+}
+
+// LookupTxtAttribute looks up a TXT attribute by name.
+func (node *operationalNode) LookupTxtAttribute(name string) (string, bool) {
+	attr, ok := node.Service.LookupResourceAttribute(name)
+	if !ok {
+		return "", false
+	}
+	return attr.Value(), true
+}
+
+// lookupMilliseconds looks up a TXT attribute holding milliseconds.
+func (node *operationalNode) lookupMilliseconds(name string) (time.Duration, bool) {
+	v, ok := node.LookupTxtAttribute(name)
+	if !ok {
+		return 0, false
+	}
+	ms, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// InstanceName returns the operational instance name.
+// 4.3.2.1. Operational Instance Name.
+func (node *operationalNode) InstanceName() string {
+	return OperationalInstanceName(node.compressedFabricID, node.nodeID)
+}
+
+// CompressedFabricID returns the compressed fabric identifier.
+func (node *operationalNode) CompressedFabricID() uint64 {
+	return node.compressedFabricID
+}
+
+// NodeID returns the operational node ID.
+func (node *operationalNode) NodeID() uint64 {
+	return node.nodeID
+}
+
+// Hostname returns the host name from the SRV target.
+// 4.3.2.4. Host Name Construction.
+func (node *operationalNode) Hostname() (string, bool) {
+	for _, rr := range node.Service.ResourceRecords() {
+		switch v := rr.(type) {
+		case dns.SRVRecord:
+			names := dns.SplitName(v.Target())
+			if 0 < len(names) && HostnameRegexp.MatchString(names[0]) {
+				return names[0], true
+			}
+		}
+	}
+	return "", false
+}
+
+// Addresses returns the IP addresses.
+func (node *operationalNode) Addresses() ([]net.IP, bool) {
+	addrs := node.Service.Addresses()
+	if len(addrs) == 0 {
+		return []net.IP{}, false
+	}
+	return addrs, true
+}
+
+// Port returns the port number.
+func (node *operationalNode) Port() (int, bool) {
+	port := node.Service.Port()
+	if port <= 0 {
+		return 0, false
+	}
+	return port, true
+}
+
+// SessionIdleInterval returns the session idle interval.
+// 4.3.4.1. TXT key for session idle interval (SII).
+func (node *operationalNode) SessionIdleInterval() (time.Duration, bool) {
+	return node.lookupMilliseconds(TxtRecordSessionIdleInterval)
+}
+
+// SessionActiveInterval returns the session active interval.
+// 4.3.4.2. TXT key for session active interval (SAI).
+func (node *operationalNode) SessionActiveInterval() (time.Duration, bool) {
+	return node.lookupMilliseconds(TxtRecordSessionActiveInterval)
+}
+
+// SessionActiveThreshold returns the session active threshold.
+// 4.3.4.3. TXT key for session active threshold (SAT).
+func (node *operationalNode) SessionActiveThreshold() (time.Duration, bool) {
+	return node.lookupMilliseconds(TxtRecordSessionActiveThreshold)
+}
+
+// TCPSupport returns the supported TCP modes.
+// 4.3.4.4. TXT key for TCP support (T).
+func (node *operationalNode) TCPSupport() (TCPSupport, bool) {
+	v, ok := node.LookupTxtAttribute(TxtRecordTCPSupport)
+	if !ok {
+		return 0, false
+	}
+	t, err := NewTCPSupportFromString(v)
+	if err != nil {
+		return 0, false
+	}
+	return t, true
+}
+
+// LongIdleTimeICD returns true if the node operates as a Long Idle Time ICD.
+// 4.3.4.5. TXT key for ICD operating mode (ICD).
+func (node *operationalNode) LongIdleTimeICD() (bool, bool) {
+	v, ok := node.LookupTxtAttribute(TxtRecordICDOperatingMode)
+	if !ok {
+		return false, false
+	}
+	switch v {
+	case "0":
+		return false, true
+	case "1":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// TTL returns the shortest time to live of the records describing the node.
+func (node *operationalNode) TTL() time.Duration {
+	return serviceTTL(node.Service)
+}
+
+// String returns the string representation.
+func (node *operationalNode) String() string {
+	return node.Service.String()
+}
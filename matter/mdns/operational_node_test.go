@@ -0,0 +1,169 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdns
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOperationalInstanceName(t *testing.T) {
+	// 4.3.2.1. Operational Instance Name
+	name := OperationalInstanceName(0x2906C908D115D362, 0x8FC7772401CD0696)
+	if name != "2906C908D115D362-8FC7772401CD0696" {
+		t.Errorf("OperationalInstanceName() = %s", name)
+	}
+	cfid, nodeID, err := ParseOperationalInstanceName(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfid != 0x2906C908D115D362 || nodeID != 0x8FC7772401CD0696 {
+		t.Errorf("ParseOperationalInstanceName() = %016X, %016X", cfid, nodeID)
+	}
+	for _, name := range []string{"", "2906C908D115D362", "2906C908D115D362-8FC7", "2906C908D115D36Z-8FC7772401CD0696"} {
+		if _, _, err := ParseOperationalInstanceName(name); err == nil {
+			t.Errorf("ParseOperationalInstanceName(%q) succeeded", name)
+		}
+	}
+}
+
+func TestOperationalQuery(t *testing.T) {
+	query := NewQuery(WithQueryCompressedFabricID(0x2906C908D115D362))
+	if query.DomainName() != "_I2906C908D115D362._sub._matter._tcp.local" {
+		t.Errorf("DomainName() = %s", query.DomainName())
+	}
+}
+
+func TestTCPSupport(t *testing.T) {
+	tests := []struct {
+		s      string
+		client bool
+		server bool
+	}{
+		{"0", false, false},
+		{"2", true, false},
+		{"4", false, true},
+		{"6", true, true},
+	}
+	for _, test := range tests {
+		v, err := NewTCPSupportFromString(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.IsClient() != test.client || v.IsServer() != test.server {
+			t.Errorf("T=%s: client %t server %t", test.s, v.IsClient(), v.IsServer())
+		}
+	}
+	if _, err := NewTCPSupportFromString("x"); err == nil {
+		t.Error("NewTCPSupportFromString(x) succeeded")
+	}
+}
+
+type cachedOperationalNode struct {
+	OperationalNode
+	cfid   uint64
+	nodeID uint64
+	ttl    time.Duration
+}
+
+func (node *cachedOperationalNode) InstanceName() string {
+	return OperationalInstanceName(node.cfid, node.nodeID)
+}
+
+func (node *cachedOperationalNode) TTL() time.Duration {
+	return node.ttl
+}
+
+func TestOperationalNodeCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewOperationalNodeCache()
+	cache.now = func() time.Time { return now }
+
+	node := &cachedOperationalNode{cfid: 1, nodeID: 2, ttl: 120 * time.Second}
+	cache.Add(node)
+	if v, ok := cache.Lookup(1, 2); !ok || v != node {
+		t.Errorf("Lookup() = %v, %t", v, ok)
+	}
+	if _, ok := cache.Lookup(1, 3); ok {
+		t.Error("Lookup() found an unknown node")
+	}
+
+	now = now.Add(119 * time.Second)
+	if _, ok := cache.Lookup(1, 2); !ok {
+		t.Error("Lookup() expired before the TTL")
+	}
+	now = now.Add(time.Second)
+	if _, ok := cache.Lookup(1, 2); ok {
+		t.Error("Lookup() returned an expired node")
+	}
+
+	cache.Add(node)
+	cache.Remove(1, 2)
+	if _, ok := cache.Lookup(1, 2); ok {
+		t.Error("Lookup() returned a removed node")
+	}
+}
+
+type addressedOperationalNode struct {
+	OperationalNode
+	addrs []net.IP
+	port  int
+}
+
+func (node *addressedOperationalNode) Addresses() ([]net.IP, bool) {
+	return node.addrs, len(node.addrs) != 0
+}
+
+func (node *addressedOperationalNode) Port() (int, bool) {
+	return node.port, node.port != 0
+}
+
+func TestOperationalAddresses(t *testing.T) {
+	tests := []struct {
+		name string
+		node *addressedOperationalNode
+		want []string
+	}{
+		{
+			name: "routable ipv6 first",
+			node: &addressedOperationalNode{
+				addrs: []net.IP{net.ParseIP("fe80::1"), net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1"), net.ParseIP("192.0.2.2")},
+				port:  5541,
+			},
+			want: []string{"[2001:db8::1]:5541", "192.0.2.1:5541", "192.0.2.2:5541", "[fe80::1]:5541"},
+		},
+		{
+			name: "default port",
+			node: &addressedOperationalNode{addrs: []net.IP{net.ParseIP("192.0.2.1")}},
+			want: []string{"192.0.2.1:5540"},
+		},
+		{
+			name: "no address",
+			node: &addressedOperationalNode{port: 5540},
+			want: []string{},
+		},
+	}
+	for _, test := range tests {
+		got := []string{}
+		for _, addr := range OperationalAddresses(test.node) {
+			got = append(got, addr.String())
+		}
+		if strings.Join(got, " ") != strings.Join(test.want, " ") {
+			t.Errorf("%s: OperationalAddresses() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -40,6 +40,12 @@ const (
 	QuerySubtypeCommissioningMode = "_CM"
 )
 
+// 4.3.2.2. Compressed Fabric Identifier Subtype.
+const (
+	// QuerySubtypeCompressedFabricID represents the compressed fabric identifier query subtype.
+	QuerySubtypeCompressedFabricID = "_I"
+)
+
 // Query represents a mDNS query.
 type Query interface {
 	// Subtype returns the subtype for the query.
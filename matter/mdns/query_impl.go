@@ -66,6 +66,14 @@ func WithQueryOnboardingPayload(payload OnboardingPayload) QueryOption {
 	}
 }
 
+// WithQueryCompressedFabricID sets the operational service and the compressed fabric identifier subtype for the query.
+func WithQueryCompressedFabricID(compressedFabricID uint64) QueryOption {
+	return func(q *query) {
+		q.service = OperationalNodeService
+		q.subtype = fmt.Sprintf("%s%016X", QuerySubtypeCompressedFabricID, compressedFabricID)
+	}
+}
+
 // NewQuery creates a new Query instance.
 func NewQuery(opts ...QueryOption) Query {
 	q := &query{
@@ -0,0 +1,81 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+)
+
+// NOCIssuer signs the node operational certificates of commissionees with
+// the root CA key of a fabric the controller administers.
+type NOCIssuer struct {
+	fabric *credentials.Fabric
+}
+
+var _ commissioning.CredentialIssuer = (*NOCIssuer)(nil)
+
+// NewNOCIssuer returns an issuer for fabric, which must hold its root CA key.
+func NewNOCIssuer(fabric *credentials.Fabric) (*NOCIssuer, error) {
+	if fabric.RootKey == nil {
+		return nil, credentials.ErrNoRootKey
+	}
+	return &NOCIssuer{fabric: fabric}, nil
+}
+
+// IssueNOC checks the CSR of the commissionee against the request nonce and,
+// when the DAC is known, its attestation signature, and returns the NOC for
+// the requested node ID issued directly by the root CA. The controller's
+// node ID becomes the CASE admin subject.
+// 6.5.5. Node Operational Credentials Certificate.
+func (i *NOCIssuer) IssueNOC(_ context.Context, req commissioning.NOCRequest) (*commissioning.NOCChain, error) {
+	pub, err := credentials.ParseNOCSRElements(req.NOCSRElements, req.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.DAC) != 0 {
+		if err := credentials.VerifyNOCSRSignature(req.DAC, req.NOCSRElements, req.Challenge, req.AttestationSignature); err != nil {
+			return nil, err
+		}
+	}
+	noc, err := i.fabric.IssueNOC(rand.Reader, req.NodeID, pub)
+	if err != nil {
+		return nil, err
+	}
+	nocBytes, err := noc.Encode()
+	if err != nil {
+		return nil, err
+	}
+	rcac, err := i.fabric.RCAC.Encode()
+	if err != nil {
+		return nil, err
+	}
+	if len(i.fabric.EpochKey) != credentials.EpochKeySize {
+		return nil, fmt.Errorf("fabric %016X has no IPK epoch key", i.fabric.FabricID)
+	}
+	return &commissioning.NOCChain{
+		RCAC:             rcac,
+		NOC:              nocBytes,
+		IPK:              i.fabric.EpochKey,
+		CaseAdminSubject: i.fabric.NodeID,
+		AdminVendorID:    i.fabric.VendorID,
+		FabricID:         i.fabric.FabricID,
+		RootPublicKey:    i.fabric.RCAC.PublicKey,
+	}, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package matter
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/credentials"
+)
+
+func TestNOCIssuer(t *testing.T) {
+	fabric, err := credentials.NewFabric(rand.Reader, 0xFAB000000000001D, 0x1B669, 0xFFF1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := NewNOCIssuer(fabric)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := bytes.Repeat([]byte{0x11}, 32)
+	elements, err := credentials.NewNOCSRElements(rand.Reader, key, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		nonce   []byte
+		wantErr error
+	}{
+		{"matching nonce", nonce, nil},
+		{"stale nonce", bytes.Repeat([]byte{0x22}, 32), credentials.ErrInvalidCSR},
+	}
+	for _, tt := range tests {
+		chain, err := issuer.IssueNOC(context.Background(), commissioning.NOCRequest{
+			NodeID:        0x1234,
+			NOCSRElements: elements,
+			Nonce:         tt.nonce,
+		})
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: IssueNOC() = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		noc, err := credentials.VerifyNOC(fabric.RCAC, chain.NOC, chain.ICAC, time.Now())
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if id, _ := noc.Subject.Value(credentials.DNNodeID); id != 0x1234 {
+			t.Errorf("%s: NOC node ID %X", tt.name, id)
+		}
+		if pub, err := noc.ECDSAPublicKey(); err != nil || !pub.Equal(&key.PublicKey) {
+			t.Errorf("%s: NOC key differs from the CSR key", tt.name)
+		}
+		if chain.CaseAdminSubject != fabric.NodeID || chain.FabricID != fabric.FabricID || !bytes.Equal(chain.IPK, fabric.EpochKey) {
+			t.Errorf("%s: chain %+v", tt.name, chain)
+		}
+	}
+
+	fabric.RootKey = nil
+	if _, err := NewNOCIssuer(fabric); !errors.Is(err, credentials.ErrNoRootKey) {
+		t.Errorf("NewNOCIssuer() without root key = %v", err)
+	}
+}
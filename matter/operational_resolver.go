@@ -0,0 +1,64 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package matter
+
+import (
+	"context"
+	"net"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
+	"github.com/YashubuStudio/go-matter-pack/matter/types"
+)
+
+// OperationalResolver finds commissioned nodes through _matter._tcp
+// operational discovery for the operational discovery stage of
+// commissioning.
+type OperationalResolver struct {
+	discoverer mdns.Discoverer
+}
+
+var _ commissioning.Resolver = (*OperationalResolver)(nil)
+
+// NewOperationalResolver returns a resolver querying with discoverer.
+func NewOperationalResolver(discoverer mdns.Discoverer) *OperationalResolver {
+	return &OperationalResolver{discoverer: discoverer}
+}
+
+// ResolveOperational returns the addresses peer advertises on its new
+// fabric, routable IPv6 addresses first. The instance name is derived from
+// the compressed fabric identifier of the peer's root public key and fabric.
+// 4.3.2. Operational Discovery.
+func (r *OperationalResolver) ResolveOperational(ctx context.Context, peer commissioning.OperationalPeer) ([]*net.UDPAddr, error) {
+	cfid, err := types.NewCompressedFabricID(peer.RootPublicKey, peer.FabricID)
+	if err != nil {
+		return nil, err
+	}
+	node, err := r.discoverer.ResolveOperationalNode(ctx, uint64(cfid), peer.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return mdns.OperationalAddresses(node), nil
+}
+
+// addressResolver resolves every node to the address it was commissioned
+// at, for devices commissioned by address without operational discovery.
+type addressResolver struct {
+	addr *net.UDPAddr
+}
+
+// ResolveOperational returns the commissioning address.
+func (r addressResolver) ResolveOperational(context.Context, commissioning.OperationalPeer) ([]*net.UDPAddr, error) {
+	return []*net.UDPAddr{r.addr}, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package matter
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/commissioning"
+	"github.com/YashubuStudio/go-matter-pack/matter/mdns"
+)
+
+type fakeOperationalNode struct {
+	mdns.OperationalNode
+	addrs []net.IP
+}
+
+func (node *fakeOperationalNode) Addresses() ([]net.IP, bool) {
+	return node.addrs, len(node.addrs) != 0
+}
+func (node *fakeOperationalNode) Port() (int, bool) { return 5540, true }
+
+// fakeOperationalDiscoverer answers operational queries from nodes keyed by
+// operational instance name.
+type fakeOperationalDiscoverer struct {
+	mdns.Discoverer
+	nodes map[string]mdns.OperationalNode
+}
+
+func (d *fakeOperationalDiscoverer) ResolveOperationalNode(_ context.Context, compressedFabricID uint64, nodeID uint64) (mdns.OperationalNode, error) {
+	name := mdns.OperationalInstanceName(compressedFabricID, nodeID)
+	node, ok := d.nodes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", mdns.ErrOperationalNodeNotFound, name)
+	}
+	return node, nil
+}
+
+func TestOperationalResolver(t *testing.T) {
+	// 4.3.2.2. Compressed Fabric Identifier: the example root public key and
+	// fabric ID give the compressed fabric ID 87E1B004E235A130.
+	rootPublicKey, _ := hex.DecodeString(
+		"044a9f42b1ca4840d37292bbc7f6a7e11e22200c976fc900dbc98a7a383a641c" +
+			"b8254a2e56d4e295a847943b4e3897c4a773e930277b4d9fbede8a052686bfacfa")
+	resolver := NewOperationalResolver(&fakeOperationalDiscoverer{
+		nodes: map[string]mdns.OperationalNode{
+			"87E1B004E235A130-0000000000000010": &fakeOperationalNode{
+				addrs: []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1")},
+			},
+		},
+	})
+
+	tests := []struct {
+		name    string
+		peer    commissioning.OperationalPeer
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "resolved",
+			peer: commissioning.OperationalPeer{FabricID: 0x2906C908D115D362, NodeID: 0x10, RootPublicKey: rootPublicKey},
+			want: []string{"[2001:db8::1]:5540", "192.0.2.1:5540"},
+		},
+		{
+			name:    "other node",
+			peer:    commissioning.OperationalPeer{FabricID: 0x2906C908D115D362, NodeID: 0x11, RootPublicKey: rootPublicKey},
+			wantErr: true,
+		},
+		{
+			name:    "other fabric",
+			peer:    commissioning.OperationalPeer{FabricID: 0x2906C908D115D363, NodeID: 0x10, RootPublicKey: rootPublicKey},
+			wantErr: true,
+		},
+		{
+			name:    "invalid root public key",
+			peer:    commissioning.OperationalPeer{FabricID: 0x2906C908D115D362, NodeID: 0x10, RootPublicKey: rootPublicKey[1:]},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		addrs, err := resolver.ResolveOperational(context.Background(), tt.peer)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ResolveOperational() error = %v", tt.name, err)
+			continue
+		}
+		got := []string{}
+		for _, addr := range addrs {
+			got = append(got, addr.String())
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) && !tt.wantErr {
+			t.Errorf("%s: ResolveOperational() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -16,12 +16,16 @@ package pase
 
 import (
 	"crypto/sha256"
+	"encoding/binary"
 	"hash"
 
 	"github.com/YashubuStudio/go-matter-pack/matter/crypto/pake/spake2p"
 	"github.com/YashubuStudio/go-matter-pack/matter/crypto/pbkdf"
 )
 
+// wSize is CRYPTO_W_SIZE_BYTES for P-256.
+const wSize = 40
+
 // HandshakeRole represents the role in the PASE handshake.
 type HandshakeRole int
 
@@ -35,11 +39,14 @@ const (
 // HandshakeOptions holds the options for creating a PASE handshake.
 type HandshakeOptions struct {
 	// Passcode is the Matter passcode used to derive w0 and w1.
-	Passcode []byte
+	Passcode Passcode
 	// Salt is the salt used in PBKDF2 derivation.
 	Salt []byte
 	// PBKDFIter is the number of iterations for PBKDF2 (default 1000 per Matter spec).
 	PBKDFIter int
+	// Context is the SPAKE2+ context: the hash of the "CHIP PAKE V1
+	// Commissioning" prefix and the PBKDF parameter messages.
+	Context []byte
 	// Hash function to use (defaults to SHA-256).
 	Hash func() hash.Hash
 }
@@ -64,20 +71,7 @@ func NewHandshake(role HandshakeRole, opts HandshakeOptions) *Handshake {
 		opts.PBKDFIter = 1000 // Default per Matter Core Spec 1.5 Section 3.9
 	}
 
-	// Derive w0 and w1 using PBKDF2
-	// Reference: Matter Core Spec 1.5, Section 3.9 (PBKDF), Section 4.14.1 (PASE)
-	// According to Matter spec, we derive a 64-byte buffer and split it into two 32-byte halves
-	w0w1 := pbkdf.CryptoPBKDF(pbkdf.Params{
-		Password: opts.Passcode,
-		Salt:     opts.Salt,
-		Iter:     opts.PBKDFIter,
-		KeyLen:   64, // 64 bytes total: 32 for w0, 32 for w1
-		Hash:     opts.Hash,
-	})
-
-	// Split into w0 (first 32 bytes) and w1 (last 32 bytes)
-	w0 := w0w1[:32]
-	w1 := w0w1[32:64]
+	w0, w1 := deriveW(opts.Passcode, opts.Salt, opts.PBKDFIter, opts.Hash)
 
 	// Map HandshakeRole to SPAKE2+ Role
 	var spakeRole spake2p.Role
@@ -89,9 +83,10 @@ func NewHandshake(role HandshakeRole, opts HandshakeOptions) *Handshake {
 
 	// Create SPAKE2+ suite
 	suite := spake2p.New(spakeRole, spake2p.Params{
-		W0:   w0,
-		W1:   w1,
-		Hash: opts.Hash,
+		W0:      w0,
+		W1:      w1,
+		Context: opts.Context,
+		Hash:    opts.Hash,
 	})
 
 	return &Handshake{
@@ -100,6 +95,34 @@ func NewHandshake(role HandshakeRole, opts HandshakeOptions) *Handshake {
 	}
 }
 
+// deriveW derives w0s and w1s using PBKDF2 over the little-endian passcode.
+// Reference: Matter Core Spec 1.5, Section 3.9 (PBKDF), Section 3.10 (SPAKE2+)
+// Each half is CRYPTO_W_SIZE_BYTES (group size + 8) long; the SPAKE2+
+// suite reduces them modulo the group order.
+func deriveW(passcode Passcode, salt []byte, iter int, h func() hash.Hash) ([]byte, []byte) {
+	password := binary.LittleEndian.AppendUint32(nil, passcode)
+	w0w1 := pbkdf.CryptoPBKDF(pbkdf.Params{
+		Password: password,
+		Salt:     salt,
+		Iter:     iter,
+		KeyLen:   2 * wSize,
+		Hash:     h,
+	})
+	return w0w1[:wSize], w0w1[wSize:]
+}
+
+// ComputeVerifier returns the SPAKE2+ verifier (w0 || L) for passcode, as
+// sent in an Enhanced Commissioning Window so the commissionee never
+// learns the passcode.
+func ComputeVerifier(passcode Passcode, salt []byte, iterations uint32) ([]byte, error) {
+	params := PBKDFParameters{Iterations: iterations, Salt: salt}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	w0, w1 := deriveW(passcode, salt, int(iterations), sha256.New)
+	return spake2p.Verifier(w0, w1), nil
+}
+
 // Start initiates the PASE handshake and returns the public value to send to the peer.
 // Reference: Matter Core Spec 1.5, Section 4.14.1 (PASE Protocol)
 // For client role, this returns X (Pake1 message).
@@ -134,7 +157,7 @@ func (h *Handshake) GetConfirmation() ([]byte, error) {
 
 // ExportKeys derives the session keys after successful handshake completion.
 // Reference: Matter Core Spec 1.5, Section 4.14.1.4 (Session Key Generation)
-// Returns (I2R key, R2I key, error).
-func (h *Handshake) ExportKeys() ([]byte, []byte, error) {
+// Returns the I2R and R2I keys and the attestation challenge.
+func (h *Handshake) ExportKeys() (*spake2p.SessionKeys, error) {
 	return h.suite.ExportKeys()
 }
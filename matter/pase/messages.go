@@ -14,13 +14,25 @@
 
 package pase
 
+import (
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// Sizes of the PAKE message fields for the P-256 / SHA-256 suite.
+const (
+	pointLength = 65
+	macLength   = 32
+)
+
 // Pake1 represents the PASE PAKE1 message (first message in PASE handshake).
 // Reference: Matter Core Spec 1.5, Section 4.14.1.2 (PASE Message Flow)
 // This message contains the prover's (client's) public value X.
 type Pake1 struct {
 	// X is the prover's public value (SPAKE2+ X point in SEC1 uncompressed form).
 	// Expected size: 65 bytes for P-256 curve (0x04 || 32-byte x || 32-byte y).
-	X []byte
+	X []byte `tlv:"1"`
 }
 
 // NewPake1 creates a new Pake1 message with the given public value.
@@ -28,16 +40,21 @@ func NewPake1(x []byte) *Pake1 {
 	return &Pake1{X: x}
 }
 
-// Bytes returns the byte representation of the Pake1 message.
-// The message format is: opcode (1 byte) || X (typically 65 bytes for P-256 uncompressed point).
-// Note: X length is not validated here; validation should occur in SPAKE2+ implementation.
-// TODO: Migrate to encoding.tlv for proper TLV encoding per Matter Core Spec 1.5 Section 4.14.1.2.
-func (p *Pake1) Bytes() []byte {
-	// Prefix with opPASEPake1 opcode
-	result := make([]byte, 1+len(p.X))
-	result[0] = opPASEPake1
-	copy(result[1:], p.X)
-	return result
+// Encode returns the TLV encoding of the Pake1 message.
+func (p *Pake1) Encode() ([]byte, error) {
+	return tlv.Marshal(p)
+}
+
+// DecodePake1 parses a Pake1 payload.
+func DecodePake1(b []byte) (*Pake1, error) {
+	p := &Pake1{}
+	if err := decode(b, p); err != nil {
+		return nil, err
+	}
+	if len(p.X) != pointLength {
+		return nil, fmt.Errorf("%w: pA of %d bytes", ErrInvalidMessage, len(p.X))
+	}
+	return p, nil
 }
 
 // Pake2 represents the PASE PAKE2 message (second message in PASE handshake).
@@ -46,10 +63,9 @@ func (p *Pake1) Bytes() []byte {
 type Pake2 struct {
 	// Y is the verifier's public value (SPAKE2+ Y point in SEC1 uncompressed form).
 	// Expected size: 65 bytes for P-256 curve (0x04 || 32-byte x || 32-byte y).
-	Y []byte
-	// CMac is the verifier's confirmation MAC.
-	// Size depends on HKDF/HMAC output (typically 32 bytes for SHA-256).
-	CMac []byte
+	Y []byte `tlv:"1"`
+	// CMac is the verifier's confirmation MAC (cB), 32 bytes for HMAC-SHA256.
+	CMac []byte `tlv:"2"`
 }
 
 // NewPake2 creates a new Pake2 message with the given public value and confirmation MAC.
@@ -57,26 +73,29 @@ func NewPake2(y, cmac []byte) *Pake2 {
 	return &Pake2{Y: y, CMac: cmac}
 }
 
-// Bytes returns the byte representation of the Pake2 message.
-// The message format is: opcode (1 byte) || Y (typically 65 bytes) || CMac (typically 32 bytes).
-// Note: Field lengths are not validated here; validation should occur in SPAKE2+ implementation.
-// TODO: Migrate to encoding.tlv for proper TLV encoding per Matter Core Spec 1.5 Section 4.14.1.2.
-func (p *Pake2) Bytes() []byte {
-	// Prefix with opPASEPake2 opcode
-	result := make([]byte, 1+len(p.Y)+len(p.CMac))
-	result[0] = opPASEPake2
-	copy(result[1:], p.Y)
-	copy(result[1+len(p.Y):], p.CMac)
-	return result
+// Encode returns the TLV encoding of the Pake2 message.
+func (p *Pake2) Encode() ([]byte, error) {
+	return tlv.Marshal(p)
+}
+
+// DecodePake2 parses a Pake2 payload.
+func DecodePake2(b []byte) (*Pake2, error) {
+	p := &Pake2{}
+	if err := decode(b, p); err != nil {
+		return nil, err
+	}
+	if len(p.Y) != pointLength || len(p.CMac) != macLength {
+		return nil, fmt.Errorf("%w: pB of %d bytes, cB of %d bytes", ErrInvalidMessage, len(p.Y), len(p.CMac))
+	}
+	return p, nil
 }
 
 // Pake3 represents the PASE PAKE3 message (third message in PASE handshake).
 // Reference: Matter Core Spec 1.5, Section 4.14.1.2 (PASE Message Flow)
 // This message contains the prover's (client's) confirmation MAC.
 type Pake3 struct {
-	// SMac is the prover's confirmation MAC.
-	// Size depends on HKDF/HMAC output (typically 32 bytes for SHA-256).
-	SMac []byte
+	// SMac is the prover's confirmation MAC (cA), 32 bytes for HMAC-SHA256.
+	SMac []byte `tlv:"1"`
 }
 
 // NewPake3 creates a new Pake3 message with the given confirmation MAC.
@@ -84,14 +103,19 @@ func NewPake3(smac []byte) *Pake3 {
 	return &Pake3{SMac: smac}
 }
 
-// Bytes returns the byte representation of the Pake3 message.
-// The message format is: opcode (1 byte) || SMac (typically 32 bytes).
-// Note: SMac length is not validated here; validation should occur in SPAKE2+ implementation.
-// TODO: Migrate to encoding.tlv for proper TLV encoding per Matter Core Spec 1.5 Section 4.14.1.2.
-func (p *Pake3) Bytes() []byte {
-	// Prefix with opPASEPake3 opcode
-	result := make([]byte, 1+len(p.SMac))
-	result[0] = opPASEPake3
-	copy(result[1:], p.SMac)
-	return result
+// Encode returns the TLV encoding of the Pake3 message.
+func (p *Pake3) Encode() ([]byte, error) {
+	return tlv.Marshal(p)
+}
+
+// DecodePake3 parses a Pake3 payload.
+func DecodePake3(b []byte) (*Pake3, error) {
+	p := &Pake3{}
+	if err := decode(b, p); err != nil {
+		return nil, err
+	}
+	if len(p.SMac) != macLength {
+		return nil, fmt.Errorf("%w: cA of %d bytes", ErrInvalidMessage, len(p.SMac))
+	}
+	return p, nil
 }
@@ -13,10 +13,15 @@
 
 package pase
 
+import (
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
+
+// PASE opcodes of the Secure Channel protocol.
 const (
-	opPBKDFParamRequest  = 0x00
-	opPBKDFParamResponse = 0x01
-	opPASEPake1          = 0x02
-	opPASEPake2          = 0x03
-	opPASEPake3          = 0x04
+	opPBKDFParamRequest  protocol.Opcode = 0x20
+	opPBKDFParamResponse protocol.Opcode = 0x21
+	opPASEPake1          protocol.Opcode = 0x22
+	opPASEPake2          protocol.Opcode = 0x23
+	opPASEPake3          protocol.Opcode = 0x24
 )
@@ -14,37 +14,111 @@
 
 package pase
 
-// ParamRequest represents a PASE parameter request.
-type ParamRequest interface {
-	Bytes() []byte
+import (
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+)
+
+// PBKDF parameter limits.
+// Reference: Matter Core Spec 1.5, Section 3.9 (PBKDF)
+const (
+	PBKDFMinIterations = 1000
+	PBKDFMaxIterations = 100000
+	PBKDFMinSaltLength = 16
+	PBKDFMaxSaltLength = 32
+)
+
+// randomLength is the size of the initiator and responder randoms.
+const randomLength = 32
+
+// SessionParameters are the MRP parameters a node advertises during session
+// establishment. Intervals are in milliseconds.
+type SessionParameters struct {
+	SessionIdleInterval    *uint32 `tlv:"1,omitempty"`
+	SessionActiveInterval  *uint32 `tlv:"2,omitempty"`
+	SessionActiveThreshold *uint16 `tlv:"4,omitempty"`
 }
 
-type paramRequest struct {
-	bytes []byte
+// PBKDFParameters are the passcode derivation parameters of the responder.
+type PBKDFParameters struct {
+	Iterations uint32 `tlv:"1"`
+	Salt       []byte `tlv:"2"`
 }
 
-func NewParamRequest() ParamRequest {
-	// 4.14.1. Passcode-Authenticated Session Establishment (PASE) - PBKDFParamRequest
-	return &paramRequest{
-		bytes: []byte{opPBKDFParamRequest},
+// Validate checks the parameters against the limits of the specification.
+func (p *PBKDFParameters) Validate() error {
+	if p.Iterations < PBKDFMinIterations || p.Iterations > PBKDFMaxIterations {
+		return fmt.Errorf("%w: PBKDF iterations %d out of range", ErrInvalidMessage, p.Iterations)
+	}
+	if len(p.Salt) < PBKDFMinSaltLength || len(p.Salt) > PBKDFMaxSaltLength {
+		return fmt.Errorf("%w: PBKDF salt of %d bytes", ErrInvalidMessage, len(p.Salt))
 	}
+	return nil
 }
 
-// Bytes returns the byte representation of the parameter request.
-func (req *paramRequest) Bytes() []byte {
-	return req.bytes
+// ParamRequest represents a PASE parameter request (PBKDFParamRequest).
+// Reference: Matter Core Spec 1.5, Section 4.14.1.2 (Protocol Details)
+type ParamRequest struct {
+	InitiatorRandom        []byte             `tlv:"1"`
+	InitiatorSessionID     uint16             `tlv:"2"`
+	PasscodeID             uint16             `tlv:"3"`
+	HasPBKDFParameters     bool               `tlv:"4"`
+	InitiatorSessionParams *SessionParameters `tlv:"5,omitempty"`
 }
 
-// ParamResponse represents a PASE parameter response.
-type ParamResponse interface {
-	Bytes() []byte
+// ParamResponse represents a PASE parameter response (PBKDFParamResponse).
+// PBKDFParameters is omitted when the initiator already has them.
+type ParamResponse struct {
+	InitiatorRandom        []byte             `tlv:"1"`
+	ResponderRandom        []byte             `tlv:"2"`
+	ResponderSessionID     uint16             `tlv:"3"`
+	PBKDFParameters        *PBKDFParameters   `tlv:"4,omitempty"`
+	ResponderSessionParams *SessionParameters `tlv:"5,omitempty"`
 }
 
-type paramResoponse struct {
-	bytes []byte
+// Encode returns the TLV encoding of the request.
+func (req *ParamRequest) Encode() ([]byte, error) {
+	return tlv.Marshal(req)
+}
+
+// DecodeParamRequest parses a PBKDFParamRequest payload.
+func DecodeParamRequest(b []byte) (*ParamRequest, error) {
+	req := &ParamRequest{}
+	if err := decode(b, req); err != nil {
+		return nil, err
+	}
+	if len(req.InitiatorRandom) != randomLength {
+		return nil, fmt.Errorf("%w: initiator random of %d bytes", ErrInvalidMessage, len(req.InitiatorRandom))
+	}
+	return req, nil
 }
 
-// Bytes returns the byte representation of the parameter response.
-func (res *paramResoponse) Bytes() []byte {
-	return res.bytes
+// Encode returns the TLV encoding of the response.
+func (res *ParamResponse) Encode() ([]byte, error) {
+	return tlv.Marshal(res)
+}
+
+// DecodeParamResponse parses a PBKDFParamResponse payload.
+func DecodeParamResponse(b []byte) (*ParamResponse, error) {
+	res := &ParamResponse{}
+	if err := decode(b, res); err != nil {
+		return nil, err
+	}
+	if len(res.ResponderRandom) != randomLength {
+		return nil, fmt.Errorf("%w: responder random of %d bytes", ErrInvalidMessage, len(res.ResponderRandom))
+	}
+	if res.PBKDFParameters != nil {
+		if err := res.PBKDFParameters.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func decode(b []byte, v any) error {
+	if err := tlv.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+	}
+	return nil
 }
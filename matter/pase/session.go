@@ -15,36 +15,306 @@
 package pase
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
 	"github.com/YashubuStudio/go-matter-pack/matter/encoding"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
 )
 
-// Session represents a PASE session.
-type Session any
-
-// SessionOption represents a session option.
-type SessionOption func(*session)
+var (
+	// ErrInvalidMessage is returned when a PASE message is malformed or
+	// unexpected.
+	ErrInvalidMessage = errors.New("pase: invalid message")
+	// ErrAuthentication is returned when the peer confirmation does not
+	// match, which usually means the passcodes differ.
+	ErrAuthentication = errors.New("pase: peer authentication failed")
+)
 
 // Passcode represents a passcode.
 type Passcode = encoding.Passcode
 
-// WithPasscode returns a session option that sets the passcode.
-func WithPasscode(passcode Passcode) SessionOption {
-	return func(sess *session) {
-		sess.passcode = passcode
+// contextPrefix starts the SPAKE2+ context hash.
+const contextPrefix = "CHIP PAKE V1 Commissioning"
+
+// Establish runs the PASE handshake as the initiator over the unsecured
+// session s and returns the resulting secure session, which shares the
+// connection of s.
+// Reference: Matter Core Spec 1.5, Passcode-Authenticated Session Establishment
+func Establish(ctx context.Context, s *transport.Session, passcode Passcode) (*transport.Session, error) {
+	if s.IsSecure() {
+		return nil, errors.New("pase: handshake must run over the unsecured session")
+	}
+	localSessionID, err := randomSessionID()
+	if err != nil {
+		return nil, err
+	}
+	initiatorRandom := make([]byte, randomLength)
+	if _, err := rand.Read(initiatorRandom); err != nil {
+		return nil, err
+	}
+	req := &ParamRequest{
+		InitiatorRandom:    initiatorRandom,
+		InitiatorSessionID: localSessionID,
+	}
+	reqBytes, err := req.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	ex := s.NewExchange()
+	defer ex.Close()
+	msg, err := ex.Request(ctx, protocol.SecureChannelProtocol, opPBKDFParamRequest, reqBytes)
+	if err := expect(msg, err, opPBKDFParamResponse); err != nil {
+		return nil, fmt.Errorf("PBKDFParamRequest: %w", err)
+	}
+	res, err := DecodeParamResponse(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(res.InitiatorRandom, initiatorRandom) {
+		return nil, fmt.Errorf("%w: PBKDFParamResponse does not echo the initiator random", ErrInvalidMessage)
+	}
+	if res.PBKDFParameters == nil {
+		return nil, fmt.Errorf("%w: PBKDFParamResponse without PBKDF parameters", ErrInvalidMessage)
+	}
+
+	hs := NewHandshake(HandshakeRoleClient, HandshakeOptions{
+		Passcode:  passcode,
+		Salt:      res.PBKDFParameters.Salt,
+		PBKDFIter: int(res.PBKDFParameters.Iterations),
+		Context:   handshakeContext(reqBytes, msg.Payload),
+	})
+	x, err := hs.Start()
+	if err != nil {
+		return nil, err
+	}
+	pake1, err := NewPake1(x).Encode()
+	if err != nil {
+		return nil, err
+	}
+	msg, err = ex.Request(ctx, protocol.SecureChannelProtocol, opPASEPake1, pake1)
+	if err := expect(msg, err, opPASEPake2); err != nil {
+		return nil, fmt.Errorf("Pake1: %w", err)
+	}
+	pake2, err := DecodePake2(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := hs.ProcessPeer(pake2.Y); err != nil {
+		return nil, sendFailure(ctx, ex, fmt.Errorf("%w: %w", ErrInvalidMessage, err))
+	}
+	if err := hs.Verify(pake2.CMac); err != nil {
+		return nil, sendFailure(ctx, ex, fmt.Errorf("%w: check the setup passcode", ErrAuthentication))
+	}
+	cA, err := hs.GetConfirmation()
+	if err != nil {
+		return nil, err
+	}
+	pake3, err := NewPake3(cA).Encode()
+	if err != nil {
+		return nil, err
+	}
+	msg, err = ex.Request(ctx, protocol.SecureChannelProtocol, opPASEPake3, pake3)
+	if err := expect(msg, err, protocol.StatusReportMessage); err != nil {
+		return nil, fmt.Errorf("Pake3: %w", err)
+	}
+	if err := sessionEstablished(msg.Payload); err != nil {
+		return nil, err
+	}
+
+	keys, err := hs.ExportKeys()
+	if err != nil {
+		return nil, err
+	}
+	return s.NewSecureSession(transport.SecureSessionParams{
+		LocalSessionID:       localSessionID,
+		PeerSessionID:        res.ResponderSessionID,
+		EncryptKey:           keys.I2RKey,
+		DecryptKey:           keys.R2IKey,
+		AttestationChallenge: keys.AttestationChallenge,
+	})
+}
+
+// Responder answers PASE handshakes as the commissionee, for device
+// simulators and tests.
+type Responder struct {
+	Passcode   Passcode
+	Salt       []byte
+	Iterations uint32
+}
+
+// Accept waits for a PBKDFParamRequest on the unsecured session s, runs the
+// handshake as the responder and returns the resulting secure session.
+func (r *Responder) Accept(ctx context.Context, s *transport.Session) (*transport.Session, error) {
+	params := &PBKDFParameters{Iterations: r.Iterations, Salt: r.Salt}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	ex, msg, err := s.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer ex.Close()
+	if err := expect(msg, nil, opPBKDFParamRequest); err != nil {
+		return nil, err
+	}
+	reqBytes := msg.Payload
+	req, err := DecodeParamRequest(reqBytes)
+	if err != nil {
+		return nil, err
+	}
+	localSessionID, err := randomSessionID()
+	if err != nil {
+		return nil, err
+	}
+	responderRandom := make([]byte, randomLength)
+	if _, err := rand.Read(responderRandom); err != nil {
+		return nil, err
+	}
+	res := &ParamResponse{
+		InitiatorRandom:    req.InitiatorRandom,
+		ResponderRandom:    responderRandom,
+		ResponderSessionID: localSessionID,
+	}
+	if !req.HasPBKDFParameters {
+		res.PBKDFParameters = params
+	}
+	resBytes, err := res.Encode()
+	if err != nil {
+		return nil, err
+	}
+	msg, err = ex.Request(ctx, protocol.SecureChannelProtocol, opPBKDFParamResponse, resBytes)
+	if err := expect(msg, err, opPASEPake1); err != nil {
+		return nil, fmt.Errorf("PBKDFParamResponse: %w", err)
+	}
+	pake1, err := DecodePake1(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	hs := NewHandshake(HandshakeRoleServer, HandshakeOptions{
+		Passcode:  r.Passcode,
+		Salt:      r.Salt,
+		PBKDFIter: int(r.Iterations),
+		Context:   handshakeContext(reqBytes, resBytes),
+	})
+	y, err := hs.Start()
+	if err != nil {
+		return nil, err
+	}
+	if err := hs.ProcessPeer(pake1.X); err != nil {
+		return nil, sendFailure(ctx, ex, fmt.Errorf("%w: %w", ErrInvalidMessage, err))
+	}
+	cB, err := hs.GetConfirmation()
+	if err != nil {
+		return nil, err
+	}
+	pake2, err := NewPake2(y, cB).Encode()
+	if err != nil {
+		return nil, err
+	}
+	msg, err = ex.Request(ctx, protocol.SecureChannelProtocol, opPASEPake2, pake2)
+	if err := expect(msg, err, opPASEPake3); err != nil {
+		return nil, fmt.Errorf("Pake2: %w", err)
+	}
+	pake3, err := DecodePake3(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := hs.Verify(pake3.SMac); err != nil {
+		return nil, sendFailure(ctx, ex, ErrAuthentication)
+	}
+	success := protocol.StatusReport{
+		GeneralCode:  protocol.GeneralCodeSuccess,
+		ProtocolID:   protocol.SecureChannelProtocol,
+		ProtocolCode: protocol.SessionEstablishmentSuccess,
+	}
+	if err := ex.Send(ctx, protocol.SecureChannelProtocol, protocol.StatusReportMessage, success.Bytes()); err != nil {
+		return nil, err
+	}
+
+	keys, err := hs.ExportKeys()
+	if err != nil {
+		return nil, err
+	}
+	return s.NewSecureSession(transport.SecureSessionParams{
+		LocalSessionID:       localSessionID,
+		PeerSessionID:        req.InitiatorSessionID,
+		EncryptKey:           keys.R2IKey,
+		DecryptKey:           keys.I2RKey,
+		AttestationChallenge: keys.AttestationChallenge,
+	})
+}
+
+// handshakeContext returns the SPAKE2+ context for the PBKDF parameter
+// messages exchanged.
+func handshakeContext(req, res []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(contextPrefix))
+	h.Write(req)
+	h.Write(res)
+	return h.Sum(nil)
+}
+
+// expect checks that msg, received with err, has the given Secure Channel
+// opcode. A failure StatusReport from the peer is returned as the error.
+func expect(msg *transport.Message, err error, opcode protocol.Opcode) error {
+	if err != nil {
+		return err
+	}
+	if msg.Is(protocol.SecureChannelProtocol, opcode) {
+		return nil
+	}
+	if msg.Is(protocol.SecureChannelProtocol, protocol.StatusReportMessage) {
+		if report, err := protocol.DecodeStatusReport(msg.Payload); err == nil {
+			return fmt.Errorf("pase: peer aborted the handshake: %w", report)
+		}
 	}
+	return fmt.Errorf("%w: unexpected protocol 0x%04X opcode 0x%02X", ErrInvalidMessage,
+		uint16(msg.Protocol.ProtocolID), uint8(msg.Protocol.Opcode))
 }
 
-type session struct {
-	passcode Passcode
+// sessionEstablished checks the StatusReport concluding the handshake.
+func sessionEstablished(payload []byte) error {
+	report, err := protocol.DecodeStatusReport(payload)
+	if err != nil {
+		return err
+	}
+	if !report.IsSuccess() || report.ProtocolID != protocol.SecureChannelProtocol ||
+		report.ProtocolCode != protocol.SessionEstablishmentSuccess {
+		return fmt.Errorf("pase: handshake failed: %w", report)
+	}
+	return nil
 }
 
-// NewSessionWith returns a new PASE session with the given options.
-func NewSessionWith(options ...SessionOption) Session {
-	sess := &session{
-		passcode: 0,
+// sendFailure tells the peer the handshake failed with an InvalidParameter
+// StatusReport and returns err.
+func sendFailure(ctx context.Context, ex *transport.Exchange, err error) error {
+	report := protocol.StatusReport{
+		GeneralCode:  protocol.GeneralCodeFailure,
+		ProtocolID:   protocol.SecureChannelProtocol,
+		ProtocolCode: protocol.InvalidParameter,
 	}
-	for _, opt := range options {
-		opt(sess)
+	_ = ex.Send(ctx, protocol.SecureChannelProtocol, protocol.StatusReportMessage, report.Bytes())
+	return err
+}
+
+// randomSessionID returns a random non-zero session ID.
+func randomSessionID() (uint16, error) {
+	var b [2]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, err
+		}
+		if id := binary.LittleEndian.Uint16(b[:]); id != 0 {
+			return id, nil
+		}
 	}
-	return sess
 }
@@ -0,0 +1,128 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pase
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+	"github.com/YashubuStudio/go-matter-pack/matter/transport"
+)
+
+const testPasscode = 20202021
+
+// handshake runs Establish against a Responder over loopback UDP.
+func handshake(t *testing.T, passcode Passcode) (*transport.Session, *transport.Session, error, error) {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pc.Close() })
+	conn, err := transport.Dial(pc.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	opt := transport.WithRetransmitInterval(50 * time.Millisecond)
+	responder := &Responder{
+		Passcode:   testPasscode,
+		Salt:       []byte("SPAKE2P Key Salt"),
+		Iterations: 1000,
+	}
+	type result struct {
+		s   *transport.Session
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		s, err := responder.Accept(ctx, transport.NewUnsecuredSession(transport.NewConn(pc, nil), 0, opt))
+		done <- result{s, err}
+	}()
+	initiator, initiatorErr := Establish(ctx, transport.NewUnsecuredSession(conn, 0x1122334455667788, opt), passcode)
+	r := <-done
+	return initiator, r.s, initiatorErr, r.err
+}
+
+func TestEstablish(t *testing.T) {
+	initiator, responder, err, rerr := handshake(t, testPasscode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	if !initiator.IsSecure() || !responder.IsSecure() {
+		t.Fatal("sessions are not secure")
+	}
+	if !bytes.Equal(initiator.AttestationChallenge(), responder.AttestationChallenge()) {
+		t.Error("attestation challenges differ")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go func() {
+		ex, msg, err := responder.Accept(ctx)
+		if err != nil {
+			return
+		}
+		defer ex.Close()
+		_ = ex.Send(ctx, msg.Protocol.ProtocolID, msg.Protocol.Opcode+1, append([]byte("re:"), msg.Payload...))
+	}()
+	ex := initiator.NewExchange()
+	defer ex.Close()
+	msg, err := ex.Request(ctx, protocol.InteractionModelProtocol, 0x08, []byte("ping"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !msg.Is(protocol.InteractionModelProtocol, 0x09) || string(msg.Payload) != "re:ping" {
+		t.Errorf("unexpected reply %+v %q", msg.Protocol, msg.Payload)
+	}
+}
+
+func TestEstablishWrongPasscode(t *testing.T) {
+	_, _, err, rerr := handshake(t, testPasscode+1)
+	if !errors.Is(err, ErrAuthentication) {
+		t.Errorf("initiator: expected ErrAuthentication, got %v", err)
+	}
+	if rerr == nil {
+		t.Error("responder: expected an error")
+	}
+}
+
+func TestComputeVerifier(t *testing.T) {
+	// Default verifier of the SDK example devices.
+	want, _ := base64.StdEncoding.DecodeString("uWFwqugDNGiEck/po7KHwwMwwqZgN10XuyBajPGuyzUEV/iree4lOrao5GuwnlQ65CJzbeUB49s31EH+NEkg0JVI5MGCQGMMT/SRPFNRODm3wH/MBiehuFc6FJ/NH6Rmzw==")
+	got, err := ComputeVerifier(testPasscode, []byte("SPAKE2P Key Salt"), 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("verifier = %X, want %X", got, want)
+	}
+	if _, err := ComputeVerifier(testPasscode, []byte("short"), 1000); err == nil {
+		t.Error("expected an error for a short salt")
+	}
+}
@@ -20,27 +20,36 @@ type ExchangeFlag uint8
 // ExchangeID represents a exchange ID.
 type ExchangeID uint16
 
+// 4.4.3.1. Exchange Flags (8 bits).
+const (
+	ExchangeFlagInitiator        ExchangeFlag = 0x01
+	ExchangeFlagAcknowledgement  ExchangeFlag = 0x02
+	ExchangeFlagReliability      ExchangeFlag = 0x04
+	ExchangeFlagSecuredExtension ExchangeFlag = 0x08
+	ExchangeFlagVendor           ExchangeFlag = 0x10
+)
+
 // IsInitiator returns true if the flag is initiator.
 func (flag ExchangeFlag) IsInitiator() bool {
-	return (flag & 0x01) != 0
+	return (flag & ExchangeFlagInitiator) != 0
 }
 
 // IsAcknowledgement returns true if the flag is acknowledgement.
 func (flag ExchangeFlag) IsAcknowledgement() bool {
-	return (flag & 0x20) != 0
+	return (flag & ExchangeFlagAcknowledgement) != 0
 }
 
 // IsReliability returns true if the flag is reliability.
 func (flag ExchangeFlag) IsReliability() bool {
-	return (flag & 0x40) != 0
+	return (flag & ExchangeFlagReliability) != 0
 }
 
 // IsSecuredExtension returns true if the flag is secured extension.
 func (flag ExchangeFlag) IsSecuredExtension() bool {
-	return (flag & 0x80) != 0
+	return (flag & ExchangeFlagSecuredExtension) != 0
 }
 
 // IsVendor returns true if the flag is vendor.
 func (flag ExchangeFlag) IsVendor() bool {
-	return (flag & 0x10) != 0
+	return (flag & ExchangeFlagVendor) != 0
 }
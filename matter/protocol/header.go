@@ -14,6 +14,15 @@
 
 package protocol
 
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrMalformed is returned when a protocol header or message cannot be decoded.
+var ErrMalformed = errors.New("protocol: malformed message")
+
 // Header represents a protocol header.
 // 4.4.3. Protocol Header Field Descriptions.
 type Header struct {
@@ -22,4 +31,67 @@ type Header struct {
 	ExchangeID   ExchangeID
 	VendorID     VendorID
 	ProtocolID   ProtocolID
+	// AckCounter is the acknowledged message counter, present when the
+	// Acknowledgement flag is set.
+	AckCounter uint32
+}
+
+// Bytes returns the encoded header. The vendor ID is written when the Vendor
+// flag is set and the acknowledged counter when the Acknowledgement flag is.
+func (header *Header) Bytes() []byte {
+	b := make([]byte, 0, 12)
+	b = append(b, byte(header.ExchangeFlag), byte(header.Opcode))
+	b = binary.LittleEndian.AppendUint16(b, uint16(header.ExchangeID))
+	if header.ExchangeFlag.IsVendor() {
+		b = binary.LittleEndian.AppendUint16(b, uint16(header.VendorID))
+	}
+	b = binary.LittleEndian.AppendUint16(b, uint16(header.ProtocolID))
+	if header.ExchangeFlag.IsAcknowledgement() {
+		b = binary.LittleEndian.AppendUint32(b, header.AckCounter)
+	}
+	return b
+}
+
+// DecodeHeader parses a protocol header and returns it with the remaining
+// application payload. Secured extensions are skipped.
+func DecodeHeader(b []byte) (*Header, []byte, error) {
+	if len(b) < 6 {
+		return nil, nil, fmt.Errorf("%w: header too short", ErrMalformed)
+	}
+	header := &Header{
+		ExchangeFlag: ExchangeFlag(b[0]),
+		Opcode:       Opcode(b[1]),
+		ExchangeID:   ExchangeID(binary.LittleEndian.Uint16(b[2:])),
+	}
+	b = b[4:]
+	if header.ExchangeFlag.IsVendor() {
+		if len(b) < 2 {
+			return nil, nil, fmt.Errorf("%w: missing vendor ID", ErrMalformed)
+		}
+		header.VendorID = VendorID(binary.LittleEndian.Uint16(b))
+		b = b[2:]
+	}
+	if len(b) < 2 {
+		return nil, nil, fmt.Errorf("%w: missing protocol ID", ErrMalformed)
+	}
+	header.ProtocolID = ProtocolID(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if header.ExchangeFlag.IsAcknowledgement() {
+		if len(b) < 4 {
+			return nil, nil, fmt.Errorf("%w: missing acknowledged counter", ErrMalformed)
+		}
+		header.AckCounter = binary.LittleEndian.Uint32(b)
+		b = b[4:]
+	}
+	if header.ExchangeFlag.IsSecuredExtension() {
+		if len(b) < 2 {
+			return nil, nil, fmt.Errorf("%w: missing secured extension length", ErrMalformed)
+		}
+		n := int(binary.LittleEndian.Uint16(b))
+		if len(b) < 2+n {
+			return nil, nil, fmt.Errorf("%w: truncated secured extension", ErrMalformed)
+		}
+		b = b[2+n:]
+	}
+	return header, b, nil
 }
@@ -17,3 +17,10 @@ package protocol
 // ProtocolID represents a protocol ID.
 // 4.4.3.4. Protocol ID (16 bits).
 type ProtocolID uint16
+
+const (
+	// SecureChannelProtocol carries session establishment, MRP and status reports.
+	SecureChannelProtocol ProtocolID = 0x0000
+	// InteractionModelProtocol carries Interaction Model messages.
+	InteractionModelProtocol ProtocolID = 0x0001
+)
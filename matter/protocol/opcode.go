@@ -29,3 +29,9 @@ const (
 	InvokeResponseMessage    Opcode = 0x09
 	TimedRequestMessage      Opcode = 0x0A
 )
+
+// Secure Channel protocol opcodes shared by all session types.
+const (
+	StandaloneAckMessage Opcode = 0x10
+	StatusReportMessage  Opcode = 0x40
+)
@@ -0,0 +1,91 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// GeneralCode is the protocol-independent status of a StatusReport.
+// Appendix D.3.1. General Status Codes.
+type GeneralCode uint16
+
+const (
+	GeneralCodeSuccess         GeneralCode = 0
+	GeneralCodeFailure         GeneralCode = 1
+	GeneralCodeBadPrecondition GeneralCode = 2
+	GeneralCodeOutOfRange      GeneralCode = 3
+	GeneralCodeBadRequest      GeneralCode = 4
+	GeneralCodeUnsupported     GeneralCode = 5
+	GeneralCodeUnexpected      GeneralCode = 6
+	GeneralCodeResourceLimit   GeneralCode = 7
+	GeneralCodeBusy            GeneralCode = 8
+	GeneralCodeTimeout         GeneralCode = 9
+)
+
+// Secure Channel protocol codes carried in a StatusReport.
+const (
+	SessionEstablishmentSuccess uint16 = 0x0000
+	NoSharedTrustRoots          uint16 = 0x0001
+	InvalidParameter            uint16 = 0x0002
+	CloseSession                uint16 = 0x0003
+	Busy                        uint16 = 0x0004
+)
+
+// StatusReport is the StatusReport message of the Secure Channel protocol.
+// Appendix D. Status Report Messages.
+type StatusReport struct {
+	GeneralCode  GeneralCode
+	VendorID     VendorID
+	ProtocolID   ProtocolID
+	ProtocolCode uint16
+	ProtocolData []byte
+}
+
+// Bytes returns the encoded status report.
+func (report *StatusReport) Bytes() []byte {
+	b := make([]byte, 0, 8+len(report.ProtocolData))
+	b = binary.LittleEndian.AppendUint16(b, uint16(report.GeneralCode))
+	b = binary.LittleEndian.AppendUint16(b, uint16(report.ProtocolID))
+	b = binary.LittleEndian.AppendUint16(b, uint16(report.VendorID))
+	b = binary.LittleEndian.AppendUint16(b, report.ProtocolCode)
+	return append(b, report.ProtocolData...)
+}
+
+// DecodeStatusReport parses a StatusReport message payload.
+func DecodeStatusReport(b []byte) (*StatusReport, error) {
+	if len(b) < 8 {
+		return nil, fmt.Errorf("%w: status report too short", ErrMalformed)
+	}
+	return &StatusReport{
+		GeneralCode:  GeneralCode(binary.LittleEndian.Uint16(b)),
+		ProtocolID:   ProtocolID(binary.LittleEndian.Uint16(b[2:])),
+		VendorID:     VendorID(binary.LittleEndian.Uint16(b[4:])),
+		ProtocolCode: binary.LittleEndian.Uint16(b[6:]),
+		ProtocolData: b[8:],
+	}, nil
+}
+
+// IsSuccess reports whether the report signals success.
+func (report *StatusReport) IsSuccess() bool {
+	return report.GeneralCode == GeneralCodeSuccess
+}
+
+// Error implements error for failed reports.
+func (report *StatusReport) Error() string {
+	return fmt.Sprintf("status report: general code %d, protocol 0x%04X code 0x%04X",
+		report.GeneralCode, uint16(report.ProtocolID), report.ProtocolCode)
+}
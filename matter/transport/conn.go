@@ -0,0 +1,117 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transport implements Matter message exchanges over UDP: message
+// framing, unsecured and secure unicast sessions, and the Message
+// Reliability Protocol (MRP).
+// Reference: Matter Core Spec 1.5, Section 4 (Secure Channel)
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// MaxMessageSize is the largest UDP message a Matter node sends.
+// 4.4.4. Message Size Requirements.
+const MaxMessageSize = 1280
+
+// errTimeout is returned by Conn.read when the read deadline passes.
+var errTimeout = errors.New("transport: read timeout")
+
+// Conn is a UDP socket exchanging messages with a single peer.
+type Conn struct {
+	pc   net.PacketConn
+	peer net.Addr
+	buf  []byte
+}
+
+// Dial opens a UDP socket for messages to addr.
+func Dial(addr *net.UDPAddr) (*Conn, error) {
+	network := "udp6"
+	if addr.IP.To4() != nil {
+		network = "udp4"
+	}
+	pc, err := net.ListenUDP(network, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(pc, addr), nil
+}
+
+// NewConn wraps pc. Datagrams from addresses other than peer are ignored;
+// a nil peer is set to the source of the first datagram received, which
+// suits the responder side of a session.
+func NewConn(pc net.PacketConn, peer net.Addr) *Conn {
+	return &Conn{pc: pc, peer: peer, buf: make([]byte, MaxMessageSize)}
+}
+
+// LocalAddr returns the local address of the socket.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.pc.LocalAddr()
+}
+
+// RemoteAddr returns the peer address, or nil before one is known.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.peer
+}
+
+// Close closes the socket.
+func (c *Conn) Close() error {
+	return c.pc.Close()
+}
+
+func (c *Conn) write(b []byte) error {
+	if c.peer == nil {
+		return errors.New("transport: no peer address")
+	}
+	_, err := c.pc.WriteTo(b, c.peer)
+	return err
+}
+
+// read returns the next datagram from the peer, or errTimeout once deadline
+// passes.
+func (c *Conn) read(deadline time.Time) ([]byte, error) {
+	if err := c.pc.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+	for {
+		n, addr, err := c.pc.ReadFrom(c.buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return nil, errTimeout
+		}
+		if err != nil {
+			return nil, fmt.Errorf("transport: %w", err)
+		}
+		if c.peer == nil {
+			c.peer = addr
+		} else if !sameAddr(c.peer, addr) {
+			continue
+		}
+		return bytes.Clone(c.buf[:n]), nil
+	}
+}
+
+func sameAddr(a, b net.Addr) bool {
+	ua, ok := a.(*net.UDPAddr)
+	if !ok {
+		return a.String() == b.String()
+	}
+	ub, ok := b.(*net.UDPAddr)
+	return ok && ua.IP.Equal(ub.IP) && ua.Port == ub.Port
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	mathrand "math/rand/v2"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
+
+// ErrNoAck is returned when a reliable message is not acknowledged after
+// the maximum number of transmissions.
+var ErrNoAck = errors.New("transport: message not acknowledged")
+
+// MRP parameters.
+// Reference: Matter Core Spec 1.5, Section 4.12 (Message Reliability Protocol)
+const (
+	// DefaultActiveRetransmitInterval is the default SESSION_ACTIVE_INTERVAL.
+	DefaultActiveRetransmitInterval = 300 * time.Millisecond
+
+	mrpMaxTransmissions = 5
+	mrpBackoffBase      = 1.6
+	mrpBackoffJitter    = 0.25
+	mrpBackoffMargin    = 1.1
+	mrpBackoffThreshold = 1
+)
+
+// Exchange is a request/response conversation within a session. Every
+// message it sends is reliable unless the session is WithoutMRP;
+// acknowledgements of received messages are
+// piggybacked on the next message sent, or sent standalone by Close.
+type Exchange struct {
+	s          *Session
+	id         protocol.ExchangeID
+	initiator  bool
+	pendingAck *uint32
+}
+
+// NewExchange opens an exchange initiated by the local node.
+func (s *Session) NewExchange() *Exchange {
+	return &Exchange{s: s, id: protocol.ExchangeID(s.nextExchangeID()), initiator: true}
+}
+
+// Accept waits for the peer to open an exchange and returns it along with
+// its first message.
+func (s *Session) Accept(ctx context.Context) (*Exchange, *Message, error) {
+	for {
+		msg, err := s.receive(ctx, time.Time{})
+		if err != nil {
+			return nil, nil, err
+		}
+		if !msg.Protocol.ExchangeFlag.IsInitiator() || msg.IsStandaloneAck() {
+			s.acknowledge(msg)
+			continue
+		}
+		ex := &Exchange{s: s, id: msg.Protocol.ExchangeID}
+		ex.received(msg)
+		return ex, msg, nil
+	}
+}
+
+// Request sends a message and returns the next message the peer sends on
+// the exchange, retransmitting until the peer acknowledges or answers.
+func (ex *Exchange) Request(ctx context.Context, protocolID protocol.ProtocolID, opcode protocol.Opcode, payload []byte) (*Message, error) {
+	return ex.transmit(ctx, protocolID, opcode, payload, true)
+}
+
+// Send sends a message and waits until the peer acknowledges it.
+func (ex *Exchange) Send(ctx context.Context, protocolID protocol.ProtocolID, opcode protocol.Opcode, payload []byte) error {
+	_, err := ex.transmit(ctx, protocolID, opcode, payload, false)
+	return err
+}
+
+// Close acknowledges the last message received if no message carried the
+// acknowledgement yet.
+func (ex *Exchange) Close() {
+	if ex.pendingAck != nil {
+		ex.s.sendAck(ex.id, ex.initiator, *ex.pendingAck)
+		ex.pendingAck = nil
+	}
+}
+
+func (ex *Exchange) transmit(ctx context.Context, protocolID protocol.ProtocolID, opcode protocol.Opcode, payload []byte, reply bool) (*Message, error) {
+	header := protocol.Header{
+		Opcode:     opcode,
+		ExchangeID: ex.id,
+		ProtocolID: protocolID,
+	}
+	if !ex.s.config.withoutMRP {
+		header.ExchangeFlag |= protocol.ExchangeFlagReliability
+	}
+	if ex.initiator {
+		header.ExchangeFlag |= protocol.ExchangeFlagInitiator
+	}
+	if ex.pendingAck != nil {
+		header.ExchangeFlag |= protocol.ExchangeFlagAcknowledgement
+		header.AckCounter = *ex.pendingAck
+		ex.pendingAck = nil
+	}
+	frame, counter, err := ex.s.seal(&header, payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := ex.s.conn.write(frame); err != nil {
+		return nil, err
+	}
+	// Without MRP the transport delivers the message: there is nothing to
+	// wait for but the reply.
+	if ex.s.config.withoutMRP && !reply {
+		return nil, nil
+	}
+	transmissions := 1
+	acked := ex.s.config.withoutMRP
+	retransmitAt := time.Now().Add(ex.s.retransmitTimeout(0))
+	for {
+		var deadline time.Time
+		if !acked {
+			deadline = retransmitAt
+		}
+		msg, err := ex.s.receive(ctx, deadline)
+		if errors.Is(err, errTimeout) {
+			if transmissions >= mrpMaxTransmissions {
+				return nil, fmt.Errorf("%w after %d transmissions", ErrNoAck, transmissions)
+			}
+			if err := ex.s.conn.write(frame); err != nil {
+				return nil, err
+			}
+			retransmitAt = time.Now().Add(ex.s.retransmitTimeout(transmissions))
+			transmissions++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if msg.Protocol.ExchangeID != ex.id || msg.Protocol.ExchangeFlag.IsInitiator() == ex.initiator {
+			ex.s.acknowledge(msg)
+			continue
+		}
+		if msg.Protocol.ExchangeFlag.IsAcknowledgement() && msg.Protocol.AckCounter == counter {
+			acked = true
+		}
+		if msg.IsStandaloneAck() {
+			if acked && !reply {
+				return nil, nil
+			}
+			continue
+		}
+		ex.received(msg)
+		if reply {
+			return msg, nil
+		}
+		if acked {
+			return nil, nil
+		}
+	}
+}
+
+// received records that msg needs an acknowledgement.
+func (ex *Exchange) received(msg *Message) {
+	if msg.Protocol.ExchangeFlag.IsReliability() {
+		counter := uint32(msg.Header.Counter)
+		ex.pendingAck = &counter
+	}
+}
+
+// retransmitTimeout returns the wait before retransmission n+1 of a
+// message: the base interval with exponential backoff, margin and jitter.
+func (s *Session) retransmitTimeout(n int) time.Duration {
+	backoff := math.Pow(mrpBackoffBase, math.Max(0, float64(n-mrpBackoffThreshold)))
+	jitter := 1 + mrpBackoffJitter*mathrand.Float64()
+	return time.Duration(float64(s.config.retransmitInterval) * mrpBackoffMargin * backoff * jitter)
+}
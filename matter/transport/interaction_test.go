@@ -0,0 +1,274 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/encoding/tlv"
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
+
+// sessionPair returns an unsecured session and the session of its peer on
+// the loopback interface.
+func sessionPair(t *testing.T) (*Session, *Session) {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := Dial(pc.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		pc.Close()
+		t.Fatal(err)
+	}
+	client := NewUnsecuredSession(conn, 1, WithRetransmitInterval(50*time.Millisecond))
+	peer := NewUnsecuredSession(NewConn(pc, nil), 0, WithRetransmitInterval(50*time.Millisecond))
+	t.Cleanup(func() {
+		client.Close()
+		peer.Close()
+	})
+	return client, peer
+}
+
+// expect fails the test unless msg has the interaction model opcode.
+func expect(t *testing.T, msg *Message, opcode protocol.Opcode) {
+	t.Helper()
+	if !msg.Is(protocol.InteractionModelProtocol, opcode) {
+		t.Errorf("received opcode 0x%02X, want 0x%02X", uint8(msg.Protocol.Opcode), uint8(opcode))
+	}
+}
+
+func encodeReport(t *testing.T, report *im.ReportData) []byte {
+	t.Helper()
+	b, err := report.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func statusPayload(t *testing.T, status im.Status) []byte {
+	t.Helper()
+	b, err := im.EncodeStatusResponse(status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestRead(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, peer := sessionPair(t)
+	on, off := []byte{0x09}, []byte{0x08}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ex, msg, err := peer.Accept(ctx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer ex.Close()
+		expect(t, msg, protocol.ReadRequestMessage)
+		for _, report := range []*im.ReportData{
+			{Attributes: []im.AttributeData{{Path: im.NewAttributePath(1, 0x0006, 0x0000), Data: on}}, MoreChunkedMessages: true},
+			{Attributes: []im.AttributeData{{Path: im.NewAttributePath(2, 0x0006, 0x0000), Data: off}}},
+		} {
+			if msg, err = ex.Request(ctx, protocol.InteractionModelProtocol, protocol.ReportDataMessage, encodeReport(t, report)); err != nil {
+				t.Error(err)
+				return
+			}
+			expect(t, msg, protocol.StatusResponseMessage)
+		}
+	}()
+
+	report, err := client.Read(ctx, &im.ReadRequest{AttributeRequests: []im.AttributePath{im.NewAttributePath(0, 0x0006, 0x0000)}})
+	if err != nil {
+		t.Fatalf("Read() = %v", err)
+	}
+	if len(report.Attributes) != 2 || report.Attributes[0].Data[0] != on[0] || report.Attributes[1].Data[0] != off[0] {
+		t.Errorf("merged attributes = %+v", report.Attributes)
+	}
+	<-done
+}
+
+func TestTimedInvoke(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, peer := sessionPair(t)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ex, msg, err := peer.Accept(ctx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer ex.Close()
+		expect(t, msg, protocol.TimedRequestMessage)
+		if msg, err = ex.Request(ctx, protocol.InteractionModelProtocol, protocol.StatusResponseMessage, statusPayload(t, im.StatusSuccess)); err != nil {
+			t.Error(err)
+			return
+		}
+		expect(t, msg, protocol.InvokeRequestMessage)
+		req, err := im.DecodeInvokeRequest(msg.Payload)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !req.TimedRequest {
+			t.Error("invoke request is not timed")
+		}
+		resp, err := im.NewInvokeStatusResponse(req.Commands[0].Path, im.StatusSuccess).Encode()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if err := ex.Send(ctx, protocol.InteractionModelProtocol, protocol.InvokeResponseMessage, resp); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	req := &im.InvokeRequest{Commands: []im.CommandData{{Path: im.CommandPath{Endpoint: 0, Cluster: 0x003C, Command: 0x02}}}}
+	if _, err := client.InvokeRequest(ctx, req, time.Second); err != nil {
+		t.Fatalf("InvokeRequest() = %v", err)
+	}
+	if req.TimedRequest {
+		t.Error("InvokeRequest() modified the request")
+	}
+	<-done
+}
+
+func TestWrite(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, peer := sessionPair(t)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ex, msg, err := peer.Accept(ctx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer ex.Close()
+		expect(t, msg, protocol.WriteRequestMessage)
+		type pathView struct {
+			Endpoint  uint16 `tlv:"2"`
+			Cluster   uint32 `tlv:"3"`
+			Attribute uint32 `tlv:"4"`
+		}
+		type statusView struct {
+			Status uint8 `tlv:"0"`
+		}
+		resp, _ := tlv.Marshal(struct {
+			Responses []struct {
+				Path   pathView   `tlv:"0"`
+				Status statusView `tlv:"1"`
+			} `tlv:"0"`
+		}{Responses: []struct {
+			Path   pathView   `tlv:"0"`
+			Status statusView `tlv:"1"`
+		}{{pathView{0, 0x0028, 0x0005}, statusView{uint8(im.StatusUnsupportedAccess)}}}})
+		if err := ex.Send(ctx, protocol.InteractionModelProtocol, protocol.WriteResponseMessage, resp); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	label, _ := tlv.Marshal("kitchen")
+	req := &im.WriteRequest{Attributes: []im.AttributeData{{Path: im.NewAttributePath(0, 0x0028, 0x0005), Data: label}}}
+	var status *im.StatusError
+	if err := client.Write(ctx, req, 0); !errors.As(err, &status) || status.Status != im.StatusUnsupportedAccess {
+		t.Errorf("Write() = %v, want status 0x%02X", err, uint8(im.StatusUnsupportedAccess))
+	}
+	<-done
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, peer := sessionPair(t)
+	id := uint32(5)
+	event := im.EventData{Path: im.NewEventPath(1, 0x0101, 0x02), EventNumber: 10, Priority: im.EventPriorityCritical, EpochTimestamp: 1700000000000}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ex, msg, err := peer.Accept(ctx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expect(t, msg, protocol.SubscribeRequestMessage)
+		if msg, err = ex.Request(ctx, protocol.InteractionModelProtocol, protocol.ReportDataMessage, encodeReport(t, &im.ReportData{SubscriptionID: &id})); err != nil {
+			t.Error(err)
+			return
+		}
+		expect(t, msg, protocol.StatusResponseMessage)
+		resp, _ := (&im.SubscribeResponse{SubscriptionID: id, MaxInterval: time.Minute}).Encode()
+		if err := ex.Send(ctx, protocol.InteractionModelProtocol, protocol.SubscribeResponseMessage, resp); err != nil {
+			t.Error(err)
+			return
+		}
+		ex.Close()
+
+		// A report of another subscription is rejected before the event
+		// is reported.
+		other := id + 1
+		for _, report := range []struct {
+			data *im.ReportData
+			want im.Status
+		}{
+			{&im.ReportData{SubscriptionID: &other, Events: []im.EventData{event}}, im.StatusInvalidSubscription},
+			{&im.ReportData{SubscriptionID: &id, Events: []im.EventData{event}}, im.StatusSuccess},
+		} {
+			ex := peer.NewExchange()
+			msg, err := ex.Request(ctx, protocol.InteractionModelProtocol, protocol.ReportDataMessage, encodeReport(t, report.data))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if err := statusResponse(msg); report.want == im.StatusSuccess && err != nil {
+				t.Errorf("report answered with %v", err)
+			} else if report.want != im.StatusSuccess && err == nil {
+				t.Error("report of another subscription accepted")
+			}
+			ex.Close()
+		}
+	}()
+
+	sub, priming, err := client.Subscribe(ctx, &im.SubscribeRequest{MaxIntervalCeil: time.Minute, EventRequests: []im.EventPath{event.Path}})
+	if err != nil {
+		t.Fatalf("Subscribe() = %v", err)
+	}
+	if sub.ID != id || sub.MaxInterval != time.Minute || priming.SubscriptionID == nil {
+		t.Errorf("subscription %+v, priming report %+v", sub, priming)
+	}
+	report, err := sub.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next() = %v", err)
+	}
+	if len(report.Events) != 1 || report.Events[0].EventNumber != event.EventNumber {
+		t.Errorf("events = %+v", report.Events)
+	}
+	<-done
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
+
+// Invoke sends cmd to endpoint in an InvokeRequestMessage and returns the
+// response command fields. Commands answered with a success status return
+// the zero Value; a failure status is returned as *im.StatusError.
+func (s *Session) Invoke(ctx context.Context, endpoint uint16, cmd im.Command) (im.Value, error) {
+	req, err := im.NewInvokeRequest(endpoint, cmd)
+	if err != nil {
+		return im.Value{}, err
+	}
+	return s.InvokeRequest(ctx, req, 0)
+}
+
+// InvokeRequest sends req, which must carry a single command, and returns
+// the response like Invoke. A positive timeout makes it a timed invoke: a
+// TimedRequestMessage opening a window of timeout precedes the request on
+// the exchange.
+func (s *Session) InvokeRequest(ctx context.Context, req *im.InvokeRequest, timeout time.Duration) (im.Value, error) {
+	if len(req.Commands) != 1 {
+		return im.Value{}, fmt.Errorf("invoke: %d commands in a request, want 1", len(req.Commands))
+	}
+	path := req.Commands[0].Path
+
+	ex := s.NewExchange()
+	defer ex.Close()
+	if timeout > 0 {
+		if err := ex.timed(ctx, timeout); err != nil {
+			return im.Value{}, fmt.Errorf("invoke %s: %w", path, err)
+		}
+		timedReq := *req
+		timedReq.TimedRequest = true
+		req = &timedReq
+	}
+	payload, err := req.Encode()
+	if err != nil {
+		return im.Value{}, err
+	}
+	msg, err := ex.Request(ctx, protocol.InteractionModelProtocol, protocol.InvokeRequestMessage, payload)
+	if err != nil {
+		return im.Value{}, fmt.Errorf("invoke %s: %w", path, err)
+	}
+	switch {
+	case msg.Is(protocol.InteractionModelProtocol, protocol.InvokeResponseMessage):
+		resp, err := im.DecodeInvokeResponse(msg.Payload)
+		if err != nil {
+			return im.Value{}, err
+		}
+		res, err := resp.Result(path)
+		if err != nil {
+			return im.Value{}, err
+		}
+		return res.Value()
+	case msg.Is(protocol.InteractionModelProtocol, protocol.StatusResponseMessage):
+		status, err := im.DecodeStatusResponse(msg.Payload)
+		if err != nil {
+			return im.Value{}, err
+		}
+		if status.Status == im.StatusSuccess {
+			return im.Value{}, fmt.Errorf("invoke %s: status response without command response", path)
+		}
+		status.Path = path.String()
+		return im.Value{}, status
+	}
+	return im.Value{}, fmt.Errorf("invoke %s: %w", path, unexpectedMessage(msg))
+}
+
+// timed sends a TimedRequestMessage on the exchange and waits for the peer
+// to accept it.
+func (ex *Exchange) timed(ctx context.Context, timeout time.Duration) error {
+	payload, err := (&im.TimedRequest{Timeout: timeout}).Encode()
+	if err != nil {
+		return err
+	}
+	msg, err := ex.Request(ctx, protocol.InteractionModelProtocol, protocol.TimedRequestMessage, payload)
+	if err != nil {
+		return err
+	}
+	return statusResponse(msg)
+}
+
+// respond answers the last message of the exchange with a
+// StatusResponseMessage carrying status.
+func (ex *Exchange) respond(ctx context.Context, status im.Status) error {
+	payload, err := im.EncodeStatusResponse(status)
+	if err != nil {
+		return err
+	}
+	return ex.Send(ctx, protocol.InteractionModelProtocol, protocol.StatusResponseMessage, payload)
+}
+
+// statusResponse returns the failure status msg reports as *im.StatusError,
+// nil for a success status, or an error if msg is no StatusResponseMessage.
+func statusResponse(msg *Message) error {
+	if !msg.Is(protocol.InteractionModelProtocol, protocol.StatusResponseMessage) {
+		return unexpectedMessage(msg)
+	}
+	status, err := im.DecodeStatusResponse(msg.Payload)
+	if err != nil {
+		return err
+	}
+	if status.Status != im.StatusSuccess {
+		return status
+	}
+	return nil
+}
+
+// unexpectedMessage reports a response the interaction does not expect. A
+// failure status response is returned as *im.StatusError.
+func unexpectedMessage(msg *Message) error {
+	if msg.Is(protocol.InteractionModelProtocol, protocol.StatusResponseMessage) {
+		var status *im.StatusError
+		if err := statusResponse(msg); errors.As(err, &status) {
+			return status
+		}
+	}
+	return fmt.Errorf("unexpected response protocol 0x%04X opcode 0x%02X",
+		uint16(msg.Protocol.ProtocolID), uint8(msg.Protocol.Opcode))
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/message"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
+
+// ErrMalformed is returned when a message cannot be decoded.
+var ErrMalformed = errors.New("transport: malformed message")
+
+// Message flag bits.
+// 4.4.1.2. Message Flags (8 bits).
+const (
+	messageFlagSourceNodeID = 0x04
+	messageFlagDSIZMask     = 0x03
+	messageFlagDSIZNodeID   = 0x01
+	messageFlagDSIZGroupID  = 0x02
+	messageFlagVersionMask  = 0xF0
+)
+
+// messageExtensionsFlag is the MX bit of the security flags.
+const messageExtensionsFlag message.SecurityFlag = 0x20
+
+// MessageHeader is the unencrypted header of a message.
+// 4.4.1. Message Header Field Descriptions.
+type MessageHeader struct {
+	SessionID     message.SessionID
+	SecurityFlags message.SecurityFlag
+	Counter       message.Counter
+	// SourceNodeID and DestinationNodeID are nil when absent.
+	SourceNodeID      *uint64
+	DestinationNodeID *uint64
+}
+
+// Bytes returns the encoded header.
+func (h *MessageHeader) Bytes() []byte {
+	b := make([]byte, 0, 24)
+	var flags byte
+	if h.SourceNodeID != nil {
+		flags |= messageFlagSourceNodeID
+	}
+	if h.DestinationNodeID != nil {
+		flags |= messageFlagDSIZNodeID
+	}
+	b = append(b, flags)
+	b = binary.LittleEndian.AppendUint16(b, uint16(h.SessionID))
+	b = append(b, byte(h.SecurityFlags))
+	b = binary.LittleEndian.AppendUint32(b, uint32(h.Counter))
+	if h.SourceNodeID != nil {
+		b = binary.LittleEndian.AppendUint64(b, *h.SourceNodeID)
+	}
+	if h.DestinationNodeID != nil {
+		b = binary.LittleEndian.AppendUint64(b, *h.DestinationNodeID)
+	}
+	return b
+}
+
+// decodeMessageHeader parses the message header at the start of b and
+// returns it with its encoded length. Group destinations are rejected and
+// message extensions are skipped.
+func decodeMessageHeader(b []byte) (*MessageHeader, int, error) {
+	if len(b) < 8 {
+		return nil, 0, fmt.Errorf("%w: header too short", ErrMalformed)
+	}
+	flags := b[0]
+	if flags&messageFlagVersionMask != 0 {
+		return nil, 0, fmt.Errorf("%w: unsupported version %d", ErrMalformed, flags>>4)
+	}
+	h := &MessageHeader{
+		SessionID:     message.SessionID(binary.LittleEndian.Uint16(b[1:])),
+		SecurityFlags: message.SecurityFlag(b[3]),
+		Counter:       message.Counter(binary.LittleEndian.Uint32(b[4:])),
+	}
+	n := 8
+	readNodeID := func() (*uint64, error) {
+		if len(b) < n+8 {
+			return nil, fmt.Errorf("%w: truncated node ID", ErrMalformed)
+		}
+		id := binary.LittleEndian.Uint64(b[n:])
+		n += 8
+		return &id, nil
+	}
+	var err error
+	if flags&messageFlagSourceNodeID != 0 {
+		if h.SourceNodeID, err = readNodeID(); err != nil {
+			return nil, 0, err
+		}
+	}
+	switch flags & messageFlagDSIZMask {
+	case 0:
+	case messageFlagDSIZNodeID:
+		if h.DestinationNodeID, err = readNodeID(); err != nil {
+			return nil, 0, err
+		}
+	case messageFlagDSIZGroupID:
+		return nil, 0, fmt.Errorf("%w: group messages are not supported", ErrMalformed)
+	default:
+		return nil, 0, fmt.Errorf("%w: reserved destination size", ErrMalformed)
+	}
+	if h.SecurityFlags&messageExtensionsFlag != 0 {
+		if len(b) < n+2 {
+			return nil, 0, fmt.Errorf("%w: missing message extensions length", ErrMalformed)
+		}
+		ext := int(binary.LittleEndian.Uint16(b[n:]))
+		if len(b) < n+2+ext {
+			return nil, 0, fmt.Errorf("%w: truncated message extensions", ErrMalformed)
+		}
+		n += 2 + ext
+	}
+	return h, n, nil
+}
+
+// Message is a received message.
+type Message struct {
+	Header   MessageHeader
+	Protocol protocol.Header
+	Payload  []byte
+}
+
+// IsStandaloneAck reports whether the message is an MRP standalone
+// acknowledgement.
+func (msg *Message) IsStandaloneAck() bool {
+	return msg.Protocol.ProtocolID == protocol.SecureChannelProtocol && msg.Protocol.Opcode == protocol.StandaloneAckMessage
+}
+
+// Is reports whether the message has the given protocol and opcode.
+func (msg *Message) Is(protocolID protocol.ProtocolID, opcode protocol.Opcode) bool {
+	return msg.Protocol.ProtocolID == protocolID && msg.Protocol.Opcode == opcode
+}
+
+// counterWindow detects duplicate message counters with a 32 message
+// window behind the largest counter received.
+type counterWindow struct {
+	valid  bool
+	max    uint32
+	bitmap uint32
+}
+
+// accept records counter and reports whether it was not seen before.
+func (w *counterWindow) accept(counter uint32) bool {
+	if !w.valid {
+		w.valid, w.max = true, counter
+		return true
+	}
+	switch {
+	case counter == w.max:
+		return false
+	case counter-w.max < 1<<31:
+		shift := counter - w.max
+		if shift >= 32 {
+			w.bitmap = 0
+		} else {
+			w.bitmap = w.bitmap<<shift | 1<<(shift-1)
+		}
+		w.max = counter
+		return true
+	}
+	offset := w.max - counter
+	if offset > 32 {
+		return false
+	}
+	bit := uint32(1) << (offset - 1)
+	if w.bitmap&bit != 0 {
+		return false
+	}
+	w.bitmap |= bit
+	return true
+}
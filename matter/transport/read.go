@@ -0,0 +1,182 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
+
+// ErrSubscriptionLost is returned when a subscribed node sends no report
+// within the negotiated maximum interval.
+var ErrSubscriptionLost = errors.New("transport: subscription lost")
+
+// subscriptionMargin is the time allowed for a report past the maximum
+// interval before a subscription is considered lost.
+const subscriptionMargin = 5 * time.Second
+
+// Read sends req in a ReadRequestMessage and returns the report of the
+// node, merging chunked reports. Per-path failures are part of the report;
+// a failure status for the whole request is returned as *im.StatusError.
+func (s *Session) Read(ctx context.Context, req *im.ReadRequest) (*im.ReportData, error) {
+	payload, err := req.Encode()
+	if err != nil {
+		return nil, err
+	}
+	ex := s.NewExchange()
+	defer ex.Close()
+	msg, err := ex.Request(ctx, protocol.InteractionModelProtocol, protocol.ReadRequestMessage, payload)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	report, err := ex.reports(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	if !report.SuppressResponse {
+		if err := ex.respond(ctx, im.StatusSuccess); err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// reports decodes the report msg starts and the chunks that follow it,
+// answering every chunk but the last with a success status, and returns
+// them merged. Answering the last chunk is left to the interaction.
+func (ex *Exchange) reports(ctx context.Context, msg *Message) (*im.ReportData, error) {
+	merged := &im.ReportData{}
+	for {
+		if !msg.Is(protocol.InteractionModelProtocol, protocol.ReportDataMessage) {
+			return nil, unexpectedMessage(msg)
+		}
+		report, err := im.DecodeReportData(msg.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if report.SubscriptionID != nil {
+			merged.SubscriptionID = report.SubscriptionID
+		}
+		merged.Attributes = append(merged.Attributes, report.Attributes...)
+		merged.AttributeStatuses = append(merged.AttributeStatuses, report.AttributeStatuses...)
+		merged.Events = append(merged.Events, report.Events...)
+		merged.EventStatuses = append(merged.EventStatuses, report.EventStatuses...)
+		merged.SuppressResponse = report.SuppressResponse
+		if !report.MoreChunkedMessages {
+			return merged, nil
+		}
+		payload, err := im.EncodeStatusResponse(im.StatusSuccess)
+		if err != nil {
+			return nil, err
+		}
+		msg, err = ex.Request(ctx, protocol.InteractionModelProtocol, protocol.StatusResponseMessage, payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Subscription is a subscription established on a session. The node sends
+// its reports on the session, which is not used for anything else while
+// the subscription lasts.
+type Subscription struct {
+	s           *Session
+	ID          uint32
+	MaxInterval time.Duration
+}
+
+// Subscribe sends req in a SubscribeRequestMessage and returns the
+// established subscription along with the priming report.
+func (s *Session) Subscribe(ctx context.Context, req *im.SubscribeRequest) (*Subscription, *im.ReportData, error) {
+	payload, err := req.Encode()
+	if err != nil {
+		return nil, nil, err
+	}
+	ex := s.NewExchange()
+	defer ex.Close()
+	msg, err := ex.Request(ctx, protocol.InteractionModelProtocol, protocol.SubscribeRequestMessage, payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("subscribe: %w", err)
+	}
+	report, err := ex.reports(ctx, msg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("subscribe: %w", err)
+	}
+	if payload, err = im.EncodeStatusResponse(im.StatusSuccess); err != nil {
+		return nil, nil, err
+	}
+	msg, err = ex.Request(ctx, protocol.InteractionModelProtocol, protocol.StatusResponseMessage, payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("subscribe: %w", err)
+	}
+	if !msg.Is(protocol.InteractionModelProtocol, protocol.SubscribeResponseMessage) {
+		return nil, nil, fmt.Errorf("subscribe: %w", unexpectedMessage(msg))
+	}
+	resp, err := im.DecodeSubscribeResponse(msg.Payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	sub := &Subscription{s: s, ID: resp.SubscriptionID, MaxInterval: resp.MaxInterval}
+	return sub, report, nil
+}
+
+// Next waits for the next report of the subscription and answers it. It
+// fails with ErrSubscriptionLost when no report arrives within MaxInterval
+// and a margin.
+func (sub *Subscription) Next(ctx context.Context) (*im.ReportData, error) {
+	wait, cancel := context.WithTimeout(ctx, sub.MaxInterval+subscriptionMargin)
+	defer cancel()
+	for {
+		ex, msg, err := sub.s.Accept(wait)
+		if err != nil {
+			if ctx.Err() == nil && wait.Err() != nil {
+				return nil, ErrSubscriptionLost
+			}
+			return nil, err
+		}
+		report, err := ex.reports(wait, msg)
+		if err != nil {
+			ex.Close()
+			return nil, fmt.Errorf("subscription %d: %w", sub.ID, err)
+		}
+		if report.SubscriptionID == nil || *report.SubscriptionID != sub.ID {
+			err = ex.respond(wait, im.StatusInvalidSubscription)
+			ex.Close()
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !report.SuppressResponse {
+			err = ex.respond(wait, im.StatusSuccess)
+		}
+		ex.Close()
+		if err != nil {
+			return nil, err
+		}
+		return report, nil
+	}
+}
+
+// Close ends the subscription by closing its session.
+func (sub *Subscription) Close() error {
+	return sub.s.Close()
+}
@@ -0,0 +1,349 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/crypto/ccm"
+	"github.com/YashubuStudio/go-matter-pack/matter/message"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
+
+// Message security parameters: AES-CCM with a 16-byte MIC and 13-byte nonce.
+const (
+	micSize   = 16
+	nonceSize = 13
+	keySize   = 16
+)
+
+// pollInterval bounds how long a read blocks before the context is checked.
+const pollInterval = 250 * time.Millisecond
+
+// SessionOption configures a Session.
+type SessionOption func(*sessionConfig)
+
+type sessionConfig struct {
+	retransmitInterval time.Duration
+	withoutMRP         bool
+}
+
+// WithRetransmitInterval sets the base MRP retransmission interval, which
+// defaults to DefaultActiveRetransmitInterval.
+func WithRetransmitInterval(d time.Duration) SessionOption {
+	return func(c *sessionConfig) {
+		c.retransmitInterval = d
+	}
+}
+
+// WithoutMRP sends messages without requesting acknowledgements and never
+// retransmits, for sessions over transports that are reliable themselves
+// such as BTP.
+// 4.12.1. Reliable Messaging Header Fields.
+func WithoutMRP() SessionOption {
+	return func(c *sessionConfig) {
+		c.withoutMRP = true
+	}
+}
+
+// SecureSessionParams holds the keys and identifiers of a secure session
+// established by PASE or CASE.
+type SecureSessionParams struct {
+	LocalSessionID uint16
+	PeerSessionID  uint16
+	// LocalNodeID and PeerNodeID are used in the nonce; both are zero for
+	// PASE sessions.
+	LocalNodeID uint64
+	PeerNodeID  uint64
+	// EncryptKey protects messages sent and DecryptKey messages received:
+	// I2R and R2I respectively on the initiator.
+	EncryptKey           []byte
+	DecryptKey           []byte
+	AttestationChallenge []byte
+}
+
+// Session is a unicast session with a peer over a Conn, either the
+// unsecured session used for session establishment or a secure session.
+// A Session runs one exchange at a time and is not safe for concurrent use.
+type Session struct {
+	conn   *Conn
+	config sessionConfig
+
+	secure         bool
+	localSessionID uint16
+	peerSessionID  uint16
+	localNodeID    uint64
+	peerNodeID     uint64
+	encrypt        cipher.AEAD
+	decrypt        cipher.AEAD
+	challenge      []byte
+
+	counter    uint32
+	received   counterWindow
+	exchangeID uint16
+}
+
+// NewUnsecuredSession returns the unsecured session over conn.
+// Initiators pass a random ephemeral localNodeID, which is sent as the
+// source node ID; responders pass zero and address replies to the source
+// node ID of the peer.
+func NewUnsecuredSession(conn *Conn, localNodeID uint64, opts ...SessionOption) *Session {
+	s := &Session{
+		conn:        conn,
+		config:      sessionConfig{retransmitInterval: DefaultActiveRetransmitInterval},
+		localNodeID: localNodeID,
+		counter:     randomCounter(),
+		exchangeID:  randomUint16(),
+	}
+	for _, opt := range opts {
+		opt(&s.config)
+	}
+	return s
+}
+
+// NewSecureSession returns a secure session over the connection of s,
+// keeping its options.
+func (s *Session) NewSecureSession(params SecureSessionParams) (*Session, error) {
+	if params.LocalSessionID == 0 {
+		return nil, errors.New("transport: secure sessions need a non-zero local session ID")
+	}
+	encrypt, err := newAEAD(params.EncryptKey)
+	if err != nil {
+		return nil, err
+	}
+	decrypt, err := newAEAD(params.DecryptKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		conn:           s.conn,
+		config:         s.config,
+		secure:         true,
+		localSessionID: params.LocalSessionID,
+		peerSessionID:  params.PeerSessionID,
+		localNodeID:    params.LocalNodeID,
+		peerNodeID:     params.PeerNodeID,
+		encrypt:        encrypt,
+		decrypt:        decrypt,
+		challenge:      params.AttestationChallenge,
+		counter:        randomCounter(),
+		exchangeID:     randomUint16(),
+	}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("transport: session key is %d bytes, want %d", len(key), keySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return ccm.New(block, micSize, nonceSize)
+}
+
+// Conn returns the connection the session runs over.
+func (s *Session) Conn() *Conn {
+	return s.conn
+}
+
+// IsSecure reports whether the session is encrypted.
+func (s *Session) IsSecure() bool {
+	return s.secure
+}
+
+// AttestationChallenge returns the attestation challenge of a secure
+// session, or nil for the unsecured session.
+func (s *Session) AttestationChallenge() []byte {
+	return s.challenge
+}
+
+// Close tells the peer that a secure session is closed, without waiting for
+// an acknowledgement, and closes the connection.
+func (s *Session) Close() error {
+	if s.secure {
+		report := protocol.StatusReport{
+			GeneralCode:  protocol.GeneralCodeSuccess,
+			ProtocolID:   protocol.SecureChannelProtocol,
+			ProtocolCode: protocol.CloseSession,
+		}
+		header := protocol.Header{
+			ExchangeFlag: protocol.ExchangeFlagInitiator,
+			Opcode:       protocol.StatusReportMessage,
+			ExchangeID:   protocol.ExchangeID(s.nextExchangeID()),
+			ProtocolID:   protocol.SecureChannelProtocol,
+		}
+		if frame, _, err := s.seal(&header, report.Bytes()); err == nil {
+			_ = s.conn.write(frame)
+		}
+	}
+	return s.conn.Close()
+}
+
+func (s *Session) nextExchangeID() uint16 {
+	s.exchangeID++
+	return s.exchangeID
+}
+
+// seal frames a message with the next message counter, encrypting it on
+// secure sessions.
+func (s *Session) seal(header *protocol.Header, payload []byte) ([]byte, uint32, error) {
+	counter := s.counter
+	s.counter++
+	mh := MessageHeader{
+		SessionID: message.SessionID(s.peerSessionID),
+		Counter:   message.Counter(counter),
+	}
+	if !s.secure {
+		if s.localNodeID != 0 {
+			mh.SourceNodeID = &s.localNodeID
+		}
+		if s.peerNodeID != 0 {
+			mh.DestinationNodeID = &s.peerNodeID
+		}
+	}
+	frame := mh.Bytes()
+	body := append(header.Bytes(), payload...)
+	if !s.secure {
+		return append(frame, body...), counter, nil
+	}
+	sealed := s.encrypt.Seal(nil, nonce(mh.SecurityFlags, counter, s.localNodeID), body, frame)
+	if len(frame)+len(sealed) > MaxMessageSize {
+		return nil, 0, fmt.Errorf("transport: message of %d bytes exceeds %d", len(frame)+len(sealed), MaxMessageSize)
+	}
+	return append(frame, sealed...), counter, nil
+}
+
+// open decodes a datagram for the session. Datagrams for other sessions or
+// failing authentication return a nil Message.
+func (s *Session) open(b []byte) (*Message, error) {
+	mh, n, err := decodeMessageHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	if uint16(mh.SessionID) != s.localSessionID || mh.SecurityFlags.SessionType() != message.UnicastSession {
+		return nil, nil
+	}
+	body := b[n:]
+	if s.secure {
+		sender := s.peerNodeID
+		if mh.SourceNodeID != nil {
+			sender = *mh.SourceNodeID
+		}
+		body, err = s.decrypt.Open(nil, nonce(mh.SecurityFlags, uint32(mh.Counter), sender), body, b[:n])
+		if err != nil {
+			return nil, nil
+		}
+	} else if mh.SourceNodeID != nil && s.peerNodeID == 0 {
+		s.peerNodeID = *mh.SourceNodeID
+	}
+	ph, payload, err := protocol.DecodeHeader(body)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{Header: *mh, Protocol: *ph, Payload: payload}, nil
+}
+
+// receive returns the next message of the session, waiting until deadline
+// or without limit for a zero deadline. Duplicate messages are acknowledged
+// again and dropped.
+func (s *Session) receive(ctx context.Context, deadline time.Time) (*Message, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		wait := time.Now().Add(pollInterval)
+		if !deadline.IsZero() && deadline.Before(wait) {
+			wait = deadline
+		}
+		if d, ok := ctx.Deadline(); ok && d.Before(wait) {
+			wait = d
+		}
+		b, err := s.conn.read(wait)
+		if errors.Is(err, errTimeout) {
+			if !deadline.IsZero() && !time.Now().Before(deadline) {
+				return nil, errTimeout
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		msg, err := s.open(b)
+		if err != nil || msg == nil {
+			continue
+		}
+		if !s.received.accept(uint32(msg.Header.Counter)) {
+			s.acknowledge(msg)
+			continue
+		}
+		return msg, nil
+	}
+}
+
+// acknowledge sends a standalone acknowledgement for msg if it requested
+// one.
+func (s *Session) acknowledge(msg *Message) {
+	if !msg.Protocol.ExchangeFlag.IsReliability() {
+		return
+	}
+	s.sendAck(msg.Protocol.ExchangeID, !msg.Protocol.ExchangeFlag.IsInitiator(), uint32(msg.Header.Counter))
+}
+
+// sendAck sends a standalone acknowledgement of counter on an exchange.
+func (s *Session) sendAck(exchangeID protocol.ExchangeID, initiator bool, counter uint32) {
+	header := protocol.Header{
+		ExchangeFlag: protocol.ExchangeFlagAcknowledgement,
+		Opcode:       protocol.StandaloneAckMessage,
+		ExchangeID:   exchangeID,
+		ProtocolID:   protocol.SecureChannelProtocol,
+		AckCounter:   counter,
+	}
+	if initiator {
+		header.ExchangeFlag |= protocol.ExchangeFlagInitiator
+	}
+	if frame, _, err := s.seal(&header, nil); err == nil {
+		_ = s.conn.write(frame)
+	}
+}
+
+// nonce builds the CCM nonce from the security flags, message counter and
+// source node ID.
+func nonce(flags message.SecurityFlag, counter uint32, source uint64) []byte {
+	n := make([]byte, 0, nonceSize)
+	n = append(n, byte(flags))
+	n = binary.LittleEndian.AppendUint32(n, counter)
+	return binary.LittleEndian.AppendUint64(n, source)
+}
+
+// randomCounter returns an initial message counter in [1, 2^28].
+func randomCounter() uint32 {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return binary.LittleEndian.Uint32(b[:])&(1<<28-1) + 1
+}
+
+func randomUint16() uint16 {
+	var b [2]byte
+	_, _ = rand.Read(b[:])
+	return binary.LittleEndian.Uint16(b[:])
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
+
+func TestMessageHeader(t *testing.T) {
+	source, destination := uint64(0x1122334455667788), uint64(0x0102030405060708)
+	tests := []MessageHeader{
+		{SessionID: 0, Counter: 1, SourceNodeID: &source},
+		{SessionID: 0x1234, SecurityFlags: 0x01, Counter: 0xAABBCCDD, DestinationNodeID: &destination},
+		{SessionID: 7, Counter: 2, SourceNodeID: &source, DestinationNodeID: &destination},
+	}
+	for _, h := range tests {
+		b := h.Bytes()
+		got, n, err := decodeMessageHeader(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(b) {
+			t.Errorf("decoded %d bytes, want %d", n, len(b))
+		}
+		if !bytes.Equal(got.Bytes(), b) {
+			t.Errorf("round trip: got % X, want % X", got.Bytes(), b)
+		}
+	}
+
+	bad := [][]byte{
+		{0x00, 0x00},
+		{0x10, 0, 0, 0, 0, 0, 0, 0},
+		{0x02, 0, 0, 0, 0, 0, 0, 0, 1, 0},
+		{0x04, 0, 0, 0, 0, 0, 0, 0, 1, 2, 3},
+	}
+	for _, b := range bad {
+		if _, _, err := decodeMessageHeader(b); !errors.Is(err, ErrMalformed) {
+			t.Errorf("% X: expected ErrMalformed, got %v", b, err)
+		}
+	}
+}
+
+func TestCounterWindow(t *testing.T) {
+	var w counterWindow
+	steps := []struct {
+		counter uint32
+		want    bool
+	}{
+		{100, true},
+		{100, false},
+		{102, true},
+		{101, true},
+		{101, false},
+		{99, true},
+		{99, false},
+		{200, true},
+		{102, false},
+		{190, true},
+		{190, false},
+	}
+	for _, step := range steps {
+		if got := w.accept(step.counter); got != step.want {
+			t.Errorf("accept(%d) = %v, want %v", step.counter, got, step.want)
+		}
+	}
+}
+
+func TestNoAck(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	conn, err := Dial(pc.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	s := NewUnsecuredSession(conn, 1, WithRetransmitInterval(time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ex := s.NewExchange()
+	defer ex.Close()
+	if err := ex.Send(ctx, protocol.SecureChannelProtocol, 0x20, []byte{0x15, 0x18}); !errors.Is(err, ErrNoAck) {
+		t.Errorf("expected ErrNoAck, got %v", err)
+	}
+}
+
+func TestWithoutMRP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	conn, err := Dial(pc.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	s := NewUnsecuredSession(conn, 1, WithoutMRP())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ex := s.NewExchange()
+	defer ex.Close()
+	// Nothing acknowledges the message: Send returns once it is written.
+	if err := ex.Send(ctx, protocol.SecureChannelProtocol, 0x20, []byte{0x15, 0x18}); err != nil {
+		t.Fatal(err)
+	}
+	peer := NewConn(pc, nil)
+	b, err := peer.read(time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	responder := NewUnsecuredSession(peer, 0)
+	msg, err := responder.open(b)
+	if err != nil || msg == nil {
+		t.Fatalf("open() = %v, %v", msg, err)
+	}
+	if msg.Protocol.ExchangeFlag.IsReliability() {
+		t.Error("message requests an acknowledgement")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/YashubuStudio/go-matter-pack/matter/im"
+	"github.com/YashubuStudio/go-matter-pack/matter/protocol"
+)
+
+// Write sends req in a WriteRequestMessage, as a timed write when timeout
+// is positive, and returns the first path the node failed to write as
+// *im.StatusError.
+func (s *Session) Write(ctx context.Context, req *im.WriteRequest, timeout time.Duration) error {
+	ex := s.NewExchange()
+	defer ex.Close()
+	if timeout > 0 {
+		if err := ex.timed(ctx, timeout); err != nil {
+			return fmt.Errorf("write: %w", err)
+		}
+		timedReq := *req
+		timedReq.TimedRequest = true
+		req = &timedReq
+	}
+	payload, err := req.Encode()
+	if err != nil {
+		return err
+	}
+	msg, err := ex.Request(ctx, protocol.InteractionModelProtocol, protocol.WriteRequestMessage, payload)
+	if err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	if !msg.Is(protocol.InteractionModelProtocol, protocol.WriteResponseMessage) {
+		return fmt.Errorf("write: %w", unexpectedMessage(msg))
+	}
+	resp, err := im.DecodeWriteResponse(msg.Payload)
+	if err != nil {
+		return err
+	}
+	return resp.Err()
+}
@@ -14,6 +14,11 @@
 
 package types
 
+import (
+	"fmt"
+	"strings"
+)
+
 // CommissioningFlow represents the commissioning flow type.
 type CommissioningFlow uint8
 
@@ -23,6 +28,17 @@ const (
 	CommissioningFlowCustom     CommissioningFlow = 2
 )
 
+// NewCommissioningFlowFromString returns the commissioning flow named s
+// (standard, user-action or custom).
+func NewCommissioningFlowFromString(s string) (CommissioningFlow, error) {
+	for _, f := range []CommissioningFlow{CommissioningFlowStandard, CommissioningFlowUserAction, CommissioningFlowCustom} {
+		if strings.EqualFold(strings.TrimSpace(s), f.String()) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown commissioning flow: %s", s)
+}
+
 // IsStandard returns whether the commissioning flow is standard.
 func (f CommissioningFlow) IsStandard() bool {
 	return f == CommissioningFlowStandard
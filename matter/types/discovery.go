@@ -0,0 +1,100 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DiscoveryCapabilities represents the discovery capabilities bitmap of an
+// onboarding payload, the transports a commissionable device can be found on.
+type DiscoveryCapabilities uint8
+
+const (
+	DiscoveryCapabilitySoftAP    DiscoveryCapabilities = 0x01
+	DiscoveryCapabilityBLE       DiscoveryCapabilities = 0x02
+	DiscoveryCapabilityOnNetwork DiscoveryCapabilities = 0x04
+	DiscoveryCapabilityWiFiPAF   DiscoveryCapabilities = 0x08
+)
+
+var discoveryCapabilityNames = map[string]DiscoveryCapabilities{
+	"soft-ap":    DiscoveryCapabilitySoftAP,
+	"ble":        DiscoveryCapabilityBLE,
+	"on-network": DiscoveryCapabilityOnNetwork,
+	"wifi-paf":   DiscoveryCapabilityWiFiPAF,
+}
+
+// NewDiscoveryCapabilitiesFromString parses a comma separated list of
+// capability names (soft-ap, ble, on-network, wifi-paf), "none", or a number.
+func NewDiscoveryCapabilitiesFromString(s string) (DiscoveryCapabilities, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "none" {
+		return 0, nil
+	}
+	if v, err := strconv.ParseUint(s, 0, 8); err == nil {
+		return DiscoveryCapabilities(v), nil
+	}
+	var c DiscoveryCapabilities
+	for name := range strings.SplitSeq(s, ",") {
+		bit, ok := discoveryCapabilityNames[strings.TrimSpace(name)]
+		if !ok {
+			return 0, fmt.Errorf("unknown discovery capability: %s", name)
+		}
+		c |= bit
+	}
+	return c, nil
+}
+
+// HasSoftAP returns whether the device can be discovered over Soft-AP.
+func (c DiscoveryCapabilities) HasSoftAP() bool {
+	return c&DiscoveryCapabilitySoftAP != 0
+}
+
+// HasBLE returns whether the device can be discovered over BLE.
+func (c DiscoveryCapabilities) HasBLE() bool {
+	return c&DiscoveryCapabilityBLE != 0
+}
+
+// HasOnNetwork returns whether the device can be discovered on the IP network.
+func (c DiscoveryCapabilities) HasOnNetwork() bool {
+	return c&DiscoveryCapabilityOnNetwork != 0
+}
+
+// HasWiFiPAF returns whether the device can be discovered over Wi-Fi Public Action Frames.
+func (c DiscoveryCapabilities) HasWiFiPAF() bool {
+	return c&DiscoveryCapabilityWiFiPAF != 0
+}
+
+func (c DiscoveryCapabilities) String() string {
+	names := []string{}
+	if c.HasSoftAP() {
+		names = append(names, "soft-ap")
+	}
+	if c.HasBLE() {
+		names = append(names, "ble")
+	}
+	if c.HasOnNetwork() {
+		names = append(names, "on-network")
+	}
+	if c.HasWiFiPAF() {
+		names = append(names, "wifi-paf")
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// compressedFabricInfo is the KDF info string of the compressed fabric identifier.
+const compressedFabricInfo = "CompressedFabric"
+
+// CompressedFabricID represents a compressed fabric identifier.
+// 4.3.2.2. Compressed Fabric Identifier.
+type CompressedFabricID uint64
+
+// NewCompressedFabricID derives the compressed fabric identifier from the
+// uncompressed root public key of a fabric and its fabric ID.
+// 4.3.2.2. Compressed Fabric Identifier.
+func NewCompressedFabricID(rootPublicKey []byte, fabricID uint64) (CompressedFabricID, error) {
+	if len(rootPublicKey) != 65 || rootPublicKey[0] != 0x04 {
+		return 0, fmt.Errorf("invalid root public key: %d bytes", len(rootPublicKey))
+	}
+	salt := binary.BigEndian.AppendUint64(nil, fabricID)
+	key, err := hkdf.Key(sha256.New, rootPublicKey[1:], salt, compressedFabricInfo, 8)
+	if err != nil {
+		return 0, err
+	}
+	return CompressedFabricID(binary.BigEndian.Uint64(key)), nil
+}
+
+// String returns the string representation of the CompressedFabricID.
+func (cfid CompressedFabricID) String() string {
+	return fmt.Sprintf("%016X", uint64(cfid))
+}
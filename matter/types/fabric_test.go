@@ -0,0 +1,42 @@
+// Copyright (C) 2025 The go-matter Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestCompressedFabricID(t *testing.T) {
+	// 4.3.2.2. Compressed Fabric Identifier
+	rootPublicKey, _ := hex.DecodeString(
+		"044a9f42b1ca4840d37292bbc7f6a7e11e22200c976fc900dbc98a7a383a641c" +
+			"b8254a2e56d4e295a847943b4e3897c4a773e930277b4d9fbede8a052686bfacfa")
+	cfid, err := NewCompressedFabricID(rootPublicKey, 0x2906C908D115D362)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfid != 0x87E1B004E235A130 {
+		t.Errorf("NewCompressedFabricID() = %s, want 87E1B004E235A130", cfid)
+	}
+	if cfid.String() != "87E1B004E235A130" {
+		t.Errorf("String() = %s", cfid.String())
+	}
+	for _, key := range [][]byte{nil, rootPublicKey[1:], append([]byte{0x02}, rootPublicKey[1:]...)} {
+		if _, err := NewCompressedFabricID(key, 0x2906C908D115D362); err == nil {
+			t.Errorf("NewCompressedFabricID(% X) succeeded", key)
+		}
+	}
+}